@@ -46,6 +46,11 @@ const (
 	// RuntimeContainerMetaKey is a key in pod annotations. Kruise-daemon should report the
 	// states of runtime containers into its value, which is a structure JSON of RuntimeContainerMetaSet type.
 	RuntimeContainerMetaKey = "apps.kruise.io/runtime-containers-meta"
+
+	// EphemeralContainersCleanupKey is a key in pod annotations, which means the ephemeral containers in this pod
+	// are expected to be cleaned up. Since ephemeral containers can not be removed from a pod, workloads should
+	// recreate the pod instead of in-place update when updating it.
+	EphemeralContainersCleanupKey = "apps.kruise.io/ephemeral-containers-cleanup"
 )
 
 // InPlaceUpdateState records latest inplace-update state, including old statuses of containers.
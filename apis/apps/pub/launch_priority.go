@@ -16,6 +16,12 @@ limitations under the License.
 
 package pub
 
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 const (
 	// ContainerLaunchPriorityEnvName is the env name that users have to define in pod container
 	// to identity the launch priority of this container.
@@ -33,4 +39,92 @@ const (
 	// ContainerLaunchPriorityCompletedKey is the annotation indicates the pod has all its priorities
 	// patched into its barrier configmap.
 	ContainerLaunchPriorityCompletedKey = "apps.kruise.io/container-launch-priority-completed"
+
+	// ContainerLaunchDependenciesKey is the annotation key that users could define in pod annotation
+	// to make containers in pod launched after their dependencies are satisfied.
+	// The value is a structure JSON of ContainerLaunchDependencies type, which can not be used with launch priority.
+	ContainerLaunchDependenciesKey = "apps.kruise.io/container-launch-dependencies"
+	// ContainerLaunchDependenciesProbedKey is the annotation that kruise-daemon reports the names of containers
+	// whose file and tcpSocket dependencies have been satisfied into, the value is a JSON list.
+	ContainerLaunchDependenciesProbedKey = "apps.kruise.io/container-launch-dependencies-probed"
 )
+
+// ContainerLaunchDependencies is a map from container name to the dependencies that should be satisfied
+// before the container launches. The dependencies are only checked once when the container launches for the first time.
+type ContainerLaunchDependencies map[string][]ContainerLaunchDependency
+
+// ContainerLaunchDependency describes a dependency of container launching, only one of container, file
+// and tcpSocket should be set.
+type ContainerLaunchDependency struct {
+	// Container is the name of another container in pod that should reach the condition.
+	// +optional
+	Container string `json:"container,omitempty"`
+	// Condition of the container, Started or Ready. Defaults to Ready.
+	// It can only be set together with container.
+	// +optional
+	Condition ContainerLaunchCondition `json:"condition,omitempty"`
+	// File is a file that should exist in another container, which is checked by kruise-daemon.
+	// +optional
+	File *ContainerLaunchFileDependency `json:"file,omitempty"`
+	// TCPSocket is a port of pod that should be opened, which is checked by kruise-daemon.
+	// +optional
+	TCPSocket *ContainerLaunchTCPSocketDependency `json:"tcpSocket,omitempty"`
+}
+
+// ContainerLaunchCondition is the condition of a container that others depend on.
+type ContainerLaunchCondition string
+
+const (
+	// ContainerLaunchConditionStarted means the container has started and passed its startup probe.
+	ContainerLaunchConditionStarted ContainerLaunchCondition = "Started"
+	// ContainerLaunchConditionReady means the container is ready.
+	ContainerLaunchConditionReady ContainerLaunchCondition = "Ready"
+)
+
+// ContainerLaunchFileDependency describes a file in container.
+// The file is checked by executing `test -e <path>` in the container, so the container image must have
+// the `test` command, e.g., from a shell or busybox. Otherwise the dependency will never be satisfied,
+// and a FailedProbeLaunchDependency warning event is recorded on the Pod.
+type ContainerLaunchFileDependency struct {
+	// Container is the name of container where the file should exist.
+	Container string `json:"container"`
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+}
+
+// ContainerLaunchTCPSocketDependency describes a tcp port of pod.
+type ContainerLaunchTCPSocketDependency struct {
+	// Port is the tcp port on the pod IP.
+	Port int32 `json:"port"`
+}
+
+// IsProbeRequired returns true if the dependency should be checked by kruise-daemon.
+func (d *ContainerLaunchDependency) IsProbeRequired() bool {
+	return d.File != nil || d.TCPSocket != nil
+}
+
+func GetContainerLaunchDependencies(obj metav1.Object) (ContainerLaunchDependencies, error) {
+	str, ok := obj.GetAnnotations()[ContainerLaunchDependenciesKey]
+	if !ok {
+		return nil, nil
+	}
+
+	dependencies := ContainerLaunchDependencies{}
+	if err := json.Unmarshal([]byte(str), &dependencies); err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+func GetContainerLaunchDependenciesProbed(obj metav1.Object) ([]string, error) {
+	str, ok := obj.GetAnnotations()[ContainerLaunchDependenciesProbedKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(str), &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
@@ -26,6 +26,93 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ContainerLaunchDependencies) DeepCopyInto(out *ContainerLaunchDependencies) {
+	{
+		in := &in
+		*out = make(ContainerLaunchDependencies, len(*in))
+		for key, val := range *in {
+			var outVal []ContainerLaunchDependency
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]ContainerLaunchDependency, len(*in))
+				for i := range *in {
+					(*in)[i].DeepCopyInto(&(*out)[i])
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerLaunchDependencies.
+func (in ContainerLaunchDependencies) DeepCopy() ContainerLaunchDependencies {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerLaunchDependencies)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerLaunchDependency) DeepCopyInto(out *ContainerLaunchDependency) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(ContainerLaunchFileDependency)
+		**out = **in
+	}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(ContainerLaunchTCPSocketDependency)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerLaunchDependency.
+func (in *ContainerLaunchDependency) DeepCopy() *ContainerLaunchDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerLaunchDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerLaunchFileDependency) DeepCopyInto(out *ContainerLaunchFileDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerLaunchFileDependency.
+func (in *ContainerLaunchFileDependency) DeepCopy() *ContainerLaunchFileDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerLaunchFileDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerLaunchTCPSocketDependency) DeepCopyInto(out *ContainerLaunchTCPSocketDependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerLaunchTCPSocketDependency.
+func (in *ContainerLaunchTCPSocketDependency) DeepCopy() *ContainerLaunchTCPSocketDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerLaunchTCPSocketDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InPlaceUpdateContainerBatch) DeepCopyInto(out *InPlaceUpdateContainerBatch) {
 	*out = *in
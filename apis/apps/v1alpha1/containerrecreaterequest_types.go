@@ -37,6 +37,13 @@ const (
 	// ContainerRecreateRequestUnreadyAcquiredKey indicates the Pod has been forced to not-ready.
 	// It is required if the unreadyGracePeriodSeconds is set in ContainerRecreateRequests.
 	ContainerRecreateRequestUnreadyAcquiredKey = "crr.apps.kruise.io/unready-acquired"
+	// ContainerRecreateRequestOverrideOriginKey contains the original values of the Pod metadata
+	// which are changed by the override of containers, so that they can be reverted once the ContainerRecreateRequest is deleted.
+	// It is also the finalizer of ContainerRecreateRequest until the override has been reverted.
+	ContainerRecreateRequestOverrideOriginKey = "crr.apps.kruise.io/override-origin"
+	// ContainerRecreateRequestOverrideAppliedKey indicates the override has been applied to the Pod metadata,
+	// kruise-daemon will not recreate the containers until it is set.
+	ContainerRecreateRequestOverrideAppliedKey = "crr.apps.kruise.io/override-applied"
 )
 
 // ContainerRecreateRequestSpec defines the desired state of ContainerRecreateRequest
@@ -72,6 +79,33 @@ type ContainerRecreateRequestContainer struct {
 	// Populated by the system.
 	// Read-only.
 	StatusContext *ContainerRecreateRequestContainerContext `json:"statusContext,omitempty"`
+	// Override contains the changes that only take effect in this recreation of the container.
+	// They are reverted with another recreation once the ContainerRecreateRequest is deleted,
+	// which happens when the TTLSecondsAfterFinished expires, so the TTL is required.
+	// The reverting ContainerRecreateRequest is named with a `-revert` suffix and overrides the env
+	// with the reverted values, so it also waits for the reverted Pod metadata before recreation.
+	// +optional
+	Override *ContainerRecreateRequestContainerOverride `json:"override,omitempty"`
+}
+
+// ContainerRecreateRequestContainerOverride defines the one-shot changes to the container.
+type ContainerRecreateRequestContainerOverride struct {
+	// Env contains the new values of env in the container.
+	// Each env must be declared in the container with valueFrom fieldRef of `metadata.annotations['<KEY>']`
+	// or `metadata.labels['<KEY>']`, which will be updated in the Pod before recreation, like InPlaceUpdateEnvFromMetadata.
+	// Only env can be overridden, the args and other fields of container are not supported and will not be changed.
+	// Args that refer to these env with $(NAME) are expanded with the overridden values by kubelet.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Env []ContainerRecreateRequestEnvOverride `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+}
+
+// ContainerRecreateRequestEnvOverride defines the new value of an env.
+type ContainerRecreateRequestEnvOverride struct {
+	// Name of the env.
+	Name string `json:"name"`
+	// Value of the env.
+	Value string `json:"value"`
 }
 
 // ProbeHandler defines a specific action that should be taken
@@ -121,6 +155,21 @@ type ContainerRecreateRequestStrategy struct {
 	// without any of its container crashing, for it to be considered Succeeded.
 	// Defaults to 0 (container will be considered Succeeded as soon as it is started and ready)
 	MinStartedSeconds int32 `json:"minStartedSeconds,omitempty"`
+	// CaptureBeforeRecreate makes kruise-daemon capture the tail of logs and the last termination state of
+	// each container into the status before executing preStop hook and stopping it.
+	// The captured logs can be read by anyone who can get the ContainerRecreateRequest, so the creator of it
+	// or of the ContainerRestartJob must be allowed to get the logs of Pods (pods/log), otherwise it is rejected.
+	// +optional
+	CaptureBeforeRecreate *ContainerRecreateRequestCaptureStrategy `json:"captureBeforeRecreate,omitempty"`
+}
+
+// ContainerRecreateRequestCaptureStrategy defines what to capture before recreating containers.
+type ContainerRecreateRequestCaptureStrategy struct {
+	// TailLines is the number of lines from the end of the container logs to capture.
+	// The captured logs of each container are truncated to 16KiB.
+	// Defaults to 50, and the maximum is 500.
+	// +optional
+	TailLines *int64 `json:"tailLines,omitempty"`
 }
 
 type ContainerRecreateRequestFailurePolicyType string
@@ -164,6 +213,25 @@ type ContainerRecreateRequestContainerRecreateState struct {
 	Message string `json:"message,omitempty"`
 	// Containers are killed by kruise daemon
 	IsKilled bool `json:"isKilled,omitempty"`
+	// Captured contains the logs and state of the container captured before it is killed.
+	// It is only set when captureBeforeRecreate is enabled in strategy.
+	Captured *ContainerRecreateRequestCapturedState `json:"captured,omitempty"`
+}
+
+// ContainerRecreateRequestCapturedState contains the logs and state of a container captured before it is killed.
+type ContainerRecreateRequestCapturedState struct {
+	// CaptureTime is the time when the container was captured.
+	CaptureTime metav1.Time `json:"captureTime"`
+	// ContainerID of the captured container.
+	ContainerID string `json:"containerID,omitempty"`
+	// RestartCount of the captured container.
+	RestartCount int32 `json:"restartCount,omitempty"`
+	// Logs is the tail of the container logs.
+	Logs string `json:"logs,omitempty"`
+	// LastTerminationState is the state of the previous terminated instance of the container, if any.
+	LastTerminationState *v1.ContainerStateTerminated `json:"lastTerminationState,omitempty"`
+	// Message indicates why the logs or state failed to be captured.
+	Message string `json:"message,omitempty"`
 }
 
 // ContainerRecreateRequestSyncContainerStatus only uses in the annotation `crr.apps.kruise.io/sync-container-statuses`.
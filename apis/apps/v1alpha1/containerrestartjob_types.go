@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// [Immutable] ContainerRestartJobNameKey is the name of the ContainerRestartJob that creates the ContainerRecreateRequest.
+	ContainerRestartJobNameKey = "crj.apps.kruise.io/job-name"
+)
+
+// ContainerRestartJobSpec defines the desired state of ContainerRestartJob
+type ContainerRestartJobSpec struct {
+	// Selector is a label query over the pods whose containers should be restarted.
+	// Only one of Selector and TargetReference can be set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// TargetReference is the workload whose pods should be restarted, such as CloneSet, Deployment and StatefulSet.
+	// Only one of Selector and TargetReference can be set.
+	// +optional
+	TargetReference *TargetReference `json:"targetRef,omitempty"`
+	// Containers contains the containers that need to restart in each pod.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	Containers []ContainerRestartJobContainer `json:"containers" patchStrategy:"merge" patchMergeKey:"name"`
+	// Strategy defines strategies for containers recreation in each pod.
+	// +optional
+	Strategy *ContainerRecreateRequestStrategy `json:"strategy,omitempty"`
+	// MaxUnavailable is the maximum number of pods that can be unavailable during the restarting.
+	// The pods that are not ready, or whose containers are restarting, are counted as unavailable.
+	// Value can be an absolute number (ex: 5) or a percentage of the pods to restart (ex: 10%).
+	// Defaults to 1.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// Paused indicates that no more pods will be restarted, the restarting pods are not affected.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// ActiveDeadlineSeconds is the deadline duration of the ContainerRecreateRequest of each pod.
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// TTLSecondsAfterFinished is the TTL duration after this ContainerRestartJob has completed.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// ContainerRestartJobContainer defines the container that need to restart.
+type ContainerRestartJobContainer struct {
+	// Name of the container that need to restart.
+	// The pods without this container are skipped.
+	Name string `json:"name"`
+}
+
+// ContainerRestartJobStatus defines the observed state of ContainerRestartJob
+type ContainerRestartJobStatus struct {
+	// ObservedGeneration is the most recent generation observed for this ContainerRestartJob.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Phase of this ContainerRestartJob, e.g. Running, Paused, Succeeded, Failed
+	Phase ContainerRestartJobPhase `json:"phase,omitempty"`
+	// Represents time when the ContainerRestartJob was acknowledged by the controller.
+	// Only the pods created before it will be restarted.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Represents time when the ContainerRestartJob was completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Desired is the number of pods to restart.
+	Desired int32 `json:"desired"`
+	// Waiting is the number of pods waiting to restart.
+	Waiting int32 `json:"waiting"`
+	// Active is the number of pods whose containers are restarting.
+	Active int32 `json:"active"`
+	// Succeeded is the number of pods whose containers have restarted successfully.
+	Succeeded int32 `json:"succeeded"`
+	// Failed is the number of pods whose containers failed to restart.
+	Failed int32 `json:"failed"`
+	// FailedPods contains the names of the pods failed to restart.
+	// +optional
+	FailedPods []string `json:"failedPods,omitempty"`
+	// A human readable message indicating details about this ContainerRestartJob.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type ContainerRestartJobPhase string
+
+const (
+	ContainerRestartJobRunning   ContainerRestartJobPhase = "Running"
+	ContainerRestartJobPaused    ContainerRestartJobPhase = "Paused"
+	ContainerRestartJobSucceeded ContainerRestartJobPhase = "Succeeded"
+	ContainerRestartJobFailed    ContainerRestartJobPhase = "Failed"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=crj
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.phase",description="Phase of this ContainerRestartJob."
+// +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".status.desired",description="The number of pods to restart."
+// +kubebuilder:printcolumn:name="ACTIVE",type="integer",JSONPath=".status.active",description="The number of pods whose containers are restarting."
+// +kubebuilder:printcolumn:name="SUCCEEDED",type="integer",JSONPath=".status.succeeded",description="The number of pods whose containers have restarted successfully."
+// +kubebuilder:printcolumn:name="FAILED",type="integer",JSONPath=".status.failed",description="The number of pods whose containers failed to restart."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp",description="CreationTimestamp is a timestamp representing the server time when this object was created. It is not guaranteed to be set in happens-before order across separate operations. Clients may not set this value. It is represented in RFC3339 form and is in UTC."
+
+// ContainerRestartJob is the Schema for the containerrestartjobs API.
+// It restarts the containers across the pods of a workload by ContainerRecreateRequests with rolling semantics.
+type ContainerRestartJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ContainerRestartJobSpec   `json:"spec,omitempty"`
+	Status ContainerRestartJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ContainerRestartJobList contains a list of ContainerRestartJob
+type ContainerRestartJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ContainerRestartJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ContainerRestartJob{}, &ContainerRestartJobList{})
+}
@@ -4393,6 +4393,28 @@ func TestWorkloadSpread_ConvertTo(t *testing.T) {
 				assert.Nil(t, dst.Spec.Subsets)
 			},
 		},
+		{
+			name: "rebalance strategy and last rebalance time are converted",
+			src: &WorkloadSpread{
+				ObjectMeta: metav1.ObjectMeta{Name: "ws-rebalance"},
+				Spec: WorkloadSpreadSpec{
+					RebalanceStrategy: &WorkloadSpreadRebalanceStrategy{
+						MaxEvictionsPerInterval: int32Ptr(2),
+						IntervalSeconds:         int32Ptr(30),
+					},
+				},
+				Status: WorkloadSpreadStatus{
+					LastRebalanceTime: &metav1.Time{Time: time.Unix(1700000000, 0)},
+				},
+			},
+			validate: func(t *testing.T, dst *v1beta1.WorkloadSpread) {
+				assert.NotNil(t, dst.Spec.RebalanceStrategy)
+				assert.Equal(t, int32(2), *dst.Spec.RebalanceStrategy.MaxEvictionsPerInterval)
+				assert.Equal(t, int32(30), *dst.Spec.RebalanceStrategy.IntervalSeconds)
+				assert.NotNil(t, dst.Status.LastRebalanceTime)
+				assert.Equal(t, int64(1700000000), dst.Status.LastRebalanceTime.Unix())
+			},
+		},
 		{
 			name: "nil VersionedSubsetStatuses map does not panic",
 			src: &WorkloadSpread{
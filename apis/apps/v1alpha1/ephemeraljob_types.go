@@ -26,6 +26,13 @@ import (
 
 const (
 	EphemeralContainerEnvKey = "KRUISE_EJOB_ID"
+
+	// EphemeralJobNameLabelKey is the label key of ConfigMaps that store the outputs collected by EphemeralJob.
+	EphemeralJobNameLabelKey = "apps.kruise.io/ephemeral-job-name"
+
+	// EphemeralJobRecreatedPodsKey is the annotation key of EphemeralJob that records the pods recreated by the job
+	// with Recreate cleanup policy, whose replacements have not been ready yet.
+	EphemeralJobRecreatedPodsKey = "apps.kruise.io/ephemeral-job-recreated-pods"
 )
 
 // EphemeralJobSpec defines the desired state of EphemeralJob
@@ -67,6 +74,54 @@ type EphemeralJobSpec struct {
 	// the Job becomes eligible to be deleted immediately after it finishes.
 	// +optional
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty" protobuf:"varint,4,opt,name=ttlSecondsAfterFinished"`
+
+	// TargetContainerName is the name of the container in matched pods whose process namespace
+	// the ephemeral containers share, unless the ephemeral container has set its own targetContainerName.
+	// Pods that do not have this container will be ignored.
+	// +optional
+	TargetContainerName string `json:"targetContainerName,omitempty"`
+
+	// CleanupPolicy decides how to clean up the ephemeral containers in pods after the job has finished.
+	// +optional
+	CleanupPolicy *EphemeralJobCleanupPolicy `json:"cleanupPolicy,omitempty"`
+
+	// OutputCollection makes the job collect the exit code and the tail of logs of ephemeral containers
+	// in each pod after they have finished. The outputs of at most 100 pods are collected.
+	// +optional
+	OutputCollection *EphemeralJobOutputCollection `json:"outputCollection,omitempty"`
+}
+
+// EphemeralJobCleanupPolicyType is the type of EphemeralJobCleanupPolicy.
+type EphemeralJobCleanupPolicyType string
+
+const (
+	// EphemeralJobCleanupNone means the ephemeral containers are kept in pods, which is the default policy.
+	EphemeralJobCleanupNone EphemeralJobCleanupPolicyType = "None"
+	// EphemeralJobCleanupOnNextUpdate means the pods are marked after the job has finished, so that CloneSet and
+	// Advanced StatefulSet will recreate them instead of in-place update at the next update.
+	EphemeralJobCleanupOnNextUpdate EphemeralJobCleanupPolicyType = "OnNextUpdate"
+	// EphemeralJobCleanupRecreate means the pods are marked and then recreated by the job after it has finished,
+	// if they are allowed by PodUnavailableBudget. Pods without a controller owner will only be marked.
+	EphemeralJobCleanupRecreate EphemeralJobCleanupPolicyType = "Recreate"
+)
+
+// EphemeralJobCleanupPolicy describes how to clean up the ephemeral containers in pods.
+type EphemeralJobCleanupPolicy struct {
+	// Type of the cleanup policy, None, OnNextUpdate or Recreate. Defaults to None.
+	// Pods will be recreated at most parallelism at a time with Recreate, the pods recreated by this job that have not
+	// been replaced by ready ones are counted against parallelism. The job will not be deleted after ttlSecondsAfterFinished
+	// until all the pods have been recreated.
+	// +optional
+	Type EphemeralJobCleanupPolicyType `json:"type,omitempty"`
+}
+
+// EphemeralJobOutputCollection describes how to collect the outputs of ephemeral containers.
+type EphemeralJobOutputCollection struct {
+	// TailLines is the number of lines from the end of the logs of each ephemeral container to collect.
+	// The collected logs of each container are truncated to 16KiB.
+	// Defaults to 100, and the maximum is 1000.
+	// +optional
+	TailLines *int64 `json:"tailLines,omitempty"`
 }
 
 // EphemeralContainerTemplateSpec describes template spec of ephemeral containers
@@ -124,6 +179,43 @@ type EphemeralJobStatus struct {
 	// The number of pods which reached phase Failed.
 	// +optional
 	Failed int32 `json:"failed" protobuf:"varint,6,opt,name=failed"`
+
+	// Outputs contains the outputs of ephemeral containers collected from the pods that have finished.
+	// It only works when outputCollection is set.
+	// +optional
+	Outputs []EphemeralJobPodOutput `json:"outputs,omitempty"`
+
+	// OutputsOmitted is the number of finished pods whose outputs are not collected,
+	// because the outputs have reached the limit of pods.
+	// +optional
+	OutputsOmitted int32 `json:"outputsOmitted,omitempty"`
+}
+
+// EphemeralJobPodOutput contains the outputs of ephemeral containers in a pod.
+type EphemeralJobPodOutput struct {
+	// PodName is the name of the pod.
+	PodName string `json:"podName"`
+	// ConfigMapName is the name of the ConfigMap that stores the logs of ephemeral containers,
+	// whose keys are the container names.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Containers contains the outputs of each ephemeral container.
+	Containers []EphemeralJobContainerOutput `json:"containers"`
+}
+
+// EphemeralJobContainerOutput contains the output of an ephemeral container.
+type EphemeralJobContainerOutput struct {
+	// Name of the ephemeral container.
+	Name string `json:"name"`
+	// ExitCode of the ephemeral container, it is nil if the container failed to run.
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// Reason of the container termination or failure.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message indicates the error if it failed to collect the logs.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // JobCondition describes current state of a job.
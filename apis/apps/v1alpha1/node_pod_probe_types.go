@@ -46,6 +46,10 @@ type ContainerProbe struct {
 	ContainerName string `json:"containerName"`
 	// container probe spec
 	Probe ContainerProbeSpec `json:"probe"`
+	// DerivedStates of the probe in PodProbeMarker, kruise-daemon reports the recent results immediately
+	// when the derived state changes, otherwise they are reported in batches.
+	// +optional
+	DerivedStates []ProbeDerivedState `json:"derivedStates,omitempty"`
 }
 
 type NodePodProbeStatus struct {
@@ -78,6 +82,26 @@ type ContainerProbeState struct {
 	// If Status=True, Message records the return result of Probe.
 	// If Status=False, Message records Probe's error message
 	Message string `json:"message,omitempty"`
+	// Latency is the duration of the last probe.
+	// +optional
+	Latency *metav1.Duration `json:"latency,omitempty"`
+	// RecentResults records the results of the recent probes regardless of the thresholds, from the oldest to the latest.
+	// It helps to find out the flapping probes whose state does not change.
+	// +optional
+	RecentResults []ProbeResult `json:"recentResults,omitempty"`
+}
+
+// MaxRecentProbeResults is the max number of the recent results recorded in ContainerProbeState
+const MaxRecentProbeResults = 10
+
+// ProbeResult is the result of a single probe
+type ProbeResult struct {
+	// State of the probe, Succeeded or Failed
+	State ProbeState `json:"state"`
+	// Time of the probe
+	ProbeTime metav1.Time `json:"probeTime"`
+	// Latency of the probe
+	Latency metav1.Duration `json:"latency"`
 }
 
 type ProbeState string
@@ -49,6 +49,7 @@ func convertPersistentPodStateToV1beta1(src *PersistentPodState, dst *appsv1beta
 			Kind:       src.Spec.TargetReference.Kind,
 			Name:       src.Spec.TargetReference.Name,
 		},
+		PodIdentityLabel:                  src.Spec.PodIdentityLabel,
 		PersistentPodStateRetentionPolicy: appsv1beta1.PersistentPodStateRetentionPolicyType(src.Spec.PersistentPodStateRetentionPolicy),
 	}
 	if len(src.Spec.PersistentPodAnnotations) > 0 {
@@ -57,6 +58,12 @@ func convertPersistentPodStateToV1beta1(src *PersistentPodState, dst *appsv1beta
 			dst.Spec.PersistentPodAnnotations[i] = appsv1beta1.PersistentPodAnnotation{Key: item.Key}
 		}
 	}
+	if len(src.Spec.PersistentPodFields) > 0 {
+		dst.Spec.PersistentPodFields = make([]appsv1beta1.PersistentPodField, len(src.Spec.PersistentPodFields))
+		for i, item := range src.Spec.PersistentPodFields {
+			dst.Spec.PersistentPodFields[i] = appsv1beta1.PersistentPodField{Path: item.Path, RestorePath: item.RestorePath}
+		}
+	}
 	if src.Spec.RequiredPersistentTopology != nil {
 		dst.Spec.RequiredPersistentTopology = &appsv1beta1.NodeTopologyTerm{
 			Keys: append([]string(nil), src.Spec.RequiredPersistentTopology.NodeTopologyKeys...),
@@ -86,6 +93,7 @@ func convertPersistentPodStateFromV1beta1(src *appsv1beta1.PersistentPodState, d
 			Kind:       src.Spec.TargetReference.Kind,
 			Name:       src.Spec.TargetReference.Name,
 		},
+		PodIdentityLabel:                  src.Spec.PodIdentityLabel,
 		PersistentPodStateRetentionPolicy: PersistentPodStateRetentionPolicyType(src.Spec.PersistentPodStateRetentionPolicy),
 	}
 	if len(src.Spec.PersistentPodAnnotations) > 0 {
@@ -94,6 +102,12 @@ func convertPersistentPodStateFromV1beta1(src *appsv1beta1.PersistentPodState, d
 			dst.Spec.PersistentPodAnnotations[i] = PersistentPodAnnotation{Key: item.Key}
 		}
 	}
+	if len(src.Spec.PersistentPodFields) > 0 {
+		dst.Spec.PersistentPodFields = make([]PersistentPodField, len(src.Spec.PersistentPodFields))
+		for i, item := range src.Spec.PersistentPodFields {
+			dst.Spec.PersistentPodFields[i] = PersistentPodField{Path: item.Path, RestorePath: item.RestorePath}
+		}
+	}
 	if src.Spec.RequiredPersistentTopology != nil {
 		dst.Spec.RequiredPersistentTopology = &NodeTopologyTerm{
 			NodeTopologyKeys: append([]string(nil), src.Spec.RequiredPersistentTopology.Keys...),
@@ -136,6 +150,12 @@ func convertPersistentPodStateStatusToV1beta1(src PersistentPodStateStatus) apps
 				podState.Annotations[k] = v
 			}
 		}
+		if len(state.Fields) > 0 {
+			podState.Fields = make(map[string]string, len(state.Fields))
+			for k, v := range state.Fields {
+				podState.Fields[k] = v
+			}
+		}
 		dst.PodStates[name] = podState
 	}
 	return dst
@@ -163,6 +183,12 @@ func convertPersistentPodStateStatusFromV1beta1(src appsv1beta1.PersistentPodSta
 				podState.Annotations[k] = v
 			}
 		}
+		if len(state.Fields) > 0 {
+			podState.Fields = make(map[string]string, len(state.Fields))
+			for k, v := range state.Fields {
+				podState.Fields[k] = v
+			}
+		}
 		dst.PodStates[name] = podState
 	}
 	return dst
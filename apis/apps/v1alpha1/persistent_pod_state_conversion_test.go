@@ -205,6 +205,43 @@ func TestPersistentPodState_RoundTrip(t *testing.T) {
 	assert.Equal(t, original.Status, roundTrip.Status)
 }
 
+func TestPersistentPodState_HubRoundTrip(t *testing.T) {
+	original := &v1beta1.PersistentPodState{
+		ObjectMeta: metav1.ObjectMeta{Name: "pps", Namespace: "default", ResourceVersion: "1"},
+		Spec: v1beta1.PersistentPodStateSpec{
+			TargetReference:          v1beta1.TargetReference{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet", Name: "web"},
+			PodIdentityLabel:         "apps.kruise.io/cloneset-instance-id",
+			PersistentPodAnnotations: []v1beta1.PersistentPodAnnotation{{Key: "foo"}},
+			PersistentPodFields: []v1beta1.PersistentPodField{
+				{Path: "/metadata/labels/app"},
+				{Path: "/status/podIP", RestorePath: "/metadata/annotations/pod-ip"},
+			},
+			RequiredPersistentTopology: &v1beta1.NodeTopologyTerm{Keys: []string{"kubernetes.io/hostname"}},
+		},
+		Status: v1beta1.PersistentPodStateStatus{
+			ObservedGeneration: 2,
+			PodStates: map[string]v1beta1.PodState{
+				"abcde": {
+					NodeName:    "node-a",
+					Annotations: map[string]string{"foo": "bar"},
+					Fields:      map[string]string{"/metadata/labels/app": `"web"`, "/status/podIP": `"10.0.0.1"`},
+				},
+			},
+		},
+	}
+
+	spoke := &PersistentPodState{}
+	require.NoError(t, spoke.ConvertFrom(original.DeepCopy()))
+	assert.Equal(t, "apps.kruise.io/cloneset-instance-id", spoke.Spec.PodIdentityLabel)
+	assert.Len(t, spoke.Spec.PersistentPodFields, 2)
+	assert.Equal(t, `"10.0.0.1"`, spoke.Status.PodStates["abcde"].Fields["/status/podIP"])
+
+	hub := &v1beta1.PersistentPodState{}
+	require.NoError(t, spoke.ConvertTo(hub))
+	assert.Equal(t, original.Spec, hub.Spec)
+	assert.Equal(t, original.Status, hub.Status)
+}
+
 func FuzzPersistentPodStateConversion(f *testing.F) {
 	f.Add("hostname", "zone", int32(100))
 	f.Fuzz(func(t *testing.T, requiredKey, preferredKey string, weight int32) {
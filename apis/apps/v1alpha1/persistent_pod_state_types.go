@@ -48,9 +48,19 @@ type PersistentPodStateSpec struct {
 	// current only support StatefulSet
 	TargetReference TargetReference `json:"targetRef"`
 
+	// PodIdentityLabel is the key of the pod label whose value identifies a pod across recreation,
+	// and the PodStates are keyed by the label value instead of the pod name.
+	// +optional
+	PodIdentityLabel string `json:"podIdentityLabel,omitempty"`
+
 	// Persist the annotations information of the pods that need to be saved
 	PersistentPodAnnotations []PersistentPodAnnotation `json:"persistentPodAnnotations,omitempty"`
 
+	// Persist the fields of the pods that need to be saved, and restore them when the pods are recreated
+	// for example /metadata/labels/app, /spec/containers/0/resources
+	// +optional
+	PersistentPodFields []PersistentPodField `json:"persistentPodFields,omitempty"`
+
 	// Pod rebuilt topology required for node labels
 	// for example kubernetes.io/hostname, failure-domain.beta.kubernetes.io/zone
 	RequiredPersistentTopology *NodeTopologyTerm `json:"requiredPersistentTopology,omitempty"`
@@ -78,6 +88,14 @@ type PersistentPodAnnotation struct {
 	Key string `json:"key"`
 }
 
+type PersistentPodField struct {
+	// Path is the JSON pointer (RFC 6901) of the pod field to be saved, e.g. /metadata/labels/app.
+	Path string `json:"path"`
+	// RestorePath is the JSON pointer of the pod field that the saved value is restored into, defaults to Path.
+	// +optional
+	RestorePath string `json:"restorePath,omitempty"`
+}
+
 type PersistentPodStateRetentionPolicyType string
 
 const (
@@ -104,6 +122,8 @@ type PodState struct {
 	NodeTopologyLabels map[string]string `json:"nodeTopologyLabels,omitempty"`
 	// pod persistent annotations
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// pod persistent fields, path -> JSON encoded value
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // +genclient
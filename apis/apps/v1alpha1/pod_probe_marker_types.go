@@ -70,7 +70,7 @@ type PodContainerProbe struct {
 	Probe ContainerProbeSpec `json:"probe"`
 	// According to the execution result of ContainerProbe, perform specific actions,
 	// such as: patch Pod labels, annotations, ReadinessGate Condition
-	// It cannot be null at the same time as PodConditionType.
+	// It cannot be null at the same time as PodConditionType and ScoreMarker.
 	// +patchMergeKey=state
 	// +patchStrategy=merge
 	MarkerPolicy []ProbeMarkerPolicy `json:"markerPolicy,omitempty"  patchStrategy:"merge" patchMergeKey:"state"`
@@ -79,14 +79,64 @@ type PodContainerProbe struct {
 	// For example PodConditionType=game.kruise.io/healthy, pod.status.condition.type = game.kruise.io/healthy.
 	// When probe is Succeeded, pod.status.condition.status = True. Otherwise, when the probe fails to execute, pod.status.condition.status = False.
 	PodConditionType string `json:"podConditionType,omitempty"`
+	// DerivedStates derives the custom states from the recent results of the probe, such as Degraded after
+	// several failures within a window, and the derived states can be used as the state of MarkerPolicy.
+	// They are only derived when the probe is Succeeded, and the first matched one takes precedence over Succeeded.
+	// +optional
+	DerivedStates []ProbeDerivedState `json:"derivedStates,omitempty"`
+	// ScoreMarker writes the score returned by the probe into pod labels or annotations,
+	// for example, the weight in the HTTP response body is written into controller.kubernetes.io/pod-deletion-cost.
+	// +optional
+	ScoreMarker *ProbeScoreMarker `json:"scoreMarker,omitempty"`
+}
+
+// ProbeDerivedState is a state derived from the recent results of the probe
+type ProbeDerivedState struct {
+	// Name of the derived state, which can not be Succeeded, Failed or Unknown.
+	Name ProbeState `json:"name"`
+	// FailureThreshold is the number of the failed probes within the window to enter the state,
+	// which can not be greater than 10.
+	FailureThreshold int32 `json:"failureThreshold"`
+	// WindowSeconds is the length of the window in seconds.
+	WindowSeconds int32 `json:"windowSeconds"`
+}
+
+// ProbeScoreMarker writes the score returned by the probe into pod labels or annotations.
+// The score is the message of the Succeeded probe, such as the HTTP response body or the exec output,
+// which must be an integer.
+type ProbeScoreMarker struct {
+	// Labels are the keys of pod labels the score is written into.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+	// Annotations are the keys of pod annotations the score is written into.
+	// +optional
+	Annotations []string `json:"annotations,omitempty"`
+	// DefaultScore is written when the probe is not Succeeded or the score is invalid.
+	// If it is nil, the labels and annotations are removed.
+	// +optional
+	DefaultScore *int32 `json:"defaultScore,omitempty"`
 }
 
 type ContainerProbeSpec struct {
 	v1.Probe `json:",inline"`
+	// GRPCTLS enables TLS for the gRPC probe, which connects without TLS by default.
+	// It is only valid when the probe handler is gRPC.
+	// +optional
+	GRPCTLS *GRPCProbeTLS `json:"grpcTLS,omitempty"`
+}
+
+// GRPCProbeTLS is the TLS config of the gRPC probe
+type GRPCProbeTLS struct {
+	// ServerName is used to verify the hostname of the server certificate, defaults to the pod ip.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify skips the verification of the server certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
 
 type ProbeMarkerPolicy struct {
-	// probe status, True or False
+	// probe status, Succeeded, Failed or the name of a derived state
 	// For example: State=Succeeded, annotations[controller.kubernetes.io/pod-deletion-cost] = '10'.
 	// State=Failed, annotations[controller.kubernetes.io/pod-deletion-cost] = '-10'.
 	// In addition, if State=Failed is not defined, Exec execution fails, and the annotations[controller.kubernetes.io/pod-deletion-cost] will be Deleted
@@ -95,6 +145,12 @@ type ProbeMarkerPolicy struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// Patch annotations pod.annotations
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// MinStableSeconds is the minimum seconds for which the probe must keep the state before the markers are applied,
+	// and the markers of the previous state are kept meanwhile. Setting it for Succeeded and Failed differently
+	// provides the hysteresis that avoids flapping markers. It is not supported for the derived states,
+	// which are debounced by their windows. Defaults to 0, the markers are applied immediately.
+	// +optional
+	MinStableSeconds int32 `json:"minStableSeconds,omitempty"`
 }
 
 type PodProbeMarkerStatus struct {
@@ -60,6 +60,12 @@ func (src *WorkloadSpread) ConvertTo(dstRaw conversion.Hub) error {
 			RescheduleCriticalSeconds: src.Spec.ScheduleStrategy.Adaptive.RescheduleCriticalSeconds,
 		}
 	}
+	if src.Spec.RebalanceStrategy != nil {
+		dst.Spec.RebalanceStrategy = &v1beta1.WorkloadSpreadRebalanceStrategy{
+			MaxEvictionsPerInterval: src.Spec.RebalanceStrategy.MaxEvictionsPerInterval,
+			IntervalSeconds:         src.Spec.RebalanceStrategy.IntervalSeconds,
+		}
+	}
 	if src.Spec.Subsets != nil {
 		dst.Spec.Subsets = make([]v1beta1.WorkloadSpreadSubset, len(src.Spec.Subsets))
 		for i, s := range src.Spec.Subsets {
@@ -79,6 +85,7 @@ func (src *WorkloadSpread) ConvertTo(dstRaw conversion.Hub) error {
 	// status
 	dst.Status = v1beta1.WorkloadSpreadStatus{
 		ObservedGeneration: src.Status.ObservedGeneration,
+		LastRebalanceTime:  src.Status.LastRebalanceTime,
 	}
 	if src.Status.SubsetStatuses != nil {
 		dst.Status.SubsetStatuses = convertSubsetStatusesToV1Beta1(src.Status.SubsetStatuses)
@@ -127,6 +134,12 @@ func (dst *WorkloadSpread) ConvertFrom(srcRaw conversion.Hub) error {
 			RescheduleCriticalSeconds: src.Spec.ScheduleStrategy.Adaptive.RescheduleCriticalSeconds,
 		}
 	}
+	if src.Spec.RebalanceStrategy != nil {
+		dst.Spec.RebalanceStrategy = &WorkloadSpreadRebalanceStrategy{
+			MaxEvictionsPerInterval: src.Spec.RebalanceStrategy.MaxEvictionsPerInterval,
+			IntervalSeconds:         src.Spec.RebalanceStrategy.IntervalSeconds,
+		}
+	}
 	if src.Spec.Subsets != nil {
 		dst.Spec.Subsets = make([]WorkloadSpreadSubset, len(src.Spec.Subsets))
 		for i, s := range src.Spec.Subsets {
@@ -146,6 +159,7 @@ func (dst *WorkloadSpread) ConvertFrom(srcRaw conversion.Hub) error {
 	// status
 	dst.Status = WorkloadSpreadStatus{
 		ObservedGeneration: src.Status.ObservedGeneration,
+		LastRebalanceTime:  src.Status.LastRebalanceTime,
 	}
 	if src.Status.SubsetStatuses != nil {
 		dst.Status.SubsetStatuses = convertSubsetStatusesToV1Alpha1(src.Status.SubsetStatuses)
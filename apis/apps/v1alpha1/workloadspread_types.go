@@ -41,6 +41,11 @@ type WorkloadSpreadSpec struct {
 	// ScheduleStrategy indicates the strategy the WorkloadSpread used to preform the schedule between each of subsets.
 	// +optional
 	ScheduleStrategy WorkloadSpreadScheduleStrategy `json:"scheduleStrategy,omitempty"`
+
+	// RebalanceStrategy indicates how the WorkloadSpread moves existing Pods out of subsets that have
+	// more Pods than their maxReplicas. If it is nil, existing Pods are never evicted for rebalancing.
+	// +optional
+	RebalanceStrategy *WorkloadSpreadRebalanceStrategy `json:"rebalanceStrategy,omitempty"`
 }
 
 // TargetReference contains enough information to let you identify a workload
@@ -146,6 +151,20 @@ type AdaptiveWorkloadSpreadStrategy struct {
 	RescheduleCriticalSeconds *int32 `json:"rescheduleCriticalSeconds,omitempty"`
 }
 
+// WorkloadSpreadRebalanceStrategy defines how the controller evicts surplus Pods from over-full subsets,
+// so that the target workload recreates them in subsets that still miss replicas.
+type WorkloadSpreadRebalanceStrategy struct {
+	// MaxEvictionsPerInterval is the maximum number of Pods that can be evicted in one interval.
+	// Default is 1.
+	// +optional
+	MaxEvictionsPerInterval *int32 `json:"maxEvictionsPerInterval,omitempty"`
+
+	// IntervalSeconds is the minimum duration between two batches of rebalance evictions.
+	// Default is 60.
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
+}
+
 // WorkloadSpreadSubset defines the details of a subset.
 type WorkloadSpreadSubset struct {
 	// Name should be unique between all of the subsets under one WorkloadSpread.
@@ -192,6 +211,10 @@ type WorkloadSpreadStatus struct {
 	// may be earlier than deletion of old-version pod. We have to calculate the pod subset distribution for
 	// each version.
 	VersionedSubsetStatuses map[string][]WorkloadSpreadSubsetStatus `json:"versionedSubsetStatuses,omitempty"`
+
+	// LastRebalanceTime is the last time the controller evicted Pods to rebalance the subsets.
+	// +optional
+	LastRebalanceTime *metav1.Time `json:"lastRebalanceTime,omitempty"`
 }
 
 type WorkloadSpreadSubsetConditionType string
@@ -614,6 +614,11 @@ func (in *CompletionPolicy) DeepCopy() *CompletionPolicy {
 func (in *ContainerProbe) DeepCopyInto(out *ContainerProbe) {
 	*out = *in
 	in.Probe.DeepCopyInto(&out.Probe)
+	if in.DerivedStates != nil {
+		in, out := &in.DerivedStates, &out.DerivedStates
+		*out = make([]ProbeDerivedState, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProbe.
@@ -630,6 +635,11 @@ func (in *ContainerProbe) DeepCopy() *ContainerProbe {
 func (in *ContainerProbeSpec) DeepCopyInto(out *ContainerProbeSpec) {
 	*out = *in
 	in.Probe.DeepCopyInto(&out.Probe)
+	if in.GRPCTLS != nil {
+		in, out := &in.GRPCTLS, &out.GRPCTLS
+		*out = new(GRPCProbeTLS)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProbeSpec.
@@ -647,6 +657,18 @@ func (in *ContainerProbeState) DeepCopyInto(out *ContainerProbeState) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Latency != nil {
+		in, out := &in.Latency, &out.Latency
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RecentResults != nil {
+		in, out := &in.RecentResults, &out.RecentResults
+		*out = make([]ProbeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerProbeState.
@@ -686,6 +708,47 @@ func (in *ContainerRecreateRequest) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRecreateRequestCaptureStrategy) DeepCopyInto(out *ContainerRecreateRequestCaptureStrategy) {
+	*out = *in
+	if in.TailLines != nil {
+		in, out := &in.TailLines, &out.TailLines
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestCaptureStrategy.
+func (in *ContainerRecreateRequestCaptureStrategy) DeepCopy() *ContainerRecreateRequestCaptureStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRecreateRequestCaptureStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRecreateRequestCapturedState) DeepCopyInto(out *ContainerRecreateRequestCapturedState) {
+	*out = *in
+	in.CaptureTime.DeepCopyInto(&out.CaptureTime)
+	if in.LastTerminationState != nil {
+		in, out := &in.LastTerminationState, &out.LastTerminationState
+		*out = new(corev1.ContainerStateTerminated)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestCapturedState.
+func (in *ContainerRecreateRequestCapturedState) DeepCopy() *ContainerRecreateRequestCapturedState {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRecreateRequestCapturedState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerRecreateRequestContainer) DeepCopyInto(out *ContainerRecreateRequestContainer) {
 	*out = *in
@@ -704,6 +767,11 @@ func (in *ContainerRecreateRequestContainer) DeepCopyInto(out *ContainerRecreate
 		*out = new(ContainerRecreateRequestContainerContext)
 		**out = **in
 	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(ContainerRecreateRequestContainerOverride)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestContainer.
@@ -731,9 +799,34 @@ func (in *ContainerRecreateRequestContainerContext) DeepCopy() *ContainerRecreat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRecreateRequestContainerOverride) DeepCopyInto(out *ContainerRecreateRequestContainerOverride) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ContainerRecreateRequestEnvOverride, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestContainerOverride.
+func (in *ContainerRecreateRequestContainerOverride) DeepCopy() *ContainerRecreateRequestContainerOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRecreateRequestContainerOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerRecreateRequestContainerRecreateState) DeepCopyInto(out *ContainerRecreateRequestContainerRecreateState) {
 	*out = *in
+	if in.Captured != nil {
+		in, out := &in.Captured, &out.Captured
+		*out = new(ContainerRecreateRequestCapturedState)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestContainerRecreateState.
@@ -746,6 +839,21 @@ func (in *ContainerRecreateRequestContainerRecreateState) DeepCopy() *ContainerR
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRecreateRequestEnvOverride) DeepCopyInto(out *ContainerRecreateRequestEnvOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestEnvOverride.
+func (in *ContainerRecreateRequestEnvOverride) DeepCopy() *ContainerRecreateRequestEnvOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRecreateRequestEnvOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerRecreateRequestList) DeepCopyInto(out *ContainerRecreateRequestList) {
 	*out = *in
@@ -825,7 +933,9 @@ func (in *ContainerRecreateRequestStatus) DeepCopyInto(out *ContainerRecreateReq
 	if in.ContainerRecreateStates != nil {
 		in, out := &in.ContainerRecreateStates, &out.ContainerRecreateStates
 		*out = make([]ContainerRecreateRequestContainerRecreateState, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -852,6 +962,11 @@ func (in *ContainerRecreateRequestStrategy) DeepCopyInto(out *ContainerRecreateR
 		*out = new(int64)
 		**out = **in
 	}
+	if in.CaptureBeforeRecreate != nil {
+		in, out := &in.CaptureBeforeRecreate, &out.CaptureBeforeRecreate
+		*out = new(ContainerRecreateRequestCaptureStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecreateRequestStrategy.
@@ -879,6 +994,158 @@ func (in *ContainerRecreateRequestSyncContainerStatus) DeepCopy() *ContainerRecr
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRestartJob) DeepCopyInto(out *ContainerRestartJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRestartJob.
+func (in *ContainerRestartJob) DeepCopy() *ContainerRestartJob {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRestartJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerRestartJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRestartJobContainer) DeepCopyInto(out *ContainerRestartJobContainer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRestartJobContainer.
+func (in *ContainerRestartJobContainer) DeepCopy() *ContainerRestartJobContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRestartJobContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRestartJobList) DeepCopyInto(out *ContainerRestartJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ContainerRestartJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRestartJobList.
+func (in *ContainerRestartJobList) DeepCopy() *ContainerRestartJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRestartJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerRestartJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRestartJobSpec) DeepCopyInto(out *ContainerRestartJobSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetReference != nil {
+		in, out := &in.TargetReference, &out.TargetReference
+		*out = new(TargetReference)
+		**out = **in
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerRestartJobContainer, len(*in))
+		copy(*out, *in)
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(ContainerRecreateRequestStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRestartJobSpec.
+func (in *ContainerRestartJobSpec) DeepCopy() *ContainerRestartJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRestartJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRestartJobStatus) DeepCopyInto(out *ContainerRestartJobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailedPods != nil {
+		in, out := &in.FailedPods, &out.FailedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRestartJobStatus.
+func (in *ContainerRestartJobStatus) DeepCopy() *ContainerRestartJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRestartJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CronJobTemplate) DeepCopyInto(out *CronJobTemplate) {
 	*out = *in
@@ -1113,6 +1380,21 @@ func (in *EphemeralJob) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralJobCleanupPolicy) DeepCopyInto(out *EphemeralJobCleanupPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralJobCleanupPolicy.
+func (in *EphemeralJobCleanupPolicy) DeepCopy() *EphemeralJobCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralJobCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralJobCondition) DeepCopyInto(out *EphemeralJobCondition) {
 	*out = *in
@@ -1130,6 +1412,26 @@ func (in *EphemeralJobCondition) DeepCopy() *EphemeralJobCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralJobContainerOutput) DeepCopyInto(out *EphemeralJobContainerOutput) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralJobContainerOutput.
+func (in *EphemeralJobContainerOutput) DeepCopy() *EphemeralJobContainerOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralJobContainerOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralJobList) DeepCopyInto(out *EphemeralJobList) {
 	*out = *in
@@ -1162,6 +1464,48 @@ func (in *EphemeralJobList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralJobOutputCollection) DeepCopyInto(out *EphemeralJobOutputCollection) {
+	*out = *in
+	if in.TailLines != nil {
+		in, out := &in.TailLines, &out.TailLines
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralJobOutputCollection.
+func (in *EphemeralJobOutputCollection) DeepCopy() *EphemeralJobOutputCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralJobOutputCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralJobPodOutput) DeepCopyInto(out *EphemeralJobPodOutput) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]EphemeralJobContainerOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralJobPodOutput.
+func (in *EphemeralJobPodOutput) DeepCopy() *EphemeralJobPodOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(EphemeralJobPodOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralJobSpec) DeepCopyInto(out *EphemeralJobSpec) {
 	*out = *in
@@ -1191,6 +1535,16 @@ func (in *EphemeralJobSpec) DeepCopyInto(out *EphemeralJobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.CleanupPolicy != nil {
+		in, out := &in.CleanupPolicy, &out.CleanupPolicy
+		*out = new(EphemeralJobCleanupPolicy)
+		**out = **in
+	}
+	if in.OutputCollection != nil {
+		in, out := &in.OutputCollection, &out.OutputCollection
+		*out = new(EphemeralJobOutputCollection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralJobSpec.
@@ -1221,6 +1575,13 @@ func (in *EphemeralJobStatus) DeepCopyInto(out *EphemeralJobStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]EphemeralJobPodOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralJobStatus.
@@ -1263,6 +1624,21 @@ func (in *FailurePolicy) DeepCopy() *FailurePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCProbeTLS) DeepCopyInto(out *GRPCProbeTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCProbeTLS.
+func (in *GRPCProbeTLS) DeepCopy() *GRPCProbeTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCProbeTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageListPullJob) DeepCopyInto(out *ImageListPullJob) {
 	*out = *in
@@ -1989,6 +2365,21 @@ func (in *PersistentPodAnnotation) DeepCopy() *PersistentPodAnnotation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentPodField) DeepCopyInto(out *PersistentPodField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentPodField.
+func (in *PersistentPodField) DeepCopy() *PersistentPodField {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentPodField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentPodState) DeepCopyInto(out *PersistentPodState) {
 	*out = *in
@@ -2057,6 +2448,11 @@ func (in *PersistentPodStateSpec) DeepCopyInto(out *PersistentPodStateSpec) {
 		*out = make([]PersistentPodAnnotation, len(*in))
 		copy(*out, *in)
 	}
+	if in.PersistentPodFields != nil {
+		in, out := &in.PersistentPodFields, &out.PersistentPodFields
+		*out = make([]PersistentPodField, len(*in))
+		copy(*out, *in)
+	}
 	if in.RequiredPersistentTopology != nil {
 		in, out := &in.RequiredPersistentTopology, &out.RequiredPersistentTopology
 		*out = new(NodeTopologyTerm)
@@ -2114,6 +2510,16 @@ func (in *PodContainerProbe) DeepCopyInto(out *PodContainerProbe) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DerivedStates != nil {
+		in, out := &in.DerivedStates, &out.DerivedStates
+		*out = make([]ProbeDerivedState, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScoreMarker != nil {
+		in, out := &in.ScoreMarker, &out.ScoreMarker
+		*out = new(ProbeScoreMarker)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodContainerProbe.
@@ -2288,6 +2694,13 @@ func (in *PodState) DeepCopyInto(out *PodState) {
 			(*out)[key] = val
 		}
 	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodState.
@@ -2316,6 +2729,21 @@ func (in *PreferredTopologyTerm) DeepCopy() *PreferredTopologyTerm {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeDerivedState) DeepCopyInto(out *ProbeDerivedState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeDerivedState.
+func (in *ProbeDerivedState) DeepCopy() *ProbeDerivedState {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeDerivedState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeHandler) DeepCopyInto(out *ProbeHandler) {
 	*out = *in
@@ -2375,6 +2803,53 @@ func (in *ProbeMarkerPolicy) DeepCopy() *ProbeMarkerPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeResult) DeepCopyInto(out *ProbeResult) {
+	*out = *in
+	in.ProbeTime.DeepCopyInto(&out.ProbeTime)
+	out.Latency = in.Latency
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeResult.
+func (in *ProbeResult) DeepCopy() *ProbeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeScoreMarker) DeepCopyInto(out *ProbeScoreMarker) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultScore != nil {
+		in, out := &in.DefaultScore, &out.DefaultScore
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeScoreMarker.
+func (in *ProbeScoreMarker) DeepCopy() *ProbeScoreMarker {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeScoreMarker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullPolicy) DeepCopyInto(out *PullPolicy) {
 	*out = *in
@@ -3810,6 +4285,31 @@ func (in *WorkloadSpreadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSpreadRebalanceStrategy) DeepCopyInto(out *WorkloadSpreadRebalanceStrategy) {
+	*out = *in
+	if in.MaxEvictionsPerInterval != nil {
+		in, out := &in.MaxEvictionsPerInterval, &out.MaxEvictionsPerInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpreadRebalanceStrategy.
+func (in *WorkloadSpreadRebalanceStrategy) DeepCopy() *WorkloadSpreadRebalanceStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSpreadRebalanceStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadSpreadScheduleStrategy) DeepCopyInto(out *WorkloadSpreadScheduleStrategy) {
 	*out = *in
@@ -3851,6 +4351,11 @@ func (in *WorkloadSpreadSpec) DeepCopyInto(out *WorkloadSpreadSpec) {
 		}
 	}
 	in.ScheduleStrategy.DeepCopyInto(&out.ScheduleStrategy)
+	if in.RebalanceStrategy != nil {
+		in, out := &in.RebalanceStrategy, &out.RebalanceStrategy
+		*out = new(WorkloadSpreadRebalanceStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpreadSpec.
@@ -3891,6 +4396,10 @@ func (in *WorkloadSpreadStatus) DeepCopyInto(out *WorkloadSpreadStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.LastRebalanceTime != nil {
+		in, out := &in.LastRebalanceTime, &out.LastRebalanceTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpreadStatus.
@@ -99,6 +99,42 @@ type ImagePullJobTemplate struct {
 	// One of Always, IfNotPresent. Defaults to IfNotPresent.
 	// +optional
 	ImagePullPolicy ImagePullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// PeerToPeer enables nodes to fetch the image layers from the nodes which already hold the image
+	// before pulling from the registry. The nodes are synced in a fan-out tree limited by parallelism.
+	// +optional
+	PeerToPeer *ImagePullJobPeerToPeer `json:"peerToPeer,omitempty"`
+
+	// PinDigest resolves the tag of the image to a digest on the first node, and then pulls the digest on
+	// the other nodes, so that all nodes get identical content even if the tag is moved during the job.
+	// +optional
+	PinDigest bool `json:"pinDigest,omitempty"`
+
+	// Verification is the policy to verify the image on the nodes before pulling it.
+	// The nodes failing the verification are marked failed without pulling.
+	// +optional
+	Verification *ImagePullJobVerification `json:"verification,omitempty"`
+}
+
+// ImagePullJobPeerToPeer defines the peer-to-peer distribution of the image
+type ImagePullJobPeerToPeer struct {
+	// FanOut is the maximum number of nodes that one node holding the image serves at the same time.
+	// Defaults to 3
+	// +optional
+	FanOut *int32 `json:"fanOut,omitempty"`
+}
+
+// ImagePullJobVerification defines the policy to verify the image before pulling it
+type ImagePullJobVerification struct {
+	// PublicKeys are the PEM encoded public keys. If specified, the image must have a cosign signature
+	// stored in its repository, which is signed by any of the keys.
+	// +optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+
+	// AllowedDigestsConfigMap is the name of the ConfigMap in the namespace of the job. If specified,
+	// the digest of the image must be listed in the values of it, which are digests separated by whitespaces.
+	// +optional
+	AllowedDigestsConfigMap string `json:"allowedDigestsConfigMap,omitempty"`
 }
 
 // ImagePullJobPodSelector is a selector over pods
@@ -160,6 +196,77 @@ type ImagePullJobStatus struct {
 	// The nodes that failed to pull the image.
 	// +optional
 	FailedNodes []string `json:"failedNodes,omitempty"`
+
+	// The telemetry aggregated from the completed pulling tasks.
+	// +optional
+	PullStats *ImagePullJobStats `json:"pullStats,omitempty"`
+
+	// The digest which the image is resolved to and pinned on the nodes, if pinDigest is enabled.
+	// +optional
+	ResolvedDigest string `json:"resolvedDigest,omitempty"`
+
+	// The reasons of the nodes that failed to pull the image.
+	// +optional
+	FailedNodeDetails []ImagePullJobFailedNode `json:"failedNodeDetails,omitempty"`
+}
+
+// ImagePullJobFailedNode is the node that failed to pull the image
+type ImagePullJobFailedNode struct {
+	// NodeName is the name of the node.
+	NodeName string `json:"nodeName"`
+
+	// Message is the reason reported by the node.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ImagePullJobStats is the telemetry aggregated from the pulling tasks on nodes.
+type ImagePullJobStats struct {
+	// The number of pulling tasks that the telemetry is aggregated from.
+	SampledNodes int32 `json:"sampledNodes"`
+
+	// The percentiles of the pulling duration.
+	// +optional
+	Duration *ImagePullDurationPercentiles `json:"duration,omitempty"`
+
+	// The percentiles of the downloaded bytes.
+	// +optional
+	DownloadedBytes *ImagePullBytesPercentiles `json:"downloadedBytes,omitempty"`
+
+	// The slowest nodes, at most 5, sorted by the pulling duration in descending order.
+	// +optional
+	SlowestNodes []ImagePullNodeStats `json:"slowestNodes,omitempty"`
+}
+
+// ImagePullDurationPercentiles contains the percentiles of the pulling duration.
+type ImagePullDurationPercentiles struct {
+	P50 metav1.Duration `json:"p50"`
+	P90 metav1.Duration `json:"p90"`
+	P99 metav1.Duration `json:"p99"`
+}
+
+// ImagePullBytesPercentiles contains the percentiles of the downloaded bytes.
+type ImagePullBytesPercentiles struct {
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P99 int64 `json:"p99"`
+}
+
+// ImagePullNodeStats is the telemetry of the pulling task on a node.
+type ImagePullNodeStats struct {
+	// Name of the node.
+	NodeName string `json:"nodeName"`
+
+	// Registry is the registry endpoint the image is pulled from.
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// Duration is the time spent on pulling the image.
+	Duration metav1.Duration `json:"duration"`
+
+	// DownloadedBytes is the number of bytes downloaded.
+	// +optional
+	DownloadedBytes int64 `json:"downloadedBytes,omitempty"`
 }
 
 // +genclient
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +29,47 @@ type NodeImageSpec struct {
 	// Specifies images to be pulled on this node
 	// It can not be more than 256 for each NodeImage
 	Images map[string]ImageSpec `json:"images,omitempty"`
+
+	// GCPolicy defines how kruise-daemon removes the unused images on this node.
+	// The images in spec.images, the images used by containers on this node and the images
+	// referenced by the pod templates of workloads are never removed.
+	// +optional
+	GCPolicy *ImageGCPolicy `json:"gcPolicy,omitempty"`
+}
+
+const (
+	// TemplateImagesConfigMapName is the name of ConfigMap in kruise-daemon-config namespace, which contains
+	// the images referenced by the pod templates of workloads. They are never removed by image garbage collection.
+	TemplateImagesConfigMapName = "kruise-template-images"
+	// TemplateImagesKey is the key of the JSON list of images in the ConfigMap of TemplateImagesConfigMapName.
+	TemplateImagesKey = "images"
+)
+
+// ImageGCPolicy defines the garbage collection policy of images on the node.
+type ImageGCPolicy struct {
+	// KeepImages are the images never removed, in the form of name or name:tag, e.g. nginx or nginx:1.25.
+	// An image without tag keeps all tags of it.
+	// +optional
+	KeepImages []string `json:"keepImages,omitempty"`
+
+	// MaxAgeSeconds is the max seconds that an image can be unused, the age of an image is counted from
+	// the last time it was observed to be used by containers or, if never used, first detected by kruise-daemon.
+	// +optional
+	MaxAgeSeconds *int64 `json:"maxAgeSeconds,omitempty"`
+
+	// MaxCountPerRepository is the max number of images kept for each repository, the least recently used
+	// images beyond it are removed.
+	// +optional
+	MaxCountPerRepository *int32 `json:"maxCountPerRepository,omitempty"`
+
+	// ImageFsUsageTarget is the target of used bytes of the image filesystem reported by the runtime,
+	// the least recently used images are removed until the usage is below it.
+	// +optional
+	ImageFsUsageTarget *resource.Quantity `json:"imageFsUsageTarget,omitempty"`
+
+	// IntervalSeconds is the interval of garbage collection. Defaults to 300.
+	// +optional
+	IntervalSeconds *int32 `json:"intervalSeconds,omitempty"`
 }
 
 // ImageSpec defines the pulling spec of an image
@@ -123,6 +165,33 @@ type ImageTagSpec struct {
 	// One of Always, IfNotPresent. Defaults to IfNotPresent.
 	// +optional
 	ImagePullPolicy ImagePullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// PeerToPeer indicates kruise-daemon to fetch the image from peers and serve it to other nodes.
+	// +optional
+	PeerToPeer *ImageTagPeerToPeer `json:"peerToPeer,omitempty"`
+
+	// Verification indicates kruise-daemon to verify the image before pulling it, and the verified image is pulled by digest.
+	// +optional
+	Verification *ImageTagVerification `json:"verification,omitempty"`
+}
+
+// ImageTagVerification defines the policy to verify the image before pulling it
+type ImageTagVerification struct {
+	// PublicKeys are the PEM encoded public keys, the image must have a cosign signature signed by any of them.
+	// +optional
+	PublicKeys []string `json:"publicKeys,omitempty"`
+
+	// AllowedDigests is the allowlist of the digest of the image.
+	// +optional
+	AllowedDigests []string `json:"allowedDigests,omitempty"`
+}
+
+// ImageTagPeerToPeer defines the peers to fetch the image from
+type ImageTagPeerToPeer struct {
+	// Peers are the endpoints of kruise-daemon on the nodes which hold the image, in order of preference.
+	// kruise-daemon falls back to the registry if the image can not be fetched from any of them.
+	// +optional
+	Peers []string `json:"peers,omitempty"`
 }
 
 // ImageTagPullPolicy defines the policy of the pulling task
@@ -179,6 +248,91 @@ type NodeImageStatus struct {
 	// the time when the node's image pulling is completed, and use it to trigger the operation of the upper system.
 	// +optional
 	FirstSyncStatus *SyncStatus `json:"firstSyncStatus,omitempty"`
+
+	// The result of the latest image garbage collection on this node.
+	// +optional
+	GarbageCollection *ImageGCStatus `json:"garbageCollection,omitempty"`
+
+	// The endpoint on which kruise-daemon serves the cached image layers to peers,
+	// empty if peer-to-peer distribution is disabled on this node.
+	// +optional
+	PeerEndpoint string `json:"peerEndpoint,omitempty"`
+
+	// The inventory of all images on the node, including the images not pulled by Kruise.
+	// It is reported only if the NodeImageInventory feature is enabled on kruise-daemon.
+	// +optional
+	Inventory *NodeImageInventory `json:"inventory,omitempty"`
+}
+
+// NodeImageInventory is the list of images on the node.
+type NodeImageInventory struct {
+	// The last time the inventory changed.
+	// +optional
+	UpdateTime *metav1.Time `json:"updateTime,omitempty"`
+
+	// The images on the node, sorted by the first reference of each image.
+	// +optional
+	Images []InventoryImage `json:"images,omitempty"`
+}
+
+// InventoryImage is an image on the node. The references are in the familiar form used by
+// NodeImage spec, e.g. nginx:1.25 or registry.example.com/app@sha256:...
+type InventoryImage struct {
+	// The tagged references of the image.
+	// +optional
+	RepoTags []string `json:"repoTags,omitempty"`
+
+	// The digested references of the image.
+	// +optional
+	RepoDigests []string `json:"repoDigests,omitempty"`
+
+	// The size of the image in bytes.
+	// +optional
+	Size int64 `json:"size,omitempty"`
+}
+
+// ImageGCStatus is the result of image garbage collection.
+type ImageGCStatus struct {
+	// The time of the latest garbage collection.
+	// +optional
+	LastGCTime *metav1.Time `json:"lastGCTime,omitempty"`
+
+	// The used bytes of the image filesystem observed after the latest garbage collection.
+	// +optional
+	ImageFsUsedBytes int64 `json:"imageFsUsedBytes,omitempty"`
+
+	// The recently removed images, at most 20, sorted by removed time in descending order.
+	// +optional
+	RemovedImages []RemovedImage `json:"removedImages,omitempty"`
+
+	// The error message of the latest garbage collection.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The last used time of the images that are not used by containers, keyed by image ID.
+	// It is restored after kruise-daemon restarts, so that the ages of images are not reset.
+	// +optional
+	UnusedImages map[string]metav1.Time `json:"unusedImages,omitempty"`
+}
+
+// RemovedImage is an image removed by garbage collection.
+type RemovedImage struct {
+	// Represents the image, in the form of name:tag if the image has tags, otherwise the image ID.
+	Image string `json:"image"`
+
+	// Represents the ID of this image.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// The size of the image.
+	// +optional
+	Size int64 `json:"size,omitempty"`
+
+	// The reason why the image is removed, one of MaxAge, MaxCountPerRepository and ImageFsUsage.
+	Reason string `json:"reason"`
+
+	// The time when the image is removed.
+	RemovedTime metav1.Time `json:"removedTime"`
 }
 
 // ImageStatus defines the pulling status of an image
@@ -219,9 +373,45 @@ type ImageTagStatus struct {
 	// +optional
 	ImageID string `json:"imageID,omitempty"`
 
+	// Represents the digest of the manifest of this image in the repository.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
 	// Represents the summary information of this node
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// Represents the telemetry of the latest pulling task of this tag.
+	// +optional
+	PullStats *ImagePullStats `json:"pullStats,omitempty"`
+}
+
+// ImagePullStats is the telemetry of an image pulling task on the node.
+type ImagePullStats struct {
+	// Registry is the registry endpoint the image is pulled from.
+	// +optional
+	Registry string `json:"registry,omitempty"`
+
+	// DownloadedBytes is the number of bytes downloaded. If the runtime does not report the pulling progress,
+	// it is the size of the pulled image.
+	// +optional
+	DownloadedBytes int64 `json:"downloadedBytes,omitempty"`
+
+	// TotalBytes is the number of bytes to download, it is zero if unknown.
+	// +optional
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+
+	// LayersCompleted is the number of layers completed.
+	// +optional
+	LayersCompleted int32 `json:"layersCompleted,omitempty"`
+
+	// LayersTotal is the number of layers of the image, it is zero if unknown.
+	// +optional
+	LayersTotal int32 `json:"layersTotal,omitempty"`
+
+	// Duration is the time spent on the latest attempt of pulling the image, excluding the backoff of retries.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // ImagePullPhase defines the tasks status
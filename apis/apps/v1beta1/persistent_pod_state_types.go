@@ -24,12 +24,24 @@ import (
 type PersistentPodStateSpec struct {
 	// TargetReference contains enough information to let you identify a workload for PersistentPodState
 	// Selector and TargetReference are mutually exclusive, TargetReference is priority to take effect
-	// current only support StatefulSet
+	// current support StatefulSet, CloneSet and the workloads in PPS_Watch_Custom_Workload_WhiteList
 	TargetReference TargetReference `json:"targetRef"`
 
+	// PodIdentityLabel is the key of the pod label whose value identifies a pod across recreation,
+	// and the PodStates are keyed by the label value instead of the pod name.
+	// Defaults to apps.kruise.io/cloneset-instance-id for CloneSet, whose pods get new names on recreation,
+	// so it works for the CloneSet with EnablePVCReuse which keeps the instance id of the recreated pods.
+	// +optional
+	PodIdentityLabel string `json:"podIdentityLabel,omitempty"`
+
 	// Persist the annotations information of the pods that need to be saved
 	PersistentPodAnnotations []PersistentPodAnnotation `json:"persistentPodAnnotations,omitempty"`
 
+	// Persist the fields of the pods that need to be saved, and restore them when the pods are recreated
+	// for example /metadata/labels/app, /spec/containers/0/resources
+	// +optional
+	PersistentPodFields []PersistentPodField `json:"persistentPodFields,omitempty"`
+
 	// Pod rebuilt topology required for node labels
 	// for example kubernetes.io/hostname, failure-domain.beta.kubernetes.io/zone
 	RequiredPersistentTopology *NodeTopologyTerm `json:"requiredPersistentTopology,omitempty"`
@@ -59,6 +71,17 @@ type PersistentPodAnnotation struct {
 	Key string `json:"key"`
 }
 
+type PersistentPodField struct {
+	// Path is the JSON pointer (RFC 6901) of the pod field to be saved, e.g. /metadata/labels/app.
+	// It must be in metadata.labels, metadata.annotations, spec or status of the pod.
+	Path string `json:"path"`
+	// RestorePath is the JSON pointer of the pod field that the saved value is restored into, defaults to Path.
+	// It must be in metadata.labels, metadata.annotations or spec of the pod, so it is required if Path is in status,
+	// e.g. save /status/podIP and restore it into an annotation which is read by the CNI plugin.
+	// +optional
+	RestorePath string `json:"restorePath,omitempty"`
+}
+
 // +kubebuilder:validation:Enum=WhenScaled;WhenDeleted
 type PersistentPodStateRetentionPolicyType string
 
@@ -72,7 +95,7 @@ type PersistentPodStateStatus struct {
 	// PersistentPodState's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration"`
 	// When the pod is ready, record some status information of the pod, such as: labels, annotations, topologies, etc.
-	// map[string]PodState -> map[Pod.Name]PodState
+	// map[string]PodState -> map[Pod.Name]PodState, or map[Pod identity label value]PodState if PodIdentityLabel takes effect
 	PodStates map[string]PodState `json:"podStates,omitempty"`
 }
 
@@ -84,6 +107,8 @@ type PodState struct {
 	NodeTopologyLabels map[string]string `json:"nodeTopologyLabels,omitempty"`
 	// pod persistent annotations
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// pod persistent fields, path -> JSON encoded value
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // TargetReference contains enough information to let you identify a workload
@@ -33,6 +33,59 @@ type ResourceDistributionSpec struct {
 
 	// Targets defines the namespaces that users want to distribute to.
 	Targets ResourceDistributionTargets `json:"targets"`
+
+	// Template enables rendering per-namespace values into Resource.
+	// If it is set, the string fields of Resource, except metadata.name and metadata.namespace,
+	// are rendered as Go text/template for each target namespace with the following data:
+	// .Namespace.Name, .Namespace.Labels, .Namespace.Annotations and .Values.
+	// +optional
+	Template *ResourceDistributionTemplate `json:"template,omitempty"`
+
+	// SyncPolicy defines how the distributed resources are kept in sync with Resource.
+	// Defaults to Enforce.
+	// +optional
+	SyncPolicy ResourceDistributionSyncPolicyType `json:"syncPolicy,omitempty"`
+}
+
+// ResourceDistributionSyncPolicyType defines how the distributed resources are kept in sync.
+// +kubebuilder:validation:Enum=Enforce;CreateOnly;Observe
+type ResourceDistributionSyncPolicyType string
+
+const (
+	// EnforceResourceDistributionSyncPolicyType means the distributed resources are watched,
+	// and the changes to them are reverted immediately.
+	EnforceResourceDistributionSyncPolicyType ResourceDistributionSyncPolicyType = "Enforce"
+
+	// CreateOnlyResourceDistributionSyncPolicyType means the distributed resources are never overwritten once created,
+	// the drifts of them are reported in status.
+	CreateOnlyResourceDistributionSyncPolicyType ResourceDistributionSyncPolicyType = "CreateOnly"
+
+	// ObserveResourceDistributionSyncPolicyType means the resources are neither created, updated nor deleted,
+	// the drifts of them are reported in status only.
+	ObserveResourceDistributionSyncPolicyType ResourceDistributionSyncPolicyType = "Observe"
+)
+
+// ResourceDistributionTemplate defines the values rendered into Resource.
+type ResourceDistributionTemplate struct {
+	// Values are the default values referred by .Values in Resource.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
+
+	// NamespaceValues override Values for the listed namespaces.
+	// +patchMergeKey=name
+	// +patchStrategy=merge
+	// +optional
+	NamespaceValues []ResourceDistributionNamespaceValues `json:"namespaceValues,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+}
+
+// ResourceDistributionNamespaceValues contains the values for a namespace
+type ResourceDistributionNamespaceValues struct {
+	// Namespace name
+	Name string `json:"name"`
+
+	// Values override the default values of Template for this namespace.
+	// +optional
+	Values map[string]string `json:"values,omitempty"`
 }
 
 // ResourceDistributionTargets defines the targets of Resource.
@@ -141,6 +194,10 @@ const (
 
 	// ResourceDistributionDeleteResourceFailed means some delete operations about Resource are failed.
 	ResourceDistributionDeleteResourceFailed ResourceDistributionConditionType = "DeleteResourceFailed"
+
+	// ResourceDistributionResourceDrifted means some distributed resources differ from Resource and are not reverted
+	// because of SyncPolicy.
+	ResourceDistributionResourceDrifted ResourceDistributionConditionType = "ResourceDrifted"
 )
 
 type ResourceDistributionConditionStatus string
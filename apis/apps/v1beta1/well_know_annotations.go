@@ -5,4 +5,7 @@ const (
 	AnnotationUsingEnhancedLiveness = "apps.kruise.io/using-enhanced-liveness"
 	// AnnotationUsingEnhancedLiveness indicates the backup probe (json types) of the pod native container livenessProbe configuration.
 	AnnotationNativeContainerProbeContext = "apps.kruise.io/container-probe-context"
+	// AnnotationEnhancedLivenessRestartBudget is the max number (e.g. 2) or percentage (e.g. 10%) of the pods of a workload
+	// that can be restarted by the enhanced liveness probe within the restart window, defaults to 10%.
+	AnnotationEnhancedLivenessRestartBudget = "apps.kruise.io/enhanced-liveness-restart-budget"
 )
@@ -825,6 +825,79 @@ func (in *FailurePolicy) DeepCopy() *FailurePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageGCPolicy) DeepCopyInto(out *ImageGCPolicy) {
+	*out = *in
+	if in.KeepImages != nil {
+		in, out := &in.KeepImages, &out.KeepImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxCountPerRepository != nil {
+		in, out := &in.MaxCountPerRepository, &out.MaxCountPerRepository
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ImageFsUsageTarget != nil {
+		in, out := &in.ImageFsUsageTarget, &out.ImageFsUsageTarget
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGCPolicy.
+func (in *ImageGCPolicy) DeepCopy() *ImageGCPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageGCPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageGCStatus) DeepCopyInto(out *ImageGCStatus) {
+	*out = *in
+	if in.LastGCTime != nil {
+		in, out := &in.LastGCTime, &out.LastGCTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RemovedImages != nil {
+		in, out := &in.RemovedImages, &out.RemovedImages
+		*out = make([]RemovedImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnusedImages != nil {
+		in, out := &in.UnusedImages, &out.UnusedImages
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGCStatus.
+func (in *ImageGCStatus) DeepCopy() *ImageGCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageGCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageListPullJobSpec) DeepCopyInto(out *ImageListPullJobSpec) {
 	*out = *in
@@ -897,6 +970,54 @@ func (in *ImageListPullJobTemplateSpec) DeepCopy() *ImageListPullJobTemplateSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullBytesPercentiles) DeepCopyInto(out *ImagePullBytesPercentiles) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullBytesPercentiles.
+func (in *ImagePullBytesPercentiles) DeepCopy() *ImagePullBytesPercentiles {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullBytesPercentiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullDurationPercentiles) DeepCopyInto(out *ImagePullDurationPercentiles) {
+	*out = *in
+	out.P50 = in.P50
+	out.P90 = in.P90
+	out.P99 = in.P99
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullDurationPercentiles.
+func (in *ImagePullDurationPercentiles) DeepCopy() *ImagePullDurationPercentiles {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullDurationPercentiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullJobFailedNode) DeepCopyInto(out *ImagePullJobFailedNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullJobFailedNode.
+func (in *ImagePullJobFailedNode) DeepCopy() *ImagePullJobFailedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullJobFailedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullJobNodeSelector) DeepCopyInto(out *ImagePullJobNodeSelector) {
 	*out = *in
@@ -918,6 +1039,26 @@ func (in *ImagePullJobNodeSelector) DeepCopy() *ImagePullJobNodeSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullJobPeerToPeer) DeepCopyInto(out *ImagePullJobPeerToPeer) {
+	*out = *in
+	if in.FanOut != nil {
+		in, out := &in.FanOut, &out.FanOut
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullJobPeerToPeer.
+func (in *ImagePullJobPeerToPeer) DeepCopy() *ImagePullJobPeerToPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullJobPeerToPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullJobPodSelector) DeepCopyInto(out *ImagePullJobPodSelector) {
 	*out = *in
@@ -950,6 +1091,36 @@ func (in *ImagePullJobSpec) DeepCopy() *ImagePullJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullJobStats) DeepCopyInto(out *ImagePullJobStats) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(ImagePullDurationPercentiles)
+		**out = **in
+	}
+	if in.DownloadedBytes != nil {
+		in, out := &in.DownloadedBytes, &out.DownloadedBytes
+		*out = new(ImagePullBytesPercentiles)
+		**out = **in
+	}
+	if in.SlowestNodes != nil {
+		in, out := &in.SlowestNodes, &out.SlowestNodes
+		*out = make([]ImagePullNodeStats, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullJobStats.
+func (in *ImagePullJobStats) DeepCopy() *ImagePullJobStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullJobStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePullJobStatus) DeepCopyInto(out *ImagePullJobStatus) {
 	*out = *in
@@ -966,6 +1137,16 @@ func (in *ImagePullJobStatus) DeepCopyInto(out *ImagePullJobStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullStats != nil {
+		in, out := &in.PullStats, &out.PullStats
+		*out = new(ImagePullJobStats)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedNodeDetails != nil {
+		in, out := &in.FailedNodeDetails, &out.FailedNodeDetails
+		*out = make([]ImagePullJobFailedNode, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullJobStatus.
@@ -1012,6 +1193,16 @@ func (in *ImagePullJobTemplate) DeepCopyInto(out *ImagePullJobTemplate) {
 		*out = new(SandboxConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PeerToPeer != nil {
+		in, out := &in.PeerToPeer, &out.PeerToPeer
+		*out = new(ImagePullJobPeerToPeer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(ImagePullJobVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullJobTemplate.
@@ -1024,6 +1215,62 @@ func (in *ImagePullJobTemplate) DeepCopy() *ImagePullJobTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullJobVerification) DeepCopyInto(out *ImagePullJobVerification) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullJobVerification.
+func (in *ImagePullJobVerification) DeepCopy() *ImagePullJobVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullJobVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullNodeStats) DeepCopyInto(out *ImagePullNodeStats) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullNodeStats.
+func (in *ImagePullNodeStats) DeepCopy() *ImagePullNodeStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullNodeStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullStats) DeepCopyInto(out *ImagePullStats) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullStats.
+func (in *ImagePullStats) DeepCopy() *ImagePullStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
@@ -1078,6 +1325,26 @@ func (in *ImageStatus) DeepCopy() *ImageStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTagPeerToPeer) DeepCopyInto(out *ImageTagPeerToPeer) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTagPeerToPeer.
+func (in *ImageTagPeerToPeer) DeepCopy() *ImageTagPeerToPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTagPeerToPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageTagPullPolicy) DeepCopyInto(out *ImageTagPullPolicy) {
 	*out = *in
@@ -1130,6 +1397,16 @@ func (in *ImageTagSpec) DeepCopyInto(out *ImageTagSpec) {
 		*out = make([]corev1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.PeerToPeer != nil {
+		in, out := &in.PeerToPeer, &out.PeerToPeer
+		*out = new(ImageTagPeerToPeer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(ImageTagVerification)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTagSpec.
@@ -1153,6 +1430,11 @@ func (in *ImageTagStatus) DeepCopyInto(out *ImageTagStatus) {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PullStats != nil {
+		in, out := &in.PullStats, &out.PullStats
+		*out = new(ImagePullStats)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTagStatus.
@@ -1165,6 +1447,56 @@ func (in *ImageTagStatus) DeepCopy() *ImageTagStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTagVerification) DeepCopyInto(out *ImageTagVerification) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedDigests != nil {
+		in, out := &in.AllowedDigests, &out.AllowedDigests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTagVerification.
+func (in *ImageTagVerification) DeepCopy() *ImageTagVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTagVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryImage) DeepCopyInto(out *InventoryImage) {
+	*out = *in
+	if in.RepoTags != nil {
+		in, out := &in.RepoTags, &out.RepoTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RepoDigests != nil {
+		in, out := &in.RepoDigests, &out.RepoDigests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryImage.
+func (in *InventoryImage) DeepCopy() *InventoryImage {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JobCondition) DeepCopyInto(out *JobCondition) {
 	*out = *in
@@ -1231,6 +1563,32 @@ func (in *NodeImage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImageInventory) DeepCopyInto(out *NodeImageInventory) {
+	*out = *in
+	if in.UpdateTime != nil {
+		in, out := &in.UpdateTime, &out.UpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]InventoryImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageInventory.
+func (in *NodeImageInventory) DeepCopy() *NodeImageInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeImageInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeImageList) DeepCopyInto(out *NodeImageList) {
 	*out = *in
@@ -1273,6 +1631,11 @@ func (in *NodeImageSpec) DeepCopyInto(out *NodeImageSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.GCPolicy != nil {
+		in, out := &in.GCPolicy, &out.GCPolicy
+		*out = new(ImageGCPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageSpec.
@@ -1300,6 +1663,16 @@ func (in *NodeImageStatus) DeepCopyInto(out *NodeImageStatus) {
 		*out = new(SyncStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(ImageGCStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(NodeImageInventory)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageStatus.
@@ -1347,6 +1720,21 @@ func (in *PersistentPodAnnotation) DeepCopy() *PersistentPodAnnotation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentPodField) DeepCopyInto(out *PersistentPodField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentPodField.
+func (in *PersistentPodField) DeepCopy() *PersistentPodField {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentPodField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentPodState) DeepCopyInto(out *PersistentPodState) {
 	*out = *in
@@ -1415,6 +1803,11 @@ func (in *PersistentPodStateSpec) DeepCopyInto(out *PersistentPodStateSpec) {
 		*out = make([]PersistentPodAnnotation, len(*in))
 		copy(*out, *in)
 	}
+	if in.PersistentPodFields != nil {
+		in, out := &in.PersistentPodFields, &out.PersistentPodFields
+		*out = make([]PersistentPodField, len(*in))
+		copy(*out, *in)
+	}
 	if in.RequiredPersistentTopology != nil {
 		in, out := &in.RequiredPersistentTopology, &out.RequiredPersistentTopology
 		*out = new(NodeTopologyTerm)
@@ -1478,6 +1871,13 @@ func (in *PodState) DeepCopyInto(out *PodState) {
 			(*out)[key] = val
 		}
 	}
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodState.
@@ -1546,6 +1946,22 @@ func (in *ReferenceObject) DeepCopy() *ReferenceObject {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemovedImage) DeepCopyInto(out *RemovedImage) {
+	*out = *in
+	in.RemovedTime.DeepCopyInto(&out.RemovedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemovedImage.
+func (in *RemovedImage) DeepCopy() *RemovedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(RemovedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceDistribution) DeepCopyInto(out *ResourceDistribution) {
 	*out = *in
@@ -1641,11 +2057,38 @@ func (in *ResourceDistributionNamespace) DeepCopy() *ResourceDistributionNamespa
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDistributionNamespaceValues) DeepCopyInto(out *ResourceDistributionNamespaceValues) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDistributionNamespaceValues.
+func (in *ResourceDistributionNamespaceValues) DeepCopy() *ResourceDistributionNamespaceValues {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDistributionNamespaceValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceDistributionSpec) DeepCopyInto(out *ResourceDistributionSpec) {
 	*out = *in
 	in.Resource.DeepCopyInto(&out.Resource)
 	in.Targets.DeepCopyInto(&out.Targets)
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ResourceDistributionTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDistributionSpec.
@@ -1718,6 +2161,35 @@ func (in *ResourceDistributionTargets) DeepCopy() *ResourceDistributionTargets {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDistributionTemplate) DeepCopyInto(out *ResourceDistributionTemplate) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NamespaceValues != nil {
+		in, out := &in.NamespaceValues, &out.NamespaceValues
+		*out = make([]ResourceDistributionNamespaceValues, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDistributionTemplate.
+func (in *ResourceDistributionTemplate) DeepCopy() *ResourceDistributionTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDistributionTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceExprLimits) DeepCopyInto(out *ResourceExprLimits) {
 	*out = *in
@@ -3063,6 +3535,31 @@ func (in *WorkloadSpreadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSpreadRebalanceStrategy) DeepCopyInto(out *WorkloadSpreadRebalanceStrategy) {
+	*out = *in
+	if in.MaxEvictionsPerInterval != nil {
+		in, out := &in.MaxEvictionsPerInterval, &out.MaxEvictionsPerInterval
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpreadRebalanceStrategy.
+func (in *WorkloadSpreadRebalanceStrategy) DeepCopy() *WorkloadSpreadRebalanceStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSpreadRebalanceStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadSpreadScheduleStrategy) DeepCopyInto(out *WorkloadSpreadScheduleStrategy) {
 	*out = *in
@@ -3104,6 +3601,11 @@ func (in *WorkloadSpreadSpec) DeepCopyInto(out *WorkloadSpreadSpec) {
 		}
 	}
 	in.ScheduleStrategy.DeepCopyInto(&out.ScheduleStrategy)
+	if in.RebalanceStrategy != nil {
+		in, out := &in.RebalanceStrategy, &out.RebalanceStrategy
+		*out = new(WorkloadSpreadRebalanceStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpreadSpec.
@@ -3144,6 +3646,10 @@ func (in *WorkloadSpreadStatus) DeepCopyInto(out *WorkloadSpreadStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.LastRebalanceTime != nil {
+		in, out := &in.LastRebalanceTime, &out.LastRebalanceTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpreadStatus.
@@ -58,6 +58,9 @@ func convertPodUnavailableBudgetToV1beta1(src *PodUnavailableBudget, dst *policy
 		TargetReference: convertTargetReferenceToV1beta1(src.Spec.TargetReference),
 		MaxUnavailable:  src.Spec.MaxUnavailable,
 		MinAvailable:    src.Spec.MinAvailable,
+		ChildBudgets:    src.Spec.ChildBudgets,
+		Schedules:       convertScheduleWindowsToV1beta1(src.Spec.Schedules),
+		DisruptionQueue: convertDisruptionQueueToV1beta1(src.Spec.DisruptionQueue),
 	}
 	dst.Status = convertPodUnavailableBudgetStatusToV1beta1(src.Status)
 
@@ -99,6 +102,9 @@ func convertPodUnavailableBudgetFromV1beta1(src *policyv1beta1.PodUnavailableBud
 		TargetReference: convertTargetReferenceFromV1beta1(src.Spec.TargetReference),
 		MaxUnavailable:  src.Spec.MaxUnavailable,
 		MinAvailable:    src.Spec.MinAvailable,
+		ChildBudgets:    src.Spec.ChildBudgets,
+		Schedules:       convertScheduleWindowsFromV1beta1(src.Spec.Schedules),
+		DisruptionQueue: convertDisruptionQueueFromV1beta1(src.Spec.DisruptionQueue),
 	}
 	dst.Status = convertPodUnavailableBudgetStatusFromV1beta1(src.Status)
 
@@ -156,6 +162,8 @@ func convertPodUnavailableBudgetStatusToV1beta1(src PodUnavailableBudgetStatus)
 		CurrentAvailable:   src.CurrentAvailable,
 		DesiredAvailable:   src.DesiredAvailable,
 		TotalReplicas:      src.TotalReplicas,
+		ActiveSchedule:     src.ActiveSchedule,
+		DisruptionQueue:    convertDisruptionRequestsToV1beta1(src.DisruptionQueue),
 	}
 }
 
@@ -169,9 +177,111 @@ func convertPodUnavailableBudgetStatusFromV1beta1(src policyv1beta1.PodUnavailab
 		CurrentAvailable:   src.CurrentAvailable,
 		DesiredAvailable:   src.DesiredAvailable,
 		TotalReplicas:      src.TotalReplicas,
+		ActiveSchedule:     src.ActiveSchedule,
+		DisruptionQueue:    convertDisruptionRequestsFromV1beta1(src.DisruptionQueue),
 	}
 }
 
+// convertScheduleWindowsToV1beta1 converts v1alpha1 schedule windows into the v1beta1 type.
+func convertScheduleWindowsToV1beta1(src []PubScheduleWindow) []policyv1beta1.PubScheduleWindow {
+	if src == nil {
+		return nil
+	}
+	dst := make([]policyv1beta1.PubScheduleWindow, len(src))
+	for i := range src {
+		dst[i] = policyv1beta1.PubScheduleWindow{
+			Name:            src[i].Name,
+			Schedule:        src[i].Schedule,
+			TimeZone:        src[i].TimeZone,
+			DurationSeconds: src[i].DurationSeconds,
+			MaxUnavailable:  src[i].MaxUnavailable,
+			MinAvailable:    src[i].MinAvailable,
+			Freeze:          src[i].Freeze,
+		}
+	}
+	return dst
+}
+
+// convertScheduleWindowsFromV1beta1 converts v1beta1 schedule windows into the v1alpha1 type.
+func convertScheduleWindowsFromV1beta1(src []policyv1beta1.PubScheduleWindow) []PubScheduleWindow {
+	if src == nil {
+		return nil
+	}
+	dst := make([]PubScheduleWindow, len(src))
+	for i := range src {
+		dst[i] = PubScheduleWindow{
+			Name:            src[i].Name,
+			Schedule:        src[i].Schedule,
+			TimeZone:        src[i].TimeZone,
+			DurationSeconds: src[i].DurationSeconds,
+			MaxUnavailable:  src[i].MaxUnavailable,
+			MinAvailable:    src[i].MinAvailable,
+			Freeze:          src[i].Freeze,
+		}
+	}
+	return dst
+}
+
+// convertDisruptionQueueToV1beta1 converts the v1alpha1 disruption queue settings into the v1beta1 type.
+func convertDisruptionQueueToV1beta1(src *PubDisruptionQueue) *policyv1beta1.PubDisruptionQueue {
+	if src == nil {
+		return nil
+	}
+	return &policyv1beta1.PubDisruptionQueue{
+		Policy:                policyv1beta1.PubDisruptionQueuePolicy(src.Policy),
+		RequestTimeoutSeconds: src.RequestTimeoutSeconds,
+		GrantTimeoutSeconds:   src.GrantTimeoutSeconds,
+	}
+}
+
+// convertDisruptionQueueFromV1beta1 converts the v1beta1 disruption queue settings into the v1alpha1 type.
+func convertDisruptionQueueFromV1beta1(src *policyv1beta1.PubDisruptionQueue) *PubDisruptionQueue {
+	if src == nil {
+		return nil
+	}
+	return &PubDisruptionQueue{
+		Policy:                PubDisruptionQueuePolicy(src.Policy),
+		RequestTimeoutSeconds: src.RequestTimeoutSeconds,
+		GrantTimeoutSeconds:   src.GrantTimeoutSeconds,
+	}
+}
+
+// convertDisruptionRequestsToV1beta1 converts the v1alpha1 queued disruption requests into the v1beta1 type.
+func convertDisruptionRequestsToV1beta1(src []PubDisruptionRequest) []policyv1beta1.PubDisruptionRequest {
+	if src == nil {
+		return nil
+	}
+	dst := make([]policyv1beta1.PubDisruptionRequest, len(src))
+	for i := range src {
+		dst[i] = policyv1beta1.PubDisruptionRequest{
+			PodName:     src[i].PodName,
+			Operation:   policyv1beta1.PubOperation(src[i].Operation),
+			Priority:    src[i].Priority,
+			RequestTime: src[i].RequestTime,
+			GrantedTime: src[i].GrantedTime,
+		}
+	}
+	return dst
+}
+
+// convertDisruptionRequestsFromV1beta1 converts the v1beta1 queued disruption requests into the v1alpha1 type.
+func convertDisruptionRequestsFromV1beta1(src []policyv1beta1.PubDisruptionRequest) []PubDisruptionRequest {
+	if src == nil {
+		return nil
+	}
+	dst := make([]PubDisruptionRequest, len(src))
+	for i := range src {
+		dst[i] = PubDisruptionRequest{
+			PodName:     src[i].PodName,
+			Operation:   PubOperation(src[i].Operation),
+			Priority:    src[i].Priority,
+			RequestTime: src[i].RequestTime,
+			GrantedTime: src[i].GrantedTime,
+		}
+	}
+	return dst
+}
+
 // parsePubProtectOperations converts the deprecated alpha annotation value
 // "DELETE,EVICT" into the typed v1beta1 []PubOperation slice.
 func parsePubProtectOperations(value string) ([]policyv1beta1.PubOperation, error) {
@@ -117,3 +117,59 @@ func TestPodUnavailableBudgetConvertFromV1beta1DropsDefaultProtectOperationsAnno
 	_, exists := dst.Annotations[PubProtectOperationAnnotation]
 	assert.False(t, exists)
 }
+
+func TestPodUnavailableBudgetRoundTripKeepsBetaFields(t *testing.T) {
+	maxUnavailable := intstr.FromInt(2)
+	windowMinAvailable := intstr.FromString("90%")
+	timeZone := "Asia/Shanghai"
+	requestTimeout := int32(300)
+	grantTimeout := int32(30)
+	now := metav1.Now()
+	hub := &policyv1beta1.PodUnavailableBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1beta1.GroupVersion.String(),
+			Kind:       "PodUnavailableBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pub-group",
+			Namespace:   "default",
+			Annotations: map[string]string{},
+		},
+		Spec: policyv1beta1.PodUnavailableBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			ChildBudgets:   []string{"pub-a", "pub-b"},
+			Schedules: []policyv1beta1.PubScheduleWindow{
+				{Name: "business-hours", Schedule: "0 9 * * 1-5", TimeZone: &timeZone, DurationSeconds: 28800, MinAvailable: &windowMinAvailable},
+				{Name: "freeze", Schedule: "0 0 1 * *", DurationSeconds: 3600, Freeze: true},
+			},
+			DisruptionQueue: &policyv1beta1.PubDisruptionQueue{
+				Policy:                policyv1beta1.PubDisruptionQueuePriority,
+				RequestTimeoutSeconds: &requestTimeout,
+				GrantTimeoutSeconds:   &grantTimeout,
+			},
+		},
+		Status: policyv1beta1.PodUnavailableBudgetStatus{
+			ObservedGeneration: 3,
+			UnavailableAllowed: 1,
+			CurrentAvailable:   9,
+			DesiredAvailable:   8,
+			TotalReplicas:      10,
+			ActiveSchedule:     "business-hours",
+			DisruptionQueue: []policyv1beta1.PubDisruptionRequest{
+				{PodName: "pod-1", Operation: policyv1beta1.PubEvictOperation, Priority: 10, RequestTime: now, GrantedTime: &now},
+				{PodName: "pod-2", Operation: policyv1beta1.PubDeleteOperation, RequestTime: now},
+			},
+		},
+	}
+
+	spoke := &PodUnavailableBudget{}
+	require.NoError(t, spoke.ConvertFrom(hub.DeepCopy()))
+	assert.Equal(t, []string{"pub-a", "pub-b"}, spoke.Spec.ChildBudgets)
+	assert.Len(t, spoke.Spec.Schedules, 2)
+	assert.Equal(t, "business-hours", spoke.Status.ActiveSchedule)
+	assert.Len(t, spoke.Status.DisruptionQueue, 2)
+
+	roundTrip := &policyv1beta1.PodUnavailableBudget{}
+	require.NoError(t, spoke.ConvertTo(roundTrip))
+	assert.Equal(t, hub, roundTrip)
+}
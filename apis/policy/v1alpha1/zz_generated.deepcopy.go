@@ -108,6 +108,23 @@ func (in *PodUnavailableBudgetSpec) DeepCopyInto(out *PodUnavailableBudgetSpec)
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.ChildBudgets != nil {
+		in, out := &in.ChildBudgets, &out.ChildBudgets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]PubScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DisruptionQueue != nil {
+		in, out := &in.DisruptionQueue, &out.DisruptionQueue
+		*out = new(PubDisruptionQueue)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodUnavailableBudgetSpec.
@@ -137,6 +154,13 @@ func (in *PodUnavailableBudgetStatus) DeepCopyInto(out *PodUnavailableBudgetStat
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.DisruptionQueue != nil {
+		in, out := &in.DisruptionQueue, &out.DisruptionQueue
+		*out = make([]PubDisruptionRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodUnavailableBudgetStatus.
@@ -149,6 +173,81 @@ func (in *PodUnavailableBudgetStatus) DeepCopy() *PodUnavailableBudgetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PubDisruptionQueue) DeepCopyInto(out *PubDisruptionQueue) {
+	*out = *in
+	if in.RequestTimeoutSeconds != nil {
+		in, out := &in.RequestTimeoutSeconds, &out.RequestTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrantTimeoutSeconds != nil {
+		in, out := &in.GrantTimeoutSeconds, &out.GrantTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PubDisruptionQueue.
+func (in *PubDisruptionQueue) DeepCopy() *PubDisruptionQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(PubDisruptionQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PubDisruptionRequest) DeepCopyInto(out *PubDisruptionRequest) {
+	*out = *in
+	in.RequestTime.DeepCopyInto(&out.RequestTime)
+	if in.GrantedTime != nil {
+		in, out := &in.GrantedTime, &out.GrantedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PubDisruptionRequest.
+func (in *PubDisruptionRequest) DeepCopy() *PubDisruptionRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(PubDisruptionRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PubScheduleWindow) DeepCopyInto(out *PubScheduleWindow) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PubScheduleWindow.
+func (in *PubScheduleWindow) DeepCopy() *PubScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PubScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetReference) DeepCopyInto(out *TargetReference) {
 	*out = *in
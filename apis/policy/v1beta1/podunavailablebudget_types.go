@@ -35,6 +35,9 @@ const (
 	PodRelatedPubAnnotation = "pub.kruise.io/related-pub"
 	// DeprecatedPodRelatedPubAnnotation is kept for backward compatibility with existing pods.
 	DeprecatedPodRelatedPubAnnotation = "kruise.io/related-pub"
+	// PodDisruptionPriorityAnnotation is the priority of the disruption request of pod in the Priority disruption queue,
+	// the higher value is granted first. The default priority is 0.
+	PodDisruptionPriorityAnnotation = "pub.kruise.io/disruption-priority"
 
 	PubUpdateOperation PubOperation = "UPDATE"
 	PubDeleteOperation PubOperation = "DELETE"
@@ -74,6 +77,85 @@ type PodUnavailableBudgetSpec struct {
 	// IgnoredPodSelector selects pods that should always bypass PUB protection.
 	// +optional
 	IgnoredPodSelector *metav1.LabelSelector `json:"ignoredPodSelector,omitempty"`
+
+	// ChildBudgets are the names of other PodUnavailableBudgets in the same namespace that are grouped by this PUB.
+	// A grouping PUB protects the pods of all its child budgets together, so a disruption is allowed only if
+	// both the child budget and this budget allow it. ChildBudgets is mutually exclusive with Selector and TargetReference,
+	// and a child budget can belong to at most one grouping PUB.
+	// +optional
+	ChildBudgets []string `json:"childBudgets,omitempty"`
+
+	// Schedules are the recurring time windows that override the budget of this PUB, e.g. a stricter maxUnavailable
+	// during business hours or a freeze window for maintenance. If several windows are active at the same time,
+	// the first one in the list takes effect.
+	// +optional
+	Schedules []PubScheduleWindow `json:"schedules,omitempty"`
+
+	// DisruptionQueue enables the queueing mode. When no unavailable is allowed, the disruption request of pod is rejected
+	// and queued in status, the budget slot released later is reserved for the head of the queue, and the request of pod
+	// is admitted when it is retried after being granted.
+	// +optional
+	DisruptionQueue *PubDisruptionQueue `json:"disruptionQueue,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=FIFO;Priority
+type PubDisruptionQueuePolicy string
+
+const (
+	// PubDisruptionQueueFIFO grants the requests in the order they are queued.
+	PubDisruptionQueueFIFO PubDisruptionQueuePolicy = "FIFO"
+	// PubDisruptionQueuePriority grants the requests with higher pub.kruise.io/disruption-priority first,
+	// and in the order they are queued for the same priority.
+	PubDisruptionQueuePriority PubDisruptionQueuePolicy = "Priority"
+)
+
+// PubDisruptionQueue defines how the disruption requests are queued.
+type PubDisruptionQueue struct {
+	// Policy is the order to grant the queued requests, defaults to FIFO.
+	// +optional
+	Policy PubDisruptionQueuePolicy `json:"policy,omitempty"`
+
+	// RequestTimeoutSeconds is how long a request stays in the queue before being granted, defaults to 600.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	RequestTimeoutSeconds *int32 `json:"requestTimeoutSeconds,omitempty"`
+
+	// GrantTimeoutSeconds is how long a budget slot is reserved for the granted request, defaults to 60.
+	// The request should be retried within this time, otherwise the slot will be released.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	GrantTimeoutSeconds *int32 `json:"grantTimeoutSeconds,omitempty"`
+}
+
+// PubScheduleWindow overrides the budget of PodUnavailableBudget during a recurring time window.
+type PubScheduleWindow struct {
+	// Name of the window, which is shown in status and in the rejection message.
+	Name string `json:"name"`
+
+	// Schedule is the start time of the window in Cron format, see https://en.wikipedia.org/wiki/Cron.
+	Schedule string `json:"schedule"`
+
+	// The time zone name for the given schedule, see https://en.wikipedia.org/wiki/List_of_tz_database_time_zones.
+	// If not specified, this will default to the time zone of the kruise-controller-manager process.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// DurationSeconds is how long the window lasts after each start of the schedule.
+	// +kubebuilder:validation:Minimum=1
+	DurationSeconds int32 `json:"durationSeconds"`
+
+	// MaxUnavailable overrides spec.maxUnavailable during the window.
+	// MaxUnavailable and MinAvailable are mutually exclusive.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// MinAvailable overrides spec.minAvailable during the window.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Freeze blocks all the protected operations during the window.
+	// +optional
+	Freeze bool `json:"freeze,omitempty"`
 }
 
 // TargetReference contains enough information to let you identify a workload for PodUnavailableBudget.
@@ -114,6 +196,35 @@ type PodUnavailableBudgetStatus struct {
 
 	// TotalReplicas total number of pods counted by this unavailable budget.
 	TotalReplicas int32 `json:"totalReplicas"`
+
+	// ActiveSchedule is the name of the schedule window that was in effect when the status was calculated.
+	// +optional
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+
+	// DisruptionQueue contains the queued disruption requests in the order they will be granted,
+	// only used when spec.disruptionQueue is set.
+	// +optional
+	DisruptionQueue []PubDisruptionRequest `json:"disruptionQueue,omitempty"`
+}
+
+// PubDisruptionRequest is a queued request to disrupt a pod.
+type PubDisruptionRequest struct {
+	// PodName is the name of pod to be disrupted.
+	PodName string `json:"podName"`
+
+	// Operation is the operation of the request.
+	Operation PubOperation `json:"operation"`
+
+	// Priority of the request, only used by the Priority policy.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// RequestTime is when the request was queued.
+	RequestTime metav1.Time `json:"requestTime"`
+
+	// GrantedTime is when a budget slot was reserved for the request. Nil means the request is still waiting.
+	// +optional
+	GrantedTime *metav1.Time `json:"grantedTime,omitempty"`
 }
 
 // +genclient
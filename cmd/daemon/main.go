@@ -23,6 +23,7 @@ import (
 	"os"
 
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
 	"k8s.io/klog/v2/klogr"
 	"k8s.io/kubernetes/pkg/credentialprovider/plugin"
@@ -32,6 +33,7 @@ import (
 
 	"github.com/openkruise/kruise/pkg/client"
 	"github.com/openkruise/kruise/pkg/daemon"
+	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
 	"github.com/openkruise/kruise/pkg/features"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/secret"
@@ -51,6 +53,15 @@ var (
 	// which could otherwise impact the performance of other running pods.
 	maxWorkersForPullImage = flag.Int("max-workers-for-pull-image", -1, "The maximum number of workers for pulling images.")
 
+	// The peer endpoint only serves the cached image layers over TLS to the other kruise-daemons authenticated by TokenReview,
+	// and the mirror endpoint only serves them to the container runtime of this node on the loopback address.
+	imagePeerAddr          = flag.String("image-peer-addr", "", "The address the peer-to-peer image distribution endpoint binds to, e.g. :10223. Empty to disable it.")
+	imagePeerAdvertiseAddr = flag.String("image-peer-advertise-addr", "", "The address other nodes use to reach the peer endpoint. Defaults to $NODE_IP with the port of --image-peer-addr.")
+	imagePeerMirrorAddr    = flag.String("image-peer-mirror-addr", "127.0.0.1:10224", "The loopback address the registry mirror endpoint for the container runtime of this node binds to.")
+	imagePeerCacheDir      = flag.String("image-peer-cache-dir", "/var/lib/kruise-daemon/image-peer", "The directory of the image layers served to other nodes.")
+	imagePeerHostsDir      = flag.String("image-peer-containerd-hosts-dir", "/etc/containerd/certs.d", "The config_path of the CRI registry in containerd config, where the peer endpoint is registered as the registry mirror.")
+	imagePeerCacheSize     = flag.String("image-peer-cache-size", "10Gi", "The maximum size of the image layers in --image-peer-cache-dir, the least recently used ones are evicted beyond it.")
+
 	restConfigQPS   = flag.Int("rest-config-qps", 0, "QPS of rest config. Defaults to 0, which means using the default value of client-go.")
 	restConfigBurst = flag.Int("rest-config-burst", 0, "Burst of rest config. Defaults to 0, which means using the default value of client-go.")
 )
@@ -81,8 +92,19 @@ func main() {
 			}
 		}()
 	}
+	imagePeerCacheQuantity, err := resource.ParseQuantity(*imagePeerCacheSize)
+	if err != nil {
+		klog.Fatalf("Invalid image peer cache size %s: %v", *imagePeerCacheSize, err)
+	}
 	ctx := signals.SetupSignalHandler()
-	d, err := daemon.NewDaemon(cfg, *bindAddr, *maxWorkersForPullImage)
+	d, err := daemon.NewDaemon(cfg, *bindAddr, *maxWorkersForPullImage, daemonoptions.ImagePeerOptions{
+		Addr:               *imagePeerAddr,
+		AdvertiseAddr:      *imagePeerAdvertiseAddr,
+		MirrorAddr:         *imagePeerMirrorAddr,
+		CacheDir:           *imagePeerCacheDir,
+		CacheSize:          imagePeerCacheQuantity.Value(),
+		ContainerdHostsDir: *imagePeerHostsDir,
+	})
 	if err != nil {
 		klog.Fatalf("Failed to new daemon: %v", err)
 	}
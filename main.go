@@ -58,6 +58,7 @@ import (
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/fieldindex"
 	_ "github.com/openkruise/kruise/pkg/util/metrics/leadership"
+	wsutil "github.com/openkruise/kruise/pkg/util/workloadspread"
 	"github.com/openkruise/kruise/pkg/webhook"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
@@ -107,6 +108,7 @@ func main() {
 	var controllerCacheSyncTimeout time.Duration
 	var webhookInitializeTimeout time.Duration
 	var defaultTtlsecondsForAlwaysNodeimage int
+	var enableWorkloadSpreadPreview bool
 
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&healthProbeAddr, "health-probe-addr", ":8000", "The address the healthz/readyz endpoint binds to.")
@@ -119,6 +121,8 @@ func main() {
 		"Namespace if specified restricts the manager's cache to watch objects in the desired namespace. Defaults to all namespaces.")
 	flag.BoolVar(&enablePprof, "enable-pprof", true, "Enable pprof for controller manager.")
 	flag.StringVar(&pprofAddr, "pprof-addr", ":8090", "The address the pprof binds to.")
+	flag.BoolVar(&enableWorkloadSpreadPreview, "enable-workloadspread-preview", false,
+		"Serve the WorkloadSpread preview on the metrics endpoint. The endpoint is not authenticated and exposes workload and topology details.")
 	flag.StringVar(&syncPeriodStr, "sync-period", "", "Determines the minimum frequency at which watched resources are reconciled.")
 	flag.DurationVar(&leaseDuration, "leader-election-lease-duration", defaultLeaseDuration,
 		"leader-election-lease-duration is the duration that non-leader candidates will wait to force acquire leadership. This is measured against time of last observed ack. Default is 15 seconds.")
@@ -256,6 +260,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	if enableWorkloadSpreadPreview && utilfeature.DefaultFeatureGate.Enabled(features.WorkloadSpread) {
+		if err := mgr.AddMetricsServerExtraHandler(wsutil.PreviewPath, wsutil.NewPreviewHandler(mgr.GetClient())); err != nil {
+			setupLog.Error(err, "unable to add workloadspread preview handler")
+			os.Exit(1)
+		}
+	}
+
 	go func() {
 		setupLog.Info("wait webhook ready")
 		if err = webhook.WaitReady(); err != nil {
@@ -43,6 +43,10 @@ func (c *FakeAppsV1alpha1) ContainerRecreateRequests(namespace string) v1alpha1.
 	return newFakeContainerRecreateRequests(c, namespace)
 }
 
+func (c *FakeAppsV1alpha1) ContainerRestartJobs(namespace string) v1alpha1.ContainerRestartJobInterface {
+	return newFakeContainerRestartJobs(c, namespace)
+}
+
 func (c *FakeAppsV1alpha1) DaemonSets(namespace string) v1alpha1.DaemonSetInterface {
 	return newFakeDaemonSets(c, namespace)
 }
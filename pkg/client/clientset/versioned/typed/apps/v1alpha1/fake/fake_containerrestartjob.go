@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	appsv1alpha1 "github.com/openkruise/kruise/pkg/client/clientset/versioned/typed/apps/v1alpha1"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// fakeContainerRestartJobs implements ContainerRestartJobInterface
+type fakeContainerRestartJobs struct {
+	*gentype.FakeClientWithList[*v1alpha1.ContainerRestartJob, *v1alpha1.ContainerRestartJobList]
+	Fake *FakeAppsV1alpha1
+}
+
+func newFakeContainerRestartJobs(fake *FakeAppsV1alpha1, namespace string) appsv1alpha1.ContainerRestartJobInterface {
+	return &fakeContainerRestartJobs{
+		gentype.NewFakeClientWithList[*v1alpha1.ContainerRestartJob, *v1alpha1.ContainerRestartJobList](
+			fake.Fake,
+			namespace,
+			v1alpha1.SchemeGroupVersion.WithResource("containerrestartjobs"),
+			v1alpha1.SchemeGroupVersion.WithKind("ContainerRestartJob"),
+			func() *v1alpha1.ContainerRestartJob { return &v1alpha1.ContainerRestartJob{} },
+			func() *v1alpha1.ContainerRestartJobList { return &v1alpha1.ContainerRestartJobList{} },
+			func(dst, src *v1alpha1.ContainerRestartJobList) { dst.ListMeta = src.ListMeta },
+			func(list *v1alpha1.ContainerRestartJobList) []*v1alpha1.ContainerRestartJob {
+				return gentype.ToPointerSlice(list.Items)
+			},
+			func(list *v1alpha1.ContainerRestartJobList, items []*v1alpha1.ContainerRestartJob) {
+				list.Items = gentype.FromPointerSlice(items)
+			},
+		),
+		fake,
+	}
+}
@@ -25,6 +25,8 @@ type CloneSetExpansion interface{}
 
 type ContainerRecreateRequestExpansion interface{}
 
+type ContainerRestartJobExpansion interface{}
+
 type DaemonSetExpansion interface{}
 
 type EphemeralJobExpansion interface{}
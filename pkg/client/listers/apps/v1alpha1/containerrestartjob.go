@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ContainerRestartJobLister helps list ContainerRestartJobs.
+// All objects returned here must be treated as read-only.
+type ContainerRestartJobLister interface {
+	// List lists all ContainerRestartJobs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*appsv1alpha1.ContainerRestartJob, err error)
+	// ContainerRestartJobs returns an object that can list and get ContainerRestartJobs.
+	ContainerRestartJobs(namespace string) ContainerRestartJobNamespaceLister
+	ContainerRestartJobListerExpansion
+}
+
+// containerRestartJobLister implements the ContainerRestartJobLister interface.
+type containerRestartJobLister struct {
+	listers.ResourceIndexer[*appsv1alpha1.ContainerRestartJob]
+}
+
+// NewContainerRestartJobLister returns a new ContainerRestartJobLister.
+func NewContainerRestartJobLister(indexer cache.Indexer) ContainerRestartJobLister {
+	return &containerRestartJobLister{listers.New[*appsv1alpha1.ContainerRestartJob](indexer, appsv1alpha1.Resource("containerrestartjob"))}
+}
+
+// ContainerRestartJobs returns an object that can list and get ContainerRestartJobs.
+func (s *containerRestartJobLister) ContainerRestartJobs(namespace string) ContainerRestartJobNamespaceLister {
+	return containerRestartJobNamespaceLister{listers.NewNamespaced[*appsv1alpha1.ContainerRestartJob](s.ResourceIndexer, namespace)}
+}
+
+// ContainerRestartJobNamespaceLister helps list and get ContainerRestartJobs.
+// All objects returned here must be treated as read-only.
+type ContainerRestartJobNamespaceLister interface {
+	// List lists all ContainerRestartJobs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*appsv1alpha1.ContainerRestartJob, err error)
+	// Get retrieves the ContainerRestartJob from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*appsv1alpha1.ContainerRestartJob, error)
+	ContainerRestartJobNamespaceListerExpansion
+}
+
+// containerRestartJobNamespaceLister implements the ContainerRestartJobNamespaceLister
+// interface.
+type containerRestartJobNamespaceLister struct {
+	listers.ResourceIndexer[*appsv1alpha1.ContainerRestartJob]
+}
@@ -49,6 +49,14 @@ type ContainerRecreateRequestListerExpansion interface{}
 // ContainerRecreateRequestNamespaceLister.
 type ContainerRecreateRequestNamespaceListerExpansion interface{}
 
+// ContainerRestartJobListerExpansion allows custom methods to be added to
+// ContainerRestartJobLister.
+type ContainerRestartJobListerExpansion interface{}
+
+// ContainerRestartJobNamespaceListerExpansion allows custom methods to be added to
+// ContainerRestartJobNamespaceLister.
+type ContainerRestartJobNamespaceListerExpansion interface{}
+
 // DaemonSetListerExpansion allows custom methods to be added to
 // DaemonSetLister.
 type DaemonSetListerExpansion interface{}
@@ -50,6 +50,8 @@ type pubControl interface {
 	CanResizeInplace(oldPod, newPod *corev1.Pod) bool
 	// get pub for pod
 	GetPubForPod(pod *corev1.Pod) (*policyv1beta1.PodUnavailableBudget, error)
+	// get the grouping pub whose childBudgets contains the pub
+	GetParentPubForPub(pub *policyv1beta1.PodUnavailableBudget) (*policyv1beta1.PodUnavailableBudget, error)
 	// get pod controller of
 	GetPodControllerOf(pod *corev1.Pod) *metav1.OwnerReference
 }
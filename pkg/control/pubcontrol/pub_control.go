@@ -239,6 +239,10 @@ func hash(data string) string {
 // 1. podList
 // 2. expectedCount, the default is workload.Replicas
 func (c *commonControl) GetPodsForPub(pub *policyv1beta1.PodUnavailableBudget) ([]*corev1.Pod, int32, error) {
+	// grouping pub protects the pods of all its child budgets
+	if len(pub.Spec.ChildBudgets) > 0 {
+		return c.getPodsForGroupingPub(pub)
+	}
 	// if targetReference isn't nil, priority to take effect
 	var listOptions *client.ListOptions
 	if pub.Spec.TargetReference != nil {
@@ -280,6 +284,43 @@ func (c *commonControl) GetPodsForPub(pub *policyv1beta1.PodUnavailableBudget) (
 	return matchedPods, expectedCount, nil
 }
 
+// getPodsForGroupingPub returns the union of pods protected by the child budgets, and the sum of their expectedCount.
+// Child budgets that don't exist or are grouping pubs themselves are ignored.
+func (c *commonControl) getPodsForGroupingPub(pub *policyv1beta1.PodUnavailableBudget) ([]*corev1.Pod, int32, error) {
+	var matchedPods []*corev1.Pod
+	var expectedCount int32
+	podNames := sets.NewString()
+	for _, name := range pub.Spec.ChildBudgets {
+		child := &policyv1beta1.PodUnavailableBudget{}
+		if err := c.Get(context.TODO(), client.ObjectKey{Namespace: pub.Namespace, Name: name}, child); err != nil {
+			if errors.IsNotFound(err) {
+				klog.InfoS("Child budget of pub was NotFound", "pub", klog.KObj(pub), "childBudget", name)
+				continue
+			}
+			return nil, 0, err
+		}
+		if len(child.Spec.ChildBudgets) > 0 {
+			klog.InfoS("Nested grouping pub was ignored", "pub", klog.KObj(pub), "childBudget", name)
+			continue
+		}
+		pods, count, err := c.GetPodsForPub(child)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, pod := range pods {
+			if !podNames.Has(pod.Name) {
+				podNames.Insert(pod.Name)
+				matchedPods = append(matchedPods, pod)
+			}
+		}
+		expectedCount += count
+	}
+	if totalReplicas := getPubProtectTotalReplicas(pub); totalReplicas != nil {
+		expectedCount = *totalReplicas
+	}
+	return matchedPods, expectedCount, nil
+}
+
 func (c *commonControl) IsPodStateConsistent(pod *corev1.Pod) bool {
 	// if all container image is digest format
 	// by comparing status.containers[x].ImageID with spec.container[x].Image can determine whether pod is consistent
@@ -337,6 +378,25 @@ func (c *commonControl) GetPubForPod(pod *corev1.Pod) (*policyv1beta1.PodUnavail
 	return pub, nil
 }
 
+func (c *commonControl) GetParentPubForPub(pub *policyv1beta1.PodUnavailableBudget) (*policyv1beta1.PodUnavailableBudget, error) {
+	pubList := &policyv1beta1.PodUnavailableBudgetList{}
+	if err := c.List(context.TODO(), pubList, &client.ListOptions{Namespace: pub.Namespace}, utilclient.DisableDeepCopy); err != nil {
+		return nil, err
+	}
+	for i := range pubList.Items {
+		parent := &pubList.Items[i]
+		if parent.Name == pub.Name || !parent.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, name := range parent.Spec.ChildBudgets {
+			if name == pub.Name {
+				return parent.DeepCopy(), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func (c *commonControl) GetPodControllerOf(pod *corev1.Pod) *metav1.OwnerReference {
 	return metav1.GetControllerOf(pod)
 }
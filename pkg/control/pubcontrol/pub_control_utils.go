@@ -65,45 +65,174 @@ const (
 // 1. allowed(bool) indicates whether to allow this update operation
 // 2. err(error)
 func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1beta1.PubOperation, username string, dryRun bool) (allowed bool, reason string, err error) {
+	allowed, reason, _, err = validatePod(pod, operation, username, dryRun)
+	return allowed, reason, err
+}
+
+// PodUnavailableBudgetReservePod takes the quota of pub for the pod operation the same as PodUnavailableBudgetValidatePod,
+// and returns a function to give back the quota taken by this call, which should be called if the allowed operation
+// is not performed eventually. Otherwise the quota will not be released by pub controller until DeletionTimeout.
+func PodUnavailableBudgetReservePod(pod *corev1.Pod, operation policyv1beta1.PubOperation, username string) (allowed bool, reason string, release func() error, err error) {
+	allowed, reason, taken, err := validatePod(pod, operation, username, false)
+	release = func() error {
+		for _, pub := range taken {
+			if err := releasePubQuota(pod.Name, pub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return allowed, reason, release, err
+}
+
+// validatePod validates the pod operation against pub, and returns the pubs whose quota is taken by the operation.
+func validatePod(pod *corev1.Pod, operation policyv1beta1.PubOperation, username string, dryRun bool) (allowed bool, reason string, taken []*policyv1beta1.PodUnavailableBudget, err error) {
 	klog.V(3).InfoS("Validated pod operation for podUnavailableBudget", "pod", klog.KObj(pod), "operation", operation)
 	if isPodNoProtection(pod) {
 		klog.V(3).InfoS("Pod is exempt from PUB enforcement", "pod", klog.KObj(pod))
-		return true, "", nil
+		return true, "", nil, nil
 		// If the pod is not ready or state is inconsistent, it doesn't count towards healthy and we should not decrement
 	} else if !PubControl.IsPodReady(pod) || !PubControl.IsPodStateConsistent(pod) {
 		klog.V(3).InfoS("Pod was not ready or state was inconsistent, then didn't need check pub", "pod", klog.KObj(pod))
-		return true, "", nil
+		return true, "", nil, nil
 	}
 
 	pub, err := PubControl.GetPubForPod(pod)
 	if err != nil {
-		return false, "", err
+		return false, "", nil, err
 		// if there is no matching PodUnavailableBudget, just return true
 	} else if pub == nil {
-		return true, "", nil
+		return true, "", nil, nil
 	}
 	if matched, err := isPodMatchedIgnoredPubSelector(pub, pod); err != nil {
-		return false, "", err
+		return false, "", nil, err
 	} else if matched {
 		klog.V(3).InfoS("Pod is exempt from PUB enforcement", "pod", klog.KObj(pod))
-		return true, "", nil
+		return true, "", nil, nil
 	}
 	if pub.Status.DesiredAvailable == 0 {
-		return true, "", nil
+		return true, "", nil, nil
 	} else if !isNeedPubProtection(pub, operation) {
 		klog.V(3).InfoS("Pod operation was not in pub protection", "pod", klog.KObj(pod), "operation", operation, "pubName", pub.Name)
-		return true, "", nil
+		return true, "", nil, nil
 		// pod is in pub.Status.DisruptedPods or pub.Status.UnavailablePods, then don't need check it
 	} else if isPodRecordedInPub(pod.Name, pub) {
 		klog.V(3).InfoS("Pod was already recorded in pub", "pod", klog.KObj(pod), "pub", klog.KObj(pub))
-		return true, "", nil
+		return true, "", nil, nil
+	}
+	now := time.Now()
+	if frozen, window := isPubFrozen(pub, now); frozen {
+		klog.V(3).InfoS("Pod operation was rejected by the freeze window of pub", "pod", klog.KObj(pod), "operation", operation, "pub", klog.KObj(pub), "window", window)
+		return false, fmt.Sprintf("pub %s is frozen by schedule window %s", pub.Name, window), nil, nil
+	}
+	// the grouping pub caps the total unavailability of all its child budgets
+	parent, err := getParentPubNeedProtection(pod, pub, operation)
+	if err != nil {
+		return false, "", nil, err
+	} else if parent != nil {
+		if frozen, window := isPubFrozen(parent, now); frozen {
+			klog.V(3).InfoS("Pod operation was rejected by the freeze window of the grouping pub", "pod", klog.KObj(pod), "operation", operation, "pub", klog.KObj(parent), "window", window)
+			return false, fmt.Sprintf("pub %s that groups pub %s is frozen by schedule window %s", parent.Name, pub.Name, window), nil, nil
+		} else if parent.Status.UnavailableAllowed <= 0 && !isDisruptionQueueEnabled(parent) {
+			// reject in advance, so that the quota of child budget will not be taken
+			klog.V(3).InfoS("Pod operation was rejected by the grouping pub", "pod", klog.KObj(pod), "operation", operation, "pub", klog.KObj(parent))
+			return false, fmt.Sprintf("pub %s that groups pub %s has no unavailable allowed", parent.Name, pub.Name), nil, nil
+		}
+	}
+
+	if allowed, reason = checkAndDecrementPubQuota(pod, pub, operation, username, dryRun); !allowed {
+		return allowed, reason, nil, nil
+	} else if !dryRun {
+		taken = append(taken, pub)
+	}
+	if parent == nil {
+		return allowed, reason, taken, nil
+	}
+	// The quota of child budget has been taken, give it back if the grouping pub rejects the operation,
+	// otherwise it will not be released by pub controller until DeletionTimeout.
+	if allowed, reason = checkAndDecrementPubQuota(pod, parent, operation, username, dryRun); !allowed {
+		if !dryRun {
+			if err = releasePubQuota(pod.Name, pub); err != nil {
+				klog.ErrorS(err, "Failed to release the quota of pub rejected by the grouping pub", "pod", klog.KObj(pod), "pub", klog.KObj(pub), "groupingPub", klog.KObj(parent))
+			}
+		}
+		return allowed, reason, nil, nil
+	} else if !dryRun {
+		taken = append(taken, parent)
+	}
+	return allowed, reason, taken, nil
+}
+
+// releasePubQuota removes the pod from DisruptedPods and UnavailablePods of pub, and gives back the quota taken by it.
+func releasePubQuota(podName string, pub *policyv1beta1.PodUnavailableBudget) error {
+	refresh := false
+	return retry.RetryOnConflict(ConflictRetry, func() error {
+		unlock := util.GlobalKeyedMutex.Lock(string(pub.UID))
+		defer unlock()
+
+		var pubClone *policyv1beta1.PodUnavailableBudget
+		if refresh {
+			var err error
+			pubClone, err = kubeClient.GetGenericClient().KruiseClient.PolicyV1beta1().
+				PodUnavailableBudgets(pub.Namespace).Get(context.TODO(), pub.Name, metav1.GetOptions{})
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+		} else if item, _, _ := util.GlobalCache.Get(pub); item != nil {
+			// the local cache holds the pub just updated by checkAndDecrementPubQuota
+			pubClone = item.(*policyv1beta1.PodUnavailableBudget).DeepCopy()
+		} else {
+			pubClone = pub.DeepCopy()
+		}
+		refresh = true
+		if !isPodRecordedInPub(podName, pubClone) {
+			return nil
+		}
+		delete(pubClone.Status.DisruptedPods, podName)
+		delete(pubClone.Status.UnavailablePods, podName)
+		pubClone.Status.UnavailableAllowed++
+		if err := kclient.Status().Update(context.TODO(), pubClone); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if err := util.GlobalCache.Add(pubClone); err != nil {
+			klog.ErrorS(err, "Failed to add cache for podUnavailableBudget", "pub", klog.KObj(pub))
+		}
+		klog.V(3).InfoS("Released the quota of pub", "podName", podName, "pub", klog.KObj(pub))
+		return nil
+	})
+}
+
+// getParentPubNeedProtection returns the grouping pub of the pub, if the operation of pod is also protected by it.
+func getParentPubNeedProtection(pod *corev1.Pod, pub *policyv1beta1.PodUnavailableBudget, operation policyv1beta1.PubOperation) (*policyv1beta1.PodUnavailableBudget, error) {
+	parent, err := PubControl.GetParentPubForPub(pub)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	if parent.Status.DesiredAvailable == 0 || !isNeedPubProtection(parent, operation) || isPodRecordedInPub(pod.Name, parent) {
+		return nil, nil
 	}
-	// check and decrement pub quota
+	if matched, err := isPodMatchedIgnoredPubSelector(parent, pod); err != nil || matched {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// checkAndDecrementPubQuota checks and decrements the quota of pub for the pod operation,
+// and returns whether the operation is allowed and the reason if not.
+func checkAndDecrementPubQuota(pod *corev1.Pod, pub *policyv1beta1.PodUnavailableBudget, operation policyv1beta1.PubOperation, username string, dryRun bool) (bool, string) {
 	var conflictTimes int
 	var costOfGet, costOfUpdate time.Duration
 	refresh := false
 	var pubClone *policyv1beta1.PodUnavailableBudget
+	var err error
 	err = retry.RetryOnConflict(ConflictRetry, func() error {
+		var rejectErr error
 		unlock := util.GlobalKeyedMutex.Lock(string(pub.UID))
 		defer unlock()
 
@@ -145,7 +274,12 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1beta1.Pu
 
 		// Try to verify-and-decrement
 		// If it was false already, or if it becomes false during the course of our retries,
-		err = checkAndDecrement(pod.Name, pubClone, operation)
+		var queued bool
+		if isDisruptionQueueEnabled(pubClone) {
+			queued, err = checkAndDecrementWithQueue(pod, pubClone, operation)
+		} else {
+			err = checkAndDecrement(pod.Name, pubClone, operation)
+		}
 		if err != nil {
 			var kind, namespace, name string
 			if ref := PubControl.GetPodControllerOf(pod); ref != nil {
@@ -162,7 +296,11 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1beta1.Pu
 			PodUnavailableBudgetMetrics.WithLabelValues(fmt.Sprintf("%s_%s_%s", kind, namespace, name), username).Add(1)
 			recorder.Eventf(pod, corev1.EventTypeWarning, "PubPreventPodDeletion", "openkruise pub prevents pod deletion")
 			util.LoggerProtectionInfo(util.ProtectionEventPub, kind, namespace, name, username)
-			return err
+			// the queued request should be persisted in pub status, even though it is rejected
+			if !queued || dryRun {
+				return err
+			}
+			rejectErr = err
 		}
 
 		// If this is a dry-run, we don't need to go any further than that.
@@ -180,7 +318,7 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1beta1.Pu
 			if err = util.GlobalCache.Add(pubClone); err != nil {
 				klog.ErrorS(err, "Failed to add cache for podUnavailableBudget", "pub", klog.KObj(pub))
 			}
-			return nil
+			return rejectErr
 		} else {
 			if errors.IsNotFound(err) {
 				return nil
@@ -196,20 +334,23 @@ func PodUnavailableBudgetValidatePod(pod *corev1.Pod, operation policyv1beta1.Pu
 	if err != nil && err != wait.ErrWaitTimeout {
 		klog.V(3).InfoS("Pod operation for pub failed", "pod", klog.KObj(pod), "operation", operation,
 			"pub", klog.KObj(pub), "error", err)
-		return false, err.Error(), nil
+		return false, err.Error()
 	} else if err == wait.ErrWaitTimeout {
 		err = errors.NewTimeoutError(fmt.Sprintf("couldn't update PodUnavailableBudget %s due to conflicts", pub.Name), 10)
 		klog.ErrorS(err, "Pod operation failed", "pod", klog.KObj(pod), "operation", operation)
-		return false, err.Error(), nil
+		return false, err.Error()
 	}
 
 	klog.V(3).InfoS("Admitted pod operation for pub", "pod", klog.KObj(pod),
 		"operation", operation, "pub", klog.KObj(pub))
-	return true, "", nil
+	return true, ""
 }
 
 func checkAndDecrement(podName string, pub *policyv1beta1.PodUnavailableBudget, operation policyv1beta1.PubOperation) error {
 	if pub.Status.UnavailableAllowed <= 0 {
+		if window := getActiveScheduleWindowName(pub, time.Now()); window != "" {
+			return errors.NewForbidden(policyv1beta1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf("pub unavailable allowed is negative in schedule window %s", window))
+		}
 		return errors.NewForbidden(policyv1beta1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf("pub unavailable allowed is negative"))
 	}
 	if len(pub.Status.DisruptedPods)+len(pub.Status.UnavailablePods) > MaxUnavailablePodSize {
@@ -217,7 +358,11 @@ func checkAndDecrement(podName string, pub *policyv1beta1.PodUnavailableBudget,
 	}
 
 	pub.Status.UnavailableAllowed--
+	recordPodInPub(podName, pub, operation)
+	return nil
+}
 
+func recordPodInPub(podName string, pub *policyv1beta1.PodUnavailableBudget, operation policyv1beta1.PubOperation) {
 	if pub.Status.DisruptedPods == nil {
 		pub.Status.DisruptedPods = make(map[string]metav1.Time)
 	}
@@ -232,7 +377,6 @@ func checkAndDecrement(podName string, pub *policyv1beta1.PodUnavailableBudget,
 		pub.Status.DisruptedPods[podName] = metav1.Time{Time: time.Now()}
 		klog.V(3).InfoS("Pod was recorded in pub disruptedPods", "podName", podName, "pub", klog.KObj(pub))
 	}
-	return nil
 }
 
 func isPodRecordedInPub(podName string, pub *policyv1beta1.PodUnavailableBudget) bool {
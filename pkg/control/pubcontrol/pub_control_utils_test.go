@@ -17,6 +17,7 @@ limitations under the License.
 package pubcontrol
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -37,6 +38,7 @@ import (
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
 	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
 	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
 	"github.com/openkruise/kruise/pkg/util/controllerfinder"
 	"github.com/openkruise/kruise/pkg/util/feature"
 )
@@ -358,6 +360,215 @@ func TestPodUnavailableBudgetValidatePod(t *testing.T) {
 	}
 }
 
+func TestPodUnavailableBudgetValidatePodWithGroupingPub(t *testing.T) {
+	newGroupingPub := func(unavailableAllowed int32) *policyv1beta1.PodUnavailableBudget {
+		return &policyv1beta1.PodUnavailableBudget{
+			ObjectMeta: metav1.ObjectMeta{Namespace: pubDemo.Namespace, Name: "pub-group"},
+			Spec: policyv1beta1.PodUnavailableBudgetSpec{
+				ChildBudgets:   []string{pubDemo.Name, "pub-other"},
+				MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+			},
+			Status: policyv1beta1.PodUnavailableBudgetStatus{
+				UnavailableAllowed: unavailableAllowed,
+				DesiredAvailable:   5,
+			},
+		}
+	}
+
+	cases := []struct {
+		name                     string
+		getPub                   func() *policyv1beta1.PodUnavailableBudget
+		getGroupingPub           func() *policyv1beta1.PodUnavailableBudget
+		getCachedGroupingPub     func() *policyv1beta1.PodUnavailableBudget
+		expectAllow              bool
+		expectUnavailableAllowed int32
+		expectGroupingAllowed    int32
+	}{
+		{
+			name: "both child and grouping pub allow",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Status.UnavailableAllowed = 2
+				return pub
+			},
+			getGroupingPub:           func() *policyv1beta1.PodUnavailableBudget { return newGroupingPub(1) },
+			expectAllow:              true,
+			expectUnavailableAllowed: 1,
+			expectGroupingAllowed:    0,
+		},
+		{
+			name: "grouping pub rejects, child quota is not taken",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Status.UnavailableAllowed = 2
+				return pub
+			},
+			getGroupingPub:           func() *policyv1beta1.PodUnavailableBudget { return newGroupingPub(0) },
+			expectAllow:              false,
+			expectUnavailableAllowed: 2,
+			expectGroupingAllowed:    0,
+		},
+		{
+			name: "grouping pub rejects after child quota is taken, child quota is given back",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Status.UnavailableAllowed = 2
+				return pub
+			},
+			getGroupingPub: func() *policyv1beta1.PodUnavailableBudget { return newGroupingPub(1) },
+			// the quota of grouping pub has been taken by another webhook, which is only known by the local cache
+			getCachedGroupingPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := newGroupingPub(0)
+				pub.ResourceVersion = "999999"
+				return pub
+			},
+			expectAllow:              false,
+			expectUnavailableAllowed: 2,
+			expectGroupingAllowed:    1,
+		},
+		{
+			name:                     "child pub rejects, grouping quota is not taken",
+			getPub:                   func() *policyv1beta1.PodUnavailableBudget { return pubDemo.DeepCopy() },
+			getGroupingPub:           func() *policyv1beta1.PodUnavailableBudget { return newGroupingPub(1) },
+			expectAllow:              false,
+			expectUnavailableAllowed: 0,
+			expectGroupingAllowed:    1,
+		},
+		{
+			name: "grouping pub doesn't protect the operation",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Status.UnavailableAllowed = 1
+				return pub
+			},
+			getGroupingPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := newGroupingPub(0)
+				pub.Spec.ProtectOperations = []policyv1beta1.PubOperation{policyv1beta1.PubDeleteOperation}
+				return pub
+			},
+			expectAllow:              true,
+			expectUnavailableAllowed: 0,
+			expectGroupingAllowed:    0,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pub, grouping := cs.getPub(), cs.getGroupingPub()
+			// clean the local cache left by other cases
+			_ = util.GlobalCache.Delete(pub)
+			_ = util.GlobalCache.Delete(grouping)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).
+				WithObjects(pub, grouping).Build()
+			finder := &controllerfinder.ControllerFinder{Client: fakeClient}
+			InitPubControl(fakeClient, finder, record.NewFakeRecorder(10))
+			if cs.getCachedGroupingPub != nil {
+				_ = util.GlobalCache.Add(cs.getCachedGroupingPub())
+				defer func() { _ = util.GlobalCache.Delete(grouping) }()
+			}
+			allow, _, err := PodUnavailableBudgetValidatePod(podDemo.DeepCopy(), policyv1beta1.PubUpdateOperation, "fake-user", false)
+			if err != nil {
+				t.Fatalf("PodUnavailableBudgetValidatePod failed: %s", err.Error())
+			}
+			if cs.expectAllow != allow {
+				t.Fatalf("expect allow %v, but got %v", cs.expectAllow, allow)
+			}
+			pub = &policyv1beta1.PodUnavailableBudget{}
+			if err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pubDemo.Namespace, Name: pubDemo.Name}, pub); err != nil {
+				t.Fatalf("get pub failed: %s", err.Error())
+			}
+			if pub.Status.UnavailableAllowed != cs.expectUnavailableAllowed {
+				t.Fatalf("expect pub unavailableAllowed %d, but got %d", cs.expectUnavailableAllowed, pub.Status.UnavailableAllowed)
+			}
+			grouping = &policyv1beta1.PodUnavailableBudget{}
+			if err = fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pubDemo.Namespace, Name: "pub-group"}, grouping); err != nil {
+				t.Fatalf("get grouping pub failed: %s", err.Error())
+			}
+			if grouping.Status.UnavailableAllowed != cs.expectGroupingAllowed {
+				t.Fatalf("expect grouping pub unavailableAllowed %d, but got %d", cs.expectGroupingAllowed, grouping.Status.UnavailableAllowed)
+			}
+		})
+	}
+}
+
+func TestPodUnavailableBudgetReservePod(t *testing.T) {
+	cases := []struct {
+		name                     string
+		getPub                   func() *policyv1beta1.PodUnavailableBudget
+		expectAllow              bool
+		expectReservedAllowed    int32
+		expectUnavailableAllowed int32
+	}{
+		{
+			name: "quota is taken and given back",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Status.UnavailableAllowed = 2
+				return pub
+			},
+			expectAllow:              true,
+			expectReservedAllowed:    1,
+			expectUnavailableAllowed: 2,
+		},
+		{
+			name: "pod has been recorded by another operation, quota is not given back",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Status.UnavailableAllowed = 1
+				pub.Status.UnavailablePods = map[string]metav1.Time{podDemo.Name: metav1.Now()}
+				return pub
+			},
+			expectAllow:              true,
+			expectReservedAllowed:    1,
+			expectUnavailableAllowed: 1,
+		},
+		{
+			name:                     "pub rejects, nothing to give back",
+			getPub:                   func() *policyv1beta1.PodUnavailableBudget { return pubDemo.DeepCopy() },
+			expectAllow:              false,
+			expectReservedAllowed:    0,
+			expectUnavailableAllowed: 0,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pub := cs.getPub()
+			_ = util.GlobalCache.Delete(pub)
+			defer func() { _ = util.GlobalCache.Delete(pub) }()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).
+				WithObjects(pub).Build()
+			InitPubControl(fakeClient, &controllerfinder.ControllerFinder{Client: fakeClient}, record.NewFakeRecorder(10))
+			getUnavailableAllowed := func() int32 {
+				latest := &policyv1beta1.PodUnavailableBudget{}
+				if err := fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pubDemo.Namespace, Name: pubDemo.Name}, latest); err != nil {
+					t.Fatalf("get pub failed: %s", err.Error())
+				}
+				return latest.Status.UnavailableAllowed
+			}
+
+			allow, _, release, err := PodUnavailableBudgetReservePod(podDemo.DeepCopy(), policyv1beta1.PubUpdateOperation, "fake-user")
+			if err != nil {
+				t.Fatalf("PodUnavailableBudgetReservePod failed: %s", err.Error())
+			}
+			if cs.expectAllow != allow {
+				t.Fatalf("expect allow %v, but got %v", cs.expectAllow, allow)
+			}
+			if allowed := getUnavailableAllowed(); allowed != cs.expectReservedAllowed {
+				t.Fatalf("expect reserved pub unavailableAllowed %d, but got %d", cs.expectReservedAllowed, allowed)
+			}
+			if err = release(); err != nil {
+				t.Fatalf("release failed: %s", err.Error())
+			}
+			if allowed := getUnavailableAllowed(); allowed != cs.expectUnavailableAllowed {
+				t.Fatalf("expect released pub unavailableAllowed %d, but got %d", cs.expectUnavailableAllowed, allowed)
+			}
+		})
+	}
+}
+
 func TestGetPodUnavailableBudgetForPod(t *testing.T) {
 	cases := []struct {
 		name          string
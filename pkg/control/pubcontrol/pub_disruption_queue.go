@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubcontrol
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+)
+
+const (
+	defaultDisruptionRequestTimeout = 600 * time.Second
+	defaultDisruptionGrantTimeout   = 60 * time.Second
+)
+
+func isDisruptionQueueEnabled(pub *policyv1beta1.PodUnavailableBudget) bool {
+	return pub.Spec.DisruptionQueue != nil
+}
+
+// GetDisruptionQueueTimeouts returns how long a request waits in the queue, and how long a granted slot is reserved.
+func GetDisruptionQueueTimeouts(pub *policyv1beta1.PodUnavailableBudget) (requestTimeout, grantTimeout time.Duration) {
+	requestTimeout, grantTimeout = defaultDisruptionRequestTimeout, defaultDisruptionGrantTimeout
+	if queue := pub.Spec.DisruptionQueue; queue != nil {
+		if queue.RequestTimeoutSeconds != nil {
+			requestTimeout = time.Duration(*queue.RequestTimeoutSeconds) * time.Second
+		}
+		if queue.GrantTimeoutSeconds != nil {
+			grantTimeout = time.Duration(*queue.GrantTimeoutSeconds) * time.Second
+		}
+	}
+	return
+}
+
+// SortDisruptionQueue sorts the requests in the order they will be granted. Granted requests are always in front.
+func SortDisruptionQueue(pub *policyv1beta1.PodUnavailableBudget, queue []policyv1beta1.PubDisruptionRequest) {
+	byPriority := pub.Spec.DisruptionQueue != nil && pub.Spec.DisruptionQueue.Policy == policyv1beta1.PubDisruptionQueuePriority
+	sort.SliceStable(queue, func(i, j int) bool {
+		if (queue[i].GrantedTime != nil) != (queue[j].GrantedTime != nil) {
+			return queue[i].GrantedTime != nil
+		}
+		if byPriority && queue[i].Priority != queue[j].Priority {
+			return queue[i].Priority > queue[j].Priority
+		}
+		return queue[i].RequestTime.Before(&queue[j].RequestTime)
+	})
+}
+
+// CountGrantedDisruptions returns the number of budget slots reserved for the granted requests.
+func CountGrantedDisruptions(queue []policyv1beta1.PubDisruptionRequest) int32 {
+	var count int32
+	for i := range queue {
+		if queue[i].GrantedTime != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func getPodDisruptionPriority(pod *corev1.Pod) int32 {
+	if value, ok := pod.Annotations[policyv1beta1.PodDisruptionPriorityAnnotation]; ok {
+		priority, err := strconv.ParseInt(value, 10, 32)
+		if err == nil {
+			return int32(priority)
+		}
+		klog.InfoS("Invalid disruption priority of pod", "pod", klog.KObj(pod), "priority", value)
+	}
+	return 0
+}
+
+// checkAndDecrementWithQueue admits the request of pod if a slot has been granted to it, or if there is free budget and
+// no other request is waiting. Otherwise the request is queued and rejected with its position in the queue.
+// The returned queued indicates that the pub status has been changed even though the request is rejected.
+func checkAndDecrementWithQueue(pod *corev1.Pod, pub *policyv1beta1.PodUnavailableBudget, operation policyv1beta1.PubOperation) (queued bool, err error) {
+	var waiting int
+	for i := range pub.Status.DisruptionQueue {
+		request := &pub.Status.DisruptionQueue[i]
+		if request.PodName == pod.Name && request.GrantedTime != nil {
+			// the budget slot has been reserved for this request by pub controller, so don't decrement again
+			pub.Status.DisruptionQueue = append(pub.Status.DisruptionQueue[:i], pub.Status.DisruptionQueue[i+1:]...)
+			recordPodInPub(pod.Name, pub, operation)
+			klog.V(3).InfoS("Admitted the granted disruption request", "pod", klog.KObj(pod), "pub", klog.KObj(pub))
+			return false, nil
+		}
+		if request.GrantedTime == nil {
+			waiting++
+		}
+	}
+	if waiting == 0 && pub.Status.UnavailableAllowed > 0 {
+		return false, checkAndDecrement(pod.Name, pub, operation)
+	}
+
+	for i := range pub.Status.DisruptionQueue {
+		if pub.Status.DisruptionQueue[i].PodName == pod.Name {
+			return false, newDisruptionQueuedError(pub, i)
+		}
+	}
+	if len(pub.Status.DisruptionQueue)+len(pub.Status.DisruptedPods)+len(pub.Status.UnavailablePods) > MaxUnavailablePodSize {
+		return false, errors.NewForbidden(policyv1beta1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf("DisruptionQueue, DisruptedPods and UnavailablePods too big"))
+	}
+	pub.Status.DisruptionQueue = append(pub.Status.DisruptionQueue, policyv1beta1.PubDisruptionRequest{
+		PodName:     pod.Name,
+		Operation:   operation,
+		Priority:    getPodDisruptionPriority(pod),
+		RequestTime: metav1.Now(),
+	})
+	SortDisruptionQueue(pub, pub.Status.DisruptionQueue)
+	for i := range pub.Status.DisruptionQueue {
+		if pub.Status.DisruptionQueue[i].PodName == pod.Name {
+			klog.V(3).InfoS("Queued the disruption request", "pod", klog.KObj(pod), "pub", klog.KObj(pub), "position", i+1)
+			return true, newDisruptionQueuedError(pub, i)
+		}
+	}
+	return true, nil
+}
+
+func newDisruptionQueuedError(pub *policyv1beta1.PodUnavailableBudget, index int) error {
+	return errors.NewForbidden(policyv1beta1.Resource("podunavailablebudget"), pub.Name, fmt.Errorf(
+		"pub unavailable allowed is negative, the request is queued at position %d of %d, retry after it is granted",
+		index+1, len(pub.Status.DisruptionQueue)))
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubcontrol
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+)
+
+func TestPodUnavailableBudgetValidatePodWithDisruptionQueue(t *testing.T) {
+	newPod := func(name, priority string) *corev1.Pod {
+		pod := podDemo.DeepCopy()
+		pod.Name = name
+		if priority != "" {
+			pod.Annotations[policyv1beta1.PodDisruptionPriorityAnnotation] = priority
+		}
+		return pod
+	}
+	requestTime := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	cases := []struct {
+		name          string
+		policy        policyv1beta1.PubDisruptionQueuePolicy
+		allowed       int32
+		queue         []policyv1beta1.PubDisruptionRequest
+		pod           *corev1.Pod
+		expectAllow   bool
+		expectReason  string
+		expectQueue   []string
+		expectAllowed int32
+	}{
+		{
+			name:          "free budget and empty queue, admitted directly",
+			allowed:       1,
+			pod:           newPod("pod-a", ""),
+			expectAllow:   true,
+			expectQueue:   []string{},
+			expectAllowed: 0,
+		},
+		{
+			name:          "no budget, queued",
+			pod:           newPod("pod-a", ""),
+			queue:         []policyv1beta1.PubDisruptionRequest{{PodName: "pod-b", RequestTime: requestTime}},
+			expectReason:  "queued at position 2 of 2",
+			expectQueue:   []string{"pod-b", "pod-a"},
+			expectAllowed: 0,
+		},
+		{
+			name:          "free budget but other request is waiting, queued",
+			allowed:       1,
+			pod:           newPod("pod-a", ""),
+			queue:         []policyv1beta1.PubDisruptionRequest{{PodName: "pod-b", RequestTime: requestTime}},
+			expectReason:  "queued at position 2 of 2",
+			expectQueue:   []string{"pod-b", "pod-a"},
+			expectAllowed: 1,
+		},
+		{
+			name:          "no budget, queued in front of the request with lower priority",
+			policy:        policyv1beta1.PubDisruptionQueuePriority,
+			pod:           newPod("pod-a", "10"),
+			queue:         []policyv1beta1.PubDisruptionRequest{{PodName: "pod-b", RequestTime: requestTime}},
+			expectReason:  "queued at position 1 of 2",
+			expectQueue:   []string{"pod-a", "pod-b"},
+			expectAllowed: 0,
+		},
+		{
+			name: "granted, admitted without decrement",
+			pod:  newPod("pod-a", ""),
+			queue: []policyv1beta1.PubDisruptionRequest{
+				{PodName: "pod-a", RequestTime: requestTime, GrantedTime: &requestTime},
+				{PodName: "pod-b", RequestTime: requestTime},
+			},
+			expectAllow:   true,
+			expectQueue:   []string{"pod-b"},
+			expectAllowed: 0,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pub := pubDemo.DeepCopy()
+			pub.Spec.DisruptionQueue = &policyv1beta1.PubDisruptionQueue{Policy: cs.policy}
+			pub.Status.UnavailableAllowed = cs.allowed
+			pub.Status.DisruptionQueue = cs.queue
+			_ = util.GlobalCache.Delete(pub)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).WithObjects(pub).Build()
+			InitPubControl(fakeClient, &controllerfinder.ControllerFinder{Client: fakeClient}, record.NewFakeRecorder(10))
+
+			allowed, reason, err := PodUnavailableBudgetValidatePod(cs.pod, policyv1beta1.PubDeleteOperation, "fake-user", false)
+			if err != nil {
+				t.Fatalf("PodUnavailableBudgetValidatePod failed: %s", err.Error())
+			}
+			if allowed != cs.expectAllow {
+				t.Fatalf("expect allow %v, but got %v: %s", cs.expectAllow, allowed, reason)
+			}
+			if !strings.Contains(reason, cs.expectReason) {
+				t.Fatalf("expect reason contains %q, but got %q", cs.expectReason, reason)
+			}
+
+			newPub := &policyv1beta1.PodUnavailableBudget{}
+			if err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(pub), newPub); err != nil {
+				t.Fatalf("get pub failed: %s", err.Error())
+			}
+			queue := []string{}
+			for _, request := range newPub.Status.DisruptionQueue {
+				queue = append(queue, request.PodName)
+			}
+			if strings.Join(queue, ",") != strings.Join(cs.expectQueue, ",") {
+				t.Fatalf("expect queue %v, but got %v", cs.expectQueue, queue)
+			}
+			if newPub.Status.UnavailableAllowed != cs.expectAllowed {
+				t.Fatalf("expect unavailableAllowed %d, but got %d", cs.expectAllowed, newPub.Status.UnavailableAllowed)
+			}
+			if cs.expectAllow && !isPodRecordedInPub(cs.pod.Name, newPub) {
+				t.Fatalf("expect pod recorded in pub")
+			}
+		})
+	}
+}
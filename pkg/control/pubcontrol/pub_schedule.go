@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubcontrol
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/klog/v2"
+
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+)
+
+// GetActiveScheduleWindow returns the first schedule window of pub which is active at now, and the next time
+// when any of the windows starts or ends, at which the budget of pub should be recalculated.
+func GetActiveScheduleWindow(pub *policyv1beta1.PodUnavailableBudget, now time.Time) (*policyv1beta1.PubScheduleWindow, *time.Time) {
+	var active *policyv1beta1.PubScheduleWindow
+	var nextBoundary *time.Time
+	for i := range pub.Spec.Schedules {
+		window := &pub.Spec.Schedules[i]
+		sched, err := ParseScheduleWindow(window)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse schedule window of pub", "pub", klog.KObj(pub), "window", window.Name)
+			continue
+		}
+		duration := time.Duration(window.DurationSeconds) * time.Second
+		var boundary time.Time
+		// the latest start within the duration, if any, means the window is active now
+		if start := sched.Next(now.Add(-duration)); !start.After(now) {
+			if active == nil {
+				active = window
+			}
+			boundary = start.Add(duration)
+		} else {
+			boundary = start
+		}
+		if nextBoundary == nil || boundary.Before(*nextBoundary) {
+			nextBoundary = &boundary
+		}
+	}
+	return active, nextBoundary
+}
+
+// ParseScheduleWindow parses the schedule of window with its time zone.
+func ParseScheduleWindow(window *policyv1beta1.PubScheduleWindow) (sched cron.Schedule, err error) {
+	schedule := window.Schedule
+	if window.TimeZone != nil && !strings.Contains(schedule, "TZ") {
+		schedule = fmt.Sprintf("TZ=%s %s", *window.TimeZone, schedule)
+	}
+	// cron.ParseStandard may panic for some malformed schedules
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid cron schedule: %v", r)
+		}
+	}()
+	return cron.ParseStandard(schedule)
+}
+
+func getActiveScheduleWindowName(pub *policyv1beta1.PodUnavailableBudget, now time.Time) string {
+	if window, _ := GetActiveScheduleWindow(pub, now); window != nil {
+		return window.Name
+	}
+	return ""
+}
+
+func isPubFrozen(pub *policyv1beta1.PodUnavailableBudget, now time.Time) (bool, string) {
+	if window, _ := GetActiveScheduleWindow(pub, now); window != nil && window.Freeze {
+		return true, window.Name
+	}
+	return false, ""
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubcontrol
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+)
+
+func TestGetActiveScheduleWindow(t *testing.T) {
+	pub := pubDemo.DeepCopy()
+	pub.Spec.Schedules = []policyv1beta1.PubScheduleWindow{
+		{
+			Name:            "business-hours",
+			Schedule:        "0 9 * * *",
+			TimeZone:        ptr.To("UTC"),
+			DurationSeconds: 9 * 3600,
+			MaxUnavailable:  &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+		},
+		{
+			Name:            "freeze",
+			Schedule:        "0 12 * * *",
+			TimeZone:        ptr.To("UTC"),
+			DurationSeconds: 3600,
+			Freeze:          true,
+		},
+	}
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name         string
+		now          time.Time
+		expectWindow string
+		expectNext   time.Time
+	}{
+		{
+			name:       "before all windows",
+			now:        day.Add(8 * time.Hour),
+			expectNext: day.Add(9 * time.Hour),
+		},
+		{
+			name:         "in business hours",
+			now:          day.Add(10 * time.Hour),
+			expectWindow: "business-hours",
+			expectNext:   day.Add(12 * time.Hour),
+		},
+		{
+			name:         "both windows are active, the first one takes effect",
+			now:          day.Add(12*time.Hour + 30*time.Minute),
+			expectWindow: "business-hours",
+			expectNext:   day.Add(13 * time.Hour),
+		},
+		{
+			name:       "window ends exactly",
+			now:        day.Add(18 * time.Hour),
+			expectNext: day.Add(33 * time.Hour),
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			window, next := GetActiveScheduleWindow(pub, cs.now)
+			var name string
+			if window != nil {
+				name = window.Name
+			}
+			if name != cs.expectWindow {
+				t.Fatalf("expect active window %q, but got %q", cs.expectWindow, name)
+			}
+			if next == nil || !next.Equal(cs.expectNext) {
+				t.Fatalf("expect next boundary %v, but got %v", cs.expectNext, next)
+			}
+		})
+	}
+}
+
+func TestPodUnavailableBudgetValidatePodInFreezeWindow(t *testing.T) {
+	pub := pubDemo.DeepCopy()
+	pub.Status.UnavailableAllowed = 5
+	pub.Spec.Schedules = []policyv1beta1.PubScheduleWindow{
+		// a window that lasts all the time
+		{Name: "freeze", Schedule: "* * * * *", DurationSeconds: 3600, Freeze: true},
+	}
+	_ = util.GlobalCache.Delete(pub)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).WithObjects(pub).Build()
+	InitPubControl(fakeClient, &controllerfinder.ControllerFinder{Client: fakeClient}, record.NewFakeRecorder(10))
+
+	allowed, reason, err := PodUnavailableBudgetValidatePod(podDemo.DeepCopy(), policyv1beta1.PubDeleteOperation, "fake-user", false)
+	if err != nil {
+		t.Fatalf("PodUnavailableBudgetValidatePod failed: %s", err.Error())
+	}
+	if allowed {
+		t.Fatalf("expect pod deletion rejected in freeze window")
+	}
+	if expect := "pub pub-test is frozen by schedule window freeze"; reason != expect {
+		t.Fatalf("expect reason %q, but got %q", expect, reason)
+	}
+}
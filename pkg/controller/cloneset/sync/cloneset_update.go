@@ -265,7 +265,10 @@ func (c *realControl) updatePod(cs *appsv1beta1.CloneSet, coreControl clonesetco
 				break
 			}
 		}
-		if c.inplaceControl.CanUpdateInPlace(oldRevision, updateRevision, coreControl.GetUpdateOptions()) {
+		// Pods marked to clean up ephemeral containers should be recreated, unless the policy is InPlaceOnly
+		recreateForCleanup := cs.Spec.UpdateStrategy.RollingUpdate.PodUpdatePolicy == appsv1beta1.InPlaceIfPossibleCloneSetPodUpdateStrategyType &&
+			inplaceupdate.IsEphemeralContainersCleanupMarked(pod)
+		if !recreateForCleanup && c.inplaceControl.CanUpdateInPlace(oldRevision, updateRevision, coreControl.GetUpdateOptions()) {
 			switch state := lifecycle.GetPodLifecycleState(pod); state {
 			case "", appspub.LifecycleStatePreparingNormal, appspub.LifecycleStateNormal:
 				var err error
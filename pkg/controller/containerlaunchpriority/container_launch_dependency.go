@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlauchpriority
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+)
+
+// getDependencyContainers returns the names of containers waiting for launch dependencies in barrier,
+// and the ones whose dependencies have been satisfied.
+func getDependencyContainers(pod *v1.Pod) (waiting []string, launchable sets.Set[string]) {
+	var dependencies appspub.ContainerLaunchDependencies
+	var probed sets.Set[string]
+	launchable = sets.New[string]()
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if !utilcontainerlaunchpriority.IsDependencyBarrier(c) {
+			continue
+		}
+		if dependencies == nil {
+			var err error
+			if dependencies, err = appspub.GetContainerLaunchDependencies(pod); err != nil {
+				klog.ErrorS(err, "Failed to parse container launch dependencies", "pod", klog.KObj(pod))
+				return nil, nil
+			}
+			probedNames, err := appspub.GetContainerLaunchDependenciesProbed(pod)
+			if err != nil {
+				klog.ErrorS(err, "Failed to parse probed container launch dependencies", "pod", klog.KObj(pod))
+			}
+			probed = sets.New[string](probedNames...)
+		}
+
+		waiting = append(waiting, c.Name)
+		if utilcontainerlaunchpriority.IsDependenciesSatisfied(pod, c.Name, dependencies[c.Name], probed) {
+			launchable.Insert(c.Name)
+		}
+	}
+	return
+}
+
+func shouldEnqueueForDependencies(pod *v1.Pod, r client.Reader, waiting []string, launchable sets.Set[string]) bool {
+	var barrier = &v1.ConfigMap{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name + "-barrier"}, barrier); err != nil {
+		return true
+	}
+	// enqueue if any container can be launched, or all of them have been launched to mark completed
+	if hasLaunchableNotInBarrier(barrier, launchable) {
+		return true
+	}
+	for _, name := range waiting {
+		if _, exists := barrier.Data[utilcontainerlaunchpriority.GetDependencyKey(name)]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+func hasLaunchableNotInBarrier(barrier *v1.ConfigMap, launchable sets.Set[string]) bool {
+	for name := range launchable {
+		if _, exists := barrier.Data[utilcontainerlaunchpriority.GetDependencyKey(name)]; !exists {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileContainerLaunchPriority) handleDependencies(pod *v1.Pod, barrier *v1.ConfigMap, waiting []string, launchable sets.Set[string]) error {
+	data := map[string]string{}
+	for _, name := range sets.List(launchable) {
+		if key := utilcontainerlaunchpriority.GetDependencyKey(name); barrier.Data[key] == "" {
+			data[key] = "true"
+		}
+	}
+	if len(data) > 0 {
+		klog.V(3).InfoS("Adding launchable containers into barrier", "data", data, "barrier", klog.KObj(barrier))
+		body, _ := json.Marshal(map[string]interface{}{"data": data})
+		if err := r.Client.Patch(context.TODO(), barrier, client.RawPatch(types.StrategicMergePatchType, body)); err != nil {
+			return fmt.Errorf("failed to add launchable containers into barrier: %v", err)
+		}
+	}
+
+	for _, name := range waiting {
+		if !launchable.Has(name) && barrier.Data[utilcontainerlaunchpriority.GetDependencyKey(name)] == "" {
+			return nil
+		}
+	}
+	return r.patchCompleted(pod)
+}
@@ -101,6 +101,10 @@ func shouldEnqueue(pod *v1.Pod, r client.Reader) bool {
 		return false
 	}
 
+	if waiting, launchable := getDependencyContainers(pod); len(waiting) > 0 {
+		return shouldEnqueueForDependencies(pod, r, waiting, launchable)
+	}
+
 	nextPriorities := findNextPriorities(pod)
 	if len(nextPriorities) == 0 {
 		return false
@@ -183,6 +187,10 @@ func (r *ReconcileContainerLaunchPriority) Reconcile(_ context.Context, request
 }
 
 func (r *ReconcileContainerLaunchPriority) handle(pod *v1.Pod, barrier *v1.ConfigMap) error {
+	if waiting, launchable := getDependencyContainers(pod); len(waiting) > 0 {
+		return r.handleDependencies(pod, barrier, waiting, launchable)
+	}
+
 	nextPriorities := findNextPriorities(pod)
 
 	// If there is no more priorities, or the lowest priority exists in barrier, mask as completed.
@@ -521,3 +521,102 @@ func TestShouldEnqueue(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleDependencies(t *testing.T) {
+	namespace := "default"
+	podName := "fake-pod"
+	configMapName := "fake-pod-barrier"
+	newPod := func(probed string, statuses ...v1.ContainerStatus) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      podName,
+				Annotations: map[string]string{
+					appspub.ContainerLaunchDependenciesKey: `{"b":[{"container":"a"}],"c":[{"container":"b","condition":"Started"},{"tcpSocket":{"port":80}}]}`,
+				},
+			},
+			Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "a"},
+				{Name: "b", Env: []v1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("b", podName)}},
+				{Name: "c", Env: []v1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("c", podName)}},
+			}},
+			Status: v1.PodStatus{ContainerStatuses: statuses},
+		}
+		if probed != "" {
+			pod.Annotations[appspub.ContainerLaunchDependenciesProbedKey] = probed
+		}
+		return pod
+	}
+	started := true
+
+	cases := []struct {
+		name              string
+		pod               *v1.Pod
+		existedKeys       []string
+		expectedKeys      []string
+		expectedCompleted bool
+	}{
+		{
+			name:         "nothing launchable",
+			pod:          newPod("", v1.ContainerStatus{Name: "a"}),
+			expectedKeys: []string{},
+		},
+		{
+			name:         "launch b after a ready",
+			pod:          newPod("", v1.ContainerStatus{Name: "a", Ready: true}),
+			expectedKeys: []string{"d_b"},
+		},
+		{
+			name:         "c waits for tcpSocket probed",
+			pod:          newPod("", v1.ContainerStatus{Name: "a", Ready: true}, v1.ContainerStatus{Name: "b", Started: &started}),
+			existedKeys:  []string{"d_b"},
+			expectedKeys: []string{"d_b"},
+		},
+		{
+			name:              "launch c and complete",
+			pod:               newPod(`["c"]`, v1.ContainerStatus{Name: "a", Ready: true}, v1.ContainerStatus{Name: "b", Started: &started}),
+			existedKeys:       []string{"d_b"},
+			expectedKeys:      []string{"d_b", "d_c"},
+			expectedCompleted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			barrier := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: configMapName},
+				Data:       map[string]string{},
+			}
+			for _, key := range tc.existedKeys {
+				barrier.Data[key] = "true"
+			}
+			cli := fake.NewClientBuilder().WithObjects(tc.pod, barrier).Build()
+			r := &ReconcileContainerLaunchPriority{Client: cli}
+
+			if err := r.handle(tc.pod, barrier); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: configMapName}, barrier); err != nil {
+				t.Fatal(err)
+			}
+			if len(barrier.Data) != len(tc.expectedKeys) {
+				t.Fatalf("expected %v, got %v", tc.expectedKeys, barrier.Data)
+			}
+			for _, key := range tc.expectedKeys {
+				if barrier.Data[key] != "true" {
+					t.Fatalf("expected %v, got %v", tc.expectedKeys, barrier.Data)
+				}
+			}
+
+			gotPod := &v1.Pod{}
+			if err := cli.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: podName}, gotPod); err != nil {
+				t.Fatal(err)
+			}
+			gotCompleted := gotPod.Annotations[appspub.ContainerLaunchPriorityCompletedKey] == "true"
+			if gotCompleted != tc.expectedCompleted {
+				t.Fatalf("expected completed %v, got %v", tc.expectedCompleted, gotCompleted)
+			}
+		})
+	}
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	utilcontainerrecreate "github.com/openkruise/kruise/pkg/util/containerrecreate"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/podadapter"
@@ -118,6 +119,7 @@ type ReconcileContainerRecreateRequest struct {
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrecreaterequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrecreaterequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrecreaterequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch
 
 // Reconcile reads that state of the cluster for a ContainerRecreateRequest object and makes changes based on the state read
 // and what is in the ContainerRecreateRequest.Spec
@@ -160,6 +162,9 @@ func (r *ReconcileContainerRecreateRequest) Reconcile(_ context.Context, request
 		}
 
 		if crr.DeletionTimestamp != nil {
+			if slice.ContainsString(crr.Finalizers, appsv1alpha1.ContainerRecreateRequestOverrideOriginKey, nil) {
+				return reconcile.Result{}, r.releaseOverride(crr, pod)
+			}
 			return reconcile.Result{}, nil
 		}
 
@@ -210,6 +215,11 @@ func (r *ReconcileContainerRecreateRequest) Reconcile(_ context.Context, request
 		duration.Update(leftTime)
 	}
 
+	// override the Pod metadata that env refer to before kruise-daemon recreates containers
+	if utilcontainerrecreate.HasOverride(crr) && crr.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey] == "" {
+		return reconcile.Result{}, r.applyOverride(crr, pod)
+	}
+
 	if crr.Status.Phase != appsv1alpha1.ContainerRecreateRequestRecreating {
 		return reconcile.Result{RequeueAfter: duration.Get()}, nil
 	}
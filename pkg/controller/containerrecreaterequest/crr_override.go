@@ -0,0 +1,308 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrecreaterequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"k8s.io/kubernetes/pkg/util/slice"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+	utilcontainermeta "github.com/openkruise/kruise/pkg/util/containermeta"
+)
+
+// overrideMetadata contains the annotations and labels of Pod changed by override,
+// a nil value means the key does not exist.
+type overrideMetadata struct {
+	Annotations map[string]*string `json:"annotations,omitempty"`
+	Labels      map[string]*string `json:"labels,omitempty"`
+}
+
+// getOverrideMetadata returns the Pod metadata expected by the override of containers
+func getOverrideMetadata(crr *appsv1alpha1.ContainerRecreateRequest, pod *v1.Pod) (*overrideMetadata, error) {
+	expected := &overrideMetadata{Annotations: map[string]*string{}, Labels: map[string]*string{}}
+	for i := range crr.Spec.Containers {
+		c := &crr.Spec.Containers[i]
+		if c.Override == nil {
+			continue
+		}
+		podContainer := util.GetContainer(c.Name, pod)
+		if podContainer == nil {
+			return nil, fmt.Errorf("container %s not found in Pod", c.Name)
+		}
+		for j := range c.Override.Env {
+			env := &c.Override.Env[j]
+			path, key, ok := utilcontainermeta.GetEnvReferenceToMeta(podContainer, env.Name)
+			if !ok {
+				return nil, fmt.Errorf("env %s in container %s is not from Pod metadata", env.Name, c.Name)
+			}
+			value := env.Value
+			if path == "metadata.annotations" {
+				expected.Annotations[key] = &value
+			} else {
+				expected.Labels[key] = &value
+			}
+		}
+	}
+	return expected, nil
+}
+
+func getMetadataOf(keys map[string]*string, values map[string]string) map[string]*string {
+	m := make(map[string]*string, len(keys))
+	for key := range keys {
+		if value, ok := values[key]; ok {
+			m[key] = &value
+		} else {
+			m[key] = nil
+		}
+	}
+	return m
+}
+
+func isMetadataMatched(expected map[string]*string, values map[string]string) bool {
+	for key, value := range expected {
+		if current, ok := values[key]; ok != (value != nil) || (ok && current != *value) {
+			return false
+		}
+	}
+	return true
+}
+
+func newMetadataPatch(annotations, labels map[string]*string) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"metadata": overrideMetadata{Annotations: annotations, Labels: labels},
+	})
+	return body
+}
+
+// applyOverride records the original metadata of Pod into the CRR first, then updates the Pod metadata
+// that the overridden env refer to, and finally marks the override applied for kruise-daemon to recreate containers.
+func (r *ReconcileContainerRecreateRequest) applyOverride(crr *appsv1alpha1.ContainerRecreateRequest, pod *v1.Pod) error {
+	expected, err := getOverrideMetadata(crr, pod)
+	if err != nil {
+		klog.InfoS("Completed CRR as failure for invalid override", "containerRecreateRequest", klog.KObj(crr), "err", err)
+		return r.completeCRR(crr, fmt.Sprintf("invalid override: %v", err))
+	}
+
+	if _, ok := crr.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideOriginKey]; !ok {
+		origin := util.DumpJSON(overrideMetadata{
+			Annotations: getMetadataOf(expected.Annotations, pod.Annotations),
+			Labels:      getMetadataOf(expected.Labels, pod.Labels),
+		})
+		// the finalizer is added together with the origin, so that the override will be reverted even if the CRR is deleted
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			newCRR := &appsv1alpha1.ContainerRecreateRequest{}
+			if err := r.Get(context.TODO(), types.NamespacedName{Namespace: crr.Namespace, Name: crr.Name}, newCRR); err != nil {
+				return err
+			}
+			if newCRR.Annotations == nil {
+				newCRR.Annotations = map[string]string{}
+			}
+			newCRR.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideOriginKey] = origin
+			if !slice.ContainsString(newCRR.Finalizers, appsv1alpha1.ContainerRecreateRequestOverrideOriginKey, nil) {
+				newCRR.Finalizers = append(newCRR.Finalizers, appsv1alpha1.ContainerRecreateRequestOverrideOriginKey)
+			}
+			return r.Update(context.TODO(), newCRR)
+		})
+		if err != nil {
+			return fmt.Errorf("record override origin error: %v", err)
+		}
+		return nil
+	}
+
+	if !isMetadataMatched(expected.Annotations, pod.Annotations) || !isMetadataMatched(expected.Labels, pod.Labels) {
+		klog.InfoS("CRR overriding Pod metadata", "containerRecreateRequest", klog.KObj(crr), "pod", klog.KObj(pod))
+		if err := r.Patch(context.TODO(), pod, client.RawPatch(types.MergePatchType, newMetadataPatch(expected.Annotations, expected.Labels))); err != nil {
+			return fmt.Errorf("override Pod metadata error: %v", err)
+		}
+	}
+
+	body := util.DumpJSON(syncPatchBody{Metadata: syncPatchMetadata{Annotations: map[string]string{appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey: r.clock.Now().Format(time.RFC3339)}}})
+	return r.Patch(context.TODO(), crr, client.RawPatch(types.MergePatchType, []byte(body)))
+}
+
+// releaseOverride reverts the override and removes the finalizer of CRR, it is called once the CRR is being deleted.
+func (r *ReconcileContainerRecreateRequest) releaseOverride(crr *appsv1alpha1.ContainerRecreateRequest, pod *v1.Pod) error {
+	if err := r.revertOverride(crr, pod); err != nil {
+		return err
+	}
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		newCRR := &appsv1alpha1.ContainerRecreateRequest{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: crr.Namespace, Name: crr.Name}, newCRR); err != nil {
+			return err
+		}
+		newCRR.Finalizers = slice.RemoveString(newCRR.Finalizers, appsv1alpha1.ContainerRecreateRequestOverrideOriginKey, nil)
+		return r.Update(context.TODO(), newCRR)
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("remove finalizer error: %v", err)
+	}
+	return nil
+}
+
+// revertOverride restores the Pod metadata that has not been changed by others since the override,
+// and recreates the containers again by a new CRR so that they run with the original env.
+func (r *ReconcileContainerRecreateRequest) revertOverride(crr *appsv1alpha1.ContainerRecreateRequest, pod *v1.Pod) error {
+	originStr, ok := crr.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideOriginKey]
+	if !ok || pod == nil || pod.DeletionTimestamp != nil || string(pod.UID) != crr.Labels[appsv1alpha1.ContainerRecreateRequestPodUIDKey] {
+		return nil
+	}
+	origin := overrideMetadata{}
+	if err := json.Unmarshal([]byte(originStr), &origin); err != nil {
+		klog.ErrorS(err, "Failed to parse override origin of CRR, skip reverting", "containerRecreateRequest", klog.KObj(crr))
+		return nil
+	}
+	expected, err := getOverrideMetadata(crr, pod)
+	if err != nil {
+		klog.InfoS("Skip reverting override of CRR", "containerRecreateRequest", klog.KObj(crr), "reason", err)
+		return nil
+	}
+
+	revertAnnotations, revertLabels := map[string]*string{}, map[string]*string{}
+	for key, value := range origin.Annotations {
+		if isMetadataMatched(map[string]*string{key: expected.Annotations[key]}, pod.Annotations) {
+			revertAnnotations[key] = value
+		}
+	}
+	for key, value := range origin.Labels {
+		if isMetadataMatched(map[string]*string{key: expected.Labels[key]}, pod.Labels) {
+			revertLabels[key] = value
+		}
+	}
+	if len(revertAnnotations) > 0 || len(revertLabels) > 0 {
+		klog.InfoS("CRR reverting overridden Pod metadata", "containerRecreateRequest", klog.KObj(crr), "pod", klog.KObj(pod))
+		if err := r.Patch(context.TODO(), pod, client.RawPatch(types.MergePatchType, newMetadataPatch(revertAnnotations, revertLabels))); err != nil {
+			return fmt.Errorf("revert Pod metadata error: %v", err)
+		}
+	}
+
+	if !kubecontroller.IsPodActive(pod) {
+		return nil
+	}
+	revertedMeta := &metav1.ObjectMeta{Annotations: applyMetadata(pod.Annotations, revertAnnotations), Labels: applyMetadata(pod.Labels, revertLabels)}
+	revertCRR := newRevertContainerRecreateRequest(crr, pod, revertedMeta, r.clock.Now())
+	if revertCRR == nil {
+		return nil
+	}
+	if err := r.Create(context.TODO(), revertCRR); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("create CRR to revert override error: %v", err)
+	}
+	return nil
+}
+
+// applyMetadata returns a copy of values with the patch applied, a nil value in patch removes the key.
+func applyMetadata(values map[string]string, patch map[string]*string) map[string]string {
+	m := make(map[string]string, len(values))
+	for key, value := range values {
+		m[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(m, key)
+		} else {
+			m[key] = *value
+		}
+	}
+	return m
+}
+
+// newRevertContainerRecreateRequest returns the CRR to recreate the overridden containers again,
+// it returns nil if none of them has been recreated.
+// The env are overridden with the values in the reverted Pod metadata, which has been patched already,
+// so the CRR is marked applied and kruise-daemon will wait for the reverted metadata before recreation.
+func newRevertContainerRecreateRequest(crr *appsv1alpha1.ContainerRecreateRequest, pod *v1.Pod, revertedMeta *metav1.ObjectMeta, now time.Time) *appsv1alpha1.ContainerRecreateRequest {
+	if crr.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey] == "" {
+		return nil
+	}
+	var containers []appsv1alpha1.ContainerRecreateRequestContainer
+	for i := range crr.Spec.Containers {
+		c := &crr.Spec.Containers[i]
+		if c.Override == nil || len(c.Override.Env) == 0 {
+			continue
+		}
+		if !isContainerKilled(crr, c.Name) {
+			continue
+		}
+		if status := util.GetContainerStatus(c.Name, pod); status == nil || status.ContainerID == "" {
+			continue
+		}
+		podContainer := util.GetContainer(c.Name, pod)
+		if podContainer == nil {
+			continue
+		}
+		override := &appsv1alpha1.ContainerRecreateRequestContainerOverride{}
+		for j := range c.Override.Env {
+			path, key, ok := utilcontainermeta.GetEnvReferenceToMeta(podContainer, c.Override.Env[j].Name)
+			if !ok {
+				continue
+			}
+			value := revertedMeta.Labels[key]
+			if path == "metadata.annotations" {
+				value = revertedMeta.Annotations[key]
+			}
+			override.Env = append(override.Env, appsv1alpha1.ContainerRecreateRequestEnvOverride{Name: c.Override.Env[j].Name, Value: value})
+		}
+		if len(override.Env) == 0 {
+			override = nil
+		}
+		containers = append(containers, appsv1alpha1.ContainerRecreateRequestContainer{Name: c.Name, Override: override})
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+
+	var strategy *appsv1alpha1.ContainerRecreateRequestStrategy
+	if crr.Spec.Strategy != nil {
+		strategy = crr.Spec.Strategy.DeepCopy()
+	}
+	return &appsv1alpha1.ContainerRecreateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       crr.Namespace,
+			Name:            crr.Name + "-revert",
+			OwnerReferences: crr.OwnerReferences,
+			Annotations:     map[string]string{appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey: now.Format(time.RFC3339)},
+		},
+		Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+			PodName:                 crr.Spec.PodName,
+			Containers:              containers,
+			Strategy:                strategy,
+			ActiveDeadlineSeconds:   crr.Spec.ActiveDeadlineSeconds,
+			TTLSecondsAfterFinished: crr.Spec.TTLSecondsAfterFinished,
+		},
+	}
+}
+
+func isContainerKilled(crr *appsv1alpha1.ContainerRecreateRequest, name string) bool {
+	for i := range crr.Status.ContainerRecreateStates {
+		if crr.Status.ContainerRecreateStates[i].Name == name {
+			return crr.Status.ContainerRecreateStates[i].IsKilled
+		}
+	}
+	return false
+}
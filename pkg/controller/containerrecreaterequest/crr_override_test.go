@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrecreaterequest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	utilcontainerrecreate "github.com/openkruise/kruise/pkg/util/containerrecreate"
+)
+
+func TestOverride(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "pod-1",
+			UID:         "pod-1-uid",
+			Annotations: map[string]string{"log-level": "info"},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Name: "main",
+			Env: []v1.EnvVar{
+				{Name: "LOG_LEVEL", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations['log-level']"}}},
+				{Name: "PROFILE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['profile']"}}},
+			},
+		}}},
+		Status: v1.PodStatus{
+			Phase:             v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{Name: "main", ContainerID: "containerd://main-2"}},
+		},
+	}
+	crr := &appsv1alpha1.ContainerRecreateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "crr-1",
+			Labels:    map[string]string{appsv1alpha1.ContainerRecreateRequestPodUIDKey: string(pod.UID)},
+		},
+		Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+			PodName: pod.Name,
+			Containers: []appsv1alpha1.ContainerRecreateRequestContainer{{
+				Name: "main",
+				Override: &appsv1alpha1.ContainerRecreateRequestContainerOverride{Env: []appsv1alpha1.ContainerRecreateRequestEnvOverride{
+					{Name: "LOG_LEVEL", Value: "debug"},
+					{Name: "PROFILE", Value: "on"},
+				}},
+			}},
+			Strategy:                &appsv1alpha1.ContainerRecreateRequestStrategy{},
+			TTLSecondsAfterFinished: ptr.To(int32(600)),
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod, crr).Build()
+	r := &ReconcileContainerRecreateRequest{Client: fakeClient, clock: testingclock.NewFakeClock(time.Now())}
+	getObjects := func() (*appsv1alpha1.ContainerRecreateRequest, *v1.Pod) {
+		newCRR, newPod := &appsv1alpha1.ContainerRecreateRequest{}, &v1.Pod{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: crr.Namespace, Name: crr.Name}, newCRR))
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, newPod))
+		return newCRR, newPod
+	}
+
+	// the origin metadata is recorded first, together with the finalizer
+	assert.NoError(t, r.applyOverride(getObjects()))
+	newCRR, newPod := getObjects()
+	assert.JSONEq(t, `{"annotations":{"log-level":"info"},"labels":{"profile":null}}`, newCRR.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideOriginKey])
+	assert.Equal(t, []string{appsv1alpha1.ContainerRecreateRequestOverrideOriginKey}, newCRR.Finalizers)
+	assert.Equal(t, "info", newPod.Annotations["log-level"])
+
+	// then the Pod metadata is overridden and the CRR is marked applied
+	assert.NoError(t, r.applyOverride(newCRR, newPod))
+	newCRR, newPod = getObjects()
+	assert.NotEmpty(t, newCRR.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey])
+	assert.Equal(t, "debug", newPod.Annotations["log-level"])
+	assert.Equal(t, "on", newPod.Labels["profile"])
+
+	// the Pod metadata changed by others is not reverted
+	newPod.Annotations["log-level"] = "warn"
+	assert.NoError(t, fakeClient.Update(context.TODO(), newPod))
+	newCRR, newPod = getObjects()
+	newCRR.Status.ContainerRecreateStates = []appsv1alpha1.ContainerRecreateRequestContainerRecreateState{
+		{Name: "main", Phase: appsv1alpha1.ContainerRecreateRequestSucceeded, IsKilled: true},
+	}
+	assert.NoError(t, fakeClient.Update(context.TODO(), newCRR))
+
+	// the override is reverted once the CRR is deleted
+	assert.NoError(t, fakeClient.Delete(context.TODO(), newCRR))
+	newCRR, newPod = getObjects()
+	assert.NotNil(t, newCRR.DeletionTimestamp)
+	assert.NoError(t, r.releaseOverride(newCRR, newPod))
+	assert.True(t, errors.IsNotFound(fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: crr.Namespace, Name: crr.Name}, &appsv1alpha1.ContainerRecreateRequest{})))
+	newPod = &v1.Pod{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, newPod))
+	assert.Equal(t, "warn", newPod.Annotations["log-level"])
+	_, ok := newPod.Labels["profile"]
+	assert.False(t, ok)
+
+	// the containers are recreated again by a new CRR
+	revertCRR := &appsv1alpha1.ContainerRecreateRequest{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: crr.Namespace, Name: "crr-1-revert"}, revertCRR))
+	// with the env overridden by the reverted metadata, so that kruise-daemon waits for it before recreation
+	assert.Equal(t, []appsv1alpha1.ContainerRecreateRequestContainer{{
+		Name: "main",
+		Override: &appsv1alpha1.ContainerRecreateRequestContainerOverride{Env: []appsv1alpha1.ContainerRecreateRequestEnvOverride{
+			{Name: "LOG_LEVEL", Value: "warn"},
+			{Name: "PROFILE", Value: ""},
+		}},
+	}}, revertCRR.Spec.Containers)
+	assert.NotEmpty(t, revertCRR.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey])
+	assert.Empty(t, revertCRR.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideOriginKey])
+	assert.Empty(t, revertCRR.Finalizers)
+	assert.True(t, utilcontainerrecreate.IsOverrideObserved(revertCRR, newPod))
+	assert.Equal(t, crr.Spec.TTLSecondsAfterFinished, revertCRR.Spec.TTLSecondsAfterFinished)
+	assert.NoError(t, r.revertOverride(newCRR, newPod))
+}
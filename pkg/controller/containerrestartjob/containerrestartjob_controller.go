@@ -0,0 +1,465 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrestartjob
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/pubcontrol"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	"github.com/openkruise/kruise/pkg/util/expectations"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func init() {
+	flag.IntVar(&concurrentReconciles, "containerrestartjob-workers", concurrentReconciles, "Max concurrent workers for ContainerRestartJob controller.")
+}
+
+var (
+	concurrentReconciles = 3
+	controllerKind       = appsv1alpha1.SchemeGroupVersion.WithKind("ContainerRestartJob")
+	controllerName       = "containerrestartjob-controller"
+	scaleExpectations    = expectations.NewScaleExpectations()
+)
+
+// Add creates a new ContainerRestartJob Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	if !utildiscovery.DiscoverGVK(controllerKind) || !utilfeature.DefaultFeatureGate.Enabled(features.KruiseDaemon) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.ContainerRestartJobGate) {
+		return nil
+	}
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) *ReconcileContainerRestartJob {
+	return &ReconcileContainerRestartJob{
+		Client:   utilclient.NewClientFromManager(mgr, controllerName),
+		clock:    clock.RealClock{},
+		recorder: mgr.GetEventRecorderFor(controllerName),
+		finder:   controllerfinder.Finder,
+	}
+}
+
+// add a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r *ReconcileContainerRestartJob) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r,
+		MaxConcurrentReconciles: concurrentReconciles, CacheSyncTimeout: util.GetControllerCacheSyncTimeout()})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ContainerRestartJob
+	err = c.Watch(source.Kind(mgr.GetCache(), &appsv1alpha1.ContainerRestartJob{}, &handler.TypedEnqueueRequestForObject[*appsv1alpha1.ContainerRestartJob]{}))
+	if err != nil {
+		return err
+	}
+	// Watch for changes to ContainerRecreateRequest created by ContainerRestartJob
+	err = c.Watch(source.Kind(mgr.GetCache(), &appsv1alpha1.ContainerRecreateRequest{}, &crrEventHandler{
+		enqueueHandler: handler.TypedEnqueueRequestForOwner[*appsv1alpha1.ContainerRecreateRequest](mgr.GetScheme(), mgr.GetRESTMapper(),
+			&appsv1alpha1.ContainerRestartJob{}, handler.OnlyControllerOwner()),
+	}))
+	if err != nil {
+		return err
+	}
+	// Watch for the availability changes of pods
+	err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, &podEventHandler{reader: mgr.GetClient()}))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileContainerRestartJob{}
+
+// ReconcileContainerRestartJob reconciles a ContainerRestartJob object
+type ReconcileContainerRestartJob struct {
+	client.Client
+	clock    clock.Clock
+	recorder record.EventRecorder
+	finder   *controllerfinder.ControllerFinder
+}
+
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrestartjobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrestartjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrecreaterequests,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile reads that state of the cluster for a ContainerRestartJob object and makes changes based on the state read
+// and what is in the ContainerRestartJob.Spec
+func (r *ReconcileContainerRestartJob) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+	klog.V(5).InfoS("Starting to process ContainerRestartJob", "containerRestartJob", request)
+
+	// 1. Fetch the ContainerRestartJob instance
+	job := &appsv1alpha1.ContainerRestartJob{}
+	err := r.Get(context.TODO(), request.NamespacedName, job)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			scaleExpectations.DeleteExpectations(request.String())
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if !job.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	// The Job has been finished
+	if job.Status.CompletionTime != nil {
+		var leftTime time.Duration
+		if job.Spec.TTLSecondsAfterFinished != nil {
+			leftTime = time.Duration(*job.Spec.TTLSecondsAfterFinished)*time.Second - r.clock.Since(job.Status.CompletionTime.Time)
+			if leftTime <= 0 {
+				klog.InfoS("Deleting ContainerRestartJob for ttlSecondsAfterFinished", "containerRestartJob", klog.KObj(job))
+				if err = r.Delete(context.TODO(), job); err != nil {
+					return reconcile.Result{}, fmt.Errorf("delete ContainerRestartJob error: %v", err)
+				}
+				return reconcile.Result{}, nil
+			}
+		}
+		return reconcile.Result{RequeueAfter: leftTime}, nil
+	}
+
+	if scaleSatisfied, unsatisfiedDuration, dirtyPods := scaleExpectations.SatisfiedExpectations(request.String()); !scaleSatisfied {
+		if unsatisfiedDuration >= expectations.ExpectationTimeout {
+			klog.InfoS("Expectation unsatisfied overtime for ContainerRestartJob", "containerRestartJob", request, "dirtyPods", dirtyPods, "overtime", unsatisfiedDuration)
+			return reconcile.Result{}, nil
+		}
+		klog.V(4).InfoS("Not satisfied scale for ContainerRestartJob", "containerRestartJob", request, "dirtyPods", dirtyPods)
+		return reconcile.Result{RequeueAfter: expectations.ExpectationTimeout - unsatisfiedDuration}, nil
+	}
+
+	// 2. Get the pods to restart and the ContainerRecreateRequests owned by this job
+	now := metav1.NewTime(r.clock.Now())
+	startTime := job.Status.StartTime
+	if startTime == nil {
+		startTime = &now
+	}
+	pods, err := r.getTargetPods(job, startTime.Time)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get pods: %v", err)
+	}
+	crrs, err := r.getOwnedContainerRecreateRequests(job)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get ContainerRecreateRequests: %v", err)
+	}
+
+	// 3. Calculate the new status and the pods to restart in this round
+	newStatus, waitRestartPods := calculateStatus(job, pods, crrs)
+	newStatus.StartTime = startTime
+
+	// 4. Restart the containers of pods
+	var requeueAfter time.Duration
+	if !job.Spec.Paused {
+		if requeueAfter, err = r.restartPods(job, waitRestartPods, newStatus); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// 5. Update status
+	if newStatus.Phase == appsv1alpha1.ContainerRestartJobSucceeded || newStatus.Phase == appsv1alpha1.ContainerRestartJobFailed {
+		newStatus.CompletionTime = &now
+		r.recorder.Eventf(job, corev1.EventTypeNormal, "Completed", "ContainerRestartJob completed with %d succeeded and %d failed pods", newStatus.Succeeded, newStatus.Failed)
+	}
+	if !util.IsJSONObjectEqual(&job.Status, newStatus) {
+		if err = r.updateStatus(job, newStatus); err != nil {
+			return reconcile.Result{}, fmt.Errorf("update ContainerRestartJob status error: %v", err)
+		}
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// getTargetPods returns the running pods matched by the job which are created before the start time of job
+func (r *ReconcileContainerRestartJob) getTargetPods(job *appsv1alpha1.ContainerRestartJob, startTime time.Time) ([]*corev1.Pod, error) {
+	var pods []*corev1.Pod
+	if job.Spec.TargetReference != nil {
+		ref := job.Spec.TargetReference
+		refPods, _, err := r.finder.GetPodsForRef(ref.APIVersion, ref.Kind, job.Namespace, ref.Name, true)
+		if err != nil {
+			return nil, err
+		}
+		pods = refPods
+	} else {
+		selector, err := util.ValidatedLabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		// a nil or empty selector matches nothing
+		if selector.Empty() {
+			return nil, nil
+		}
+		podList := &corev1.PodList{}
+		if err = r.List(context.TODO(), podList, &client.ListOptions{Namespace: job.Namespace, LabelSelector: selector}, utilclient.DisableDeepCopy); err != nil {
+			return nil, err
+		}
+		for i := range podList.Items {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+
+	var targetPods []*corev1.Pod
+	for _, pod := range pods {
+		if !kubecontroller.IsPodActive(pod) || pod.Status.Phase != corev1.PodRunning || pod.CreationTimestamp.Time.After(startTime) {
+			continue
+		}
+		if len(getRestartContainers(job, pod)) == 0 {
+			continue
+		}
+		targetPods = append(targetPods, pod)
+	}
+	return targetPods, nil
+}
+
+// getOwnedContainerRecreateRequests returns the ContainerRecreateRequests created by the job, keyed by pod uid
+func (r *ReconcileContainerRestartJob) getOwnedContainerRecreateRequests(job *appsv1alpha1.ContainerRestartJob) (map[types.UID]*appsv1alpha1.ContainerRecreateRequest, error) {
+	crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+	if err := r.List(context.TODO(), crrList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{appsv1alpha1.ContainerRestartJobNameKey: job.Name}, utilclient.DisableDeepCopy); err != nil {
+		return nil, err
+	}
+	crrs := make(map[types.UID]*appsv1alpha1.ContainerRecreateRequest, len(crrList.Items))
+	for i := range crrList.Items {
+		crr := &crrList.Items[i]
+		if owner := metav1.GetControllerOf(crr); owner == nil || owner.UID != job.UID {
+			continue
+		}
+		crrs[types.UID(crr.Labels[appsv1alpha1.ContainerRecreateRequestPodUIDKey])] = crr
+	}
+	return crrs, nil
+}
+
+// calculateStatus aggregates the restart progress of pods, and returns the pods waiting to restart,
+// the not ready pods are ordered first since restarting them does not make more pods unavailable.
+func calculateStatus(job *appsv1alpha1.ContainerRestartJob, pods []*corev1.Pod, crrs map[types.UID]*appsv1alpha1.ContainerRecreateRequest) (*appsv1alpha1.ContainerRestartJobStatus, []*corev1.Pod) {
+	newStatus := &appsv1alpha1.ContainerRestartJobStatus{ObservedGeneration: job.Generation}
+	var waitRestartPods []*corev1.Pod
+	var unavailable int32
+	podUIDs := make(map[types.UID]struct{}, len(pods))
+	for _, pod := range pods {
+		podUIDs[pod.UID] = struct{}{}
+		crr, ok := crrs[pod.UID]
+		if !ok {
+			newStatus.Waiting++
+			waitRestartPods = append(waitRestartPods, pod)
+			if !util.IsRunningAndReady(pod) {
+				unavailable++
+			}
+			continue
+		}
+		if crr.Status.Phase != appsv1alpha1.ContainerRecreateRequestCompleted || !util.IsRunningAndReady(pod) {
+			unavailable++
+		}
+	}
+	for uid, crr := range crrs {
+		switch {
+		case crr.Status.Phase != appsv1alpha1.ContainerRecreateRequestCompleted:
+			if _, ok := podUIDs[uid]; ok {
+				newStatus.Active++
+				continue
+			}
+			// the pod has gone before its containers restarted
+			newStatus.Failed++
+			newStatus.FailedPods = append(newStatus.FailedPods, crr.Spec.PodName)
+		case isContainerRecreateRequestSucceeded(crr):
+			newStatus.Succeeded++
+		default:
+			newStatus.Failed++
+			newStatus.FailedPods = append(newStatus.FailedPods, crr.Spec.PodName)
+		}
+	}
+	newStatus.Desired = newStatus.Waiting + newStatus.Active + newStatus.Succeeded + newStatus.Failed
+	sort.Strings(newStatus.FailedPods)
+
+	switch {
+	case newStatus.Waiting == 0 && newStatus.Active == 0 && newStatus.Failed > 0:
+		newStatus.Phase = appsv1alpha1.ContainerRestartJobFailed
+	case newStatus.Waiting == 0 && newStatus.Active == 0:
+		newStatus.Phase = appsv1alpha1.ContainerRestartJobSucceeded
+	case job.Spec.Paused:
+		newStatus.Phase = appsv1alpha1.ContainerRestartJobPaused
+	default:
+		newStatus.Phase = appsv1alpha1.ContainerRestartJobRunning
+	}
+
+	sort.SliceStable(waitRestartPods, func(i, j int) bool {
+		readyI, readyJ := util.IsRunningAndReady(waitRestartPods[i]), util.IsRunningAndReady(waitRestartPods[j])
+		if readyI != readyJ {
+			return !readyI
+		}
+		return waitRestartPods[i].Name < waitRestartPods[j].Name
+	})
+	// the not ready pods can always be restarted, the ready pods are limited by maxUnavailable
+	maxUnavailable := getMaxUnavailable(job, newStatus.Desired)
+	var limited []*corev1.Pod
+	for _, pod := range waitRestartPods {
+		if util.IsRunningAndReady(pod) {
+			if unavailable >= maxUnavailable {
+				break
+			}
+			unavailable++
+		}
+		limited = append(limited, pod)
+	}
+	return newStatus, limited
+}
+
+func getMaxUnavailable(job *appsv1alpha1.ContainerRestartJob, desired int32) int32 {
+	maxUnavailable := intstr.FromInt32(1)
+	if job.Spec.MaxUnavailable != nil {
+		maxUnavailable = *job.Spec.MaxUnavailable
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(desired), true)
+	if err != nil || value < 1 {
+		return 1
+	}
+	return int32(value)
+}
+
+func isContainerRecreateRequestSucceeded(crr *appsv1alpha1.ContainerRecreateRequest) bool {
+	if len(crr.Status.ContainerRecreateStates) == 0 {
+		return false
+	}
+	for _, state := range crr.Status.ContainerRecreateStates {
+		if state.Phase != appsv1alpha1.ContainerRecreateRequestSucceeded {
+			return false
+		}
+	}
+	return true
+}
+
+// restartPods creates ContainerRecreateRequests for the pods, it returns the duration after which
+// the job should be checked again if the restarting is rejected by PodUnavailableBudget.
+func (r *ReconcileContainerRestartJob) restartPods(job *appsv1alpha1.ContainerRestartJob, pods []*corev1.Pod, newStatus *appsv1alpha1.ContainerRestartJobStatus) (time.Duration, error) {
+	key := types.NamespacedName{Namespace: job.Namespace, Name: job.Name}.String()
+	for _, pod := range pods {
+		// Determine the pub before restarting the containers of pod
+		releasePubQuota := func() error { return nil }
+		if utilfeature.DefaultFeatureGate.Enabled(features.PodUnavailableBudgetUpdateGate) {
+			allowed, reason, release, err := pubcontrol.PodUnavailableBudgetReservePod(pod, policyv1beta1.PubUpdateOperation, "kruise-manager")
+			if err != nil {
+				return 0, err
+			} else if !allowed {
+				// pub check does not pass, try again in seconds
+				newStatus.Message = fmt.Sprintf("Pod %s is waiting for PodUnavailableBudget: %s", pod.Name, reason)
+				return time.Second, nil
+			}
+			releasePubQuota = release
+		}
+
+		crr := newContainerRecreateRequest(job, pod)
+		scaleExpectations.ExpectScale(key, expectations.Create, string(pod.UID))
+		if err := r.Create(context.TODO(), crr); err != nil {
+			scaleExpectations.ObserveScale(key, expectations.Create, string(pod.UID))
+			// the containers will not be restarted this time, so give back the quota taken from pub
+			if releaseErr := releasePubQuota(); releaseErr != nil {
+				klog.ErrorS(releaseErr, "Failed to release the quota of PodUnavailableBudget", "containerRestartJob", klog.KObj(job), "pod", klog.KObj(pod))
+			}
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+			r.recorder.Eventf(job, corev1.EventTypeWarning, "FailedCreate", "Failed to create ContainerRecreateRequest for pod %s: %v", pod.Name, err)
+			return 0, err
+		}
+		klog.V(3).InfoS("ContainerRestartJob created ContainerRecreateRequest", "containerRestartJob", klog.KObj(job), "pod", klog.KObj(pod), "containerRecreateRequest", crr.Name)
+		newStatus.Waiting--
+		newStatus.Active++
+	}
+	newStatus.Message = ""
+	return 0, nil
+}
+
+func (r *ReconcileContainerRestartJob) updateStatus(job *appsv1alpha1.ContainerRestartJob, newStatus *appsv1alpha1.ContainerRestartJobStatus) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		clone := &appsv1alpha1.ContainerRestartJob{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, clone); err != nil {
+			return err
+		}
+		clone.Status = *newStatus
+		return r.Status().Update(context.TODO(), clone)
+	})
+}
+
+// getRestartContainers returns the containers of job that exist and have been started in pod
+func getRestartContainers(job *appsv1alpha1.ContainerRestartJob, pod *corev1.Pod) []appsv1alpha1.ContainerRecreateRequestContainer {
+	var containers []appsv1alpha1.ContainerRecreateRequestContainer
+	for _, c := range job.Spec.Containers {
+		if status := util.GetContainerStatus(c.Name, pod); status == nil || status.ContainerID == "" {
+			continue
+		}
+		containers = append(containers, appsv1alpha1.ContainerRecreateRequestContainer{Name: c.Name})
+	}
+	return containers
+}
+
+func newContainerRecreateRequest(job *appsv1alpha1.ContainerRestartJob, pod *corev1.Pod) *appsv1alpha1.ContainerRecreateRequest {
+	hash := fnv.New32a()
+	hash.Write([]byte(pod.UID))
+	strategy := &appsv1alpha1.ContainerRecreateRequestStrategy{}
+	if job.Spec.Strategy != nil {
+		strategy = job.Spec.Strategy.DeepCopy()
+	}
+	return &appsv1alpha1.ContainerRecreateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: job.Namespace,
+			Name:      fmt.Sprintf("%s-%s", job.Name, rand.SafeEncodeString(fmt.Sprint(hash.Sum32()))),
+			Labels: map[string]string{
+				appsv1alpha1.ContainerRestartJobNameKey:        job.Name,
+				appsv1alpha1.ContainerRecreateRequestPodUIDKey: string(pod.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(job, controllerKind),
+			},
+		},
+		Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+			PodName:               pod.Name,
+			Containers:            getRestartContainers(job, pod),
+			Strategy:              strategy,
+			ActiveDeadlineSeconds: job.Spec.ActiveDeadlineSeconds,
+		},
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrestartjob
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/pubcontrol"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+	"github.com/openkruise/kruise/pkg/util/expectations"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+var scheme *runtime.Scheme
+
+func init() {
+	scheme = runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(policyv1beta1.AddToScheme(scheme))
+}
+
+func newTestPod(name string, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			UID:               types.UID(name + "-uid"),
+			Labels:            map[string]string{"app": "test"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "main", ContainerID: "containerd://main"},
+				{Name: "sidecar", ContainerID: "containerd://sidecar"},
+			},
+		},
+	}
+}
+
+func newTestJob() *appsv1alpha1.ContainerRestartJob {
+	return &appsv1alpha1.ContainerRestartJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job", UID: "job-uid", Generation: 1},
+		Spec: appsv1alpha1.ContainerRestartJobSpec{
+			Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Containers: []appsv1alpha1.ContainerRestartJobContainer{{Name: "main"}},
+		},
+	}
+}
+
+func newTestCRR(job *appsv1alpha1.ContainerRestartJob, pod *corev1.Pod, phases ...appsv1alpha1.ContainerRecreateRequestPhase) *appsv1alpha1.ContainerRecreateRequest {
+	crr := newContainerRecreateRequest(job, pod)
+	if len(phases) > 0 {
+		crr.Status.Phase = appsv1alpha1.ContainerRecreateRequestCompleted
+		for _, phase := range phases {
+			crr.Status.ContainerRecreateStates = append(crr.Status.ContainerRecreateStates, appsv1alpha1.ContainerRecreateRequestContainerRecreateState{Name: "main", Phase: phase})
+		}
+	}
+	return crr
+}
+
+func TestCalculateStatus(t *testing.T) {
+	job := newTestJob()
+	podA, podB, podC, podD := newTestPod("pod-a", true), newTestPod("pod-b", true), newTestPod("pod-c", false), newTestPod("pod-d", true)
+
+	cases := []struct {
+		name           string
+		maxUnavailable *intstr.IntOrString
+		paused         bool
+		pods           []*corev1.Pod
+		crrs           []*appsv1alpha1.ContainerRecreateRequest
+		expectPods     []string
+		expectStatus   appsv1alpha1.ContainerRestartJobStatus
+	}{
+		{
+			name:       "not ready pod restarts without consuming maxUnavailable",
+			pods:       []*corev1.Pod{podA, podB, podC},
+			expectPods: []string{"pod-c"},
+			expectStatus: appsv1alpha1.ContainerRestartJobStatus{
+				ObservedGeneration: 1, Phase: appsv1alpha1.ContainerRestartJobRunning, Desired: 3, Waiting: 3,
+			},
+		},
+		{
+			name:           "restarting pods consume maxUnavailable",
+			maxUnavailable: ptr.To(intstr.FromInt32(2)),
+			pods:           []*corev1.Pod{podA, podB, podD},
+			crrs:           []*appsv1alpha1.ContainerRecreateRequest{newTestCRR(job, podA)},
+			expectPods:     []string{"pod-b"},
+			expectStatus: appsv1alpha1.ContainerRestartJobStatus{
+				ObservedGeneration: 1, Phase: appsv1alpha1.ContainerRestartJobRunning, Desired: 3, Waiting: 2, Active: 1,
+			},
+		},
+		{
+			name:           "percentage maxUnavailable",
+			maxUnavailable: ptr.To(intstr.FromString("50%")),
+			pods:           []*corev1.Pod{podA, podB, podD},
+			crrs:           []*appsv1alpha1.ContainerRecreateRequest{newTestCRR(job, podA, appsv1alpha1.ContainerRecreateRequestSucceeded)},
+			expectPods:     []string{"pod-b", "pod-d"},
+			expectStatus: appsv1alpha1.ContainerRestartJobStatus{
+				ObservedGeneration: 1, Phase: appsv1alpha1.ContainerRestartJobRunning, Desired: 3, Waiting: 2, Succeeded: 1,
+			},
+		},
+		{
+			name:       "paused",
+			paused:     true,
+			pods:       []*corev1.Pod{podA},
+			expectPods: []string{"pod-a"},
+			expectStatus: appsv1alpha1.ContainerRestartJobStatus{
+				ObservedGeneration: 1, Phase: appsv1alpha1.ContainerRestartJobPaused, Desired: 1, Waiting: 1,
+			},
+		},
+		{
+			name: "completed with failed pods",
+			pods: []*corev1.Pod{podA, podB},
+			crrs: []*appsv1alpha1.ContainerRecreateRequest{
+				newTestCRR(job, podA, appsv1alpha1.ContainerRecreateRequestSucceeded),
+				newTestCRR(job, podB, appsv1alpha1.ContainerRecreateRequestFailed),
+				newTestCRR(job, podD),
+			},
+			expectStatus: appsv1alpha1.ContainerRestartJobStatus{
+				ObservedGeneration: 1, Phase: appsv1alpha1.ContainerRestartJobFailed, Desired: 3, Succeeded: 1, Failed: 2,
+				FailedPods: []string{"pod-b", "pod-d"},
+			},
+		},
+		{
+			name: "succeeded",
+			pods: []*corev1.Pod{podA},
+			crrs: []*appsv1alpha1.ContainerRecreateRequest{newTestCRR(job, podA, appsv1alpha1.ContainerRecreateRequestSucceeded)},
+			expectStatus: appsv1alpha1.ContainerRestartJobStatus{
+				ObservedGeneration: 1, Phase: appsv1alpha1.ContainerRestartJobSucceeded, Desired: 1, Succeeded: 1,
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			job := newTestJob()
+			job.Spec.MaxUnavailable = cs.maxUnavailable
+			job.Spec.Paused = cs.paused
+			crrs := make(map[types.UID]*appsv1alpha1.ContainerRecreateRequest)
+			for _, crr := range cs.crrs {
+				crrs[types.UID(crr.Labels[appsv1alpha1.ContainerRecreateRequestPodUIDKey])] = crr
+			}
+			status, pods := calculateStatus(job, cs.pods, crrs)
+			var podNames []string
+			for _, pod := range pods {
+				podNames = append(podNames, pod.Name)
+			}
+			assert.Equal(t, cs.expectPods, podNames)
+			assert.Equal(t, cs.expectStatus, *status)
+		})
+	}
+}
+
+func TestReconcileContainerRestartJob(t *testing.T) {
+	job := newTestJob()
+	podA, podB := newTestPod("pod-a", true), newTestPod("pod-b", true)
+	newPod := newTestPod("pod-new", true)
+	newPod.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+	noContainerPod := newTestPod("pod-no-container", true)
+	noContainerPod.Status.ContainerStatuses = noContainerPod.Status.ContainerStatuses[1:]
+
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(job, podA, podB, newPod, noContainerPod).
+		WithStatusSubresource(&appsv1alpha1.ContainerRestartJob{}, &appsv1alpha1.ContainerRecreateRequest{}).
+		Build()
+	r := &ReconcileContainerRestartJob{Client: fakeClient, clock: fakeClock, recorder: record.NewFakeRecorder(10)}
+	key := types.NamespacedName{Namespace: job.Namespace, Name: job.Name}
+
+	reconcileAndCheck := func(expectCRRs int, expectPhase appsv1alpha1.ContainerRestartJobPhase) *appsv1alpha1.ContainerRestartJob {
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		assert.NoError(t, err)
+		crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+		assert.NoError(t, fakeClient.List(context.TODO(), crrList, client.InNamespace(job.Namespace)))
+		assert.Len(t, crrList.Items, expectCRRs)
+		newJob := &appsv1alpha1.ContainerRestartJob{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), key, newJob))
+		assert.Equal(t, expectPhase, newJob.Status.Phase)
+		return newJob
+	}
+	completeCRR := func(pod *corev1.Pod) {
+		crr := &appsv1alpha1.ContainerRecreateRequest{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: newTestCRR(job, pod).Name}, crr))
+		assert.Equal(t, []appsv1alpha1.ContainerRecreateRequestContainer{{Name: "main"}}, crr.Spec.Containers)
+		assert.Equal(t, metav1.GetControllerOf(crr).UID, job.UID)
+		crr.Status.Phase = appsv1alpha1.ContainerRecreateRequestCompleted
+		crr.Status.ContainerRecreateStates = []appsv1alpha1.ContainerRecreateRequestContainerRecreateState{{Name: "main", Phase: appsv1alpha1.ContainerRecreateRequestSucceeded}}
+		assert.NoError(t, fakeClient.Status().Update(context.TODO(), crr))
+		scaleExpectations.ObserveScale(key.String(), expectations.Create, string(pod.UID))
+	}
+
+	// only one pod restarts at a time by default
+	newJob := reconcileAndCheck(1, appsv1alpha1.ContainerRestartJobRunning)
+	assert.Equal(t, int32(2), newJob.Status.Desired)
+	assert.Equal(t, int32(1), newJob.Status.Active)
+	assert.NotNil(t, newJob.Status.StartTime)
+	completeCRR(podA)
+
+	newJob = reconcileAndCheck(2, appsv1alpha1.ContainerRestartJobRunning)
+	assert.Equal(t, int32(1), newJob.Status.Succeeded)
+	completeCRR(podB)
+
+	newJob = reconcileAndCheck(2, appsv1alpha1.ContainerRestartJobSucceeded)
+	assert.Equal(t, int32(2), newJob.Status.Succeeded)
+	assert.NotNil(t, newJob.Status.CompletionTime)
+
+	// the job is deleted after ttlSecondsAfterFinished
+	newJob.Spec.TTLSecondsAfterFinished = ptr.To(int32(60))
+	assert.NoError(t, fakeClient.Update(context.TODO(), newJob))
+	res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.True(t, res.RequeueAfter > 0, fmt.Sprintf("requeueAfter %v", res.RequeueAfter))
+	fakeClock.Step(2 * time.Minute)
+	_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.True(t, errors.IsNotFound(fakeClient.Get(context.TODO(), key, newJob)))
+}
+
+func TestRestartPodsReleasePubQuotaOnCreateFailure(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.PodUnavailableBudgetUpdateGate, true)()
+
+	job := newTestJob()
+	pod := newTestPod("pod-a", true)
+	pod.Annotations = map[string]string{pubcontrol.PodRelatedPubAnnotation: "pub"}
+	pub := &policyv1beta1.PodUnavailableBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pub", UID: "pub-uid"},
+		Spec: policyv1beta1.PodUnavailableBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		Status: policyv1beta1.PodUnavailableBudgetStatus{UnavailableAllowed: 1, DesiredAvailable: 1},
+	}
+	_ = util.GlobalCache.Delete(pub)
+	defer func() { _ = util.GlobalCache.Delete(pub) }()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(job, pod, pub).
+		WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if _, ok := obj.(*appsv1alpha1.ContainerRecreateRequest); ok {
+					return errors.NewInternalError(fmt.Errorf("injected error"))
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+	pubcontrol.InitPubControl(fakeClient, &controllerfinder.ControllerFinder{Client: fakeClient}, record.NewFakeRecorder(10))
+	r := &ReconcileContainerRestartJob{Client: fakeClient, clock: testingclock.NewFakeClock(time.Now()), recorder: record.NewFakeRecorder(10)}
+
+	newStatus := &appsv1alpha1.ContainerRestartJobStatus{Waiting: 1}
+	_, err := r.restartPods(job, []*corev1.Pod{pod}, newStatus)
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), newStatus.Active)
+
+	newPub := &policyv1beta1.PodUnavailableBudget{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pub.Namespace, Name: pub.Name}, newPub))
+	assert.Equal(t, int32(1), newPub.Status.UnavailableAllowed)
+	assert.Empty(t, newPub.Status.UnavailablePods)
+}
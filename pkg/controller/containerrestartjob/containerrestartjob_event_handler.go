@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrestartjob
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	"github.com/openkruise/kruise/pkg/util/expectations"
+)
+
+var _ handler.TypedEventHandler[*appsv1alpha1.ContainerRecreateRequest, reconcile.Request] = &crrEventHandler{}
+
+type crrEventHandler struct {
+	enqueueHandler handler.TypedEventHandler[*appsv1alpha1.ContainerRecreateRequest, reconcile.Request]
+}
+
+func (e *crrEventHandler) Create(ctx context.Context, evt event.TypedCreateEvent[*appsv1alpha1.ContainerRecreateRequest], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	crr := evt.Object
+	if controllerRef := metav1.GetControllerOf(crr); controllerRef != nil && controllerRef.Kind == controllerKind.Kind {
+		key := types.NamespacedName{Namespace: crr.Namespace, Name: controllerRef.Name}.String()
+		scaleExpectations.ObserveScale(key, expectations.Create, crr.Labels[appsv1alpha1.ContainerRecreateRequestPodUIDKey])
+	}
+	e.enqueueHandler.Create(ctx, evt, q)
+}
+
+func (e *crrEventHandler) Update(ctx context.Context, evt event.TypedUpdateEvent[*appsv1alpha1.ContainerRecreateRequest], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if evt.ObjectOld.Status.Phase == evt.ObjectNew.Status.Phase {
+		return
+	}
+	e.enqueueHandler.Update(ctx, evt, q)
+}
+
+func (e *crrEventHandler) Delete(ctx context.Context, evt event.TypedDeleteEvent[*appsv1alpha1.ContainerRecreateRequest], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueueHandler.Delete(ctx, evt, q)
+}
+
+func (e *crrEventHandler) Generic(ctx context.Context, evt event.TypedGenericEvent[*appsv1alpha1.ContainerRecreateRequest], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+var _ handler.TypedEventHandler[*corev1.Pod, reconcile.Request] = &podEventHandler{}
+
+// podEventHandler enqueues the unfinished ContainerRestartJobs in the namespace of pod when the pod
+// becomes ready or is deleted, since the restarting may be waiting for the pod to be available.
+type podEventHandler struct {
+	reader client.Reader
+}
+
+func (e *podEventHandler) Create(ctx context.Context, evt event.TypedCreateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (e *podEventHandler) Update(ctx context.Context, evt event.TypedUpdateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldPod, newPod := evt.ObjectOld, evt.ObjectNew
+	if util.IsRunningAndReady(oldPod) == util.IsRunningAndReady(newPod) && oldPod.DeletionTimestamp.IsZero() == newPod.DeletionTimestamp.IsZero() {
+		return
+	}
+	e.enqueueJobs(newPod.Namespace, q)
+}
+
+func (e *podEventHandler) Delete(ctx context.Context, evt event.TypedDeleteEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.enqueueJobs(evt.Object.Namespace, q)
+}
+
+func (e *podEventHandler) Generic(ctx context.Context, evt event.TypedGenericEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (e *podEventHandler) enqueueJobs(namespace string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	jobList := &appsv1alpha1.ContainerRestartJobList{}
+	if err := e.reader.List(context.TODO(), jobList, client.InNamespace(namespace), utilclient.DisableDeepCopy); err != nil {
+		klog.ErrorS(err, "Failed to list ContainerRestartJobs", "namespace", namespace)
+		return
+	}
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if job.Status.CompletionTime != nil || job.Spec.Paused {
+			continue
+		}
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: job.Namespace, Name: job.Name}})
+	}
+}
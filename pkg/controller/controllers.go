@@ -26,7 +26,9 @@ import (
 	"github.com/openkruise/kruise/pkg/controller/cloneset"
 	containerlauchpriority "github.com/openkruise/kruise/pkg/controller/containerlaunchpriority"
 	"github.com/openkruise/kruise/pkg/controller/containerrecreaterequest"
+	"github.com/openkruise/kruise/pkg/controller/containerrestartjob"
 	"github.com/openkruise/kruise/pkg/controller/daemonset"
+	"github.com/openkruise/kruise/pkg/controller/enhancedlivenessprobe"
 	"github.com/openkruise/kruise/pkg/controller/ephemeraljob"
 	"github.com/openkruise/kruise/pkg/controller/imagelistpulljob"
 	"github.com/openkruise/kruise/pkg/controller/imagepulljob"
@@ -37,9 +39,11 @@ import (
 	"github.com/openkruise/kruise/pkg/controller/podreadiness"
 	"github.com/openkruise/kruise/pkg/controller/podunavailablebudget"
 	"github.com/openkruise/kruise/pkg/controller/resourcedistribution"
+	"github.com/openkruise/kruise/pkg/controller/serverlesspodprobe"
 	"github.com/openkruise/kruise/pkg/controller/sidecarset"
 	"github.com/openkruise/kruise/pkg/controller/sidecarterminator"
 	"github.com/openkruise/kruise/pkg/controller/statefulset"
+	"github.com/openkruise/kruise/pkg/controller/templateimage"
 	"github.com/openkruise/kruise/pkg/controller/uniteddeployment"
 	"github.com/openkruise/kruise/pkg/controller/workloadspread"
 )
@@ -51,6 +55,7 @@ func init() {
 	controllerAddFuncs = append(controllerAddFuncs, broadcastjob.Add)
 	controllerAddFuncs = append(controllerAddFuncs, cloneset.Add)
 	controllerAddFuncs = append(controllerAddFuncs, containerrecreaterequest.Add)
+	controllerAddFuncs = append(controllerAddFuncs, containerrestartjob.Add)
 	controllerAddFuncs = append(controllerAddFuncs, daemonset.Add)
 	controllerAddFuncs = append(controllerAddFuncs, nodeimage.Add)
 	controllerAddFuncs = append(controllerAddFuncs, imagepulljob.Add)
@@ -67,7 +72,10 @@ func init() {
 	controllerAddFuncs = append(controllerAddFuncs, sidecarterminator.Add)
 	controllerAddFuncs = append(controllerAddFuncs, podprobemarker.Add)
 	controllerAddFuncs = append(controllerAddFuncs, nodepodprobe.Add)
+	controllerAddFuncs = append(controllerAddFuncs, serverlesspodprobe.Add)
 	controllerAddFuncs = append(controllerAddFuncs, imagelistpulljob.Add)
+	controllerAddFuncs = append(controllerAddFuncs, enhancedlivenessprobe.Add)
+	controllerAddFuncs = append(controllerAddFuncs, templateimage.Add)
 }
 
 func SetupWithManager(m manager.Manager) error {
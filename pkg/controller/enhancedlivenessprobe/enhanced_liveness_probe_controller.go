@@ -0,0 +1,331 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/ratelimiter"
+)
+
+func init() {
+	flag.IntVar(&concurrentReconciles, "enhancedlivenessprobe-workers", concurrentReconciles, "Max concurrent workers for EnhancedLivenessProbe controller.")
+	flag.IntVar(&maxRestarts, "enhancedlivenessprobe-max-restarts", maxRestarts, "Max number of pods restarted by the enhanced liveness probe in the cluster within the restart window.")
+	flag.DurationVar(&restartWindow, "enhancedlivenessprobe-restart-window", restartWindow, "The sliding window in which the restarts of the enhanced liveness probe are limited by the budgets.")
+}
+
+var (
+	concurrentReconciles = 3
+	maxRestarts          = 50
+	restartWindow        = 10 * time.Minute
+	nodePodProbeKind     = appsv1alpha1.SchemeGroupVersion.WithKind("NodePodProbe")
+
+	// defaultWorkloadRestartBudget is the budget of the workload if the pods have no AnnotationEnhancedLivenessRestartBudget
+	defaultWorkloadRestartBudget = intstr.FromString("10%")
+)
+
+// Add creates a new EnhancedLivenessProbe Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	if !utildiscovery.DiscoverGVK(nodePodProbeKind) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.EnhancedLivenessProbeGate) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerGate) {
+		return nil
+	}
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	cli := utilclient.NewClientFromManager(mgr, "enhancedlivenessprobe-controller")
+	return &ReconcileEnhancedLivenessProbe{
+		Client:   cli,
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("enhancedlivenessprobe-controller"),
+		finder:   controllerfinder.Finder,
+		budget:   newRestartBudget(),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("enhancedlivenessprobe-controller", mgr, controller.Options{
+		Reconciler: r, MaxConcurrentReconciles: concurrentReconciles, CacheSyncTimeout: util.GetControllerCacheSyncTimeout(),
+		RateLimiter: ratelimiter.DefaultControllerRateLimiter[reconcile.Request]()})
+	if err != nil {
+		return err
+	}
+
+	// watch for changes to pod
+	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, &enqueueRequestForPod{})); err != nil {
+		return err
+	}
+
+	// watch for changes to NodePodProbe
+	if err = c.Watch(source.Kind(mgr.GetCache(), &appsv1alpha1.NodePodProbe{}, &enqueueRequestForNodePodProbe{reader: mgr.GetClient()})); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileEnhancedLivenessProbe{}
+
+// ReconcileEnhancedLivenessProbe runs the liveness probes backed up by the pod webhook with NodePodProbe,
+// and restarts the containers failing the probes with ContainerRecreateRequest.
+type ReconcileEnhancedLivenessProbe struct {
+	client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+
+	finder *controllerfinder.ControllerFinder
+	budget *restartBudget
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=nodepodprobes,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=containerrecreaterequests,verbs=get;list;watch;create
+
+// Reconcile keeps the liveness probes of the pod in NodePodProbe, and restarts the containers failing the probes
+func (r *ReconcileEnhancedLivenessProbe) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+	requeueAfter, err := r.syncPod(req.Namespace, req.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *ReconcileEnhancedLivenessProbe) syncPod(namespace, name string) (time.Duration, error) {
+	pod := &corev1.Pod{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, pod); err != nil {
+		// the probes of the deleted pod are removed by the NodePodProbe controller
+		return 0, client.IgnoreNotFound(err)
+	}
+	if !kubecontroller.IsPodActive(pod) || pod.Spec.NodeName == "" {
+		return 0, nil
+	}
+	node := &corev1.Node{}
+	if err := r.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		return 0, client.IgnoreNotFound(err)
+	}
+	if node.Labels[util.VirtualKubeletLabelKey] == util.VirtualKubeletLabelValue {
+		return 0, nil
+	}
+
+	var probes []appsv1alpha1.ContainerProbe
+	if enhancedlivenessprobe.UsingEnhancedLivenessProbe(pod) {
+		livenessProbes, err := enhancedlivenessprobe.GetContainerLivenessProbes(pod)
+		if err != nil {
+			// the annotation is written by webhook and never changed, so it is not retried
+			klog.ErrorS(err, "Failed to parse the liveness probes of pod", "pod", klog.KObj(pod))
+			return 0, nil
+		}
+		probes = buildContainerProbes(pod, livenessProbes)
+	}
+	// wait for the pod ip to probe the containers, the pod will be enqueued again once it is allocated
+	if len(probes) > 0 && pod.Status.PodIP == "" {
+		return 0, nil
+	}
+
+	npp, err := r.updateNodePodProbe(pod, probes)
+	if err != nil || npp == nil || len(probes) == 0 {
+		return 0, err
+	}
+
+	containers := getFailedContainers(pod, npp, probes)
+	if len(containers) == 0 {
+		return 0, nil
+	}
+	return r.restartContainers(pod, containers)
+}
+
+// buildContainerProbes converts the liveness probes into the probes of NodePodProbe, the named ports are converted
+// into numbers as kruise-daemon has no idea of the container ports.
+func buildContainerProbes(pod *corev1.Pod, livenessProbes []enhancedlivenessprobe.ContainerLivenessProbe) []appsv1alpha1.ContainerProbe {
+	var probes []appsv1alpha1.ContainerProbe
+	for i := range livenessProbes {
+		probe := livenessProbes[i].LivenessProbe.DeepCopy()
+		container := util.GetPodContainerByName(livenessProbes[i].Name, pod)
+		if container == nil {
+			continue
+		}
+		if err := convertProbePort(probe, container); err != nil {
+			klog.ErrorS(err, "Failed to convert the port of liveness probe", "pod", klog.KObj(pod), "container", container.Name)
+			continue
+		}
+		probes = append(probes, appsv1alpha1.ContainerProbe{
+			Name:          enhancedlivenessprobe.GetProbeName(container.Name),
+			ContainerName: container.Name,
+			Probe:         appsv1alpha1.ContainerProbeSpec{Probe: *probe},
+		})
+	}
+	return probes
+}
+
+func convertProbePort(probe *corev1.Probe, container *corev1.Container) error {
+	var port *intstr.IntOrString
+	if probe.HTTPGet != nil {
+		port = &probe.HTTPGet.Port
+	} else if probe.TCPSocket != nil {
+		port = &probe.TCPSocket.Port
+	}
+	if port == nil || port.Type == intstr.Int {
+		return nil
+	}
+	portInt, err := util.ExtractPort(*port, *container)
+	if err != nil {
+		return fmt.Errorf("failed to extract port %s: %v", port.String(), err)
+	}
+	*port = intstr.FromInt(portInt)
+	return nil
+}
+
+// updateNodePodProbe replaces the enhanced liveness probes of the pod in NodePodProbe with probes,
+// it returns nil if the NodePodProbe of the node has not been created.
+func (r *ReconcileEnhancedLivenessProbe) updateNodePodProbe(pod *corev1.Pod, probes []appsv1alpha1.ContainerProbe) (*appsv1alpha1.NodePodProbe, error) {
+	npp := &appsv1alpha1.NodePodProbe{}
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := r.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, npp); err != nil {
+			return err
+		}
+		newSpec := setPodProbes(npp.Spec.DeepCopy(), pod, probes)
+		if reflect.DeepEqual(npp.Spec, *newSpec) {
+			return nil
+		}
+		oldSpec := npp.Spec
+		npp.Spec = *newSpec
+		if err := r.Update(context.TODO(), npp); err != nil {
+			return err
+		}
+		klog.V(3).InfoS("EnhancedLivenessProbe updated NodePodProbe success", "pod", klog.KObj(pod), "nodePodProbe", npp.Name,
+			"oldSpec", util.DumpJSON(oldSpec), "newSpec", util.DumpJSON(newSpec))
+		return nil
+	})
+	if errors.IsNotFound(err) {
+		// NodePodProbe will be created by NodePodProbe controller, and the pod will be enqueued then
+		klog.V(4).InfoS("EnhancedLivenessProbe waits for NodePodProbe to be created", "pod", klog.KObj(pod), "nodeName", pod.Spec.NodeName)
+		return nil, nil
+	} else if err != nil {
+		klog.ErrorS(err, "EnhancedLivenessProbe failed to update NodePodProbe", "pod", klog.KObj(pod), "nodeName", pod.Spec.NodeName)
+		return nil, err
+	}
+	return npp, nil
+}
+
+func setPodProbes(spec *appsv1alpha1.NodePodProbeSpec, pod *corev1.Pod, probes []appsv1alpha1.ContainerProbe) *appsv1alpha1.NodePodProbeSpec {
+	var podProbe *appsv1alpha1.PodProbe
+	for i := range spec.PodProbes {
+		if spec.PodProbes[i].UID == string(pod.UID) {
+			podProbe = &spec.PodProbes[i]
+			break
+		}
+	}
+	if podProbe == nil {
+		if len(probes) == 0 {
+			return spec
+		}
+		spec.PodProbes = append(spec.PodProbes, appsv1alpha1.PodProbe{Name: pod.Name, Namespace: pod.Namespace, UID: string(pod.UID)})
+		podProbe = &spec.PodProbes[len(spec.PodProbes)-1]
+	}
+	if podProbe.IP == "" {
+		podProbe.IP = pod.Status.PodIP
+	}
+
+	newProbes := make([]appsv1alpha1.ContainerProbe, 0, len(podProbe.Probes)+len(probes))
+	for _, probe := range podProbe.Probes {
+		if !enhancedlivenessprobe.IsEnhancedLivenessProbe(probe.Name) {
+			newProbes = append(newProbes, probe)
+		}
+	}
+	newProbes = append(newProbes, probes...)
+	if len(newProbes) > 0 {
+		podProbe.Probes = newProbes
+		return spec
+	}
+
+	// remove the pod from NodePodProbe if it has no probe any more
+	podProbes := make([]appsv1alpha1.PodProbe, 0, len(spec.PodProbes))
+	for i := range spec.PodProbes {
+		if spec.PodProbes[i].UID != string(pod.UID) {
+			podProbes = append(podProbes, spec.PodProbes[i])
+		}
+	}
+	spec.PodProbes = podProbes
+	return spec
+}
+
+// getFailedContainers returns the running containers failing the liveness probes. The probe states of the previous
+// containers are ignored, which are probed before the containers started.
+func getFailedContainers(pod *corev1.Pod, npp *appsv1alpha1.NodePodProbe, probes []appsv1alpha1.ContainerProbe) []string {
+	var podProbeStatus *appsv1alpha1.PodProbeStatus
+	for i := range npp.Status.PodProbeStatuses {
+		if npp.Status.PodProbeStatuses[i].UID == string(pod.UID) {
+			podProbeStatus = &npp.Status.PodProbeStatuses[i]
+			break
+		}
+	}
+	if podProbeStatus == nil {
+		return nil
+	}
+	probeNames := make(map[string]struct{}, len(probes))
+	for _, probe := range probes {
+		probeNames[probe.Name] = struct{}{}
+	}
+
+	var containers []string
+	for _, state := range podProbeStatus.ProbeStates {
+		if _, ok := probeNames[state.Name]; !ok || state.State != appsv1alpha1.ProbeFailed {
+			continue
+		}
+		containerName, _ := enhancedlivenessprobe.ParseProbeName(state.Name)
+		status := util.GetContainerStatus(containerName, pod)
+		if status == nil || status.State.Running == nil || !state.LastProbeTime.After(status.State.Running.StartedAt.Time) {
+			continue
+		}
+		containers = append(containers, containerName)
+	}
+	return containers
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+)
+
+var (
+	scheme *runtime.Scheme
+
+	demoCloneSet = appsv1alpha1.CloneSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: appsv1alpha1.GroupVersion.String(), Kind: "CloneSet"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app", UID: "cs-uid"},
+		Spec:       appsv1alpha1.CloneSetSpec{Replicas: ptr.To(int32(10))},
+	}
+
+	demoPod = corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "app-1",
+			UID:       "pod-uid",
+			Annotations: map[string]string{
+				appsv1beta1.AnnotationUsingEnhancedLiveness:       "true",
+				appsv1beta1.AnnotationNativeContainerProbeContext: `[{"name":"main","livenessProbe":{"httpGet":{"path":"/healthz","port":"http"},"failureThreshold":3}}]`,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: appsv1alpha1.GroupVersion.String(), Kind: "CloneSet", Name: "app", UID: "cs-uid", Controller: ptr.To(true),
+			}},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node1",
+			Containers: []corev1.Container{
+				{Name: "main", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+				{Name: "sidecar"},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "10.0.0.1",
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "main", ContainerID: "containerd://main-1", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-time.Hour))}}},
+				{Name: "sidecar", ContainerID: "containerd://sidecar-1", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-time.Hour))}}},
+			},
+		},
+	}
+
+	demoNode = corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+
+	demoLivenessProbe = appsv1alpha1.ContainerProbe{
+		Name:          enhancedlivenessprobe.GetProbeName("main"),
+		ContainerName: "main",
+		Probe: appsv1alpha1.ContainerProbeSpec{Probe: corev1.Probe{
+			ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)}},
+			FailureThreshold: 3,
+		}},
+	}
+
+	demoMarkerProbe = appsv1alpha1.ContainerProbe{
+		Name:          "ppm#idle",
+		ContainerName: "sidecar",
+		Probe: appsv1alpha1.ContainerProbeSpec{Probe: corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: []string{"/idle.sh"}}},
+		}},
+	}
+)
+
+func init() {
+	scheme = runtime.NewScheme()
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+}
+
+func newNodePodProbe(podProbes []appsv1alpha1.PodProbe, states ...appsv1alpha1.ContainerProbeState) *appsv1alpha1.NodePodProbe {
+	npp := &appsv1alpha1.NodePodProbe{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Spec:       appsv1alpha1.NodePodProbeSpec{PodProbes: podProbes},
+	}
+	if len(states) > 0 {
+		npp.Status.PodProbeStatuses = []appsv1alpha1.PodProbeStatus{
+			{Namespace: "default", Name: "app-1", UID: "pod-uid", ProbeStates: states},
+		}
+	}
+	return npp
+}
+
+func newRestartCRR(name, ownerUID string, created time.Time, phase appsv1alpha1.ContainerRecreateRequestPhase) *appsv1alpha1.ContainerRecreateRequest {
+	return &appsv1alpha1.ContainerRecreateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{LabelRestartOwnerUID: ownerUID, appsv1alpha1.ContainerRecreateRequestPodUIDKey: name + "-uid"},
+		},
+		Spec:   appsv1alpha1.ContainerRecreateRequestSpec{PodName: name},
+		Status: appsv1alpha1.ContainerRecreateRequestStatus{Phase: phase},
+	}
+}
+
+func TestSyncPod(t *testing.T) {
+	failedState := appsv1alpha1.ContainerProbeState{
+		Name:          enhancedlivenessprobe.GetProbeName("main"),
+		State:         appsv1alpha1.ProbeFailed,
+		LastProbeTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+	}
+	livenessPodProbe := appsv1alpha1.PodProbe{Namespace: "default", Name: "app-1", UID: "pod-uid", IP: "10.0.0.1",
+		Probes: []appsv1alpha1.ContainerProbe{demoLivenessProbe}}
+
+	cases := []struct {
+		name            string
+		getPod          func() *corev1.Pod
+		getNodePodProbe func() *appsv1alpha1.NodePodProbe
+		getCRRs         func() []client.Object
+		maxRestarts     int
+		expectPodProbes []appsv1alpha1.PodProbe
+		expectRestart   []string
+		expectRequeue   bool
+	}{
+		{
+			name:            "add liveness probe into NodePodProbe",
+			getPod:          func() *corev1.Pod { return demoPod.DeepCopy() },
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe { return newNodePodProbe(nil) },
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+		},
+		{
+			name:   "keep the probes of PodProbeMarker",
+			getPod: func() *corev1.Pod { return demoPod.DeepCopy() },
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{{Namespace: "default", Name: "app-1", UID: "pod-uid", IP: "10.0.0.1",
+					Probes: []appsv1alpha1.ContainerProbe{demoMarkerProbe}}})
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{{Namespace: "default", Name: "app-1", UID: "pod-uid", IP: "10.0.0.1",
+				Probes: []appsv1alpha1.ContainerProbe{demoMarkerProbe, demoLivenessProbe}}},
+		},
+		{
+			name: "remove liveness probe once disabled",
+			getPod: func() *corev1.Pod {
+				pod := demoPod.DeepCopy()
+				pod.Annotations[appsv1beta1.AnnotationUsingEnhancedLiveness] = "false"
+				return pod
+			},
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{
+					{Namespace: "default", Name: "app-1", UID: "pod-uid", IP: "10.0.0.1", Probes: []appsv1alpha1.ContainerProbe{demoMarkerProbe, demoLivenessProbe}},
+					{Namespace: "default", Name: "app-2", UID: "pod-uid-2", IP: "10.0.0.2", Probes: []appsv1alpha1.ContainerProbe{demoLivenessProbe}},
+				})
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{
+				{Namespace: "default", Name: "app-1", UID: "pod-uid", IP: "10.0.0.1", Probes: []appsv1alpha1.ContainerProbe{demoMarkerProbe}},
+				{Namespace: "default", Name: "app-2", UID: "pod-uid-2", IP: "10.0.0.2", Probes: []appsv1alpha1.ContainerProbe{demoLivenessProbe}},
+			},
+		},
+		{
+			name:   "restart failed container",
+			getPod: func() *corev1.Pod { return demoPod.DeepCopy() },
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{livenessPodProbe}, failedState)
+			},
+			getCRRs: func() []client.Object {
+				return []client.Object{newRestartCRR("other", "other-uid", time.Now().Add(-time.Minute), appsv1alpha1.ContainerRecreateRequestCompleted)}
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+			expectRestart:   []string{"main"},
+		},
+		{
+			name: "ignore the failure of previous container",
+			getPod: func() *corev1.Pod {
+				pod := demoPod.DeepCopy()
+				pod.Status.ContainerStatuses[0].State.Running.StartedAt = metav1.Now()
+				return pod
+			},
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{livenessPodProbe}, failedState)
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+		},
+		{
+			name:   "wait for the recreation in progress",
+			getPod: func() *corev1.Pod { return demoPod.DeepCopy() },
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{livenessPodProbe}, failedState)
+			},
+			getCRRs: func() []client.Object {
+				crr := newRestartCRR("app-1-manual", "cs-uid", time.Now().Add(-time.Hour), appsv1alpha1.ContainerRecreateRequestRecreating)
+				crr.Labels[appsv1alpha1.ContainerRecreateRequestPodUIDKey] = "pod-uid"
+				return []client.Object{crr}
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+		},
+		{
+			name: "throttled by workload budget",
+			getPod: func() *corev1.Pod {
+				pod := demoPod.DeepCopy()
+				pod.Annotations[appsv1beta1.AnnotationEnhancedLivenessRestartBudget] = "2"
+				return pod
+			},
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{livenessPodProbe}, failedState)
+			},
+			getCRRs: func() []client.Object {
+				return []client.Object{
+					newRestartCRR("app-2", "cs-uid", time.Now().Add(-time.Minute), appsv1alpha1.ContainerRecreateRequestCompleted),
+					newRestartCRR("app-3", "cs-uid", time.Now().Add(-2*time.Minute), appsv1alpha1.ContainerRecreateRequestCompleted),
+				}
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+			expectRequeue:   true,
+		},
+		{
+			name:   "restart out of the window is not counted",
+			getPod: func() *corev1.Pod { return demoPod.DeepCopy() },
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{livenessPodProbe}, failedState)
+			},
+			getCRRs: func() []client.Object {
+				return []client.Object{newRestartCRR("app-2", "cs-uid", time.Now().Add(-time.Hour), appsv1alpha1.ContainerRecreateRequestCompleted)}
+			},
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+			expectRestart:   []string{"main"},
+		},
+		{
+			name:   "throttled by cluster budget",
+			getPod: func() *corev1.Pod { return demoPod.DeepCopy() },
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				return newNodePodProbe([]appsv1alpha1.PodProbe{livenessPodProbe}, failedState)
+			},
+			getCRRs: func() []client.Object {
+				return []client.Object{
+					newRestartCRR("other-1", "other-uid", time.Now().Add(-time.Minute), appsv1alpha1.ContainerRecreateRequestCompleted),
+					newRestartCRR("other-2", "other-uid", time.Now().Add(-time.Minute), appsv1alpha1.ContainerRecreateRequestCompleted),
+				}
+			},
+			maxRestarts:     2,
+			expectPodProbes: []appsv1alpha1.PodProbe{livenessPodProbe},
+			expectRequeue:   true,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			if cs.maxRestarts > 0 {
+				defer func(v int) { maxRestarts = v }(maxRestarts)
+				maxRestarts = cs.maxRestarts
+			}
+			objs := []client.Object{cs.getPod(), cs.getNodePodProbe(), demoNode.DeepCopy(), demoCloneSet.DeepCopy()}
+			if cs.getCRRs != nil {
+				objs = append(objs, cs.getCRRs()...)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+				WithStatusSubresource(&appsv1alpha1.NodePodProbe{}).Build()
+			r := &ReconcileEnhancedLivenessProbe{
+				Client:   fakeClient,
+				scheme:   scheme,
+				recorder: record.NewFakeRecorder(10),
+				finder:   &controllerfinder.ControllerFinder{Client: fakeClient},
+				budget:   newRestartBudget(),
+			}
+
+			requeueAfter, err := r.syncPod("default", "app-1")
+			assert.NoError(t, err)
+			assert.Equal(t, cs.expectRequeue, requeueAfter > 0)
+
+			npp := &appsv1alpha1.NodePodProbe{}
+			assert.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Name: "node1"}, npp))
+			assert.Equal(t, cs.expectPodProbes, npp.Spec.PodProbes)
+
+			crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+			assert.NoError(t, fakeClient.List(context.TODO(), crrList, client.MatchingLabels{LabelRestartOwnerUID: "cs-uid",
+				appsv1alpha1.ContainerRecreateRequestPodUIDKey: "pod-uid"}))
+			var restarted []string
+			for _, crr := range crrList.Items {
+				if crr.Status.Phase != "" {
+					continue
+				}
+				for _, c := range crr.Spec.Containers {
+					restarted = append(restarted, c.Name)
+				}
+				assert.Equal(t, ptr.To(int32(restartWindow/time.Second)), crr.Spec.TTLSecondsAfterFinished)
+			}
+			assert.Equal(t, cs.expectRestart, restarted)
+
+			// the restart is never repeated for the same failure
+			if len(cs.expectRestart) > 0 {
+				_, err = r.syncPod("default", "app-1")
+				assert.NoError(t, err)
+				assert.NoError(t, fakeClient.List(context.TODO(), crrList, client.MatchingLabels{appsv1alpha1.ContainerRecreateRequestPodUIDKey: "pod-uid"}))
+				assert.Len(t, crrList.Items, 1)
+			}
+		})
+	}
+}
+
+func TestGetWorkloadRestartBudget(t *testing.T) {
+	cases := []struct {
+		name   string
+		budget string
+		expect int
+	}{
+		{name: "default", expect: 1},
+		{name: "number", budget: "3", expect: 3},
+		{name: "percentage", budget: "25%", expect: 3},
+		{name: "invalid", budget: "abc%", expect: 1},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(demoCloneSet.DeepCopy()).Build()
+	r := &ReconcileEnhancedLivenessProbe{Client: fakeClient, finder: &controllerfinder.ControllerFinder{Client: fakeClient}}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pod := demoPod.DeepCopy()
+			if cs.budget != "" {
+				pod.Annotations[appsv1beta1.AnnotationEnhancedLivenessRestartBudget] = cs.budget
+			}
+			budget, err := r.getWorkloadRestartBudget(pod)
+			assert.NoError(t, err)
+			assert.Equal(t, cs.expect, budget)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+	"github.com/openkruise/kruise/pkg/util/fieldindex"
+)
+
+var _ handler.TypedEventHandler[*corev1.Pod, reconcile.Request] = &enqueueRequestForPod{}
+
+type enqueueRequestForPod struct{}
+
+func (p *enqueueRequestForPod) Create(ctx context.Context, evt event.TypedCreateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if isInterestingPod(evt.Object) {
+		p.queue(q, evt.Object)
+	}
+}
+
+func (p *enqueueRequestForPod) Delete(ctx context.Context, evt event.TypedDeleteEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForPod) Generic(ctx context.Context, evt event.TypedGenericEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForPod) Update(ctx context.Context, evt event.TypedUpdateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	newPod, oldPod := evt.ObjectNew, evt.ObjectOld
+	if newPod.ResourceVersion == oldPod.ResourceVersion {
+		return
+	}
+	// the pod which disabled the enhanced liveness probe is enqueued to remove its probes
+	if isInterestingPod(newPod) || isInterestingPod(oldPod) {
+		p.queue(q, newPod)
+	}
+}
+
+func (p *enqueueRequestForPod) queue(q workqueue.TypedRateLimitingInterface[reconcile.Request], pod *corev1.Pod) {
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}})
+}
+
+// isInterestingPod returns true if the pod is scheduled and has the liveness probes backed up
+func isInterestingPod(pod *corev1.Pod) bool {
+	return pod.Spec.NodeName != "" && enhancedlivenessprobe.UsingEnhancedLivenessProbe(pod) &&
+		pod.Annotations[appsv1beta1.AnnotationNativeContainerProbeContext] != ""
+}
+
+var _ handler.TypedEventHandler[*appsv1alpha1.NodePodProbe, reconcile.Request] = &enqueueRequestForNodePodProbe{}
+
+type enqueueRequestForNodePodProbe struct {
+	reader client.Reader
+}
+
+func (p *enqueueRequestForNodePodProbe) Create(ctx context.Context, evt event.TypedCreateEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// the pods on the node are waiting for the NodePodProbe to be created
+	podList := &corev1.PodList{}
+	if err := p.reader.List(context.TODO(), podList, client.MatchingFields{fieldindex.IndexNameForPodNodeName: evt.Object.Name}, utilclient.DisableDeepCopy); err != nil {
+		klog.ErrorS(err, "Failed to list pods on node", "nodeName", evt.Object.Name)
+		return
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if isInterestingPod(pod) {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}})
+		}
+	}
+}
+
+func (p *enqueueRequestForNodePodProbe) Delete(ctx context.Context, evt event.TypedDeleteEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForNodePodProbe) Generic(ctx context.Context, evt event.TypedGenericEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForNodePodProbe) Update(ctx context.Context, evt event.TypedUpdateEvent[*appsv1alpha1.NodePodProbe], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// map[pod.uid]map[probe.name]state
+	oldStates := make(map[string]map[string]appsv1alpha1.ContainerProbeState)
+	for _, status := range evt.ObjectOld.Status.PodProbeStatuses {
+		states := make(map[string]appsv1alpha1.ContainerProbeState, len(status.ProbeStates))
+		for _, state := range status.ProbeStates {
+			states[state.Name] = state
+		}
+		oldStates[status.UID] = states
+	}
+	// enqueue the pods whose liveness probes turn to fail
+	for _, status := range evt.ObjectNew.Status.PodProbeStatuses {
+		for _, state := range status.ProbeStates {
+			if !enhancedlivenessprobe.IsEnhancedLivenessProbe(state.Name) || state.State != appsv1alpha1.ProbeFailed {
+				continue
+			}
+			oldState, ok := oldStates[status.UID][state.Name]
+			if !ok || oldState.State != state.State || !oldState.LastProbeTime.Equal(&state.LastProbeTime) {
+				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: status.Namespace, Name: status.Name}})
+				break
+			}
+		}
+	}
+}
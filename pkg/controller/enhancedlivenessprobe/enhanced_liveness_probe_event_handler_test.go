@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+)
+
+func TestEnqueueRequestForPodUpdate(t *testing.T) {
+	disabled := demoPod.DeepCopy()
+	disabled.Annotations[appsv1beta1.AnnotationUsingEnhancedLiveness] = "false"
+	disabled.ResourceVersion = "2"
+	unscheduled := demoPod.DeepCopy()
+	unscheduled.Spec.NodeName = ""
+
+	cases := []struct {
+		name   string
+		oldPod *corev1.Pod
+		newPod *corev1.Pod
+		expect int
+	}{
+		{name: "enabled", oldPod: demoPod.DeepCopy(), newPod: func() *corev1.Pod { p := demoPod.DeepCopy(); p.ResourceVersion = "2"; return p }(), expect: 1},
+		{name: "disabled", oldPod: demoPod.DeepCopy(), newPod: disabled, expect: 1},
+		{name: "resync", oldPod: demoPod.DeepCopy(), newPod: demoPod.DeepCopy(), expect: 0},
+		{name: "unscheduled", oldPod: unscheduled, newPod: func() *corev1.Pod { p := unscheduled.DeepCopy(); p.ResourceVersion = "2"; return p }(), expect: 0},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			handler := &enqueueRequestForPod{}
+			handler.Update(context.TODO(), event.TypedUpdateEvent[*corev1.Pod]{ObjectOld: cs.oldPod, ObjectNew: cs.newPod}, q)
+			assert.Equal(t, cs.expect, q.Len())
+		})
+	}
+}
+
+func TestEnqueueRequestForNodePodProbeUpdate(t *testing.T) {
+	now := metav1.Now()
+	before := metav1.NewTime(now.Add(-time.Minute))
+	newStatus := func(name string, state appsv1alpha1.ProbeState, probeTime metav1.Time) appsv1alpha1.NodePodProbeStatus {
+		return appsv1alpha1.NodePodProbeStatus{PodProbeStatuses: []appsv1alpha1.PodProbeStatus{{
+			Namespace: "default", Name: "app-1", UID: "pod-uid",
+			ProbeStates: []appsv1alpha1.ContainerProbeState{{Name: name, State: state, LastProbeTime: probeTime}},
+		}}}
+	}
+	livenessProbeName := enhancedlivenessprobe.GetProbeName("main")
+
+	cases := []struct {
+		name      string
+		oldStatus appsv1alpha1.NodePodProbeStatus
+		newStatus appsv1alpha1.NodePodProbeStatus
+		expect    int
+	}{
+		{
+			name:      "liveness probe failed",
+			oldStatus: newStatus(livenessProbeName, appsv1alpha1.ProbeSucceeded, before),
+			newStatus: newStatus(livenessProbeName, appsv1alpha1.ProbeFailed, now),
+			expect:    1,
+		},
+		{
+			name:      "liveness probe failed again",
+			oldStatus: newStatus(livenessProbeName, appsv1alpha1.ProbeFailed, before),
+			newStatus: newStatus(livenessProbeName, appsv1alpha1.ProbeFailed, now),
+			expect:    1,
+		},
+		{
+			name:      "liveness probe succeeded",
+			oldStatus: newStatus(livenessProbeName, appsv1alpha1.ProbeFailed, before),
+			newStatus: newStatus(livenessProbeName, appsv1alpha1.ProbeSucceeded, now),
+			expect:    0,
+		},
+		{
+			name:      "marker probe failed",
+			oldStatus: appsv1alpha1.NodePodProbeStatus{},
+			newStatus: newStatus("ppm#idle", appsv1alpha1.ProbeFailed, now),
+			expect:    0,
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			oldObj := &appsv1alpha1.NodePodProbe{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Status: cs.oldStatus}
+			newObj := &appsv1alpha1.NodePodProbe{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Status: cs.newStatus}
+			handler := &enqueueRequestForNodePodProbe{}
+			handler.Update(context.TODO(), event.TypedUpdateEvent[*appsv1alpha1.NodePodProbe]{ObjectOld: oldObj, ObjectNew: newObj}, q)
+			assert.Equal(t, cs.expect, q.Len())
+		})
+	}
+}
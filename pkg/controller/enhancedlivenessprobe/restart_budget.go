@@ -0,0 +1,257 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+)
+
+const (
+	// LabelRestartOwnerUID is on the ContainerRecreateRequests created by the enhanced liveness probe,
+	// and its value is the uid of the controller of the restarted pod, which is used to count the restarts of workloads.
+	LabelRestartOwnerUID = "apps.kruise.io/enhanced-liveness-owner-uid"
+
+	EventLivenessProbeRestart   = "LivenessProbeRestart"
+	EventLivenessProbeThrottled = "LivenessProbeThrottled"
+)
+
+// restartBudget limits the restarts in the cluster and of each workload within the restart window.
+// The restarts are counted by the ContainerRecreateRequests created in the window, which live at least
+// as long as the window because of their ttlSecondsAfterFinished.
+type restartBudget struct {
+	sync.Mutex
+	// created records the ContainerRecreateRequests created by this controller, which might not be in the cache yet
+	created map[types.NamespacedName]restartRecord
+}
+
+type restartRecord struct {
+	ownerUID string
+	time     time.Time
+}
+
+func newRestartBudget() *restartBudget {
+	return &restartBudget{created: map[types.NamespacedName]restartRecord{}}
+}
+
+// restartContainers creates a ContainerRecreateRequest to restart the containers of pod if the budgets allow,
+// otherwise it returns the duration after which the budgets should be checked again.
+func (r *ReconcileEnhancedLivenessProbe) restartContainers(pod *corev1.Pod, containers []string) (time.Duration, error) {
+	crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+	if err := r.List(context.TODO(), crrList, client.InNamespace(pod.Namespace),
+		client.MatchingLabels{appsv1alpha1.ContainerRecreateRequestPodUIDKey: string(pod.UID)}, utilclient.DisableDeepCopy); err != nil {
+		return 0, err
+	}
+	for i := range crrList.Items {
+		crr := &crrList.Items[i]
+		if crr.Status.Phase != appsv1alpha1.ContainerRecreateRequestCompleted {
+			klog.V(4).InfoS("EnhancedLivenessProbe waits for the recreation of pod to complete", "pod", klog.KObj(pod), "containerRecreateRequest", crr.Name)
+			return 0, nil
+		}
+	}
+
+	crr := newContainerRecreateRequest(pod, containers)
+	if r.budget.acquired(crr) {
+		return 0, nil
+	}
+	ownerUID := crr.Labels[LabelRestartOwnerUID]
+	workloadBudget, err := r.getWorkloadRestartBudget(pod)
+	if err != nil {
+		return 0, err
+	}
+
+	r.budget.Lock()
+	defer r.budget.Unlock()
+	records, err := r.budget.list(r.Client, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	var workloadRecords []restartRecord
+	for _, record := range records {
+		if record.ownerUID == ownerUID {
+			workloadRecords = append(workloadRecords, record)
+		}
+	}
+	if len(records) >= maxRestarts {
+		r.recorder.Eventf(pod, corev1.EventTypeWarning, EventLivenessProbeThrottled,
+			"Restart of containers %v is throttled, %d pods have been restarted in the cluster within %v", containers, len(records), restartWindow)
+		return untilExpired(records), nil
+	}
+	if len(workloadRecords) >= workloadBudget {
+		r.recorder.Eventf(pod, corev1.EventTypeWarning, EventLivenessProbeThrottled,
+			"Restart of containers %v is throttled, %d pods of the workload have been restarted within %v", containers, len(workloadRecords), restartWindow)
+		return untilExpired(workloadRecords), nil
+	}
+
+	if err = r.Create(context.TODO(), crr); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return 0, nil
+		}
+		klog.ErrorS(err, "EnhancedLivenessProbe failed to create ContainerRecreateRequest", "pod", klog.KObj(pod), "containers", containers)
+		return 0, err
+	}
+	r.budget.created[client.ObjectKeyFromObject(crr)] = restartRecord{ownerUID: ownerUID, time: time.Now()}
+	r.recorder.Eventf(pod, corev1.EventTypeNormal, EventLivenessProbeRestart, "Restarting containers %v which failed the liveness probes", containers)
+	klog.InfoS("EnhancedLivenessProbe restarted containers", "pod", klog.KObj(pod), "containers", containers, "containerRecreateRequest", crr.Name)
+	return 0, nil
+}
+
+// getWorkloadRestartBudget returns the max number of the pods of the workload that can be restarted within the window
+func (r *ReconcileEnhancedLivenessProbe) getWorkloadRestartBudget(pod *corev1.Pod) (int, error) {
+	budget := defaultWorkloadRestartBudget
+	if value, ok := pod.Annotations[appsv1beta1.AnnotationEnhancedLivenessRestartBudget]; ok {
+		budget = intstr.Parse(value)
+	}
+
+	var replicas int
+	if ref := metav1.GetControllerOf(pod); ref != nil && r.finder != nil {
+		workload, err := r.finder.GetScaleAndSelectorForRef(ref.APIVersion, ref.Kind, pod.Namespace, ref.Name, ref.UID)
+		if err != nil {
+			return 0, err
+		}
+		if workload != nil && workload.Scale > 0 {
+			replicas = int(workload.Scale)
+		}
+	}
+	value, err := util.GetScaledValueFromIntOrPercent(&budget, replicas, true)
+	if err != nil {
+		klog.ErrorS(err, "Invalid enhanced liveness restart budget of pod, use the default one", "pod", klog.KObj(pod), "budget", budget.String())
+		value, _ = util.GetScaledValueFromIntOrPercent(&defaultWorkloadRestartBudget, replicas, true)
+	}
+	// at least one pod of the workload can be restarted
+	if value < 1 {
+		value = 1
+	}
+	return value, nil
+}
+
+// acquired returns true if the ContainerRecreateRequest has been created by this controller
+func (b *restartBudget) acquired(crr *appsv1alpha1.ContainerRecreateRequest) bool {
+	b.Lock()
+	defer b.Unlock()
+	_, ok := b.created[client.ObjectKeyFromObject(crr)]
+	return ok
+}
+
+// list returns the restarts within the window, the caller must hold the lock
+func (b *restartBudget) list(reader client.Reader, now time.Time) ([]restartRecord, error) {
+	since := now.Add(-restartWindow)
+	for key, record := range b.created {
+		if record.time.Before(since) {
+			delete(b.created, key)
+		}
+	}
+
+	requirement, _ := labels.NewRequirement(LabelRestartOwnerUID, selection.Exists, nil)
+	crrList := &appsv1alpha1.ContainerRecreateRequestList{}
+	if err := reader.List(context.TODO(), crrList, &client.ListOptions{LabelSelector: labels.NewSelector().Add(*requirement)}, utilclient.DisableDeepCopy); err != nil {
+		return nil, err
+	}
+	var records []restartRecord
+	listed := make(map[types.NamespacedName]struct{}, len(crrList.Items))
+	for i := range crrList.Items {
+		crr := &crrList.Items[i]
+		listed[client.ObjectKeyFromObject(crr)] = struct{}{}
+		if crr.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+		records = append(records, restartRecord{ownerUID: crr.Labels[LabelRestartOwnerUID], time: crr.CreationTimestamp.Time})
+	}
+	for key, record := range b.created {
+		if _, ok := listed[key]; !ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// untilExpired returns the duration until the earliest restart leaves the window
+func untilExpired(records []restartRecord) time.Duration {
+	earliest := records[0].time
+	for _, record := range records[1:] {
+		if record.time.Before(earliest) {
+			earliest = record.time
+		}
+	}
+	if d := time.Until(earliest.Add(restartWindow)); d > time.Second {
+		return d
+	}
+	return time.Second
+}
+
+func newContainerRecreateRequest(pod *corev1.Pod, containers []string) *appsv1alpha1.ContainerRecreateRequest {
+	ownerUID := string(pod.UID)
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		ownerUID = string(ref.UID)
+	}
+
+	// the name is unique for the failed containers, so that they will never be restarted twice
+	sort.Strings(containers)
+	hash := fnv.New32a()
+	hash.Write([]byte(pod.UID))
+	crrContainers := make([]appsv1alpha1.ContainerRecreateRequestContainer, 0, len(containers))
+	for _, name := range containers {
+		if status := util.GetContainerStatus(name, pod); status != nil {
+			hash.Write([]byte(status.ContainerID))
+		}
+		crrContainers = append(crrContainers, appsv1alpha1.ContainerRecreateRequestContainer{Name: name})
+	}
+
+	return &appsv1alpha1.ContainerRecreateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      fmt.Sprintf("%s-liveness-%s", pod.Name, rand.SafeEncodeString(fmt.Sprint(hash.Sum32()))),
+			Labels: map[string]string{
+				LabelRestartOwnerUID:                           ownerUID,
+				appsv1alpha1.ContainerRecreateRequestPodUIDKey: string(pod.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(pod, corev1.SchemeGroupVersion.WithKind("Pod")),
+			},
+		},
+		Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+			PodName:    pod.Name,
+			Containers: crrContainers,
+			Strategy: &appsv1alpha1.ContainerRecreateRequestStrategy{
+				FailurePolicy: appsv1alpha1.ContainerRecreateRequestFailurePolicyIgnore,
+			},
+			// keep it as long as the window to count the restarts
+			TTLSecondsAfterFinished: ptr.To(int32(restartWindow / time.Second)),
+		},
+	}
+}
@@ -24,6 +24,8 @@ type EphemeralContainerInterface interface {
 	UpdateEphemeralContainer(target *v1.Pod) error
 	CreateEphemeralContainer(target *v1.Pod) error
 	RemoveEphemeralContainer(target *v1.Pod) (*time.Duration, error)
+	// GetEphemeralContainerLogs returns the tail of logs of the ephemeral container in target pod.
+	GetEphemeralContainerLogs(target *v1.Pod, name string, tailLines, limitBytes int64) ([]byte, error)
 }
 
 func New(job *appsv1alpha1.EphemeralJob) EphemeralContainerInterface {
@@ -143,6 +143,9 @@ func (k *k8sControl) CreateEphemeralContainer(targetPod *v1.Pod) error {
 			Name:  appsv1alpha1.EphemeralContainerEnvKey,
 			Value: string(k.UID),
 		})
+		if ec.TargetContainerName == "" {
+			ec.TargetContainerName = k.Spec.TargetContainerName
+		}
 		eContainer = append(eContainer, *ec)
 	}
 
@@ -224,6 +227,13 @@ func (k *k8sControl) UpdateEphemeralContainer(target *v1.Pod) error {
 	return nil
 }
 
+func (k *k8sControl) GetEphemeralContainerLogs(target *v1.Pod, name string, tailLines, limitBytes int64) ([]byte, error) {
+	kubeClient := kubeclient.GetGenericClient().KubeClient
+	return kubeClient.CoreV1().Pods(target.Namespace).
+		GetLogs(target.Name, &v1.PodLogOptions{Container: name, TailLines: &tailLines, LimitBytes: &limitBytes}).
+		DoRaw(context.TODO())
+}
+
 func (k *k8sControl) ContainsEphemeralContainer(target *v1.Pod) (exists, owned bool) {
 	ephemeralContainersMaps, _ := getEphemeralContainersMaps(k.GetEphemeralContainers(target))
 	for _, e := range k.Spec.Template.EphemeralContainers {
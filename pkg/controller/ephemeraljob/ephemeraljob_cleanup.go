@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeraljob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/pubcontrol"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/inplaceupdate"
+)
+
+var cleanupRetryDuration = 10 * time.Second
+
+func getCleanupPolicyType(job *appsv1alpha1.EphemeralJob) appsv1alpha1.EphemeralJobCleanupPolicyType {
+	if job.Spec.CleanupPolicy == nil || job.Spec.CleanupPolicy.Type == "" {
+		return appsv1alpha1.EphemeralJobCleanupNone
+	}
+	return job.Spec.CleanupPolicy.Type
+}
+
+// cleanupEphemeralContainers marks the pods that have ephemeral containers injected by the finished job,
+// so that they will be recreated at the next update, and recreates them directly with Recreate policy.
+// It returns the duration to retry if some pods are still waiting to be recreated.
+func (r *ReconcileEphemeralJob) cleanupEphemeralContainers(job *appsv1alpha1.EphemeralJob) (*time.Duration, error) {
+	policyType := getCleanupPolicyType(job)
+	if policyType == appsv1alpha1.EphemeralJobCleanupNone {
+		return nil, nil
+	}
+
+	targetPods, err := r.filterInjectedPods(job)
+	if err != nil {
+		return nil, err
+	}
+
+	var toRecreatePods []*v1.Pod
+	for _, pod := range targetPods {
+		if !inplaceupdate.IsEphemeralContainersCleanupMarked(pod) {
+			body := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"true"}}}`, appspub.EphemeralContainersCleanupKey)
+			if err := r.Patch(context.TODO(), pod, client.RawPatch(types.MergePatchType, []byte(body))); err != nil {
+				return nil, fmt.Errorf("failed to mark pod %s to clean up ephemeral containers: %v", pod.Name, err)
+			}
+			klog.InfoS("EphemeralJob marked Pod to clean up ephemeral containers", "ephemeralJob", klog.KObj(job), "pod", klog.KObj(pod))
+		}
+		// only the pods owned by a controller can be recreated
+		if metav1.GetControllerOf(pod) != nil {
+			toRecreatePods = append(toRecreatePods, pod)
+		}
+	}
+
+	if policyType != appsv1alpha1.EphemeralJobCleanupRecreate || len(toRecreatePods) == 0 {
+		return nil, nil
+	}
+
+	parallelism := defaultParallelism
+	if job.Spec.Parallelism != nil {
+		parallelism = int(*job.Spec.Parallelism)
+	}
+	// the pods recreated before may have not been replaced or ready yet, they should be counted against parallelism
+	recreated, err := r.getUnavailableRecreatedPods(job, toRecreatePods)
+	if err != nil {
+		return nil, err
+	}
+	if parallelism -= len(recreated); parallelism <= 0 {
+		klog.InfoS("EphemeralJob waited for recreated pods to be ready", "ephemeralJob", klog.KObj(job), "unavailable", len(recreated))
+		return &cleanupRetryDuration, nil
+	}
+	if len(toRecreatePods) > parallelism {
+		toRecreatePods = toRecreatePods[:parallelism]
+	}
+	for _, pod := range toRecreatePods {
+		// Determine the pub before recreating the pod
+		releasePubQuota := func() error { return nil }
+		if utilfeature.DefaultFeatureGate.Enabled(features.PodUnavailableBudgetDeleteGate) {
+			allowed, reason, release, err := pubcontrol.PodUnavailableBudgetReservePod(pod, policyv1beta1.PubDeleteOperation, "kruise-manager")
+			if err != nil {
+				return nil, err
+			} else if !allowed {
+				klog.InfoS("EphemeralJob waited for PodUnavailableBudget to recreate Pod", "ephemeralJob", klog.KObj(job), "pod", klog.KObj(pod), "reason", reason)
+				break
+			}
+			releasePubQuota = release
+		}
+
+		// record the pod before deleting it, so that its replacement is always counted against parallelism
+		recreated = append(recreated, recreatedPod{
+			Name:         pod.Name,
+			UID:          pod.UID,
+			OwnerUID:     metav1.GetControllerOf(pod).UID,
+			RecreateTime: metav1.Now(),
+		})
+		if err := r.updateRecreatedPods(job, recreated); err != nil {
+			if releaseErr := releasePubQuota(); releaseErr != nil {
+				klog.ErrorS(releaseErr, "Failed to release the quota of PodUnavailableBudget", "ephemeralJob", klog.KObj(job), "pod", klog.KObj(pod))
+			}
+			return nil, err
+		}
+
+		if err := r.Delete(context.TODO(), pod); err != nil {
+			// the pod is not deleted by this job, so give back the quota taken from pub
+			if releaseErr := releasePubQuota(); releaseErr != nil {
+				klog.ErrorS(releaseErr, "Failed to release the quota of PodUnavailableBudget", "ephemeralJob", klog.KObj(job), "pod", klog.KObj(pod))
+			}
+			if errors.IsNotFound(err) {
+				continue
+			}
+			r.recorder.Eventf(job, v1.EventTypeWarning, "FailedRecreate", "Failed to recreate pod %s to clean up ephemeral containers: %v", pod.Name, err)
+			return nil, err
+		}
+		r.recorder.Eventf(job, v1.EventTypeNormal, "SuccessfulRecreate", "Recreate pod %s to clean up ephemeral containers", pod.Name)
+	}
+	return &cleanupRetryDuration, nil
+}
+
+// recreatedPod is recorded in the annotation of EphemeralJob for each pod recreated by the job.
+type recreatedPod struct {
+	Name         string      `json:"name"`
+	UID          types.UID   `json:"uid"`
+	OwnerUID     types.UID   `json:"ownerUID"`
+	RecreateTime metav1.Time `json:"recreateTime"`
+}
+
+// recreatedPodWaitTimeout is the max duration to wait for the replacement of a recreated pod to be created,
+// in case that the owner has been scaled in or deleted.
+var recreatedPodWaitTimeout = 10 * time.Minute
+
+func getRecreatedPods(job *appsv1alpha1.EphemeralJob) []recreatedPod {
+	value, ok := job.Annotations[appsv1alpha1.EphemeralJobRecreatedPodsKey]
+	if !ok {
+		return nil
+	}
+	var recreated []recreatedPod
+	if err := json.Unmarshal([]byte(value), &recreated); err != nil {
+		klog.ErrorS(err, "Failed to parse recreated pods of EphemeralJob", "ephemeralJob", klog.KObj(job))
+		return nil
+	}
+	return recreated
+}
+
+func (r *ReconcileEphemeralJob) updateRecreatedPods(job *appsv1alpha1.EphemeralJob, recreated []recreatedPod) error {
+	var value interface{}
+	if len(recreated) > 0 {
+		value = util.DumpJSON(recreated)
+	}
+	body := util.DumpJSON(map[string]interface{}{"metadata": map[string]interface{}{"annotations": map[string]interface{}{appsv1alpha1.EphemeralJobRecreatedPodsKey: value}}})
+	if err := r.Patch(context.TODO(), job, client.RawPatch(types.MergePatchType, []byte(body))); err != nil {
+		return fmt.Errorf("failed to record recreated pods: %v", err)
+	}
+	return nil
+}
+
+// getUnavailableRecreatedPods returns the pods recreated by the job that are still being deleted, or whose replacements
+// have not been created or ready yet. The recreated pods that have been replaced by ready ones are removed from
+// the annotation of job, and other pods matched by the job are never counted.
+func (r *ReconcileEphemeralJob) getUnavailableRecreatedPods(job *appsv1alpha1.EphemeralJob, toRecreatePods []*v1.Pod) ([]recreatedPod, error) {
+	recreated := getRecreatedPods(job)
+	if len(recreated) == 0 {
+		return nil, nil
+	}
+	selector, err := util.ValidatedLabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	podList := &v1.PodList{}
+	if err := r.List(context.TODO(), podList, &client.ListOptions{Namespace: job.Namespace, LabelSelector: selector}); err != nil {
+		return nil, err
+	}
+
+	toRecreate := sets.New[types.UID]()
+	for _, pod := range toRecreatePods {
+		toRecreate.Insert(pod.UID)
+	}
+	replacements := sets.New[types.UID]()
+	var pending []recreatedPod
+	for _, rp := range recreated {
+		// the pod has not been deleted, which will be recreated again
+		if toRecreate.Has(rp.UID) {
+			continue
+		}
+		var original, replacement *v1.Pod
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if pod.UID == rp.UID {
+				original = pod
+				break
+			}
+			if replacement != nil || replacements.Has(pod.UID) || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			if owner := metav1.GetControllerOf(pod); owner != nil && owner.UID == rp.OwnerUID && !pod.CreationTimestamp.Before(&rp.RecreateTime) {
+				replacement = pod
+			}
+		}
+		switch {
+		case original != nil:
+			pending = append(pending, rp)
+		case replacement != nil:
+			replacements.Insert(replacement.UID)
+			if replacement.DeletionTimestamp != nil || !util.IsRunningAndReady(replacement) {
+				pending = append(pending, rp)
+			}
+		case time.Since(rp.RecreateTime.Time) < recreatedPodWaitTimeout:
+			pending = append(pending, rp)
+		default:
+			klog.InfoS("EphemeralJob stopped waiting for the replacement of recreated Pod", "ephemeralJob", klog.KObj(job), "pod", rp.Name)
+		}
+	}
+	if len(pending) != len(recreated) {
+		if err := r.updateRecreatedPods(job, pending); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
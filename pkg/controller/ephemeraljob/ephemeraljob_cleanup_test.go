@@ -0,0 +1,306 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeraljob
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/pubcontrol"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/controllerfinder"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+func TestCleanupEphemeralContainers(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.PodUnavailableBudgetDeleteGate, false)()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+
+	job := &appsv1alpha1.EphemeralJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ejob", UID: "ejob-uid"},
+		Spec: appsv1alpha1.EphemeralJobSpec{
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+			Parallelism: ptr.To(int32(1)),
+			Template: appsv1alpha1.EphemeralContainerTemplateSpec{EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}},
+			}},
+		},
+	}
+	newPod := func(name string, owned, injected bool) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: map[string]string{"app": "demo"}},
+			Status: v1.PodStatus{
+				Phase:      v1.PodRunning,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+			},
+		}
+		if owned {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet", Name: "demo", UID: "cs-uid", Controller: ptr.To(true)}}
+		}
+		if injected {
+			pod.Spec.EphemeralContainers = []v1.EphemeralContainer{{EphemeralContainerCommon: v1.EphemeralContainerCommon{
+				Name: "debugger",
+				Env:  []v1.EnvVar{{Name: appsv1alpha1.EphemeralContainerEnvKey, Value: string(job.UID)}},
+			}}}
+		}
+		return pod
+	}
+
+	recreatedBefore := recreatedPod{Name: "pod-old", UID: "pod-old-uid", OwnerUID: "cs-uid", RecreateTime: metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))}
+	newReplacement := func(ready bool) *v1.Pod {
+		pod := newPod("pod-new", true, false)
+		pod.CreationTimestamp = metav1.Now()
+		if !ready {
+			pod.Status.Conditions = nil
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name              string
+		policyType        appsv1alpha1.EphemeralJobCleanupPolicyType
+		recreated         []recreatedPod
+		expectMarked      []string
+		expectDeleted     []string
+		expectRetry       bool
+		extraPods         []*v1.Pod
+		expectUnprocessed []string
+		expectRecreated   []string
+	}{
+		{
+			name:              "none",
+			expectUnprocessed: []string{"pod-a", "pod-b", "pod-c", "pod-d"},
+		},
+		{
+			name:              "on next update",
+			policyType:        appsv1alpha1.EphemeralJobCleanupOnNextUpdate,
+			expectMarked:      []string{"pod-a", "pod-b", "pod-c"},
+			expectUnprocessed: []string{"pod-d"},
+		},
+		{
+			name:              "recreate",
+			policyType:        appsv1alpha1.EphemeralJobCleanupRecreate,
+			expectMarked:      []string{"pod-b", "pod-c"},
+			expectDeleted:     []string{"pod-a"},
+			expectRetry:       true,
+			expectUnprocessed: []string{"pod-d"},
+			expectRecreated:   []string{"pod-a"},
+		},
+		{
+			name:              "recreate waits for the replacements of recreated pods to be ready",
+			policyType:        appsv1alpha1.EphemeralJobCleanupRecreate,
+			recreated:         []recreatedPod{recreatedBefore},
+			extraPods:         []*v1.Pod{newReplacement(false)},
+			expectMarked:      []string{"pod-a", "pod-b", "pod-c"},
+			expectRetry:       true,
+			expectUnprocessed: []string{"pod-d"},
+			expectRecreated:   []string{"pod-old"},
+		},
+		{
+			name:              "recreate waits for the replacements of recreated pods to be created",
+			policyType:        appsv1alpha1.EphemeralJobCleanupRecreate,
+			recreated:         []recreatedPod{recreatedBefore},
+			expectMarked:      []string{"pod-a", "pod-b", "pod-c"},
+			expectRetry:       true,
+			expectUnprocessed: []string{"pod-d"},
+			expectRecreated:   []string{"pod-old"},
+		},
+		{
+			name:       "recreate waits for the recreated pods to be deleted",
+			policyType: appsv1alpha1.EphemeralJobCleanupRecreate,
+			recreated:  []recreatedPod{recreatedBefore},
+			extraPods: func() []*v1.Pod {
+				pod := newPod("pod-old", true, false)
+				pod.UID = recreatedBefore.UID
+				pod.DeletionTimestamp = ptr.To(metav1.Now())
+				pod.Finalizers = []string{"test"}
+				return []*v1.Pod{pod}
+			}(),
+			expectMarked:      []string{"pod-a", "pod-b", "pod-c"},
+			expectRetry:       true,
+			expectUnprocessed: []string{"pod-d"},
+			expectRecreated:   []string{"pod-old"},
+		},
+		{
+			name:              "recreate forgets the recreated pods replaced by ready ones",
+			policyType:        appsv1alpha1.EphemeralJobCleanupRecreate,
+			recreated:         []recreatedPod{recreatedBefore},
+			extraPods:         []*v1.Pod{newReplacement(true)},
+			expectMarked:      []string{"pod-b", "pod-c"},
+			expectDeleted:     []string{"pod-a"},
+			expectRetry:       true,
+			expectUnprocessed: []string{"pod-d"},
+			expectRecreated:   []string{"pod-a"},
+		},
+		{
+			name:              "recreate ignores the unavailable pods not recreated by the job",
+			policyType:        appsv1alpha1.EphemeralJobCleanupRecreate,
+			extraPods:         []*v1.Pod{newReplacement(false)},
+			expectMarked:      []string{"pod-b", "pod-c"},
+			expectDeleted:     []string{"pod-a"},
+			expectRetry:       true,
+			expectUnprocessed: []string{"pod-d"},
+			expectRecreated:   []string{"pod-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := job.DeepCopy()
+			job.Spec.CleanupPolicy = &appsv1alpha1.EphemeralJobCleanupPolicy{Type: tt.policyType}
+			if len(tt.recreated) > 0 {
+				job.Annotations = map[string]string{appsv1alpha1.EphemeralJobRecreatedPodsKey: util.DumpJSON(tt.recreated)}
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				job, newPod("pod-a", true, true), newPod("pod-b", true, true), newPod("pod-c", false, true), newPod("pod-d", true, false),
+			).Build()
+			for _, pod := range tt.extraPods {
+				deleting := pod.DeletionTimestamp != nil
+				pod.DeletionTimestamp = nil
+				assert.NoError(t, fakeClient.Create(context.TODO(), pod))
+				if deleting {
+					assert.NoError(t, fakeClient.Delete(context.TODO(), pod))
+				}
+			}
+			r := &ReconcileEphemeralJob{Client: fakeClient, scheme: scheme, recorder: record.NewFakeRecorder(10)}
+
+			retryAfter, err := r.cleanupEphemeralContainers(job)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectRetry, retryAfter != nil)
+
+			getPod := func(name string) (*v1.Pod, error) {
+				pod := &v1.Pod{}
+				return pod, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, pod)
+			}
+			for _, name := range tt.expectMarked {
+				pod, err := getPod(name)
+				assert.NoError(t, err)
+				assert.Equal(t, "true", pod.Annotations[appspub.EphemeralContainersCleanupKey], name)
+			}
+			for _, name := range tt.expectDeleted {
+				_, err := getPod(name)
+				assert.True(t, errors.IsNotFound(err), name)
+			}
+			for _, name := range tt.expectUnprocessed {
+				pod, err := getPod(name)
+				assert.NoError(t, err)
+				assert.Empty(t, pod.Annotations[appspub.EphemeralContainersCleanupKey], name)
+			}
+
+			newJob := &appsv1alpha1.EphemeralJob{}
+			assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: job.Name}, newJob))
+			var recreatedNames []string
+			for _, rp := range getRecreatedPods(newJob) {
+				recreatedNames = append(recreatedNames, rp.Name)
+			}
+			assert.Equal(t, tt.expectRecreated, recreatedNames)
+		})
+	}
+}
+
+func TestCleanupEphemeralContainersReleasePubQuotaOnDeleteFailure(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.PodUnavailableBudgetDeleteGate, true)()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(policyv1beta1.AddToScheme(scheme))
+
+	job := &appsv1alpha1.EphemeralJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ejob", UID: "ejob-uid"},
+		Spec: appsv1alpha1.EphemeralJobSpec{
+			Selector:      &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+			CleanupPolicy: &appsv1alpha1.EphemeralJobCleanupPolicy{Type: appsv1alpha1.EphemeralJobCleanupRecreate},
+			Template: appsv1alpha1.EphemeralContainerTemplateSpec{EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}},
+			}},
+		},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "pod-a",
+			UID:             "pod-a-uid",
+			Labels:          map[string]string{"app": "demo"},
+			Annotations:     map[string]string{pubcontrol.PodRelatedPubAnnotation: "pub"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet", Name: "demo", UID: "cs-uid", Controller: ptr.To(true)}},
+		},
+		Spec: v1.PodSpec{EphemeralContainers: []v1.EphemeralContainer{{EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name: "debugger",
+			Env:  []v1.EnvVar{{Name: appsv1alpha1.EphemeralContainerEnvKey, Value: string(job.UID)}},
+		}}}},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+	pub := &policyv1beta1.PodUnavailableBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pub", UID: "pub-uid"},
+		Spec: policyv1beta1.PodUnavailableBudgetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "demo"}},
+			MaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		Status: policyv1beta1.PodUnavailableBudgetStatus{UnavailableAllowed: 1, DesiredAvailable: 1},
+	}
+	_ = util.GlobalCache.Delete(pub)
+	defer func() { _ = util.GlobalCache.Delete(pub) }()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(job, pod, pub).
+		WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if _, ok := obj.(*v1.Pod); ok {
+					return errors.NewInternalError(fmt.Errorf("injected error"))
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).Build()
+	pubcontrol.InitPubControl(fakeClient, &controllerfinder.ControllerFinder{Client: fakeClient}, record.NewFakeRecorder(10))
+	r := &ReconcileEphemeralJob{Client: fakeClient, scheme: scheme, recorder: record.NewFakeRecorder(10)}
+
+	_, err := r.cleanupEphemeralContainers(job)
+	assert.Error(t, err)
+
+	newPub := &policyv1beta1.PodUnavailableBudget{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: pub.Namespace, Name: pub.Name}, newPub))
+	assert.Equal(t, int32(1), newPub.Status.UnavailableAllowed)
+	assert.Empty(t, newPub.Status.UnavailablePods)
+}
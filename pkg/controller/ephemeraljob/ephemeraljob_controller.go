@@ -110,6 +110,8 @@ type ReconcileEphemeralJob struct {
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=ephemeraljobs/finalizers,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/ephemeralcontainers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reads that state of the cluster for a EphemeralJob object and makes changes based on the state read
 // and what is in the EphemeralJob.Spec
@@ -162,6 +164,11 @@ func (r *ReconcileEphemeralJob) Reconcile(context context.Context, request recon
 
 	// The Job has been finished
 	if job.Status.CompletionTime != nil {
+		cleanupRetryAfter, err := r.cleanupEphemeralContainers(job)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
 		var leftTime time.Duration
 		if job.Spec.TTLSecondsAfterFinished == nil {
 			defaultTTl := int32(1800)
@@ -169,6 +176,11 @@ func (r *ReconcileEphemeralJob) Reconcile(context context.Context, request recon
 		}
 
 		leftTime = time.Duration(*job.Spec.TTLSecondsAfterFinished)*time.Second - time.Since(job.Status.CompletionTime.Time)
+		// the job should not be deleted until the pods have been cleaned up
+		if leftTime <= 0 && cleanupRetryAfter != nil {
+			klog.InfoS("EphemeralJob waited for cleanup before deleting for ttlSecondsAfterFinished", "ephemeralJob", klog.KObj(job))
+			return reconcile.Result{RequeueAfter: *cleanupRetryAfter}, nil
+		}
 		if leftTime <= 0 {
 			klog.InfoS("Deleting EphemeralJob for ttlSecondsAfterFinished", "ephemeralJob", klog.KObj(job))
 			if err = r.Delete(context, job); err != nil {
@@ -178,6 +190,9 @@ func (r *ReconcileEphemeralJob) Reconcile(context context.Context, request recon
 			return reconcile.Result{}, nil
 		}
 
+		if cleanupRetryAfter != nil && *cleanupRetryAfter < leftTime {
+			return reconcile.Result{RequeueAfter: *cleanupRetryAfter}, nil
+		}
 		return reconcile.Result{RequeueAfter: leftTime}, nil
 	}
 
@@ -220,6 +235,11 @@ func (r *ReconcileEphemeralJob) Reconcile(context context.Context, request recon
 	klog.InfoS("Sync calculate job status", "ephemeralJob", klog.KObj(job), "match", job.Status.Matches, "success", job.Status.Succeeded,
 		"failed", job.Status.Failed, "running", job.Status.Running, "waiting", job.Status.Waiting)
 
+	if err := r.collectOutputs(job, targetPods, econtainer.New(job)); err != nil {
+		klog.ErrorS(err, "Error collect EphemeralJob outputs", "ephemeralJob", klog.KObj(job))
+		return reconcile.Result{}, err
+	}
+
 	if job.Status.Phase == appsv1alpha1.EphemeralJobPause {
 		return reconcile.Result{RequeueAfter: requeueAfter}, r.updateJobStatus(job)
 	}
@@ -265,6 +285,10 @@ func (r *ReconcileEphemeralJob) filterPods(job *appsv1alpha1.EphemeralJob) ([]*v
 			continue
 		}
 
+		if job.Spec.TargetContainerName != "" && util.GetContainer(job.Spec.TargetContainerName, &podList.Items[i]) == nil {
+			continue
+		}
+
 		if job.Spec.Replicas == nil || len(targetPods) < int(*job.Spec.Replicas) {
 			targetPods = append(targetPods, &podList.Items[i])
 		}
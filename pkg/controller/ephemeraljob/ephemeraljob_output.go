@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeraljob
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/controller/ephemeraljob/econtainer"
+)
+
+const (
+	defaultOutputTailLines = 100
+	maxOutputLogBytes      = 16 * 1024
+	maxOutputMessageLength = 256
+	// maxOutputs limits the pods whose outputs are collected, so that the job status and the ConfigMaps
+	// will not grow without bound for a job matching lots of pods.
+	maxOutputs = 100
+	// outputCollectWorkers is the number of pods whose logs are collected concurrently.
+	outputCollectWorkers = 10
+)
+
+// collectOutputs collects the outputs of ephemeral containers in the pods that have finished and not been collected yet.
+func (r *ReconcileEphemeralJob) collectOutputs(job *appsv1alpha1.EphemeralJob, targetPods []*v1.Pod, control econtainer.EphemeralContainerInterface) error {
+	if job.Spec.OutputCollection == nil {
+		return nil
+	}
+
+	collected := sets.New[string]()
+	for i := range job.Status.Outputs {
+		collected.Insert(job.Status.Outputs[i].PodName)
+	}
+
+	var pods []*v1.Pod
+	var containers [][]appsv1alpha1.EphemeralJobContainerOutput
+	var omitted int32
+	for _, pod := range targetPods {
+		if collected.Has(pod.Name) {
+			continue
+		}
+		finished := getFinishedContainerOutputs(job, control.GetEphemeralContainersStatus(pod))
+		if finished == nil {
+			continue
+		}
+		if len(job.Status.Outputs)+len(pods) >= maxOutputs {
+			omitted++
+			continue
+		}
+		pods = append(pods, pod)
+		containers = append(containers, finished)
+	}
+	job.Status.OutputsOmitted = omitted
+	if len(pods) == 0 {
+		return nil
+	}
+
+	outputs := make([]*appsv1alpha1.EphemeralJobPodOutput, len(pods))
+	errs := make([]error, len(pods))
+	workqueue.ParallelizeUntil(context.TODO(), outputCollectWorkers, len(pods), func(i int) {
+		outputs[i], errs[i] = r.collectPodOutput(job, pods[i], control, containers[i])
+	})
+	for _, output := range outputs {
+		if output != nil {
+			job.Status.Outputs = append(job.Status.Outputs, *output)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (r *ReconcileEphemeralJob) collectPodOutput(job *appsv1alpha1.EphemeralJob, pod *v1.Pod, control econtainer.EphemeralContainerInterface,
+	containers []appsv1alpha1.EphemeralJobContainerOutput) (*appsv1alpha1.EphemeralJobPodOutput, error) {
+	tailLines := int64(defaultOutputTailLines)
+	if job.Spec.OutputCollection.TailLines != nil {
+		tailLines = *job.Spec.OutputCollection.TailLines
+	}
+
+	cm := newOutputConfigMap(job, pod)
+	for i := range containers {
+		c := &containers[i]
+		logs, err := control.GetEphemeralContainerLogs(pod, c.Name, tailLines, maxOutputLogBytes)
+		if err != nil {
+			klog.ErrorS(err, "Failed to collect logs of ephemeral container", "ephemeralJob", klog.KObj(job), "pod", klog.KObj(pod), "containerName", c.Name)
+			c.Message = fmt.Sprintf("failed to collect logs: %v", err)
+			if len(c.Message) > maxOutputMessageLength {
+				c.Message = c.Message[:maxOutputMessageLength]
+			}
+			continue
+		}
+		cm.Data[c.Name] = string(logs)
+	}
+
+	if err := r.Create(context.TODO(), cm); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to create ConfigMap %s for outputs of pod %s: %v", cm.Name, pod.Name, err)
+	}
+	klog.InfoS("EphemeralJob collected outputs of Pod", "ephemeralJob", klog.KObj(job), "pod", klog.KObj(pod), "configMap", cm.Name)
+	return &appsv1alpha1.EphemeralJobPodOutput{PodName: pod.Name, ConfigMapName: cm.Name, Containers: containers}, nil
+}
+
+// getFinishedContainerOutputs returns the outputs of ephemeral containers of the job from their statuses,
+// it returns nil if any of them has not finished.
+func getFinishedContainerOutputs(job *appsv1alpha1.EphemeralJob, statuses []v1.ContainerStatus) []appsv1alpha1.EphemeralJobContainerOutput {
+	statusMap := make(map[string]*v1.ContainerStatus, len(statuses))
+	for i := range statuses {
+		statusMap[statuses[i].Name] = &statuses[i]
+	}
+
+	var outputs []appsv1alpha1.EphemeralJobContainerOutput
+	for i := range job.Spec.Template.EphemeralContainers {
+		name := job.Spec.Template.EphemeralContainers[i].Name
+		status, ok := statusMap[name]
+		if !ok {
+			return nil
+		}
+		switch {
+		case status.State.Terminated != nil:
+			exitCode := status.State.Terminated.ExitCode
+			outputs = append(outputs, appsv1alpha1.EphemeralJobContainerOutput{Name: name, ExitCode: &exitCode, Reason: status.State.Terminated.Reason})
+		case parseEphemeralContainerStatus(status) == FailedStatus:
+			outputs = append(outputs, appsv1alpha1.EphemeralJobContainerOutput{Name: name, Reason: status.State.Waiting.Reason})
+		default:
+			return nil
+		}
+	}
+	return outputs
+}
+
+func newOutputConfigMap(job *appsv1alpha1.EphemeralJob, pod *v1.Pod) *v1.ConfigMap {
+	hash := fnv.New32a()
+	hash.Write([]byte(pod.UID))
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       job.Namespace,
+			Name:            fmt.Sprintf("%s-%s", job.Name, rand.SafeEncodeString(fmt.Sprint(hash.Sum32()))),
+			Labels:          map[string]string{appsv1alpha1.EphemeralJobNameLabelKey: job.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(job, controllerKind)},
+		},
+		Data: map[string]string{},
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeraljob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/controller/ephemeraljob/econtainer"
+)
+
+func TestGetFinishedContainerOutputs(t *testing.T) {
+	job := &appsv1alpha1.EphemeralJob{
+		Spec: appsv1alpha1.EphemeralJobSpec{
+			Template: appsv1alpha1.EphemeralContainerTemplateSpec{EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}},
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "collector"}},
+			}},
+		},
+	}
+	terminated := func(name string, exitCode int32, reason string) v1.ContainerStatus {
+		return v1.ContainerStatus{Name: name, State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+			ExitCode: exitCode, Reason: reason, FinishedAt: metav1.Now(),
+		}}}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []v1.ContainerStatus
+		expected []appsv1alpha1.EphemeralJobContainerOutput
+	}{
+		{
+			name:     "not created",
+			statuses: []v1.ContainerStatus{terminated("debugger", 0, "Completed")},
+		},
+		{
+			name: "running",
+			statuses: []v1.ContainerStatus{
+				terminated("debugger", 0, "Completed"),
+				{Name: "collector", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+		{
+			name: "all finished",
+			statuses: []v1.ContainerStatus{
+				terminated("debugger", 0, "Completed"),
+				terminated("collector", 1, "Error"),
+				terminated("other", 0, "Completed"),
+			},
+			expected: []appsv1alpha1.EphemeralJobContainerOutput{
+				{Name: "debugger", ExitCode: ptr.To(int32(0)), Reason: "Completed"},
+				{Name: "collector", ExitCode: ptr.To(int32(1)), Reason: "Error"},
+			},
+		},
+		{
+			name: "failed to run",
+			statuses: []v1.ContainerStatus{
+				terminated("debugger", 0, "Completed"),
+				{Name: "collector", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "RunContainerError"}}},
+			},
+			expected: []appsv1alpha1.EphemeralJobContainerOutput{
+				{Name: "debugger", ExitCode: ptr.To(int32(0)), Reason: "Completed"},
+				{Name: "collector", Reason: "RunContainerError"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getFinishedContainerOutputs(job, tt.statuses))
+		})
+	}
+}
+
+type fakeOutputControl struct {
+	econtainer.EphemeralContainerInterface
+	concurrent, maxConcurrent int32
+}
+
+func (f *fakeOutputControl) GetEphemeralContainersStatus(target *v1.Pod) []v1.ContainerStatus {
+	return target.Status.EphemeralContainerStatuses
+}
+
+func (f *fakeOutputControl) GetEphemeralContainerLogs(target *v1.Pod, name string, tailLines, limitBytes int64) ([]byte, error) {
+	current := atomic.AddInt32(&f.concurrent, 1)
+	defer atomic.AddInt32(&f.concurrent, -1)
+	for {
+		maxConcurrent := atomic.LoadInt32(&f.maxConcurrent)
+		if current <= maxConcurrent || atomic.CompareAndSwapInt32(&f.maxConcurrent, maxConcurrent, current) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	if target.Name == "pod-0" {
+		return nil, fmt.Errorf("%s", strings.Repeat("x", 1024))
+	}
+	return []byte("logs of " + target.Name), nil
+}
+
+func TestCollectOutputs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+
+	job := &appsv1alpha1.EphemeralJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job", UID: "job-uid"},
+		Spec: appsv1alpha1.EphemeralJobSpec{
+			Template: appsv1alpha1.EphemeralContainerTemplateSpec{EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger"}},
+			}},
+			OutputCollection: &appsv1alpha1.EphemeralJobOutputCollection{},
+		},
+	}
+	// the outputs of some pods have been collected before
+	for i := 0; i < maxOutputs-outputCollectWorkers-5; i++ {
+		job.Status.Outputs = append(job.Status.Outputs, appsv1alpha1.EphemeralJobPodOutput{PodName: fmt.Sprintf("collected-%d", i)})
+	}
+
+	var pods []*v1.Pod
+	for i := 0; i < outputCollectWorkers+10; i++ {
+		pods = append(pods, &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("pod-%d", i), UID: types.UID(fmt.Sprintf("pod-%d-uid", i))},
+			Status: v1.PodStatus{EphemeralContainerStatuses: []v1.ContainerStatus{{
+				Name:  "debugger",
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}},
+			}}},
+		})
+	}
+	// the pod that has not finished is not counted
+	pods = append(pods, &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "running"}})
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	r := &ReconcileEphemeralJob{Client: fakeClient, scheme: scheme, recorder: record.NewFakeRecorder(10)}
+	control := &fakeOutputControl{}
+	assert.NoError(t, r.collectOutputs(job, pods, control))
+
+	assert.Len(t, job.Status.Outputs, maxOutputs)
+	assert.Equal(t, int32(5), job.Status.OutputsOmitted)
+	assert.True(t, control.maxConcurrent > 1 && control.maxConcurrent <= outputCollectWorkers, fmt.Sprintf("maxConcurrent %d", control.maxConcurrent))
+
+	cmList := &v1.ConfigMapList{}
+	assert.NoError(t, fakeClient.List(context.TODO(), cmList, client.InNamespace("default")))
+	assert.Len(t, cmList.Items, outputCollectWorkers+5)
+
+	newOutputs := job.Status.Outputs[maxOutputs-outputCollectWorkers-5:]
+	assert.Equal(t, "pod-0", newOutputs[0].PodName)
+	assert.Len(t, newOutputs[0].Containers[0].Message, maxOutputMessageLength)
+	cm := &v1.ConfigMap{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: newOutputs[1].ConfigMapName}, cm))
+	assert.Equal(t, map[string]string{"debugger": "logs of pod-1"}, cm.Data)
+}
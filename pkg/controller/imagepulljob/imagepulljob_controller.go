@@ -137,6 +137,12 @@ func add(mgr manager.Manager, r *ReconcileImagePullJob) error {
 		return err
 	}
 
+	// Watch for configmap for jobs that load allowed digests from it
+	err = c.Watch(source.Kind(mgr.GetCache(), &v1.ConfigMap{}, &configMapEventHandler{Reader: mgr.GetCache()}))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -153,6 +159,7 @@ type ReconcileImagePullJob struct {
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=imagepulljobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=imagepulljobs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=imagepulljobs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 
 // Reconcile reads that state of the cluster for a ImagePullJob object and makes changes based on the state read
 // and what is in the ImagePullJob.Spec
@@ -268,7 +275,7 @@ func (r *ReconcileImagePullJob) Reconcile(_ context.Context, request reconcile.R
 	}
 
 	// Sync image to more NodeImages
-	if err = r.syncNodeImages(job, newStatus, notSyncedNodeImages, secrets); err != nil {
+	if err = r.syncNodeImages(job, newStatus, notSyncedNodeImages, secrets, nodeImages); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to sync NodeImages: %v", err)
 	}
 
@@ -318,7 +325,7 @@ func (r *ReconcileImagePullJob) syncJobPullSecrets(job *appsv1beta1.ImagePullJob
 	return r.claimImagePullJobSecrets(job, pullSecrets)
 }
 
-func (r *ReconcileImagePullJob) syncNodeImages(job *appsv1beta1.ImagePullJob, newStatus *appsv1beta1.ImagePullJobStatus, notSyncedNodeImages []string, secrets []appsv1beta1.ReferenceObject) error {
+func (r *ReconcileImagePullJob) syncNodeImages(job *appsv1beta1.ImagePullJob, newStatus *appsv1beta1.ImagePullJobStatus, notSyncedNodeImages []string, secrets []appsv1beta1.ReferenceObject, nodeImages []*appsv1beta1.NodeImage) error {
 	if len(notSyncedNodeImages) == 0 {
 		return nil
 	}
@@ -338,12 +345,43 @@ func (r *ReconcileImagePullJob) syncNodeImages(job *appsv1beta1.ImagePullJob, ne
 		parallelism = len(notSyncedNodeImages)
 	}
 
+	imageName, imageTag, _ := daemonutil.NormalizeImageRefToNameTag(job.Spec.Image)
+	// the nodes holding the image pulled by tag before the digest resolved are also peers
+	holderTags := sets.New(imageTag)
+	if job.Spec.PinDigest {
+		if newStatus.ResolvedDigest != "" {
+			imageTag = newStatus.ResolvedDigest
+			holderTags.Insert(imageTag)
+		} else if newStatus.Succeeded == 0 {
+			// resolve the digest on one node before syncing the others
+			if newStatus.Active > 0 {
+				klog.V(3).InfoS("ImagePullJob is waiting for the digest of image resolved", "imagePullJob", klog.KObj(job))
+				return nil
+			}
+			parallelism = 1
+		}
+	}
+	verification, err := r.getTagVerification(job)
+	if err != nil {
+		return err
+	}
+
 	ownerRef := getOwnerRef(job)
 	pullPolicy := getImagePullPolicy(job)
 	now := metav1.NewTime(r.clock.Now())
-	imageName, imageTag, _ := daemonutil.NormalizeImageRefToNameTag(job.Spec.Image)
+	planner := newPeerPlanner(job, nodeImages, imageName, holderTags)
+	if planner != nil {
+		if capacity := planner.capacity(int(newStatus.Active)); capacity < parallelism {
+			klog.V(3).InfoS("ImagePullJob limits the parallelism by the capacity of peers", "imagePullJob", klog.KObj(job), "parallelism", parallelism, "capacity", capacity)
+			parallelism = capacity
+		}
+	}
 	for i := 0; i < parallelism; i++ {
 		var skip bool
+		var peerToPeer *appsv1beta1.ImageTagPeerToPeer
+		if planner != nil {
+			peerToPeer = &appsv1beta1.ImageTagPeerToPeer{Peers: planner.assign()}
+		}
 		updateErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 			nodeImage := appsv1beta1.NodeImage{}
 			if err := r.Get(context.TODO(), types.NamespacedName{Name: notSyncedNodeImages[i]}, &nodeImage); err != nil {
@@ -377,6 +415,8 @@ func (r *ReconcileImagePullJob) syncNodeImages(job *appsv1beta1.ImagePullJob, ne
 				tagSpec.OwnerReferences = append(tagSpec.OwnerReferences, *ownerRef)
 				tagSpec.CreatedAt = &now
 				tagSpec.ImagePullPolicy = job.Spec.ImagePullPolicy
+				tagSpec.PeerToPeer = peerToPeer
+				tagSpec.Verification = verification
 				found = true
 				break
 			}
@@ -398,6 +438,8 @@ func (r *ReconcileImagePullJob) syncNodeImages(job *appsv1beta1.ImagePullJob, ne
 					OwnerReferences: []v1.ObjectReference{*ownerRef},
 					CreatedAt:       &now,
 					ImagePullPolicy: job.Spec.ImagePullPolicy,
+					PeerToPeer:      peerToPeer,
+					Verification:    verification,
 				})
 			}
 			utilimagejob.SortSpecImageTagsV1beta1(&imageSpec)
@@ -424,6 +466,32 @@ func (r *ReconcileImagePullJob) syncNodeImages(job *appsv1beta1.ImagePullJob, ne
 	return nil
 }
 
+// getTagVerification returns the verification policy of the image with the allowlist loaded from the ConfigMap
+func (r *ReconcileImagePullJob) getTagVerification(job *appsv1beta1.ImagePullJob) (*appsv1beta1.ImageTagVerification, error) {
+	if job.Spec.Verification == nil {
+		return nil, nil
+	}
+	verification := &appsv1beta1.ImageTagVerification{PublicKeys: job.Spec.Verification.PublicKeys}
+	if name := job.Spec.Verification.AllowedDigestsConfigMap; name != "" {
+		cm := &v1.ConfigMap{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Namespace: job.Namespace, Name: name}, cm); err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s of allowed digests: %v", name, err)
+		}
+		keys := make([]string, 0, len(cm.Data))
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			verification.AllowedDigests = append(verification.AllowedDigests, strings.Fields(cm.Data[key])...)
+		}
+		if len(verification.AllowedDigests) == 0 {
+			return nil, fmt.Errorf("no digest found in ConfigMap %s of allowed digests", name)
+		}
+	}
+	return verification, nil
+}
+
 // classifyPullSecretsForJob categorizes secrets based on the requirements of the ImagePullJob
 // This function iterates through all secrets in the kruise-daemon-config namespace and classifies them into secrets to be used and secrets to be released
 //
@@ -636,11 +704,21 @@ func (r *ReconcileImagePullJob) calculateStatus(job *appsv1beta1.ImagePullJob, n
 	if err != nil {
 		return nil, nil, fmt.Errorf("invalid image %s: %v", job.Spec.Image, err)
 	}
+	if job.Spec.PinDigest {
+		newStatus.ResolvedDigest = job.Status.ResolvedDigest
+	}
+	// the nodes synced before the digest resolved pull the image by tag
+	matchTag := func(tag string) bool {
+		return tag == imageTag || (newStatus.ResolvedDigest != "" && tag == newStatus.ResolvedDigest)
+	}
 
 	var pulling, succeeded, failed []string
+	var failedDetails []appsv1beta1.ImagePullJobFailedNode
+	var samples []appsv1beta1.ImagePullNodeStats
 	notSynced := sets.NewString()
 	for _, nodeImage := range nodeImages {
 		var tagVersion int64 = -1
+		var syncedTag string
 		var secretSynced bool = true
 		if imageSpec, ok := nodeImage.Spec.Images[imageName]; ok {
 			for _, secret := range secrets {
@@ -654,7 +732,7 @@ func (r *ReconcileImagePullJob) calculateStatus(job *appsv1beta1.ImagePullJob, n
 			}
 
 			for _, tagSpec := range imageSpec.Tags {
-				if tagSpec.Tag != imageTag {
+				if !matchTag(tagSpec.Tag) {
 					continue
 				}
 				var foundOwner bool
@@ -665,9 +743,11 @@ func (r *ReconcileImagePullJob) calculateStatus(job *appsv1beta1.ImagePullJob, n
 					}
 				}
 				if !foundOwner {
-					break
+					continue
 				}
 				tagVersion = tagSpec.Version
+				syncedTag = tagSpec.Tag
+				break
 			}
 		}
 
@@ -679,7 +759,7 @@ func (r *ReconcileImagePullJob) calculateStatus(job *appsv1beta1.ImagePullJob, n
 		imageStatus, _ := nodeImage.Status.ImageStatuses[imageName]
 		foundTag := false
 		for _, tagStatus := range imageStatus.Tags {
-			if tagStatus.Tag != imageTag {
+			if tagStatus.Tag != syncedTag {
 				continue
 			}
 			if tagStatus.Version != tagVersion {
@@ -688,8 +768,20 @@ func (r *ReconcileImagePullJob) calculateStatus(job *appsv1beta1.ImagePullJob, n
 			switch tagStatus.Phase {
 			case appsv1beta1.ImagePhaseSucceeded:
 				succeeded = append(succeeded, nodeImage.Name)
+				if job.Spec.PinDigest && newStatus.ResolvedDigest == "" && tagStatus.Digest != "" {
+					newStatus.ResolvedDigest = tagStatus.Digest
+				}
+				if stats := tagStatus.PullStats; stats != nil && stats.Duration != nil {
+					samples = append(samples, appsv1beta1.ImagePullNodeStats{
+						NodeName:        nodeImage.Name,
+						Registry:        stats.Registry,
+						Duration:        *stats.Duration,
+						DownloadedBytes: stats.DownloadedBytes,
+					})
+				}
 			case appsv1beta1.ImagePhaseFailed:
 				failed = append(failed, nodeImage.Name)
+				failedDetails = append(failedDetails, appsv1beta1.ImagePullJobFailedNode{NodeName: nodeImage.Name, Message: tagStatus.Message})
 			default:
 				pulling = append(pulling, nodeImage.Name)
 			}
@@ -701,6 +793,10 @@ func (r *ReconcileImagePullJob) calculateStatus(job *appsv1beta1.ImagePullJob, n
 		}
 	}
 
+	newStatus.PullStats = calculatePullStats(samples)
+	sort.Slice(failedDetails, func(i, j int) bool { return failedDetails[i].NodeName < failedDetails[j].NodeName })
+	newStatus.FailedNodeDetails = failedDetails
+
 	if job.Spec.CompletionPolicy.Type != appsv1beta1.Never && job.Spec.CompletionPolicy.ActiveDeadlineSeconds != nil && int(newStatus.Desired) != len(succeeded)+len(failed) {
 		if time.Duration(*job.Spec.CompletionPolicy.ActiveDeadlineSeconds)*time.Second <= time.Since(newStatus.StartTime.Time) {
 			newStatus.CompletionTime = &now
@@ -2637,3 +2637,108 @@ func TestClaimImagePullJobSecrets_UpdateError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "update error")
 }
+
+func TestPinDigestAndVerification(t *testing.T) {
+	digest := "sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63"
+	job := &appsv1beta1.ImagePullJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default", UID: "job-uid"},
+		Spec: appsv1beta1.ImagePullJobSpec{
+			Image: "nginx:1.20",
+			ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+				PinDigest: true,
+				Verification: &appsv1beta1.ImagePullJobVerification{
+					PublicKeys:              []string{"key"},
+					AllowedDigestsConfigMap: "allowed",
+				},
+			},
+		},
+	}
+	newNodeImage := func(name, tag string, phase appsv1beta1.ImagePullPhase, digest, message string) *appsv1beta1.NodeImage {
+		nodeImage := &appsv1beta1.NodeImage{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if tag == "" {
+			return nodeImage
+		}
+		nodeImage.Spec.Images = map[string]appsv1beta1.ImageSpec{"nginx": {Tags: []appsv1beta1.ImageTagSpec{
+			{Tag: tag, Version: 1, OwnerReferences: []v1.ObjectReference{{UID: job.UID}}},
+		}}}
+		nodeImage.Status.ImageStatuses = map[string]appsv1beta1.ImageStatus{"nginx": {Tags: []appsv1beta1.ImageTagStatus{
+			{Tag: tag, Version: 1, Phase: phase, Digest: digest, Message: message},
+		}}}
+		return nodeImage
+	}
+
+	cases := []struct {
+		name             string
+		nodeImages       []*appsv1beta1.NodeImage
+		expectedDigest   string
+		expectedFailed   []appsv1beta1.ImagePullJobFailedNode
+		expectedSyncTags map[string]string
+	}{
+		{
+			name: "resolve the digest on one node first",
+			nodeImages: []*appsv1beta1.NodeImage{
+				newNodeImage("node1", "", "", "", ""),
+				newNodeImage("node2", "", "", "", ""),
+			},
+			expectedSyncTags: map[string]string{"node1": "1.20"},
+		},
+		{
+			name: "wait for the digest resolved",
+			nodeImages: []*appsv1beta1.NodeImage{
+				newNodeImage("node1", "1.20", appsv1beta1.ImagePhasePulling, "", ""),
+				newNodeImage("node2", "", "", "", ""),
+			},
+			expectedSyncTags: map[string]string{},
+		},
+		{
+			name: "pin the other nodes to the resolved digest",
+			nodeImages: []*appsv1beta1.NodeImage{
+				newNodeImage("node1", "1.20", appsv1beta1.ImagePhaseSucceeded, digest, ""),
+				newNodeImage("node2", "", "", "", ""),
+				newNodeImage("node3", digest, appsv1beta1.ImagePhaseFailed, "", "image verification failed: digest is not in the allowlist"),
+			},
+			expectedDigest: digest,
+			expectedFailed: []appsv1beta1.ImagePullJobFailedNode{
+				{NodeName: "node3", Message: "image verification failed: digest is not in the allowlist"},
+			},
+			expectedSyncTags: map[string]string{"node2": digest},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := []client.Object{
+				&v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "allowed"},
+					Data:       map[string]string{"b": digest, "a": " sha256:1\n sha256:2 "},
+				},
+			}
+			for _, nodeImage := range tc.nodeImages {
+				objs = append(objs, nodeImage.DeepCopy())
+			}
+			r := &ReconcileImagePullJob{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+				scheme: scheme,
+				clock:  k8stesting.NewFakeClock(time.Now()),
+			}
+			status, notSynced, err := r.calculateStatus(job, tc.nodeImages, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDigest, status.ResolvedDigest)
+			assert.Equal(t, tc.expectedFailed, status.FailedNodeDetails)
+			assert.NoError(t, r.syncNodeImages(job, status, notSynced, nil, tc.nodeImages))
+
+			syncTags := map[string]string{}
+			for _, name := range notSynced {
+				nodeImage := &appsv1beta1.NodeImage{}
+				assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: name}, nodeImage))
+				for _, tagSpec := range nodeImage.Spec.Images["nginx"].Tags {
+					syncTags[name] = tagSpec.Tag
+					assert.Equal(t, &appsv1beta1.ImageTagVerification{
+						PublicKeys:     []string{"key"},
+						AllowedDigests: []string{"sha256:1", "sha256:2", digest},
+					}, tagSpec.Verification)
+				}
+			}
+			assert.Equal(t, tc.expectedSyncTags, syncTags)
+		})
+	}
+}
@@ -280,6 +280,46 @@ func (e *secretEventHandler) getActiveJobKeysForSecret(secret *v1.Secret) ([]typ
 	return jobKeys, nil
 }
 
+type configMapEventHandler struct {
+	client.Reader
+}
+
+var _ handler.TypedEventHandler[*v1.ConfigMap, reconcile.Request] = &configMapEventHandler{}
+
+func (e *configMapEventHandler) Create(ctx context.Context, evt event.TypedCreateEvent[*v1.ConfigMap], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.handle(evt.Object, q)
+}
+
+func (e *configMapEventHandler) Update(ctx context.Context, evt event.TypedUpdateEvent[*v1.ConfigMap], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if reflect.DeepEqual(evt.ObjectNew.Data, evt.ObjectOld.Data) {
+		return
+	}
+	e.handle(evt.ObjectNew, q)
+}
+
+func (e *configMapEventHandler) Delete(ctx context.Context, evt event.TypedDeleteEvent[*v1.ConfigMap], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.handle(evt.Object, q)
+}
+
+func (e *configMapEventHandler) Generic(ctx context.Context, evt event.TypedGenericEvent[*v1.ConfigMap], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+// handle enqueues the jobs whose allowed digests are loaded from the ConfigMap
+func (e *configMapEventHandler) handle(cm *v1.ConfigMap, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	jobLister := &appsv1beta1.ImagePullJobList{}
+	if err := e.List(context.TODO(), jobLister, client.InNamespace(cm.Namespace), utilclient.DisableDeepCopy); err != nil {
+		klog.ErrorS(err, "Failed to get jobs for ConfigMap", "configMap", klog.KObj(cm))
+		return
+	}
+	for i := range jobLister.Items {
+		job := &jobLister.Items[i]
+		if job.DeletionTimestamp != nil || job.Spec.Verification == nil || job.Spec.Verification.AllowedDigestsConfigMap != cm.Name {
+			continue
+		}
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: job.Namespace, Name: job.Name}})
+	}
+}
+
 func jobContainsSecret(job *appsv1beta1.ImagePullJob, secretName string) bool {
 	for _, s := range job.Spec.PullSecrets {
 		if secretName == s {
@@ -1,6 +1,7 @@
 package imagepulljob
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
@@ -363,3 +365,90 @@ func TestNodeImageEventHandler_handleUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMapEventHandler(t *testing.T) {
+	jobs := []client.Object{
+		&appsv1beta1.ImagePullJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "default"},
+			Spec: appsv1beta1.ImagePullJobSpec{
+				Image: "nginx:1.20",
+				ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+					Verification: &appsv1beta1.ImagePullJobVerification{AllowedDigestsConfigMap: "allowed-digests"},
+				},
+			},
+		},
+		&appsv1beta1.ImagePullJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job2", Namespace: "default"},
+			Spec: appsv1beta1.ImagePullJobSpec{
+				Image: "nginx:1.20",
+				ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+					Verification: &appsv1beta1.ImagePullJobVerification{AllowedDigestsConfigMap: "other"},
+				},
+			},
+		},
+		&appsv1beta1.ImagePullJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job3", Namespace: "default"},
+			Spec:       appsv1beta1.ImagePullJobSpec{Image: "nginx:1.20"},
+		},
+		&appsv1beta1.ImagePullJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "other"},
+			Spec: appsv1beta1.ImagePullJobSpec{
+				Image: "nginx:1.20",
+				ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+					Verification: &appsv1beta1.ImagePullJobVerification{AllowedDigestsConfigMap: "allowed-digests"},
+				},
+			},
+		},
+	}
+	oldCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "allowed-digests", Namespace: "default"},
+		Data:       map[string]string{"digests": "sha256:1"},
+	}
+	newCM := oldCM.DeepCopy()
+	newCM.Data["digests"] = "sha256:2"
+
+	tests := []struct {
+		name         string
+		handle       func(h *configMapEventHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request])
+		expectedAdds []types.NamespacedName
+	}{
+		{
+			name: "configmap data changed",
+			handle: func(h *configMapEventHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Update(context.TODO(), event.TypedUpdateEvent[*corev1.ConfigMap]{ObjectOld: oldCM, ObjectNew: newCM}, q)
+			},
+			expectedAdds: []types.NamespacedName{{Namespace: "default", Name: "job1"}},
+		},
+		{
+			name: "configmap data not changed",
+			handle: func(h *configMapEventHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Update(context.TODO(), event.TypedUpdateEvent[*corev1.ConfigMap]{ObjectOld: oldCM, ObjectNew: oldCM.DeepCopy()}, q)
+			},
+		},
+		{
+			name: "configmap deleted",
+			handle: func(h *configMapEventHandler, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				h.Delete(context.TODO(), event.TypedDeleteEvent[*corev1.ConfigMap]{Object: oldCM}, q)
+			},
+			expectedAdds: []types.NamespacedName{{Namespace: "default", Name: "job1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+			defer q.ShutDown()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(jobs...).Build()
+			tt.handle(&configMapEventHandler{fakeClient}, q)
+
+			assert.Equal(t, len(tt.expectedAdds), q.Len())
+			addedItems := make([]types.NamespacedName, 0, len(tt.expectedAdds))
+			for i := 0; i < len(tt.expectedAdds); i++ {
+				item, _ := q.Get()
+				addedItems = append(addedItems, item.NamespacedName)
+				q.Done(item)
+			}
+			assert.ElementsMatch(t, tt.expectedAdds, addedItems)
+		})
+	}
+}
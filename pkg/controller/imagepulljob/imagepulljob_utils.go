@@ -19,13 +19,18 @@ package imagepulljob
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"math/rand"
+	"sort"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/integer"
 	utilpointer "k8s.io/utils/pointer"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
@@ -35,6 +40,9 @@ import (
 const (
 	defaultTTLSecondsForNever            = int32(24 * 3600)
 	defaultActiveDeadlineSecondsForNever = int64(1800)
+
+	defaultPeerFanOut = 3
+	maxPeersPerNode   = 3
 )
 
 func getTTLSecondsForAlways(job *appsv1beta1.ImagePullJob) *int32 {
@@ -160,3 +168,138 @@ func defaultGenerateRandomString() string {
 	}
 	return hex.EncodeToString(bytes)[:6]
 }
+
+// maxSlowestNodes is the max number of the slowest nodes recorded in status
+const maxSlowestNodes = 5
+
+// calculatePullStats aggregates the telemetry of pulling tasks on nodes into percentiles
+func calculatePullStats(samples []appsv1beta1.ImagePullNodeStats) *appsv1beta1.ImagePullJobStats {
+	if len(samples) == 0 {
+		return nil
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		if samples[i].Duration.Duration != samples[j].Duration.Duration {
+			return samples[i].Duration.Duration > samples[j].Duration.Duration
+		}
+		return samples[i].NodeName < samples[j].NodeName
+	})
+	durations := make([]int64, len(samples))
+	bytes := make([]int64, len(samples))
+	for i := range samples {
+		durations[i] = int64(samples[i].Duration.Duration)
+		bytes[i] = samples[i].DownloadedBytes
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	sort.Slice(bytes, func(i, j int) bool { return bytes[i] < bytes[j] })
+
+	stats := &appsv1beta1.ImagePullJobStats{
+		SampledNodes: int32(len(samples)),
+		Duration: &appsv1beta1.ImagePullDurationPercentiles{
+			P50: metav1.Duration{Duration: time.Duration(percentile(durations, 50))},
+			P90: metav1.Duration{Duration: time.Duration(percentile(durations, 90))},
+			P99: metav1.Duration{Duration: time.Duration(percentile(durations, 99))},
+		},
+		DownloadedBytes: &appsv1beta1.ImagePullBytesPercentiles{
+			P50: percentile(bytes, 50),
+			P90: percentile(bytes, 90),
+			P99: percentile(bytes, 99),
+		},
+	}
+	stats.SlowestNodes = append(stats.SlowestNodes, samples[:integer.IntMin(len(samples), maxSlowestNodes)]...)
+	return stats
+}
+
+// percentile returns the p-th percentile of sorted values by the nearest-rank method
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// peerPlanner assigns the nodes holding the image as the peers of the nodes to pull it.
+// Each peer serves at most fanOut nodes at the same time, so that the nodes are synced in a fan-out tree.
+type peerPlanner struct {
+	fanOut int
+	// the endpoints of the nodes holding the image
+	peers []string
+	// the number of pulling nodes served by each peer
+	load map[string]int
+	// the number of nodes holding the image without peer endpoint
+	holdersWithoutEndpoint int
+}
+
+func newPeerPlanner(job *appsv1beta1.ImagePullJob, nodeImages []*appsv1beta1.NodeImage, imageName string, imageTags sets.Set[string]) *peerPlanner {
+	if job.Spec.PeerToPeer == nil {
+		return nil
+	}
+	p := &peerPlanner{fanOut: defaultPeerFanOut, load: make(map[string]int)}
+	if job.Spec.PeerToPeer.FanOut != nil {
+		p.fanOut = int(*job.Spec.PeerToPeer.FanOut)
+	}
+
+	for _, nodeImage := range nodeImages {
+		var phase appsv1beta1.ImagePullPhase
+		for _, tagStatus := range nodeImage.Status.ImageStatuses[imageName].Tags {
+			if imageTags.Has(tagStatus.Tag) {
+				phase = tagStatus.Phase
+				break
+			}
+		}
+		switch phase {
+		case appsv1beta1.ImagePhaseSucceeded:
+			if nodeImage.Status.PeerEndpoint != "" {
+				p.peers = append(p.peers, nodeImage.Status.PeerEndpoint)
+			} else {
+				p.holdersWithoutEndpoint++
+			}
+		case appsv1beta1.ImagePhaseFailed:
+		default:
+			// the node is pulling the image from its first peer
+			for _, tagSpec := range nodeImage.Spec.Images[imageName].Tags {
+				if imageTags.Has(tagSpec.Tag) && tagSpec.PeerToPeer != nil && len(tagSpec.PeerToPeer.Peers) > 0 {
+					p.load[tagSpec.PeerToPeer.Peers[0]]++
+				}
+			}
+		}
+	}
+	sort.Strings(p.peers)
+	return p
+}
+
+// capacity returns the number of nodes which can start pulling now, with active nodes pulling.
+func (p *peerPlanner) capacity(active int) int {
+	if len(p.peers) == 0 {
+		if p.holdersWithoutEndpoint > 0 {
+			// the daemons do not serve peers, do not limit the parallelism
+			return math.MaxInt32
+		}
+		// no node holds the image, seed it from the registry on one node
+		if active > 0 {
+			return 0
+		}
+		return 1
+	}
+	var free int
+	for _, peer := range p.peers {
+		free += integer.IntMax(0, p.fanOut-p.load[peer])
+	}
+	return free
+}
+
+// assign returns the peers of the next node in order of preference, the least loaded peer comes first.
+func (p *peerPlanner) assign() []string {
+	if len(p.peers) == 0 {
+		return nil
+	}
+	peers := append([]string{}, p.peers...)
+	sort.SliceStable(peers, func(i, j int) bool {
+		return p.load[peers[i]] < p.load[peers[j]]
+	})
+	if len(peers) > maxPeersPerNode {
+		peers = peers[:maxPeersPerNode]
+	}
+	p.load[peers[0]]++
+	return peers
+}
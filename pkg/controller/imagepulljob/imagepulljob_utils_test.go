@@ -17,7 +17,11 @@ limitations under the License.
 package imagepulljob
 
 import (
+	"fmt"
+	"math"
+	"reflect"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -191,3 +195,129 @@ func TestGetSourceSecretWithEmptyAnnotation(t *testing.T) {
 		t.Errorf("Expected panic for empty annotation, but got %+v", result)
 	}
 }
+
+func TestCalculatePullStats(t *testing.T) {
+	if stats := calculatePullStats(nil); stats != nil {
+		t.Fatalf("expected nil stats for no samples, got %v", stats)
+	}
+
+	var samples []appsv1beta1.ImagePullNodeStats
+	for i := 1; i <= 10; i++ {
+		samples = append(samples, appsv1beta1.ImagePullNodeStats{
+			NodeName:        fmt.Sprintf("node-%02d", i),
+			Registry:        "registry.example.com",
+			Duration:        metav1.Duration{Duration: time.Duration(i) * time.Second},
+			DownloadedBytes: int64(i) << 20,
+		})
+	}
+	stats := calculatePullStats(samples)
+	if stats.SampledNodes != 10 {
+		t.Fatalf("expected 10 sampled nodes, got %d", stats.SampledNodes)
+	}
+	if stats.Duration.P50.Duration != 5*time.Second || stats.Duration.P90.Duration != 9*time.Second || stats.Duration.P99.Duration != 10*time.Second {
+		t.Fatalf("unexpected duration percentiles %+v", stats.Duration)
+	}
+	if stats.DownloadedBytes.P50 != 5<<20 || stats.DownloadedBytes.P90 != 9<<20 || stats.DownloadedBytes.P99 != 10<<20 {
+		t.Fatalf("unexpected bytes percentiles %+v", stats.DownloadedBytes)
+	}
+	if len(stats.SlowestNodes) != maxSlowestNodes || stats.SlowestNodes[0].NodeName != "node-10" || stats.SlowestNodes[4].NodeName != "node-06" {
+		t.Fatalf("unexpected slowest nodes %+v", stats.SlowestNodes)
+	}
+}
+
+func TestPeerPlanner(t *testing.T) {
+	newNodeImage := func(name, endpoint string, phase appsv1beta1.ImagePullPhase, peers ...string) *appsv1beta1.NodeImage {
+		nodeImage := &appsv1beta1.NodeImage{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     appsv1beta1.NodeImageStatus{PeerEndpoint: endpoint},
+		}
+		if peers != nil {
+			nodeImage.Spec.Images = map[string]appsv1beta1.ImageSpec{
+				"nginx": {Tags: []appsv1beta1.ImageTagSpec{{Tag: "1.0", PeerToPeer: &appsv1beta1.ImageTagPeerToPeer{Peers: peers}}}},
+			}
+		}
+		if phase != "" {
+			nodeImage.Status.ImageStatuses = map[string]appsv1beta1.ImageStatus{
+				"nginx": {Tags: []appsv1beta1.ImageTagStatus{{Tag: "1.0", Phase: phase}}},
+			}
+		}
+		return nodeImage
+	}
+
+	cases := []struct {
+		name             string
+		fanOut           *int32
+		nodeImages       []*appsv1beta1.NodeImage
+		active           int
+		expectedCapacity int
+		expectedAssigned [][]string
+	}{
+		{
+			name:             "seed the image on one node",
+			nodeImages:       []*appsv1beta1.NodeImage{newNodeImage("node1", "", ""), newNodeImage("node2", "", "")},
+			expectedCapacity: 1,
+			expectedAssigned: [][]string{nil},
+		},
+		{
+			name:             "wait for the seed",
+			nodeImages:       []*appsv1beta1.NodeImage{newNodeImage("node1", "10.0.0.1:10223", appsv1beta1.ImagePhasePulling, []string{}...), newNodeImage("node2", "", "")},
+			active:           1,
+			expectedCapacity: 0,
+		},
+		{
+			name:             "do not limit the parallelism if daemons do not serve peers",
+			nodeImages:       []*appsv1beta1.NodeImage{newNodeImage("node1", "", appsv1beta1.ImagePhaseSucceeded), newNodeImage("node2", "", "")},
+			expectedCapacity: math.MaxInt32,
+			expectedAssigned: [][]string{nil},
+		},
+		{
+			name:   "fan out from the seed",
+			fanOut: ptr.To[int32](2),
+			nodeImages: []*appsv1beta1.NodeImage{
+				newNodeImage("node1", "10.0.0.1:10223", appsv1beta1.ImagePhaseSucceeded),
+				newNodeImage("node2", "", ""), newNodeImage("node3", "", ""), newNodeImage("node4", "", ""),
+			},
+			expectedCapacity: 2,
+			expectedAssigned: [][]string{{"10.0.0.1:10223"}, {"10.0.0.1:10223"}},
+		},
+		{
+			name: "assign the least loaded peers first",
+			nodeImages: []*appsv1beta1.NodeImage{
+				newNodeImage("node1", "10.0.0.1:10223", appsv1beta1.ImagePhaseSucceeded),
+				newNodeImage("node2", "10.0.0.2:10223", appsv1beta1.ImagePhaseSucceeded),
+				newNodeImage("node3", "10.0.0.3:10223", appsv1beta1.ImagePhaseSucceeded),
+				newNodeImage("node4", "10.0.0.4:10223", appsv1beta1.ImagePhaseSucceeded),
+				newNodeImage("node5", "", appsv1beta1.ImagePhasePulling, "10.0.0.1:10223"),
+				newNodeImage("node6", "", appsv1beta1.ImagePhasePulling, "10.0.0.1:10223"),
+				newNodeImage("node7", "", appsv1beta1.ImagePhasePulling, "10.0.0.2:10223"),
+				newNodeImage("node8", "", appsv1beta1.ImagePhaseFailed, "10.0.0.3:10223"),
+			},
+			active:           3,
+			expectedCapacity: 9,
+			expectedAssigned: [][]string{
+				{"10.0.0.3:10223", "10.0.0.4:10223", "10.0.0.2:10223"},
+				{"10.0.0.4:10223", "10.0.0.2:10223", "10.0.0.3:10223"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &appsv1beta1.ImagePullJob{}
+			job.Spec.PeerToPeer = &appsv1beta1.ImagePullJobPeerToPeer{FanOut: tc.fanOut}
+			p := newPeerPlanner(job, tc.nodeImages, "nginx", sets.New("1.0"))
+			if capacity := p.capacity(tc.active); capacity != tc.expectedCapacity {
+				t.Fatalf("expected capacity %d, got %d", tc.expectedCapacity, capacity)
+			}
+			for i, expected := range tc.expectedAssigned {
+				if assigned := p.assign(); !reflect.DeepEqual(assigned, expected) {
+					t.Fatalf("expected peers %v of node %d, got %v", expected, i, assigned)
+				}
+			}
+		})
+	}
+
+	if p := newPeerPlanner(&appsv1beta1.ImagePullJob{}, nil, "nginx", sets.New("1.0")); p != nil {
+		t.Fatalf("expected no planner without peerToPeer")
+	}
+}
@@ -20,13 +20,11 @@ import (
 	"context"
 	"flag"
 	"reflect"
-	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
@@ -44,7 +42,9 @@ import (
 	"github.com/openkruise/kruise/pkg/util/controllerfinder"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/podprobemarker"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
+	"github.com/openkruise/kruise/pkg/util/requeueduration"
 )
 
 func init() {
@@ -54,6 +54,8 @@ func init() {
 var (
 	concurrentReconciles = 3
 	controllerKind       = appsv1alpha1.SchemeGroupVersion.WithKind("NodePodProbe")
+	// durationStore records the requeue durations of NodePodProbes, which wait for the markers of pods to be stable or expired
+	durationStore = requeueduration.DurationStore{}
 )
 
 /**
@@ -131,7 +133,7 @@ func (r *ReconcileNodePodProbe) Reconcile(_ context.Context, req ctrl.Request) (
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: durationStore.Pop(req.Name)}, nil
 }
 
 func (r *ReconcileNodePodProbe) syncNodePodProbe(name string) error {
@@ -165,9 +167,11 @@ func (r *ReconcileNodePodProbe) syncNodePodProbe(name string) error {
 			continue
 		}
 		// Write podProbe state to Pod metadata and condition
-		if err = r.updatePodProbeStatus(pod, status); err != nil {
+		requeueAfter, err := podprobemarker.PatchPodProbeStatus(r.Client, pod, status)
+		if err != nil {
 			return err
 		}
+		durationStore.Push(name, requeueAfter)
 	}
 	return nil
 }
@@ -217,158 +221,3 @@ func (r *ReconcileNodePodProbe) syncPodFromNodePodProbe(npp *appsv1alpha1.NodePo
 	klog.V(3).InfoS("Updated NodePodProbe success", "nodePodProbe", klog.KObj(npp), "oldSpec", util.DumpJSON(npp.Spec), "newSpec", util.DumpJSON(newSpec))
 	return matchedPods, nil
 }
-
-func (r *ReconcileNodePodProbe) updatePodProbeStatus(pod *corev1.Pod, status appsv1alpha1.PodProbeStatus) error {
-	// map[probe.name]->probeState
-	currentConditions := make(map[string]*corev1.PodCondition)
-	for i := range pod.Status.Conditions {
-		condition := &pod.Status.Conditions[i]
-		currentConditions[string(condition.Type)] = condition
-	}
-	type metadata struct {
-		Labels      map[string]interface{} `json:"labels,omitempty"`
-		Annotations map[string]interface{} `json:"annotations,omitempty"`
-	}
-	// patch labels or annotations in pod
-	probeMetadata := metadata{
-		Labels:      map[string]interface{}{},
-		Annotations: map[string]interface{}{},
-	}
-	// pod status condition record probe result
-	var probeConditions []corev1.PodCondition
-	var err error
-	validConditionTypes := sets.NewString()
-	for i := range status.ProbeStates {
-		probeState := status.ProbeStates[i]
-		if probeState.State == "" {
-			continue
-		}
-		// fetch podProbeMarker
-		ppmName, probeName := strings.Split(probeState.Name, "#")[0], strings.Split(probeState.Name, "#")[1]
-		ppm := &appsv1alpha1.PodProbeMarker{}
-		err = r.Get(context.TODO(), client.ObjectKey{Namespace: pod.Namespace, Name: ppmName}, ppm)
-		if err != nil {
-			// when NodePodProbe is deleted, should delete probes from NodePodProbe.spec
-			if errors.IsNotFound(err) {
-				continue
-			}
-			klog.ErrorS(err, "NodePodProbe got pod failed", "podProbeMarkerName", ppmName, "pod", klog.KObj(pod))
-			return err
-		} else if !ppm.DeletionTimestamp.IsZero() {
-			continue
-		}
-		var policy []appsv1alpha1.ProbeMarkerPolicy
-		var conditionType string
-		for _, probe := range ppm.Spec.Probes {
-			if probe.Name == probeName {
-				policy = probe.MarkerPolicy
-				conditionType = probe.PodConditionType
-				break
-			}
-		}
-		if conditionType != "" && validConditionTypes.Has(conditionType) {
-			klog.InfoS("NodePodProbe pod condition was conflict", "podProbeMarkerName", ppmName, "pod", klog.KObj(pod), "conditionType", conditionType)
-			// patch pod condition
-		} else if conditionType != "" {
-			validConditionTypes.Insert(conditionType)
-			var conStatus corev1.ConditionStatus
-			if probeState.State == appsv1alpha1.ProbeSucceeded {
-				conStatus = corev1.ConditionTrue
-			} else {
-				conStatus = corev1.ConditionFalse
-			}
-			probeConditions = append(probeConditions, corev1.PodCondition{
-				Type:               corev1.PodConditionType(conditionType),
-				Status:             conStatus,
-				LastProbeTime:      probeState.LastProbeTime,
-				LastTransitionTime: probeState.LastTransitionTime,
-				Message:            probeState.Message,
-			})
-		}
-		if len(policy) == 0 {
-			continue
-		}
-		// matchedPolicy is when policy.state is equal to probeState.State, otherwise oppositePolicy
-		// 1. If policy[0].state = Succeeded, policy[1].state = Failed. probeState.State = Succeeded.
-		// So policy[0] is matchedPolicy, policy[1] is oppositePolicy
-		// 2. If policy[0].state = Succeeded, and policy[1] does not exist. probeState.State = Succeeded.
-		// So policy[0] is matchedPolicy, oppositePolicy is nil
-		// 3. If policy[0].state = Succeeded, and policy[1] does not exist. probeState.State = Failed.
-		// So policy[0] is oppositePolicy, matchedPolicy is nil
-		var matchedPolicy, oppositePolicy *appsv1alpha1.ProbeMarkerPolicy
-		for j := range policy {
-			if policy[j].State == probeState.State {
-				matchedPolicy = &policy[j]
-			} else {
-				oppositePolicy = &policy[j]
-			}
-		}
-		if oppositePolicy != nil {
-			for k := range oppositePolicy.Labels {
-				probeMetadata.Labels[k] = nil
-			}
-			for k := range oppositePolicy.Annotations {
-				probeMetadata.Annotations[k] = nil
-			}
-		}
-		if matchedPolicy != nil {
-			for k, v := range matchedPolicy.Labels {
-				probeMetadata.Labels[k] = v
-			}
-			for k, v := range matchedPolicy.Annotations {
-				probeMetadata.Annotations[k] = v
-			}
-		}
-	}
-	// probe condition no changed, continue
-	if len(probeConditions) == 0 && len(probeMetadata.Labels) == 0 && len(probeMetadata.Annotations) == 0 {
-		return nil
-	}
-	//update pod metadata and status condition
-	podClone := pod.DeepCopy()
-	if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		if err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, podClone); err != nil {
-			klog.ErrorS(err, "Failed to get updated pod from client", "pod", klog.KObj(pod))
-			return err
-		}
-		oldStatus := podClone.Status.DeepCopy()
-		for i := range probeConditions {
-			condition := probeConditions[i]
-			util.SetPodConditionIfMsgChanged(podClone, condition)
-		}
-		oldMetadata := podClone.ObjectMeta.DeepCopy()
-		if podClone.Annotations == nil {
-			podClone.Annotations = map[string]string{}
-		}
-		for k, v := range probeMetadata.Labels {
-			// delete the label
-			if v == nil {
-				delete(podClone.Labels, k)
-				// patch the label
-			} else {
-				podClone.Labels[k] = v.(string)
-			}
-		}
-		for k, v := range probeMetadata.Annotations {
-			// delete the annotation
-			if v == nil {
-				delete(podClone.Annotations, k)
-				// patch the annotation
-			} else {
-				podClone.Annotations[k] = v.(string)
-			}
-		}
-		if reflect.DeepEqual(oldStatus.Conditions, podClone.Status.Conditions) && reflect.DeepEqual(oldMetadata.Labels, podClone.Labels) &&
-			reflect.DeepEqual(oldMetadata.Annotations, podClone.Annotations) {
-			return nil
-		}
-		// todo: resolve https://github.com/openkruise/kruise/issues/1597
-		return r.Client.Status().Update(context.TODO(), podClone)
-	}); err != nil {
-		klog.ErrorS(err, "NodePodProbe patched pod status failed", "pod", klog.KObj(podClone))
-		return err
-	}
-	klog.V(3).InfoS("NodePodProbe updated pod metadata and conditions success", "pod", klog.KObj(podClone), "metaData",
-		util.DumpJSON(probeMetadata), "conditions", util.DumpJSON(probeConditions))
-	return nil
-}
@@ -18,8 +18,10 @@ package persistentpodstate
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -50,6 +52,7 @@ import (
 	"github.com/openkruise/kruise/pkg/util/controllerfinder"
 	"github.com/openkruise/kruise/pkg/util/discovery"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
+	"github.com/openkruise/kruise/pkg/webhook/pod/mutating"
 )
 
 func init() {
@@ -224,8 +227,14 @@ func (r *ReconcilePersistentPodState) Reconcile(_ context.Context, req ctrl.Requ
 		if !podutil.IsPodReady(pod) || pod.Spec.NodeName == "" {
 			continue
 		}
+		podStateKey := mutating.GetPodStateKey(persistentPodState.Spec, pod)
+		if podStateKey == "" {
+			klog.V(4).InfoS("Pod has no identity label for PersistentPodState", "pod", klog.KObj(pod), "label", mutating.GetPodIdentityLabel(persistentPodState.Spec))
+			continue
+		}
+		fields := getPodFields(pod, persistentPodState.Spec.PersistentPodFields)
 		// 2. check old pod state
-		if podState, ok := newStatus.PodStates[pod.Name]; ok {
+		if podState, ok := newStatus.PodStates[podStateKey]; ok {
 			currentKeys := sets.NewString()
 			for key := range podState.NodeTopologyLabels {
 				currentKeys.Insert(key)
@@ -236,7 +245,8 @@ func (r *ReconcilePersistentPodState) Reconcile(_ context.Context, req ctrl.Requ
 			}
 
 			// already recorded, no need to regenerate
-			if podState.NodeName == pod.Spec.NodeName && nodeTopologyKeys.Equal(currentKeys) && annotationKeys.Equal(currentAns) {
+			if podState.NodeName == pod.Spec.NodeName && nodeTopologyKeys.Equal(currentKeys) && annotationKeys.Equal(currentAns) &&
+				reflect.DeepEqual(podState.Fields, fields) {
 				continue
 			}
 		}
@@ -245,12 +255,17 @@ func (r *ReconcilePersistentPodState) Reconcile(_ context.Context, req ctrl.Requ
 		if err != nil {
 			continue
 		}
+		newState.Fields = fields
 		// 4. store PodState
-		newStatus.PodStates[pod.Name] = newState
+		newStatus.PodStates[podStateKey] = newState
 	}
 
-	// scale down statefulSet scenario
-	if persistentPodState.Spec.PersistentPodStateRetentionPolicy != appsv1beta1.PersistentPodStateRetentionPolicyWhenDeleted {
+	// scale down workload keyed by pod identity label scenario
+	if persistentPodState.Spec.PersistentPodStateRetentionPolicy != appsv1beta1.PersistentPodStateRetentionPolicyWhenDeleted &&
+		mutating.GetPodIdentityLabel(persistentPodState.Spec) != "" {
+		cleanupPodStatesByIdentity(persistentPodState, newStatus, pods, innerSts)
+		// scale down statefulSet scenario
+	} else if persistentPodState.Spec.PersistentPodStateRetentionPolicy != appsv1beta1.PersistentPodStateRetentionPolicyWhenDeleted {
 		for podName := range newStatus.PodStates {
 			index, err := parseStsPodIndex(podName)
 			if err != nil {
@@ -290,6 +305,36 @@ func (r *ReconcilePersistentPodState) updatePersistentPodStateStatus(pps *appsv1
 	return nil
 }
 
+// cleanupPodStatesByIdentity deletes the pod states whose pods no longer exist, when there are more pod states than replicas.
+// The pods are not named by ordinals, so a missing pod may be recreating with the same identity, which is kept
+// as long as the number of pod states is not more than replicas.
+func cleanupPodStatesByIdentity(pps *appsv1beta1.PersistentPodState, newStatus *appsv1beta1.PersistentPodStateStatus,
+	pods map[string]*corev1.Pod, workload *innerStatefulset) {
+	exceeded := len(newStatus.PodStates) - int(workload.Replicas)
+	if exceeded <= 0 {
+		return
+	}
+	existing := sets.NewString()
+	for _, pod := range pods {
+		existing.Insert(mutating.GetPodStateKey(pps.Spec, pod))
+	}
+	keys := make([]string, 0, len(newStatus.PodStates))
+	for key := range newStatus.PodStates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if exceeded <= 0 {
+			break
+		}
+		if existing.Has(key) {
+			continue
+		}
+		delete(newStatus.PodStates, key)
+		exceeded--
+	}
+}
+
 func parseStsPodIndex(podName string) (int, error) {
 	index := strings.LastIndex(podName, "-")
 	return strconv.Atoi(podName[index+1:])
@@ -351,6 +396,35 @@ func (r *ReconcilePersistentPodState) getPodState(pod *corev1.Pod, nodeTopologyK
 	return podState, nil
 }
 
+// getPodFields returns the JSON encoded values of the persistent fields of pod, the missing fields are ignored.
+func getPodFields(pod *corev1.Pod, persistentFields []appsv1beta1.PersistentPodField) map[string]string {
+	if len(persistentFields) == 0 {
+		return nil
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(util.DumpJSON(pod)), &obj); err != nil {
+		klog.ErrorS(err, "Failed to decode pod", "pod", klog.KObj(pod))
+		return nil
+	}
+	var fields map[string]string
+	for _, field := range persistentFields {
+		tokens, err := util.ParseJSONPointer(field.Path)
+		if err != nil {
+			klog.ErrorS(err, "Invalid persistent pod field", "pod", klog.KObj(pod))
+			continue
+		}
+		value, ok := util.GetJSONPointerValue(obj, tokens)
+		if !ok {
+			continue
+		}
+		if fields == nil {
+			fields = map[string]string{}
+		}
+		fields[field.Path] = util.DumpJSON(value)
+	}
+	return fields
+}
+
 func isInStatefulSetReplicas(index int, sts *innerStatefulset) bool {
 	replicas := sets.NewInt()
 	replicaIndex := 0
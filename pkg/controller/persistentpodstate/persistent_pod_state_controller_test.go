@@ -492,6 +492,75 @@ func TestReconcilePersistentPodState(t *testing.T) {
 				return staticIP
 			},
 		},
+		{
+			name: "kruise statefulset, 10 ready pod, and record persistent fields, refresh the changed fields",
+			getSts: func() (*apps.StatefulSet, *appsv1beta1.StatefulSet) {
+				return nil, kruiseStsDemo.DeepCopy()
+			},
+			getPods: func() []*corev1.Pod {
+				pods := make([]*corev1.Pod, 0)
+				for i := 0; i < 10; i++ {
+					pod := podDemo.DeepCopy()
+					pod.Name = fmt.Sprintf("%s-%d", kruiseStsDemo.Name, i)
+					pod.OwnerReferences[0].UID = kruiseStsDemo.UID
+					pod.Status.PodIP = fmt.Sprintf("10.0.0.%d", i)
+					pods = append(pods, pod)
+				}
+				return pods
+			},
+			getNodes: func() []*corev1.Node {
+				nodes := make([]*corev1.Node, 0)
+				for i := 0; i < 10; i++ {
+					node := nodeDemo.DeepCopy()
+					node.Name = fmt.Sprintf("node-%d", i)
+					node.Labels[podStateZoneTopologyLabel] = fmt.Sprintf("cn-beijing-%d", i)
+					node.Labels[podStateNodeTopologyLabel] = fmt.Sprintf("kube-resource011162007216-%d", i)
+					nodes = append(nodes, node)
+				}
+				return nodes
+			},
+			getPersistentPodState: func() *appsv1beta1.PersistentPodState {
+				staticIP := staticIPDemo.DeepCopy()
+				staticIP.Spec.PersistentPodFields = []appsv1beta1.PersistentPodField{
+					{Path: "/metadata/labels/test-labels"},
+					{Path: "/status/podIP", RestorePath: "/metadata/annotations/kruise.io~1static-ip"},
+					{Path: "/spec/containers/0/resources"},
+				}
+				// node and topology are not changed, but the ip is changed
+				staticIP.Status.PodStates[fmt.Sprintf("%s-0", kruiseStsDemo.Name)] = appsv1beta1.PodState{
+					NodeName: "node-0",
+					NodeTopologyLabels: map[string]string{
+						podStateZoneTopologyLabel: "cn-beijing-0",
+						podStateNodeTopologyLabel: "kube-resource011162007216-0",
+					},
+					Fields: map[string]string{
+						"/metadata/labels/test-labels": `"test-value"`,
+						"/status/podIP":                `"10.0.1.0"`,
+						"/spec/containers/0/resources": `{}`,
+					},
+				}
+				return staticIP
+			},
+			exceptPersistentPodState: func() *appsv1beta1.PersistentPodState {
+				staticIP := staticIPDemo.DeepCopy()
+				for i := 0; i < 10; i++ {
+					key := fmt.Sprintf("%s-%d", kruiseStsDemo.Name, i)
+					staticIP.Status.PodStates[key] = appsv1beta1.PodState{
+						NodeName: fmt.Sprintf("node-%d", i),
+						NodeTopologyLabels: map[string]string{
+							podStateZoneTopologyLabel: fmt.Sprintf("cn-beijing-%d", i),
+							podStateNodeTopologyLabel: fmt.Sprintf("kube-resource011162007216-%d", i),
+						},
+						Fields: map[string]string{
+							"/metadata/labels/test-labels": `"test-value"`,
+							"/status/podIP":                fmt.Sprintf(`"10.0.0.%d"`, i),
+							"/spec/containers/0/resources": `{}`,
+						},
+					}
+				}
+				return staticIP
+			},
+		},
 	}
 
 	for _, cs := range cases {
@@ -562,3 +631,127 @@ func getLatestPersistentPodState(client client.Client, staticIP *appsv1beta1.Per
 	err := client.Get(context.TODO(), Key, newPersistentPodState)
 	return newPersistentPodState, err
 }
+
+func TestReconcileCloneSetPersistentPodState(t *testing.T) {
+	cloneSet := &appsv1alpha1.CloneSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "CloneSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns-test",
+			Name:      "test-cs",
+			UID:       "2f7e2a10-3f1a-4f6e-9a43-1c0d4c8f5a21",
+		},
+		Spec: appsv1alpha1.CloneSetSpec{
+			Replicas: ptr.To[int32](2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test-cs"}},
+		},
+	}
+	newPod := func(name, id, nodeName string) *corev1.Pod {
+		pod := podDemo.DeepCopy()
+		pod.Name = name
+		pod.Labels["app"] = "test-cs"
+		pod.Labels[appsv1beta1.CloneSetInstanceID] = id
+		pod.OwnerReferences[0].APIVersion = appsv1alpha1.GroupVersion.String()
+		pod.OwnerReferences[0].Kind = "CloneSet"
+		pod.OwnerReferences[0].Name = cloneSet.Name
+		pod.OwnerReferences[0].UID = cloneSet.UID
+		pod.Spec.NodeName = nodeName
+		return pod
+	}
+	newPodState := func(nodeName string) appsv1beta1.PodState {
+		return appsv1beta1.PodState{
+			NodeName:           nodeName,
+			NodeTopologyLabels: map[string]string{podStateZoneTopologyLabel: "cn-beijing"},
+		}
+	}
+
+	cases := []struct {
+		name            string
+		pods            []*corev1.Pod
+		podStates       map[string]appsv1beta1.PodState
+		expectPodStates map[string]appsv1beta1.PodState
+	}{
+		{
+			name: "record pod states by instance id",
+			pods: []*corev1.Pod{newPod("test-cs-x7k2p", "aaaaa", "node-0"), newPod("test-cs-q9m4z", "bbbbb", "node-1")},
+			expectPodStates: map[string]appsv1beta1.PodState{
+				"aaaaa": newPodState("node-0"),
+				"bbbbb": newPodState("node-1"),
+			},
+		},
+		{
+			name: "pod is recreating with the same instance id, keep its pod state",
+			pods: []*corev1.Pod{newPod("test-cs-x7k2p", "aaaaa", "node-0")},
+			podStates: map[string]appsv1beta1.PodState{
+				"aaaaa": newPodState("node-0"),
+				"bbbbb": newPodState("node-1"),
+			},
+			expectPodStates: map[string]appsv1beta1.PodState{
+				"aaaaa": newPodState("node-0"),
+				"bbbbb": newPodState("node-1"),
+			},
+		},
+		{
+			name: "pod is scaled down, delete the pod state exceeding replicas",
+			pods: []*corev1.Pod{newPod("test-cs-x7k2p", "aaaaa", "node-0"), newPod("test-cs-q9m4z", "bbbbb", "node-1")},
+			podStates: map[string]appsv1beta1.PodState{
+				"aaaaa": newPodState("node-0"),
+				"bbbbb": newPodState("node-1"),
+				"ccccc": newPodState("node-2"),
+			},
+			expectPodStates: map[string]appsv1beta1.PodState{
+				"aaaaa": newPodState("node-0"),
+				"bbbbb": newPodState("node-1"),
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pps := staticIPDemo.DeepCopy()
+			pps.Spec.TargetReference = appsv1beta1.TargetReference{
+				APIVersion: appsv1alpha1.GroupVersion.String(),
+				Kind:       "CloneSet",
+				Name:       cloneSet.Name,
+			}
+			pps.Spec.PreferredPersistentTopology = nil
+			pps.Status.PodStates = cs.podStates
+
+			clientBuilder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pps, cloneSet.DeepCopy())
+			for i := 0; i < 3; i++ {
+				node := nodeDemo.DeepCopy()
+				node.Name = fmt.Sprintf("node-%d", i)
+				clientBuilder.WithObjects(node)
+			}
+			for _, pod := range cs.pods {
+				clientBuilder.WithObjects(pod)
+			}
+			fakeClient := clientBuilder.WithStatusSubresource(&appsv1beta1.PersistentPodState{}).
+				WithIndex(&corev1.Pod{}, fieldindex.IndexNameForOwnerRefUID, func(obj client.Object) []string {
+					var owners []string
+					for _, ref := range obj.GetOwnerReferences() {
+						owners = append(owners, string(ref.UID))
+					}
+					return owners
+				}).Build()
+			reconciler := ReconcilePersistentPodState{
+				Client: fakeClient,
+				finder: &controllerfinder.ControllerFinder{Client: fakeClient},
+			}
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pps.Namespace, Name: pps.Name}}
+			if _, err := reconciler.Reconcile(context.TODO(), request); err != nil {
+				t.Fatalf("reconcile failed, err: %v", err)
+			}
+
+			latest, err := getLatestPersistentPodState(fakeClient, pps)
+			if err != nil {
+				t.Fatalf("get latest PersistentPodState failed, err: %v", err)
+			}
+			if !reflect.DeepEqual(latest.Status.PodStates, cs.expectPodStates) {
+				t.Fatalf("expect pod states %v, but got %v", cs.expectPodStates, latest.Status.PodStates)
+			}
+		})
+	}
+}
@@ -176,7 +176,9 @@ func (r *ReconcilePodProbeMarker) syncPodProbeMarker(ns, name string) error {
 		}
 		markers[probe.PodConditionType] = probe.MarkerPolicy
 	}
-	if utilfeature.DefaultFeatureGate.Enabled(features.EnablePodProbeMarkerOnServerless) && len(markers) != 0 {
+	// the serverless pods probed by kruise-manager are marked by the serverlesspodprobe controller
+	if utilfeature.DefaultFeatureGate.Enabled(features.EnablePodProbeMarkerOnServerless) &&
+		!utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerServerlessProber) && len(markers) != 0 {
 		for _, pod := range serverlessPods {
 			if err = r.markServerlessPod(pod, markers); err != nil {
 				klog.ErrorS(err, "Failed to marker serverless pod", "podProbeMarker", klog.KObj(ppm), "pod", klog.KObj(pod))
@@ -356,6 +358,7 @@ func (r *ReconcilePodProbeMarker) updateNodePodProbes(ppm *appsv1alpha1.PodProbe
 					Name:          fmt.Sprintf("%s#%s", ppm.Name, probe.Name),
 					ContainerName: probe.ContainerName,
 					Probe:         probe.Probe,
+					DerivedStates: probe.DerivedStates,
 				})
 			}
 			npp.Spec.PodProbes = append(npp.Spec.PodProbes, podProbe)
@@ -417,6 +420,7 @@ func setPodContainerProbes(podProbe *appsv1alpha1.PodProbe, probe appsv1alpha1.P
 		Name:          fmt.Sprintf("%s#%s", ppmName, probe.Name),
 		ContainerName: probe.ContainerName,
 		Probe:         probe.Probe,
+		DerivedStates: probe.DerivedStates,
 	}
 	for i, obj := range podProbe.Probes {
 		if obj.Name == newProbe.Name {
@@ -497,7 +501,7 @@ func (r *ReconcilePodProbeMarker) removePodProbeFromNodePodProbe(ppmNamespace, p
 		for i := range podProbe.Probes {
 			probe := podProbe.Probes[i]
 			// probe.Name -> podProbeMarker.Name#probe.Name
-			if !strings.Contains(probe.Name, fmt.Sprintf("%s#", ppmName)) {
+			if !strings.HasPrefix(probe.Name, fmt.Sprintf("%s#", ppmName)) {
 				newPodProbe.Probes = append(newPodProbe.Probes, probe)
 			}
 		}
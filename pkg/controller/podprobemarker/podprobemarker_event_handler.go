@@ -112,7 +112,8 @@ func (p *enqueueRequestForPod) Update(ctx context.Context, evt event.TypedUpdate
 			newInitialCondition.Status == corev1.ConditionTrue) || oldObj.Status.PodIP != newObj.Status.PodIP {
 			triggerReconcile = true
 		}
-	} else if utilfeature.DefaultFeatureGate.Enabled(features.EnablePodProbeMarkerOnServerless) {
+	} else if utilfeature.DefaultFeatureGate.Enabled(features.EnablePodProbeMarkerOnServerless) &&
+		!utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerServerlessProber) {
 		// The serverless pod probe results will patch to the pod condition field,
 		// so it needs to be processed by reconcile, which in turn will patch pod labels or annotations.
 		diff = diffPodConditions(oldObj.Status.Conditions, newObj.Status.Conditions)
@@ -124,6 +124,12 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to child budgets, then reconcile the grouping PodUnavailableBudget
+	err = c.Watch(source.Kind(mgr.GetCache(), &policyv1beta1.PodUnavailableBudget{}, handler.TypedEnqueueRequestsFromMapFunc(newGroupingPubMapFunc(mgr.GetClient()))))
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to Pod
 	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, newEnqueueRequestForPod(mgr.GetClient()))); err != nil {
 		return err
@@ -256,7 +262,8 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1be
 	}
 	if len(pods) == 0 {
 		r.recorder.Eventf(pub, corev1.EventTypeNormal, "NoPods", "No matching pods found")
-	} else {
+	} else if len(pub.Spec.ChildBudgets) == 0 {
+		// pods of grouping pub are related to their child budgets
 		// patch related-pub annotation in all pods of workload
 		if err = r.patchRelatedPubAnnotationInPod(pub, pods); err != nil {
 			klog.ErrorS(err, "PodUnavailableBudget patch pod annotation failed", "podUnavailableBudget", klog.KObj(pub))
@@ -265,7 +272,12 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1be
 	}
 
 	klog.V(3).InfoS("PodUnavailableBudget controller pods expectedCount", "podUnavailableBudget", klog.KObj(pub), "podCount", len(pods), "expectedCount", expectedCount)
-	desiredAvailable, err := r.getDesiredAvailableForPub(pub, expectedCount)
+	window, nextBoundary := pubcontrol.GetActiveScheduleWindow(pub, currentTime)
+	var activeSchedule string
+	if window != nil {
+		activeSchedule = window.Name
+	}
+	desiredAvailable, err := r.getDesiredAvailableForPub(pub, window, expectedCount)
 	if err != nil {
 		r.recorder.Eventf(pub, corev1.EventTypeWarning, "CalculateExpectedPodCountFailed", "Failed to calculate the number of expected pods: %v", err)
 		return nil, err
@@ -319,11 +331,19 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1be
 		var disruptedPods, unavailablePods map[string]metav1.Time
 		disruptedPods, unavailablePods, recheckTime = r.buildDisruptedAndUnavailablePods(pods, pubClone, currentTime)
 		currentAvailable := countAvailablePods(pods, disruptedPods, unavailablePods)
+		queue, granted, queueRecheckTime := r.buildDisruptionQueue(pods, pubClone, currentAvailable-desiredAvailable, currentTime)
+		if queueRecheckTime != nil && (recheckTime == nil || queueRecheckTime.Before(*recheckTime)) {
+			recheckTime = queueRecheckTime
+		}
 
 		start = time.Now()
-		updateErr := r.updatePubStatus(pubClone, currentAvailable, desiredAvailable, expectedCount, disruptedPods, unavailablePods)
+		updateErr := r.updatePubStatus(pubClone, currentAvailable, desiredAvailable, expectedCount, activeSchedule, queue, disruptedPods, unavailablePods)
 		costOfUpdate += time.Since(start)
 		if updateErr == nil {
+			for _, pod := range granted {
+				r.recorder.Eventf(pod, corev1.EventTypeNormal, "PubDisruptionGranted",
+					"A budget slot of PUB %s/%s is reserved for the disruption of pod, please retry it", pub.Namespace, pub.Name)
+			}
 			return nil
 		}
 		// update failed, and retry
@@ -336,6 +356,10 @@ func (r *ReconcilePodUnavailableBudget) syncPodUnavailableBudget(pub *policyv1be
 	if err != nil {
 		klog.ErrorS(err, "Failed to update PodUnavailableBudget status", "podUnavailableBudget", klog.KObj(pub))
 	}
+	// recalculate the budget when any of the schedule windows starts or ends
+	if nextBoundary != nil && (recheckTime == nil || nextBoundary.Before(*recheckTime)) {
+		recheckTime = nextBoundary
+	}
 	return recheckTime, err
 }
 
@@ -388,28 +412,40 @@ func countAvailablePods(pods []*corev1.Pod, disruptedPods, unavailablePods map[s
 	return
 }
 
-func (r *ReconcilePodUnavailableBudget) getDesiredAvailableForPub(pub *policyv1beta1.PodUnavailableBudget, expectedCount int32) (desiredAvailable int32, err error) {
-	if pub.Spec.MaxUnavailable != nil {
-		var maxUnavailable int
-		maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(pub.Spec.MaxUnavailable, int(expectedCount), true)
+// getDesiredAvailableForPub calculates desiredAvailable with the budget of active schedule window if any,
+// otherwise with the budget in pub spec. No pod can be unavailable during a freeze window.
+func (r *ReconcilePodUnavailableBudget) getDesiredAvailableForPub(pub *policyv1beta1.PodUnavailableBudget, window *policyv1beta1.PubScheduleWindow,
+	expectedCount int32) (desiredAvailable int32, err error) {
+	maxUnavailable, minAvailable := pub.Spec.MaxUnavailable, pub.Spec.MinAvailable
+	if window != nil {
+		if window.Freeze {
+			return expectedCount, nil
+		} else if window.MaxUnavailable != nil || window.MinAvailable != nil {
+			maxUnavailable, minAvailable = window.MaxUnavailable, window.MinAvailable
+		}
+	}
+
+	if maxUnavailable != nil {
+		var maxUnavailableValue int
+		maxUnavailableValue, err = intstr.GetScaledValueFromIntOrPercent(maxUnavailable, int(expectedCount), true)
 		if err != nil {
 			return
 		}
 
-		desiredAvailable = expectedCount - int32(maxUnavailable)
+		desiredAvailable = expectedCount - int32(maxUnavailableValue)
 		if desiredAvailable < 0 {
 			desiredAvailable = 0
 		}
-	} else if pub.Spec.MinAvailable != nil {
-		if pub.Spec.MinAvailable.Type == intstr.Int {
-			desiredAvailable = pub.Spec.MinAvailable.IntVal
-		} else if pub.Spec.MinAvailable.Type == intstr.String {
-			var minAvailable int
-			minAvailable, err = intstr.GetScaledValueFromIntOrPercent(pub.Spec.MinAvailable, int(expectedCount), true)
+	} else if minAvailable != nil {
+		if minAvailable.Type == intstr.Int {
+			desiredAvailable = minAvailable.IntVal
+		} else if minAvailable.Type == intstr.String {
+			var minAvailableValue int
+			minAvailableValue, err = intstr.GetScaledValueFromIntOrPercent(minAvailable, int(expectedCount), true)
 			if err != nil {
 				return
 			}
-			desiredAvailable = int32(minAvailable)
+			desiredAvailable = int32(minAvailableValue)
 		}
 	}
 	return
@@ -468,10 +504,74 @@ func (r *ReconcilePodUnavailableBudget) buildDisruptedAndUnavailablePods(pods []
 	return resultDisruptedPods, resultUnavailablePods, recheckTime
 }
 
+// buildDisruptionQueue removes the requests whose pod is gone, the waiting requests that exceed requestTimeout and
+// the granted requests that exceed grantTimeout, then grants the waiting requests in order with the free budget.
+// It returns the new queue, the pods newly granted and the time to recheck the queue.
+func (r *ReconcilePodUnavailableBudget) buildDisruptionQueue(pods []*corev1.Pod, pub *policyv1beta1.PodUnavailableBudget, freeBudget int32, currentTime time.Time) (
+	[]policyv1beta1.PubDisruptionRequest, []*corev1.Pod, *time.Time) {
+	if pub.Spec.DisruptionQueue == nil || len(pub.Status.DisruptionQueue) == 0 {
+		return nil, nil, nil
+	}
+	requestTimeout, grantTimeout := pubcontrol.GetDisruptionQueueTimeouts(pub)
+	activePods := make(map[string]*corev1.Pod, len(pods))
+	for _, pod := range pods {
+		if kubecontroller.IsPodActive(pod) {
+			activePods[pod.Name] = pod
+		}
+	}
+
+	var recheckTime *time.Time
+	updateRecheckTime := func(t time.Time) {
+		if recheckTime == nil || t.Before(*recheckTime) {
+			recheckTime = &t
+		}
+	}
+	queue := make([]policyv1beta1.PubDisruptionRequest, 0, len(pub.Status.DisruptionQueue))
+	for _, request := range pub.Status.DisruptionQueue {
+		if _, ok := activePods[request.PodName]; !ok {
+			continue
+		}
+		var expiration time.Time
+		if request.GrantedTime != nil {
+			expiration = request.GrantedTime.Add(grantTimeout)
+		} else {
+			expiration = request.RequestTime.Add(requestTimeout)
+		}
+		if !expiration.After(currentTime) {
+			klog.V(3).InfoS("Disruption request of PodUnavailableBudget expired", "podUnavailableBudget", klog.KObj(pub), "podName", request.PodName,
+				"granted", request.GrantedTime != nil)
+			continue
+		}
+		queue = append(queue, *request.DeepCopy())
+	}
+	pubcontrol.SortDisruptionQueue(pub, queue)
+
+	var granted []*corev1.Pod
+	freeBudget -= pubcontrol.CountGrantedDisruptions(queue)
+	for i := range queue {
+		request := &queue[i]
+		if request.GrantedTime == nil && freeBudget > 0 {
+			request.GrantedTime = &metav1.Time{Time: currentTime}
+			granted = append(granted, activePods[request.PodName])
+			freeBudget--
+		}
+		if request.GrantedTime != nil {
+			updateRecheckTime(request.GrantedTime.Add(grantTimeout))
+		} else {
+			updateRecheckTime(request.RequestTime.Add(requestTimeout))
+		}
+	}
+	if len(queue) == 0 {
+		queue = nil
+	}
+	return queue, granted, recheckTime
+}
+
 func (r *ReconcilePodUnavailableBudget) updatePubStatus(pub *policyv1beta1.PodUnavailableBudget, currentAvailable, desiredAvailable, expectedCount int32,
-	disruptedPods, unavailablePods map[string]metav1.Time) error {
+	activeSchedule string, disruptionQueue []policyv1beta1.PubDisruptionRequest, disruptedPods, unavailablePods map[string]metav1.Time) error {
 
-	unavailableAllowed := currentAvailable - desiredAvailable
+	// the budget slots reserved for granted requests are not allowed to be taken by others
+	unavailableAllowed := currentAvailable - desiredAvailable - pubcontrol.CountGrantedDisruptions(disruptionQueue)
 	if unavailableAllowed <= 0 {
 		unavailableAllowed = 0
 	}
@@ -480,6 +580,8 @@ func (r *ReconcilePodUnavailableBudget) updatePubStatus(pub *policyv1beta1.PodUn
 		pub.Status.DesiredAvailable == desiredAvailable &&
 		pub.Status.TotalReplicas == expectedCount &&
 		pub.Status.UnavailableAllowed == unavailableAllowed &&
+		pub.Status.ActiveSchedule == activeSchedule &&
+		apiequality.Semantic.DeepEqual(pub.Status.DisruptionQueue, disruptionQueue) &&
 		pub.Status.ObservedGeneration == pub.Generation &&
 		apiequality.Semantic.DeepEqual(pub.Status.DisruptedPods, disruptedPods) &&
 		apiequality.Semantic.DeepEqual(pub.Status.UnavailablePods, unavailablePods) {
@@ -491,6 +593,8 @@ func (r *ReconcilePodUnavailableBudget) updatePubStatus(pub *policyv1beta1.PodUn
 		DesiredAvailable:   desiredAvailable,
 		TotalReplicas:      expectedCount,
 		UnavailableAllowed: unavailableAllowed,
+		ActiveSchedule:     activeSchedule,
+		DisruptionQueue:    disruptionQueue,
 		DisruptedPods:      disruptedPods,
 		UnavailablePods:    unavailablePods,
 		ObservedGeneration: pub.Generation,
@@ -503,6 +607,6 @@ func (r *ReconcilePodUnavailableBudget) updatePubStatus(pub *policyv1beta1.PodUn
 		klog.ErrorS(err, "Added cache failed for PodUnavailableBudget", "podUnavailableBudget", klog.KObj(pub))
 	}
 	klog.V(3).InfoS("PodUnavailableBudget update status", "podUnavailableBudget", klog.KObj(pub), "disruptedPods", len(disruptedPods), "unavailablePods", len(unavailablePods),
-		"expectedCount", expectedCount, "desiredAvailable", desiredAvailable, "currentAvailable", currentAvailable, "unavailableAllowed", unavailableAllowed)
+		"expectedCount", expectedCount, "desiredAvailable", desiredAvailable, "currentAvailable", currentAvailable, "unavailableAllowed", unavailableAllowed, "activeSchedule", activeSchedule)
 	return nil
 }
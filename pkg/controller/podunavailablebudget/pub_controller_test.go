@@ -1304,10 +1304,171 @@ func TestPubReconcile(t *testing.T) {
 	}
 }
 
+func TestGroupingPubReconcile(t *testing.T) {
+	newChildPub := func(name string, totalReplicas int32) *policyv1beta1.PodUnavailableBudget {
+		pub := pubDemo.DeepCopy()
+		pub.Name = name
+		pub.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}}
+		pub.Spec.ProtectTotalReplicas = ptr.To(totalReplicas)
+		return pub
+	}
+	newPods := func(app string, num, notReady int) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for i := 0; i < num; i++ {
+			pod := podDemo.DeepCopy()
+			pod.Name = fmt.Sprintf("%s-%d", app, i)
+			pod.Labels = map[string]string{"app": app}
+			pod.Annotations = pubcontrol.SetPodRelatedPubAnnotation(nil, app)
+			if i < notReady {
+				podutil.GetPodReadyCondition(pod.Status).Status = corev1.ConditionFalse
+			}
+			pods = append(pods, pod)
+		}
+		return pods
+	}
+
+	pub := pubDemo.DeepCopy()
+	pub.Name = "pub-group"
+	pub.Spec.Selector = nil
+	pub.Spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.Int, IntVal: 3}
+	pub.Spec.ChildBudgets = []string{"pub-a", "pub-b", "pub-not-found"}
+	defer util.GlobalCache.Delete(pub)
+
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&policyv1beta1.PodUnavailableBudget{}).
+		WithObjects(pub, newChildPub("pub-a", 5), newChildPub("pub-b", 4))
+	pods := append(newPods("pub-a", 5, 1), newPods("pub-b", 4, 0)...)
+	for _, pod := range pods {
+		builder.WithObjects(pod)
+	}
+	fakeClient := builder.Build()
+	finder := &controllerfinder.ControllerFinder{Client: fakeClient}
+	pubcontrol.InitPubControl(fakeClient, finder, record.NewFakeRecorder(10))
+	reconciler := ReconcilePodUnavailableBudget{
+		Client:           fakeClient,
+		recorder:         record.NewFakeRecorder(10),
+		controllerFinder: finder,
+	}
+	if _, err := reconciler.syncPodUnavailableBudget(pub); err != nil {
+		t.Fatalf("sync PodUnavailableBudget failed: %s", err.Error())
+	}
+	newPub, err := getLatestPub(fakeClient, pub)
+	if err != nil {
+		t.Fatalf("getLatestPub failed: %s", err.Error())
+	}
+	expectStatus := policyv1beta1.PodUnavailableBudgetStatus{
+		UnavailableAllowed: 2,
+		CurrentAvailable:   8,
+		DesiredAvailable:   6,
+		TotalReplicas:      9,
+	}
+	if !isPubStatusEqual(expectStatus, newPub.Status) {
+		t.Fatalf("expect pub status(%s) but get(%s)", util.DumpJSON(expectStatus), util.DumpJSON(newPub.Status))
+	}
+	for _, pod := range pods {
+		newPod := &corev1.Pod{}
+		if err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), newPod); err != nil {
+			t.Fatalf("get pod failed: %s", err.Error())
+		}
+		if name := pubcontrol.GetPodRelatedPubName(newPod); name != pod.Labels["app"] {
+			t.Fatalf("expect pod %s related to pub %s, but got %s", pod.Name, pod.Labels["app"], name)
+		}
+	}
+}
+
+func TestBuildDisruptionQueue(t *testing.T) {
+	now := time.Now()
+	newPods := func(names ...string) []*corev1.Pod {
+		var pods []*corev1.Pod
+		for _, name := range names {
+			pod := podDemo.DeepCopy()
+			pod.Name = name
+			pods = append(pods, pod)
+		}
+		return pods
+	}
+	timeBefore := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(now.Add(-d))
+	}
+	grantedTime := timeBefore(30 * time.Second)
+	expiredGrantedTime := timeBefore(2 * time.Minute)
+
+	cases := []struct {
+		name          string
+		pods          []*corev1.Pod
+		queue         []policyv1beta1.PubDisruptionRequest
+		freeBudget    int32
+		expectQueue   []string
+		expectGranted []string
+	}{
+		{
+			name: "grant waiting requests in order with free budget",
+			pods: newPods("pod-a", "pod-b", "pod-c"),
+			queue: []policyv1beta1.PubDisruptionRequest{
+				{PodName: "pod-a", RequestTime: timeBefore(time.Second)},
+				{PodName: "pod-b", RequestTime: timeBefore(2 * time.Second)},
+				{PodName: "pod-c", RequestTime: timeBefore(3 * time.Second)},
+			},
+			freeBudget:    2,
+			expectQueue:   []string{"pod-c", "pod-b", "pod-a"},
+			expectGranted: []string{"pod-c", "pod-b"},
+		},
+		{
+			name: "granted requests take the free budget",
+			pods: newPods("pod-a", "pod-b"),
+			queue: []policyv1beta1.PubDisruptionRequest{
+				{PodName: "pod-a", RequestTime: timeBefore(time.Minute), GrantedTime: &grantedTime},
+				{PodName: "pod-b", RequestTime: timeBefore(time.Second)},
+			},
+			freeBudget:  1,
+			expectQueue: []string{"pod-a", "pod-b"},
+		},
+		{
+			name: "remove expired requests and requests of pods not found",
+			pods: newPods("pod-a", "pod-b", "pod-c"),
+			queue: []policyv1beta1.PubDisruptionRequest{
+				{PodName: "pod-a", RequestTime: timeBefore(3 * time.Minute), GrantedTime: &expiredGrantedTime},
+				{PodName: "pod-b", RequestTime: timeBefore(20 * time.Minute)},
+				{PodName: "pod-c", RequestTime: timeBefore(time.Second)},
+				{PodName: "pod-d", RequestTime: timeBefore(time.Second)},
+			},
+			freeBudget:    1,
+			expectQueue:   []string{"pod-c"},
+			expectGranted: []string{"pod-c"},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pub := pubDemo.DeepCopy()
+			pub.Spec.DisruptionQueue = &policyv1beta1.PubDisruptionQueue{}
+			pub.Status.DisruptionQueue = cs.queue
+			reconciler := ReconcilePodUnavailableBudget{recorder: record.NewFakeRecorder(10)}
+			queue, granted, recheckTime := reconciler.buildDisruptionQueue(cs.pods, pub, cs.freeBudget, now)
+			var queueNames, grantedNames []string
+			for _, request := range queue {
+				queueNames = append(queueNames, request.PodName)
+			}
+			for _, pod := range granted {
+				grantedNames = append(grantedNames, pod.Name)
+			}
+			if !reflect.DeepEqual(queueNames, cs.expectQueue) {
+				t.Fatalf("expect queue %v, but got %v", cs.expectQueue, queueNames)
+			}
+			if !reflect.DeepEqual(grantedNames, cs.expectGranted) {
+				t.Fatalf("expect granted %v, but got %v", cs.expectGranted, grantedNames)
+			}
+			if recheckTime == nil {
+				t.Fatalf("expect recheck time for the queue")
+			}
+		})
+	}
+}
+
 func TestDesiredAvailableForPub(t *testing.T) {
 	cases := []struct {
 		name             string
 		getPub           func() *policyv1beta1.PodUnavailableBudget
+		window           *policyv1beta1.PubScheduleWindow
 		totalReplicas    int32
 		desiredAvailable int32
 	}{
@@ -1324,12 +1485,44 @@ func TestDesiredAvailableForPub(t *testing.T) {
 			totalReplicas:    15,
 			desiredAvailable: 13,
 		},
+		{
+			name: "DesiredAvailableForPub, window minAvailable 80% overrides maxUnavailable 10%, total 15",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				demo := pubDemo.DeepCopy()
+				demo.Spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "10%"}
+				return demo
+			},
+			window: &policyv1beta1.PubScheduleWindow{
+				Name:         "night",
+				MinAvailable: &intstr.IntOrString{Type: intstr.String, StrVal: "80%"},
+			},
+			totalReplicas:    15,
+			desiredAvailable: 12,
+		},
+		{
+			name: "DesiredAvailableForPub, window without budget, total 15",
+			getPub: func() *policyv1beta1.PodUnavailableBudget {
+				demo := pubDemo.DeepCopy()
+				demo.Spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "10%"}
+				return demo
+			},
+			window:           &policyv1beta1.PubScheduleWindow{Name: "empty"},
+			totalReplicas:    15,
+			desiredAvailable: 13,
+		},
+		{
+			name:             "DesiredAvailableForPub, freeze window, total 15",
+			getPub:           func() *policyv1beta1.PodUnavailableBudget { return pubDemo.DeepCopy() },
+			window:           &policyv1beta1.PubScheduleWindow{Name: "freeze", Freeze: true},
+			totalReplicas:    15,
+			desiredAvailable: 15,
+		},
 	}
 
 	rec := ReconcilePodUnavailableBudget{}
 	for _, cs := range cases {
 		t.Run(cs.name, func(t *testing.T) {
-			expect, _ := rec.getDesiredAvailableForPub(cs.getPub(), cs.totalReplicas)
+			expect, _ := rec.getDesiredAvailableForPub(cs.getPub(), cs.window, cs.totalReplicas)
 			if expect != cs.desiredAvailable {
 				t.Fatalf("expect %d, but get %d", cs.desiredAvailable, expect)
 			}
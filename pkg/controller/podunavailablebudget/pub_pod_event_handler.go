@@ -184,6 +184,29 @@ func isPodAvailableChanged(oldPod, newPod *corev1.Pod, pub *policyv1beta1.PodUna
 	return false, enqueueDelayTime
 }
 
+// newGroupingPubMapFunc maps a child budget to the grouping PodUnavailableBudget whose childBudgets contains it,
+// so that the grouping pub is recalculated once the status of child budget changes.
+func newGroupingPubMapFunc(c client.Client) handler.TypedMapFunc[*policyv1beta1.PodUnavailableBudget, reconcile.Request] {
+	return func(ctx context.Context, child *policyv1beta1.PodUnavailableBudget) []reconcile.Request {
+		pubList := &policyv1beta1.PodUnavailableBudgetList{}
+		if err := c.List(ctx, pubList, &client.ListOptions{Namespace: child.Namespace}, utilclient.DisableDeepCopy); err != nil {
+			klog.ErrorS(err, "Failed to list PodUnavailableBudgets for child budget", "podUnavailableBudget", klog.KObj(child))
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range pubList.Items {
+			pub := &pubList.Items[i]
+			for _, name := range pub.Spec.ChildBudgets {
+				if name == child.Name {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pub.Namespace, Name: pub.Name}})
+					break
+				}
+			}
+		}
+		return requests
+	}
+}
+
 var _ handler.EventHandler = &SetEnqueueRequestForPUB{}
 
 type SetEnqueueRequestForPUB struct {
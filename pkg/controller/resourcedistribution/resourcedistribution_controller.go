@@ -23,15 +23,20 @@ import (
 	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/csaupgrade"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -41,9 +46,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	ctrlUtil "github.com/openkruise/kruise/pkg/controller/util"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	"github.com/openkruise/kruise/pkg/util/configuration"
 	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	"github.com/openkruise/kruise/pkg/util/ratelimiter"
@@ -127,6 +134,16 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		}
 	}
 
+	// the resources in ResourceDistribution_Resource_WhiteList are watched when they are distributed,
+	// so that the white list changed after startup takes effect without restarting.
+	if rd, ok := r.(*ReconcileResourceDistribution); ok {
+		ownerHandler := handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &appsv1beta1.ResourceDistribution{}, handler.OnlyControllerOwner())
+		rd.watchResource = func(gvk schema.GroupVersionKind) error {
+			_, err := ctrlUtil.AddWatcherDynamically(mgr, c, ownerHandler, gvk, "ResourceDistribution")
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -136,14 +153,19 @@ var _ reconcile.Reconciler = &ReconcileResourceDistribution{}
 type ReconcileResourceDistribution struct {
 	client.Client
 	scheme *runtime.Scheme
+	// watchResource watches the kind in ResourceDistribution_Resource_WhiteList that is distributed
+	watchResource func(gvk schema.GroupVersionKind) error
 }
 
 //+kubebuilder:rbac:groups=apps.kruise.io,resources=resourcedistributions,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=apps.kruise.io,resources=resourcedistributions/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=apps.kruise.io,resources=resourcedistributions/finalizers,verbs=update
 //+kubebuilder:rbac:groups="core",resources=namespaces,verbs=get;list;watch;
-//+kubebuilder:rbac:groups="core",resources=configmaps,verbs=get;list;watch;create;update;delete
-//+kubebuilder:rbac:groups="core",resources=secrets,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="core",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="core",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="core",resources=limitranges,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="core",resources=resourcequotas,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="networking.k8s.io",resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -178,6 +200,13 @@ func (r *ReconcileResourceDistribution) doReconcile(distributor *appsv1beta1.Res
 		klog.ErrorS(errs.ToAggregate(), "DeserializeResource error", "resourceDistribution", klog.KObj(distributor))
 		return reconcile.Result{}, nil // no need to retry
 	}
+	if supported, err := r.ensureResourceWatched(resource); err != nil {
+		return reconcile.Result{}, err
+	} else if !supported {
+		klog.InfoS("Skipped distributing resource whose kind is not supported", "resourceDistribution", klog.KObj(distributor),
+			"groupKind", resource.GetObjectKind().GroupVersionKind().GroupKind())
+		return reconcile.Result{}, nil
+	}
 
 	matchedNamespaces, unmatchedNamespaces, err := listNamespacesForDistributor(r.Client, &distributor.Spec.Targets)
 	if err != nil {
@@ -219,6 +248,27 @@ func (r *ReconcileResourceDistribution) distributeResource(distributor *appsv1be
 			}
 		}
 
+		// render the per-namespace values into resource if it is templated
+		resource, resourceHashCode := resource, resourceHashCode
+		if distributor.Spec.Template != nil {
+			if getNSErr != nil {
+				return &UnexpectedError{
+					err:         getNSErr,
+					namespace:   namespace,
+					conditionID: GetConditionID,
+				}
+			}
+			var renderErr error
+			if resource, resourceHashCode, renderErr = renderResource(distributor, resource, ns); renderErr != nil {
+				klog.ErrorS(renderErr, "Error occurred when rendering resource for namespace", "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
+				return &UnexpectedError{
+					err:         renderErr,
+					namespace:   namespace,
+					conditionID: CreateConditionID,
+				}
+			}
+		}
+
 		// 1. try to fetch existing old resource
 		oldResource := &unstructured.Unstructured{}
 		oldResource.SetGroupVersionKind(resource.GetObjectKind().GroupVersionKind())
@@ -234,15 +284,18 @@ func (r *ReconcileResourceDistribution) distributeResource(distributor *appsv1be
 
 		// 2. if resource doesn't exist, create resource;
 		if getErr != nil && errors.IsNotFound(getErr) {
-			newResource := makeResourceObject(distributor, namespace, resource, resourceHashCode, nil)
-			if createErr := r.Client.Create(context.TODO(), newResource.(client.Object)); createErr != nil {
-				klog.ErrorS(createErr, "Error occurred when creating resource in namespace", "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
+			if distributor.Spec.SyncPolicy == appsv1beta1.ObserveResourceDistributionSyncPolicyType {
 				return &UnexpectedError{
-					err:         createErr,
+					err:         fmt.Errorf("resource does not exist"),
 					namespace:   namespace,
-					conditionID: CreateConditionID,
+					conditionID: DriftConditionID,
 				}
 			}
+			newResource := makeResourceObject(distributor, namespace, resource, resourceHashCode, nil)
+			if createErr := r.applyResource(distributor, newResource.(*unstructured.Unstructured)); createErr != nil {
+				klog.ErrorS(createErr, "Error occurred when creating resource in namespace", "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
+				return newApplyError(createErr, namespace, CreateConditionID)
+			}
 			klog.V(3).InfoS("ResourceDistribution created resource in namespace", "resourceDistribution", klog.KObj(distributor), "resourceKind", resourceKind, "resourceName", resourceName, "namespace", namespace)
 			return nil
 		}
@@ -258,25 +311,88 @@ func (r *ReconcileResourceDistribution) distributeResource(distributor *appsv1be
 		}
 
 		// 4. check whether resource need to update
-		if needToUpdate(oldResource, utils.ConvertToUnstructured(resource)) {
-			newResource := makeResourceObject(distributor, namespace, resource, resourceHashCode, oldResource)
-			if updateErr := r.Client.Update(context.TODO(), newResource.(client.Object)); updateErr != nil {
-				klog.ErrorS(updateErr, "Error occurred when updating resource in namespace", "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
+		newResource := makeResourceObject(distributor, namespace, resource, resourceHashCode, nil).(*unstructured.Unstructured)
+		if isDrifted(oldResource, newResource) {
+			switch distributor.Spec.SyncPolicy {
+			case appsv1beta1.CreateOnlyResourceDistributionSyncPolicyType, appsv1beta1.ObserveResourceDistributionSyncPolicyType:
+				klog.V(3).InfoS("Resource drifted in namespace", "resourceKind", resourceKind, "resourceName", resourceName, "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
 				return &UnexpectedError{
-					err:         updateErr,
+					err:         fmt.Errorf("resource differs from spec.resource"),
+					namespace:   namespace,
+					conditionID: DriftConditionID,
+				}
+			}
+			// the fields of the resource distributed before server-side apply are owned by the legacy field manager,
+			// which must be taken over, otherwise they will be regarded as conflicts.
+			if upgradeErr := r.upgradeManagedFields(oldResource); upgradeErr != nil {
+				klog.ErrorS(upgradeErr, "Error occurred when upgrading managed fields of resource in namespace", "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
+				return &UnexpectedError{
+					err:         upgradeErr,
 					namespace:   namespace,
 					conditionID: UpdateConditionID,
 				}
 			}
+			if updateErr := r.applyResource(distributor, newResource); updateErr != nil {
+				klog.ErrorS(updateErr, "Error occurred when updating resource in namespace", "namespace", namespace, "resourceDistribution", klog.KObj(distributor))
+				return newApplyError(updateErr, namespace, UpdateConditionID)
+			}
 			klog.V(3).InfoS("ResourceDistribution updated for namespaces", "resourceDistribution", klog.KObj(distributor), "resourceKind", resourceKind, "resourceName", resourceName, "namespace", namespace)
 		}
 		return nil
 	})
 }
 
+// applyResource creates or updates the resource by server-side apply, the fields set by others, e.g. the tenants
+// of the namespace, are kept. In Enforce policy, the ownership of the fields in Resource that are changed by others
+// is taken back, otherwise the conflicts with them are reported instead of being overwritten.
+func (r *ReconcileResourceDistribution) applyResource(distributor *appsv1beta1.ResourceDistribution, resource *unstructured.Unstructured) error {
+	resource.SetManagedFields(nil)
+	resource.SetResourceVersion("")
+	resource.SetUID("")
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if isEnforceSyncPolicy(distributor) {
+		opts = append(opts, client.ForceOwnership)
+	}
+	return r.Client.Patch(context.TODO(), resource, client.Apply, opts...)
+}
+
+func isEnforceSyncPolicy(distributor *appsv1beta1.ResourceDistribution) bool {
+	return distributor.Spec.SyncPolicy == "" || distributor.Spec.SyncPolicy == appsv1beta1.EnforceResourceDistributionSyncPolicyType
+}
+
+// upgradeManagedFields moves the fields owned by the legacy field manager of client-side update to FieldManager.
+func (r *ReconcileResourceDistribution) upgradeManagedFields(resource *unstructured.Unstructured) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(resource, sets.New(legacyFieldManager), FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return r.Client.Patch(context.TODO(), resource, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// newApplyError records the field ownership conflicts of server-side apply as ConflictOccurred
+func newApplyError(err error, namespace string, conditionID int) *UnexpectedError {
+	if isFieldManagerConflict(err) {
+		return &UnexpectedError{
+			err:         fmt.Errorf("conflict with the fields managed by others: %v", err),
+			namespace:   namespace,
+			conditionID: ConflictConditionID,
+		}
+	}
+	return &UnexpectedError{
+		err:         err,
+		namespace:   namespace,
+		conditionID: conditionID,
+	}
+}
+
 func (r *ReconcileResourceDistribution) cleanResource(distributor *appsv1beta1.ResourceDistribution,
 	unmatchedNamespaces []string, resource runtime.Object) (int32, []*UnexpectedError) {
 
+	// the resources are never deleted in Observe policy
+	if distributor.Spec.SyncPolicy == appsv1beta1.ObserveResourceDistributionSyncPolicyType {
+		return 0, nil
+	}
+
 	resourceName := utils.ConvertToUnstructured(resource).GetName()
 	resourceKind := resource.GetObjectKind().GroupVersionKind().Kind
 	return syncItSlowly(unmatchedNamespaces, 1, func(namespace string) *UnexpectedError {
@@ -342,7 +458,8 @@ func (r *ReconcileResourceDistribution) handleErrors(errLists ...[]*UnexpectedEr
 			continue
 		}
 		switch conditions[i].Type {
-		case appsv1beta1.ResourceDistributionConflictOccurred, appsv1beta1.ResourceDistributionNamespaceNotExists:
+		case appsv1beta1.ResourceDistributionConflictOccurred, appsv1beta1.ResourceDistributionNamespaceNotExists,
+			appsv1beta1.ResourceDistributionResourceDrifted:
 		default:
 			errList = append(errList, field.InternalError(field.NewPath(string(conditions[i].Type)), fmt.Errorf(conditions[i].Reason)))
 		}
@@ -373,9 +490,40 @@ func (r *ReconcileResourceDistribution) SetupWithManager(mgr ctrl.Manager) error
 		Complete(r)
 }
 
+// ensureResourceWatched checks whether the kind of resource is supported by default or in ResourceDistribution_Resource_WhiteList,
+// which is read at the same time as the webhook does, and watches the latter ones.
+func (r *ReconcileResourceDistribution) ensureResourceWatched(resource runtime.Object) (bool, error) {
+	gk := resource.GetObjectKind().GroupVersionKind().GroupKind()
+	for _, obj := range supportedResourceWatchObjects() {
+		if gvk, err := apiutil.GVKForObject(obj, r.scheme); err == nil && gvk.GroupKind() == gk {
+			return true, nil
+		}
+	}
+
+	whiteList, err := configuration.GetResourceDistributionWhiteList(r.Client)
+	if err != nil {
+		return false, err
+	}
+	for _, gvk := range whiteList.Resources {
+		if gvk.GroupKind() != gk {
+			continue
+		}
+		if r.watchResource != nil {
+			if err := r.watchResource(gvk); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
 func supportedResourceWatchObjects() []client.Object {
 	return []client.Object{
 		&corev1.Secret{},
 		&corev1.ConfigMap{},
+		&corev1.LimitRange{},
+		&corev1.ResourceQuota{},
+		&networkingv1.NetworkPolicy{},
 	}
 }
@@ -18,17 +18,27 @@ package resourcedistribution
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/configuration"
 	utils "github.com/openkruise/kruise/pkg/webhook/resourcedistribution/validating"
 )
 
@@ -41,6 +51,9 @@ func init() {
 	scheme = runtime.NewScheme()
 	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
 	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(networkingv1.AddToScheme(scheme))
+	utilruntime.Must(rbacv1.AddToScheme(scheme))
+	reconcileHandler.scheme = scheme
 }
 
 func TestDoReconcile(t *testing.T) {
@@ -96,6 +109,293 @@ func TestDoReconcile(t *testing.T) {
 	}
 }
 
+func TestDoReconcileWhiteListChanged(t *testing.T) {
+	const resourceJSON = `{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind": "Role",
+		"metadata": {
+			"name": "reader"
+		},
+		"rules": []
+	}`
+	distributor := buildResourceDistribution(runtime.RawExtension{Raw: []byte(resourceJSON)})
+	var watched []schema.GroupVersionKind
+	reconcileHandler.watchResource = func(gvk schema.GroupVersionKind) error {
+		watched = append(watched, gvk)
+		return nil
+	}
+	defer func() { reconcileHandler.watchResource = nil }()
+
+	// Role is not in the white list, so it is neither distributed nor watched
+	makeClientEnvironment(distributor)
+	if _, err := reconcileHandler.doReconcile(distributor); err != nil {
+		t.Fatalf("failed to test doReconcile, err %v", err)
+	}
+	if err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "reader"}, &rbacv1.Role{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected role not distributed, err %v", err)
+	}
+	if len(watched) != 0 {
+		t.Fatalf("expected no watch, got %v", watched)
+	}
+
+	// Role is added to the white list after startup
+	whiteList := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: configuration.KruiseConfigurationName},
+		Data:       map[string]string{configuration.RDResourceWhiteList: `{"resources":[{"group":"rbac.authorization.k8s.io","version":"v1","kind":"Role"}]}`},
+	}
+	makeClientEnvironment(distributor, whiteList)
+	if _, err := reconcileHandler.doReconcile(distributor); err != nil {
+		t.Fatalf("failed to test doReconcile, err %v", err)
+	}
+	if err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "reader"}, &rbacv1.Role{}); err != nil {
+		t.Fatalf("expected role distributed, err %v", err)
+	}
+	if len(watched) != 1 || watched[0] != rbacv1.SchemeGroupVersion.WithKind("Role") {
+		t.Fatalf("expected Role watched, got %v", watched)
+	}
+}
+
+func TestDoReconcileNetworkPolicy(t *testing.T) {
+	const resourceJSON = `{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind": "NetworkPolicy",
+		"metadata": {
+			"name": "deny-all"
+		},
+		"spec": {
+			"podSelector": {},
+			"policyTypes": ["Ingress"]
+		}
+	}`
+	distributor := buildResourceDistribution(runtime.RawExtension{Raw: []byte(resourceJSON)})
+	// the tenant has labeled the policy, which should be kept by apply
+	existing := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deny-all",
+			Namespace: "ns-1",
+			Labels:    map[string]string{"tenant": "team-a"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(distributor, distributor.GroupVersionKind()),
+			},
+		},
+	}
+	makeClientEnvironment(distributor, existing)
+
+	if _, err := reconcileHandler.doReconcile(distributor); err != nil {
+		t.Fatalf("failed to test doReconcile, err %v", err)
+	}
+	for _, namespace := range []string{"ns-1", "ns-2", "ns-3", "ns-5"} {
+		policy := &networkingv1.NetworkPolicy{}
+		if err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "deny-all"}, policy); err != nil {
+			t.Fatalf("failed to get network policy in namespace %s, err %v", namespace, err)
+		}
+		if !isControlledByDistributor(policy, distributor) {
+			t.Fatalf("network policy in namespace %s is not controlled by distributor", namespace)
+		}
+		if len(policy.Spec.PolicyTypes) != 1 || policy.Spec.PolicyTypes[0] != networkingv1.PolicyTypeIngress {
+			t.Fatalf("unexpected spec of network policy in namespace %s: %v", namespace, policy.Spec)
+		}
+	}
+	policy := &networkingv1.NetworkPolicy{}
+	_ = reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "deny-all"}, policy)
+	if policy.Labels["tenant"] != "team-a" {
+		t.Fatalf("expected label of tenant to be kept, got %v", policy.Labels)
+	}
+}
+
+func TestDoReconcileTemplate(t *testing.T) {
+	const resourceJSON = `{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind": "NetworkPolicy",
+		"metadata": {
+			"name": "allow-tenant",
+			"labels": {"environment": "{{ index .Namespace.Labels \"environment\" }}"}
+		},
+		"spec": {
+			"podSelector": {},
+			"ingress": [{"from": [{"ipBlock": {"cidr": "{{ .Values.cidr }}"}}]}]
+		}
+	}`
+	distributor := buildResourceDistribution(runtime.RawExtension{Raw: []byte(resourceJSON)})
+	distributor.Spec.Template = &appsv1beta1.ResourceDistributionTemplate{
+		Values: map[string]string{"cidr": "10.0.0.0/8"},
+		NamespaceValues: []appsv1beta1.ResourceDistributionNamespaceValues{
+			{Name: "ns-1", Values: map[string]string{"cidr": "10.1.0.0/16"}},
+		},
+	}
+	makeClientEnvironment(distributor)
+
+	if _, err := reconcileHandler.doReconcile(distributor); err != nil {
+		t.Fatalf("failed to test doReconcile, err %v", err)
+	}
+	expected := map[string][2]string{
+		"ns-1": {"10.1.0.0/16", "develop"},
+		"ns-2": {"10.0.0.0/8", "develop"},
+		"ns-5": {"10.0.0.0/8", "test"},
+	}
+	hashCodes := sets.New[string]()
+	for namespace, values := range expected {
+		policy := &networkingv1.NetworkPolicy{}
+		if err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "allow-tenant"}, policy); err != nil {
+			t.Fatalf("failed to get network policy in namespace %s, err %v", namespace, err)
+		}
+		if cidr := policy.Spec.Ingress[0].From[0].IPBlock.CIDR; cidr != values[0] {
+			t.Fatalf("expected cidr %s in namespace %s, got %s", values[0], namespace, cidr)
+		}
+		if env := policy.Labels["environment"]; env != values[1] {
+			t.Fatalf("expected environment label %s in namespace %s, got %s", values[1], namespace, env)
+		}
+		hashCodes.Insert(policy.Annotations[utils.ResourceHashCodeAnnotation])
+	}
+	if hashCodes.Len() != len(expected) {
+		t.Fatalf("expected hash code to differ among namespaces, got %v", sets.List(hashCodes))
+	}
+
+	// the resource is re-rendered when the values of namespace changed
+	distributor.Spec.Template.NamespaceValues[0].Values["cidr"] = "10.2.0.0/16"
+	if _, err := reconcileHandler.doReconcile(distributor); err != nil {
+		t.Fatalf("failed to test doReconcile, err %v", err)
+	}
+	policy := &networkingv1.NetworkPolicy{}
+	_ = reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "allow-tenant"}, policy)
+	if cidr := policy.Spec.Ingress[0].From[0].IPBlock.CIDR; cidr != "10.2.0.0/16" {
+		t.Fatalf("expected cidr to be re-rendered, got %s", cidr)
+	}
+}
+
+func TestDoReconcileSyncPolicy(t *testing.T) {
+	cases := []struct {
+		name            string
+		syncPolicy      appsv1beta1.ResourceDistributionSyncPolicyType
+		expectDrifted   []string
+		expectCreated   bool
+		expectReverted  bool
+		expectCleanedUp bool
+	}{
+		{
+			name:            "Enforce reverts the drifted resource",
+			syncPolicy:      appsv1beta1.EnforceResourceDistributionSyncPolicyType,
+			expectCreated:   true,
+			expectReverted:  true,
+			expectCleanedUp: true,
+		},
+		{
+			name:            "CreateOnly never overwrites the existing resource",
+			syncPolicy:      appsv1beta1.CreateOnlyResourceDistributionSyncPolicyType,
+			expectDrifted:   []string{"ns-1"},
+			expectCreated:   true,
+			expectCleanedUp: true,
+		},
+		{
+			name:          "Observe reports drift only",
+			syncPolicy:    appsv1beta1.ObserveResourceDistributionSyncPolicyType,
+			expectDrifted: []string{"ns-1", "ns-2", "ns-3", "ns-5"},
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			distributor := buildResourceDistributionWithSecret()
+			distributor.Spec.SyncPolicy = cs.syncPolicy
+			// the secret in ns-1 has been modified by tenant
+			makeClientEnvironment(distributor)
+
+			if _, err := reconcileHandler.doReconcile(distributor); err != nil {
+				t.Fatalf("failed to test doReconcile, err %v", err)
+			}
+			rd := &appsv1beta1.ResourceDistribution{}
+			if err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Name: distributor.Name}, rd); err != nil {
+				t.Fatalf("failed to get distributor, err %v", err)
+			}
+			condition := rd.Status.Conditions[DriftConditionID]
+			if condition.Type != appsv1beta1.ResourceDistributionResourceDrifted {
+				t.Fatalf("unexpected condition type %s", condition.Type)
+			}
+			if drifted := sets.List(sets.New(condition.FailedNamespaces...)); !reflect.DeepEqual(drifted, sets.List(sets.New(cs.expectDrifted...))) {
+				t.Fatalf("expected drifted namespaces %v, got %v", cs.expectDrifted, drifted)
+			}
+
+			secret := &corev1.Secret{}
+			_ = reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-1", Name: "test-secret-1"}, secret)
+			if reverted := string(secret.Data["test"]) != ""; reverted != cs.expectReverted {
+				t.Fatalf("expected reverted %v, got data %v", cs.expectReverted, secret.Data)
+			}
+			err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-2", Name: "test-secret-1"}, &corev1.Secret{})
+			if created := err == nil; created != cs.expectCreated {
+				t.Fatalf("expected created %v, got err %v", cs.expectCreated, err)
+			}
+			err = reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-4", Name: "test-secret-1"}, &corev1.Secret{})
+			if cleanedUp := errors.IsNotFound(err); cleanedUp != cs.expectCleanedUp {
+				t.Fatalf("expected cleaned up %v, got err %v", cs.expectCleanedUp, err)
+			}
+		})
+	}
+}
+
+func TestDoReconcileApplyConflict(t *testing.T) {
+	cases := []struct {
+		name           string
+		syncPolicy     appsv1beta1.ResourceDistributionSyncPolicyType
+		expectConflict bool
+	}{
+		{
+			name:       "Enforce takes back the field changed by another field manager",
+			syncPolicy: appsv1beta1.EnforceResourceDistributionSyncPolicyType,
+		},
+		{
+			name:           "CreateOnly reports the conflict",
+			syncPolicy:     appsv1beta1.CreateOnlyResourceDistributionSyncPolicyType,
+			expectConflict: true,
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			distributor := buildResourceDistributionWithSecret()
+			distributor.Spec.SyncPolicy = cs.syncPolicy
+			// another field manager "tenant" owns .data.test of the secret in ns-2
+			conflictApply := func(ctx context.Context, c client.WithWatch, obj client.Object, force bool) error {
+				if obj.GetNamespace() == "ns-2" && !force {
+					return &errors.StatusError{ErrStatus: metav1.Status{
+						Status: metav1.StatusFailure,
+						Code:   409,
+						Reason: metav1.StatusReasonConflict,
+						Details: &metav1.StatusDetails{
+							Causes: []metav1.StatusCause{{
+								Type:    metav1.CauseTypeFieldManagerConflict,
+								Message: `conflict with "tenant"`,
+								Field:   ".data.test",
+							}},
+						},
+						Message: "Apply failed with 1 conflict",
+					}}
+				}
+				return emulateApply(ctx, c, obj, force)
+			}
+			makeClientEnvironmentWithApply(conflictApply, distributor)
+
+			_, _ = reconcileHandler.doReconcile(distributor)
+			rd := &appsv1beta1.ResourceDistribution{}
+			if err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Name: distributor.Name}, rd); err != nil {
+				t.Fatalf("failed to get distributor, err %v", err)
+			}
+			condition := rd.Status.Conditions[ConflictConditionID]
+			if cs.expectConflict {
+				if condition.Status != appsv1beta1.ResourceDistributionConditionTrue || len(condition.FailedNamespaces) != 1 || condition.FailedNamespaces[0] != "ns-2" {
+					t.Fatalf("unexpected conflict condition %v", condition)
+				}
+			} else if condition.Status == appsv1beta1.ResourceDistributionConditionTrue {
+				t.Fatalf("expected no conflict, got %v", condition)
+			}
+			if rd.Status.Conditions[CreateConditionID].Status == appsv1beta1.ResourceDistributionConditionTrue {
+				t.Fatalf("apply conflict should not be reported as create failure, got %v", rd.Status.Conditions[CreateConditionID])
+			}
+			err := reconcileHandler.Client.Get(context.TODO(), types.NamespacedName{Namespace: "ns-2", Name: "test-secret-1"}, &corev1.Secret{})
+			if distributed := err == nil; distributed == cs.expectConflict {
+				t.Fatalf("expected distributed %v, got err %v", !cs.expectConflict, err)
+			}
+		})
+	}
+}
+
 func buildResourceDistributionWithSecret() *appsv1beta1.ResourceDistribution {
 	const resourceJSON = `{
 		"apiVersion": "v1",
@@ -232,8 +532,52 @@ func makeEnvironment() []runtime.Object {
 }
 
 func makeClientEnvironment(addition ...runtime.Object) {
+	makeClientEnvironmentWithApply(emulateApply, addition...)
+}
+
+func makeClientEnvironmentWithApply(apply func(context.Context, client.WithWatch, client.Object, bool) error, addition ...runtime.Object) {
 	env := append(makeEnvironment(), addition...)
 	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(env...).
-		WithStatusSubresource(&appsv1beta1.ResourceDistribution{}).Build()
+		WithStatusSubresource(&appsv1beta1.ResourceDistribution{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() == types.ApplyPatchType {
+					patchOptions := &client.PatchOptions{}
+					patchOptions.ApplyOptions(opts)
+					return apply(ctx, c, obj, patchOptions.Force != nil && *patchOptions.Force)
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
 	reconcileHandler.Client = fakeClient
 }
+
+// emulateApply emulates server-side apply, which is not supported by the fake client,
+// by creating the object or updating it with the labels and annotations set by others kept.
+func emulateApply(ctx context.Context, c client.WithWatch, obj client.Object, _ bool) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if errors.IsNotFound(err) {
+			return c.Create(ctx, obj)
+		}
+		return err
+	}
+	labels, annotations := existing.GetLabels(), existing.GetAnnotations()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range obj.GetLabels() {
+		labels[k] = v
+	}
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
@@ -73,11 +73,18 @@ func (p *enqueueRequestForNamespace) addNamespace(q workqueue.TypedRateLimitingI
 func (p *enqueueRequestForNamespace) updateNamespace(q workqueue.TypedRateLimitingInterface[reconcile.Request], objOld, objNew runtime.Object) {
 	namespaceOld, okOld := objOld.(*corev1.Namespace)
 	namespaceNew, okNew := objNew.(*corev1.Namespace)
-	if !okOld || !okNew || reflect.DeepEqual(namespaceNew.ObjectMeta.Labels, namespaceOld.ObjectMeta.Labels) {
+	if !okOld || !okNew {
 		return
 	}
-	p.addNamespace(q, objNew, matchViaLabelSelector)
-	p.addNamespace(q, objOld, matchViaLabelSelector)
+	labelsChanged := !reflect.DeepEqual(namespaceNew.ObjectMeta.Labels, namespaceOld.ObjectMeta.Labels)
+	if labelsChanged {
+		p.addNamespace(q, objNew, matchViaLabelSelector)
+		p.addNamespace(q, objOld, matchViaLabelSelector)
+	}
+	// the labels and annotations of namespace may be rendered into the templated resource
+	if labelsChanged || !reflect.DeepEqual(namespaceNew.ObjectMeta.Annotations, namespaceOld.ObjectMeta.Annotations) {
+		p.addNamespace(q, objNew, matchTemplatedViaTargets)
+	}
 }
 
 // getNamespaceMatchedResourceDistributions returns all matched ResourceDistributions via labelSelector
@@ -27,6 +27,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 )
 
 var (
@@ -72,6 +74,18 @@ func TestNamespaceEventHandler(t *testing.T) {
 	namespaceDemo2.SetName("ns-8")
 	namespaceDemo2.SetNamespace("ns-8")
 	testEnqueueRequestForNamespaceDelete(namespaceDemo2, 0, t)
+
+	// case 4: annotations changes only affect templated distributors
+	namespaceDemo3 := namespaceDemo1.DeepCopy()
+	namespaceDemo3.SetAnnotations(map[string]string{"owner": "team-a"})
+	testEnqueueRequestForNamespaceUpdate(namespaceDemo1, namespaceDemo3, 0, t)
+	distributor3 := buildResourceDistributionWithSecret()
+	distributor3.SetName("test-resource-distribution-3")
+	distributor3.Spec.Template = &appsv1beta1.ResourceDistributionTemplate{}
+	if err := handlerClient.Create(context.TODO(), distributor3, &client.CreateOptions{}); err != nil {
+		t.Fatalf("add distributor3 to fake client, err %v", err)
+	}
+	testEnqueueRequestForNamespaceUpdate(namespaceDemo1, namespaceDemo3, 1, t)
 }
 
 func testEnqueueRequestForNamespaceCreate(namespace *corev1.Namespace, expectedNumber int, t *testing.T) {
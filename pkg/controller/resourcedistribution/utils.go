@@ -20,11 +20,14 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -48,8 +51,15 @@ const (
 	DeleteConditionID      = 3
 	ConflictConditionID    = 4
 	NotExistConditionID    = 5
-	NumberOfConditionTypes = 6
+	DriftConditionID       = 6
+	NumberOfConditionTypes = 7
 	OperationSucceeded     = "Succeeded"
+
+	// FieldManager is the field manager of the resources distributed by server-side apply
+	FieldManager = "kruise-resourcedistribution"
+	// legacyFieldManager is the field manager of the resources distributed by client-side create and update,
+	// which is derived from the user agent of kruise-manager
+	legacyFieldManager = "kruise-manager"
 )
 
 // defaultSystemNamespaces returns system namespaces that are always excluded
@@ -114,6 +124,14 @@ func matchViaTargets(namespace *corev1.Namespace, distributor *appsv1beta1.Resou
 	return matchViaLabelSelector(namespace, distributor)
 }
 
+// matchTemplatedViaTargets check whether Namespace matches templated ResourceDistribution via spec.targets
+func matchTemplatedViaTargets(namespace *corev1.Namespace, distributor *appsv1beta1.ResourceDistribution) (bool, error) {
+	if distributor.Spec.Template == nil {
+		return false, nil
+	}
+	return matchViaTargets(namespace, distributor)
+}
+
 // addMatchedResourceDistributionToWorkQueue adds rds into q
 func addMatchedResourceDistributionToWorkQueue(q workqueue.TypedRateLimitingInterface[reconcile.Request], rds []*appsv1beta1.ResourceDistribution) {
 	for _, rd := range rds {
@@ -131,6 +149,19 @@ func hashResource(resourceYaml runtime.RawExtension) string {
 	return hex.EncodeToString(md5Hash[:])
 }
 
+// renderResource renders the values of namespace into resource, and returns the rendered resource with its hash
+func renderResource(distributor *appsv1beta1.ResourceDistribution, resource runtime.Object, namespace *corev1.Namespace) (runtime.Object, string, error) {
+	rendered, err := utils.RenderResource(resource, utils.NewTemplateData(distributor.Spec.Template, namespace))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render resource template: %v", err)
+	}
+	raw, err := json.Marshal(rendered)
+	if err != nil {
+		return nil, "", err
+	}
+	return rendered, hashResource(runtime.RawExtension{Raw: raw}), nil
+}
+
 // setCondition set condition[].Type, .Reason, and .FailedNamespaces
 func setCondition(condition *appsv1beta1.ResourceDistributionCondition, err error, namespaces ...string) {
 	if condition == nil || err == nil {
@@ -151,6 +182,7 @@ func initConditionType(conditions []appsv1beta1.ResourceDistributionCondition) {
 	conditions[DeleteConditionID].Type = appsv1beta1.ResourceDistributionDeleteResourceFailed
 	conditions[ConflictConditionID].Type = appsv1beta1.ResourceDistributionConflictOccurred
 	conditions[NotExistConditionID].Type = appsv1beta1.ResourceDistributionNamespaceNotExists
+	conditions[DriftConditionID].Type = appsv1beta1.ResourceDistributionResourceDrifted
 }
 
 // calculateNewStatus returns a complete new status to update distributor.status
@@ -176,7 +208,7 @@ func calculateNewStatus(distributor *appsv1beta1.ResourceDistribution, newCondit
 		} else {
 			newConditions[i].Status = appsv1beta1.ResourceDistributionConditionTrue
 		}
-		if len(oldConditions) == 0 || oldConditions[i].Status != newConditions[i].Status {
+		if len(oldConditions) <= i || oldConditions[i].Status != newConditions[i].Status {
 			// if .conditions.status changed
 			newConditions[i].LastTransitionTime = metav1.Time{Time: time.Now()}
 		} else {
@@ -345,14 +377,71 @@ func listNamespacesForDistributor(handlerClient client.Client, targets *appsv1be
 	return matchedSet.List(), unmatchedSet.List(), nil
 }
 
-func needToUpdate(old, new *unstructured.Unstructured) bool {
-	oldObject := old.DeepCopy().Object
-	newObject := new.DeepCopy().Object
-	oldObject["metadata"] = nil
-	newObject["metadata"] = nil
-	oldObject["status"] = nil
-	newObject["status"] = nil
-	return !reflect.DeepEqual(oldObject, newObject)
+// isDrifted returns true if the fields of desired, except metadata and status, or its labels and annotations
+// are not set in actual. The fields set by others, e.g. the tenants or the defaulting of apiserver, are ignored.
+func isDrifted(actual, desired *unstructured.Unstructured) bool {
+	for key, value := range desired.Object {
+		if key == "metadata" || key == "status" {
+			continue
+		}
+		if !isSubset(value, actual.Object[key]) {
+			return true
+		}
+	}
+	return !isSubset(desired.GetLabels(), actual.GetLabels()) || !isSubset(desired.GetAnnotations(), actual.GetAnnotations())
+}
+
+// isSubset returns true if all fields of desired are set in actual with the same values
+func isSubset(desired, actual interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return len(desiredValue) == 0 && actual == nil
+		}
+		for key, value := range desiredValue {
+			if !isSubset(value, actualValue[key]) {
+				return false
+			}
+		}
+		return true
+	case map[string]string:
+		actualValue, _ := actual.(map[string]string)
+		for key, value := range desiredValue {
+			if v, ok := actualValue[key]; !ok || v != value {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(actualValue) != len(desiredValue) {
+			return len(desiredValue) == 0 && actual == nil
+		}
+		for i := range desiredValue {
+			if !isSubset(desiredValue[i], actualValue[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, actual)
+	}
+}
+
+// isFieldManagerConflict returns true if err is caused by the conflicts of server-side apply
+func isFieldManagerConflict(err error) bool {
+	if !errors.IsConflict(err) {
+		return false
+	}
+	if status, ok := err.(errors.APIStatus); ok && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func isControlledByDistributor(resource metav1.Object, distributor *appsv1beta1.ResourceDistribution) bool {
@@ -127,6 +127,60 @@ func TestMakeResourceObjectRewritesOwnerRefToBeta(t *testing.T) {
 	}
 }
 
+func TestIsDrifted(t *testing.T) {
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":        "cm",
+			"labels":      map[string]interface{}{"app": "demo"},
+			"annotations": map[string]interface{}{"hash": "1"},
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}}
+	cases := []struct {
+		name   string
+		mutate func(actual *unstructured.Unstructured)
+		expect bool
+	}{
+		{name: "same", mutate: func(actual *unstructured.Unstructured) {}},
+		{
+			name: "fields added by others are ignored",
+			mutate: func(actual *unstructured.Unstructured) {
+				actual.Object["data"].(map[string]interface{})["extra"] = "tenant"
+				actual.SetLabels(map[string]string{"app": "demo", "tenant": "a"})
+				actual.SetResourceVersion("2")
+			},
+		},
+		{
+			name: "changed value",
+			mutate: func(actual *unstructured.Unstructured) {
+				actual.Object["data"].(map[string]interface{})["key"] = "changed"
+			},
+			expect: true,
+		},
+		{
+			name:   "removed field",
+			mutate: func(actual *unstructured.Unstructured) { delete(actual.Object, "data") },
+			expect: true,
+		},
+		{
+			name:   "outdated annotation",
+			mutate: func(actual *unstructured.Unstructured) { actual.SetAnnotations(map[string]string{"hash": "0"}) },
+			expect: true,
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			actual := desired.DeepCopy()
+			cs.mutate(actual)
+			if got := isDrifted(actual, desired); got != cs.expect {
+				t.Fatalf("expected drifted %v, got %v", cs.expect, got)
+			}
+		})
+	}
+}
+
 func ptrTo[T any](value T) *T {
 	return &value
 }
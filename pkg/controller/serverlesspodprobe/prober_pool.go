@@ -0,0 +1,368 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlesspodprobe
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/probe"
+	httpprobe "k8s.io/kubernetes/pkg/probe/http"
+	tcpprobe "k8s.io/kubernetes/pkg/probe/tcp"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+// probeKey identifies a probe of pod, the probeName is podProbeMarker.Name#probe.Name
+type probeKey struct {
+	podUID    types.UID
+	probeName string
+}
+
+// probeTarget is a probe run by the prober pool and the state of the probe
+type probeTarget struct {
+	pod       types.NamespacedName
+	podIP     string
+	container corev1.Container
+	spec      appsv1alpha1.ContainerProbeSpec
+	// containerID and startedAt of the running container, they are empty if the container is not running
+	containerID string
+	startedAt   time.Time
+
+	state appsv1alpha1.ContainerProbeState
+	// The last probe result and how many times in a row the probe has returned it.
+	lastResult appsv1alpha1.ProbeState
+	resultRun  int
+}
+
+// proberPool runs the httpGet and tcpSocket probes of the serverless pods in kruise-manager.
+// The probes are sharded by pod, each shard has a delaying queue of the probes and a limited number of workers,
+// so that the probes of a slow pod only hold up the probes in the same shard.
+type proberPool struct {
+	sync.RWMutex
+	targets map[probeKey]*probeTarget
+	// map[pod]->probes of pod
+	pods map[types.NamespacedName]map[probeKey]struct{}
+
+	shards      []workqueue.TypedDelayingInterface[probeKey]
+	concurrency int
+	http        httpprobe.Prober
+	tcp         tcpprobe.Prober
+	// onChange is called when the state of the probes of pod changed
+	onChange func(pod types.NamespacedName)
+}
+
+func newProberPool(shards, concurrency int, onChange func(pod types.NamespacedName)) *proberPool {
+	if shards < 1 {
+		shards = 1
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	p := &proberPool{
+		targets:     map[probeKey]*probeTarget{},
+		pods:        map[types.NamespacedName]map[probeKey]struct{}{},
+		concurrency: concurrency,
+		http:        httpprobe.New(false),
+		tcp:         tcpprobe.New(),
+		onChange:    onChange,
+	}
+	for i := 0; i < shards; i++ {
+		p.shards = append(p.shards, workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[probeKey]{
+			Name: fmt.Sprintf("serverless-pod-probe-%d", i),
+		}))
+	}
+	return p
+}
+
+// Start runs the workers of shards until the context is done, it implements manager.Runnable.
+func (p *proberPool) Start(ctx context.Context) error {
+	for _, queue := range p.shards {
+		for i := 0; i < p.concurrency; i++ {
+			go func(queue workqueue.TypedDelayingInterface[probeKey]) {
+				for p.processNextProbe(queue) {
+				}
+			}(queue)
+		}
+	}
+	klog.InfoS("Started serverless pod prober pool", "shards", len(p.shards), "concurrency", p.concurrency)
+	<-ctx.Done()
+	for _, queue := range p.shards {
+		queue.ShutDown()
+	}
+	return nil
+}
+
+func (p *proberPool) shardOf(key probeKey) workqueue.TypedDelayingInterface[probeKey] {
+	hash := fnv.New32a()
+	hash.Write([]byte(key.podUID))
+	return p.shards[hash.Sum32()%uint32(len(p.shards))]
+}
+
+func (p *proberPool) processNextProbe(queue workqueue.TypedDelayingInterface[probeKey]) bool {
+	key, quit := queue.Get()
+	if quit {
+		return false
+	}
+	defer queue.Done(key)
+	if period, ok := p.probe(key); ok {
+		queue.AddAfter(key, period)
+	}
+	return true
+}
+
+// updatePod syncs the probes of pod in the pool, the probes not in probes are removed
+func (p *proberPool) updatePod(pod *corev1.Pod, probes []appsv1alpha1.ContainerProbe) {
+	p.Lock()
+	defer p.Unlock()
+
+	podKey := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	desired := make(map[probeKey]struct{}, len(probes))
+	for i := range probes {
+		probe := &probes[i]
+		container := util.GetPodContainerByName(probe.ContainerName, pod)
+		if container == nil {
+			continue
+		}
+		key := probeKey{podUID: pod.UID, probeName: probe.Name}
+		desired[key] = struct{}{}
+
+		var containerID string
+		var startedAt time.Time
+		if status := util.GetContainerStatus(probe.ContainerName, pod); status != nil && status.State.Running != nil {
+			containerID, startedAt = status.ContainerID, status.State.Running.StartedAt.Time
+		}
+		target, ok := p.targets[key]
+		if !ok {
+			target = &probeTarget{pod: podKey}
+			target.reset(probe.Name)
+			p.targets[key] = target
+			p.shardOf(key).Add(key)
+		} else if !reflect.DeepEqual(target.spec.ProbeHandler, probe.Probe.ProbeHandler) || target.containerID != containerID {
+			target.reset(probe.Name)
+		}
+		target.podIP = pod.Status.PodIP
+		target.container = *container
+		target.spec = probe.Probe
+		target.containerID, target.startedAt = containerID, startedAt
+	}
+	for key := range p.pods[podKey] {
+		if _, ok := desired[key]; !ok {
+			delete(p.targets, key)
+		}
+	}
+	if len(desired) == 0 {
+		delete(p.pods, podKey)
+		return
+	}
+	p.pods[podKey] = desired
+}
+
+// removePod removes the probes of pod from the pool, the queued probes are dropped by the workers
+func (p *proberPool) removePod(pod types.NamespacedName) {
+	p.Lock()
+	defer p.Unlock()
+	for key := range p.pods[pod] {
+		delete(p.targets, key)
+	}
+	delete(p.pods, pod)
+}
+
+// getPodProbeStatus returns the states of the probes of pod
+func (p *proberPool) getPodProbeStatus(pod *corev1.Pod) appsv1alpha1.PodProbeStatus {
+	p.RLock()
+	defer p.RUnlock()
+	status := appsv1alpha1.PodProbeStatus{Namespace: pod.Namespace, Name: pod.Name, UID: string(pod.UID)}
+	for key := range p.pods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] {
+		// the probes not run yet are not reported, same as kruise-daemon
+		if target, ok := p.targets[key]; ok && key.podUID == pod.UID && target.state.State != appsv1alpha1.ProbeUnknown {
+			status.ProbeStates = append(status.ProbeStates, *target.state.DeepCopy())
+		}
+	}
+	sort.Slice(status.ProbeStates, func(i, j int) bool { return status.ProbeStates[i].Name < status.ProbeStates[j].Name })
+	return status
+}
+
+// probe runs the probe once and records the result, it returns the period of the probe and
+// whether the probe should be run again.
+func (p *proberPool) probe(key probeKey) (time.Duration, bool) {
+	p.RLock()
+	target, ok := p.targets[key]
+	if !ok {
+		p.RUnlock()
+		return 0, false
+	}
+	pod, podIP, container, startedAt := target.pod, target.podIP, target.container, target.startedAt
+	spec := target.spec.DeepCopy()
+	p.RUnlock()
+
+	period := time.Duration(spec.PeriodSeconds) * time.Second
+	if period < time.Second {
+		period = time.Second
+	}
+	now := time.Now()
+	var result appsv1alpha1.ProbeState
+	var msg string
+	var latency time.Duration
+	probed := false
+	if startedAt.IsZero() {
+		result, msg = appsv1alpha1.ProbeFailed, fmt.Sprintf("Container(%s) is Non-running", container.Name)
+	} else {
+		// Probe disabled for InitialDelaySeconds.
+		initialDelay := time.Duration(spec.InitialDelaySeconds) * time.Second
+		if initialDelay < time.Second {
+			initialDelay = time.Second
+		}
+		if now.Sub(startedAt) < initialDelay {
+			return period, true
+		}
+		res, output, err := p.runProbe(spec, podIP, &container)
+		latency, probed = time.Since(now), true
+		if err != nil {
+			klog.V(4).InfoS("Serverless pod probe failed", "pod", pod, "probeName", key.probeName, "err", err)
+			result, msg = appsv1alpha1.ProbeFailed, err.Error()
+		} else if res == probe.Success || res == probe.Warning {
+			result, msg = appsv1alpha1.ProbeSucceeded, output
+		} else {
+			result, msg = appsv1alpha1.ProbeFailed, output
+		}
+	}
+
+	p.Lock()
+	// the probe might be removed or reset while probing
+	if current, ok := p.targets[key]; !ok || current != target || current.startedAt != startedAt {
+		p.Unlock()
+		return period, ok
+	}
+	changed := target.setResult(spec, result, msg, latency, probed, now)
+	p.Unlock()
+	if changed && p.onChange != nil {
+		p.onChange(pod)
+	}
+	return period, true
+}
+
+func (p *proberPool) runProbe(spec *appsv1alpha1.ContainerProbeSpec, podIP string, container *corev1.Container) (probe.Result, string, error) {
+	timeout := time.Duration(spec.TimeoutSeconds) * time.Second
+	if timeout < time.Second {
+		timeout = time.Second
+	}
+	switch {
+	case spec.HTTPGet != nil:
+		req, err := httpprobe.NewRequestForHTTPGetAction(spec.HTTPGet, container, podIP, "probe")
+		if err != nil {
+			return probe.Unknown, "", err
+		}
+		return p.http.Probe(req, timeout)
+	case spec.TCPSocket != nil:
+		port, err := util.ExtractPort(spec.TCPSocket.Port, *container)
+		if err != nil {
+			return probe.Unknown, "", err
+		}
+		host := spec.TCPSocket.Host
+		if host == "" {
+			host = podIP
+		}
+		return p.tcp.Probe(host, port, timeout)
+	}
+	return probe.Unknown, "", fmt.Errorf("only httpGet and tcpSocket probes are supported for serverless pods")
+}
+
+// reset the state of the probe to Unknown, it is called when the probe or the container changed
+func (t *probeTarget) reset(name string) {
+	t.state = appsv1alpha1.ContainerProbeState{
+		Name:               name,
+		State:              appsv1alpha1.ProbeUnknown,
+		LastProbeTime:      metav1.Now(),
+		LastTransitionTime: metav1.Now(),
+	}
+	t.lastResult, t.resultRun = "", 0
+}
+
+// setResult records the result of the probe in the state, and returns true if the state needs to be written to pod.
+// Like kruise-daemon, the state is only changed when the thresholds are crossed. The failures are reported until
+// the recent results are full of failures, which only reconciles the pod as the state is kept in memory, so that
+// the derived states of PodProbeMarker are evaluated with the latest results.
+func (t *probeTarget) setResult(spec *appsv1alpha1.ContainerProbeSpec, result appsv1alpha1.ProbeState, msg string,
+	latency time.Duration, probed bool, now time.Time) bool {
+	changed := false
+	if probed {
+		recentResults := t.state.RecentResults
+		flapped := len(recentResults) > 0 && recentResults[len(recentResults)-1].State != result
+		changed = flapped || (result == appsv1alpha1.ProbeFailed && !isFullOfFailures(recentResults))
+		if len(recentResults) >= appsv1alpha1.MaxRecentProbeResults {
+			recentResults = recentResults[len(recentResults)-appsv1alpha1.MaxRecentProbeResults+1:]
+		}
+		t.state.RecentResults = append(append([]appsv1alpha1.ProbeResult{}, recentResults...), appsv1alpha1.ProbeResult{
+			State:     result,
+			ProbeTime: metav1.NewTime(now),
+			Latency:   metav1.Duration{Duration: latency},
+		})
+		t.state.Latency = &metav1.Duration{Duration: latency}
+
+		if t.lastResult == result {
+			t.resultRun++
+		} else {
+			t.lastResult, t.resultRun = result, 1
+		}
+		failureThreshold := spec.FailureThreshold
+		if failureThreshold <= 0 {
+			failureThreshold = 1
+		}
+		successThreshold := spec.SuccessThreshold
+		if successThreshold <= 0 {
+			successThreshold = 1
+		}
+		if (result == appsv1alpha1.ProbeFailed && t.resultRun < int(failureThreshold)) ||
+			(result == appsv1alpha1.ProbeSucceeded && t.resultRun < int(successThreshold)) {
+			// Success or failure is below threshold - leave the probe state unchanged.
+			return changed
+		}
+	}
+
+	if t.state.State != result || t.state.Message != msg {
+		changed = true
+	}
+	if t.state.State != result {
+		t.state.LastTransitionTime = metav1.NewTime(now)
+	}
+	t.state.State, t.state.Message, t.state.LastProbeTime = result, msg, metav1.NewTime(now)
+	return changed
+}
+
+func isFullOfFailures(results []appsv1alpha1.ProbeResult) bool {
+	if len(results) < appsv1alpha1.MaxRecentProbeResults {
+		return false
+	}
+	for _, result := range results {
+		if result.State != appsv1alpha1.ProbeFailed {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlesspodprobe
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+func newTestPod(port int, startedAt time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1", UID: "pod-1-uid"},
+		Spec: corev1.PodSpec{
+			NodeName: "virtual-kubelet",
+			Containers: []corev1.Container{{
+				Name:  "main",
+				Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: int32(port)}},
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "127.0.0.1",
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:        "main",
+				ContainerID: "containerd://main",
+				State:       corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(startedAt)}},
+			}},
+		},
+	}
+}
+
+func newTestProbe(handler corev1.ProbeHandler, failureThreshold int32) appsv1alpha1.ContainerProbe {
+	return appsv1alpha1.ContainerProbe{
+		Name:          "ppm-1#healthy",
+		ContainerName: "main",
+		Probe: appsv1alpha1.ContainerProbeSpec{
+			Probe: corev1.Probe{
+				ProbeHandler:     handler,
+				PeriodSeconds:    5,
+				TimeoutSeconds:   1,
+				SuccessThreshold: 1,
+				FailureThreshold: failureThreshold,
+			},
+		},
+	}
+}
+
+func testServerPort(t *testing.T, rawURL string) int {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parse url failed: %s", err.Error())
+	}
+	_, portStr, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(portStr)
+	return port
+}
+
+func TestProberPoolProbe(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if healthy {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	port := testServerPort(t, server.URL)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %s", err.Error())
+	}
+	tcpPort := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	httpGet := corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")}}
+	tcpSocket := corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(tcpPort)}}
+
+	cases := []struct {
+		name      string
+		probe     appsv1alpha1.ContainerProbe
+		startedAt time.Time
+		healthy   []bool
+		expect    []appsv1alpha1.ProbeState
+		changed   []bool
+	}{
+		{
+			name:      "http probe succeeded and then failed",
+			probe:     newTestProbe(httpGet, 1),
+			startedAt: time.Now().Add(-time.Minute),
+			healthy:   []bool{true, true, false},
+			expect:    []appsv1alpha1.ProbeState{appsv1alpha1.ProbeSucceeded, appsv1alpha1.ProbeSucceeded, appsv1alpha1.ProbeFailed},
+			changed:   []bool{true, false, true},
+		},
+		{
+			name:      "http probe failed below failure threshold",
+			probe:     newTestProbe(httpGet, 2),
+			startedAt: time.Now().Add(-time.Minute),
+			healthy:   []bool{true, false, false},
+			expect:    []appsv1alpha1.ProbeState{appsv1alpha1.ProbeSucceeded, appsv1alpha1.ProbeSucceeded, appsv1alpha1.ProbeFailed},
+			changed:   []bool{true, true, true},
+		},
+		{
+			name:      "container is not started for initial delay",
+			probe:     newTestProbe(httpGet, 1),
+			startedAt: time.Now(),
+			healthy:   []bool{true},
+			expect:    []appsv1alpha1.ProbeState{appsv1alpha1.ProbeUnknown},
+			changed:   []bool{false},
+		},
+		{
+			name:      "tcp probe connection refused",
+			probe:     newTestProbe(tcpSocket, 1),
+			startedAt: time.Now().Add(-time.Minute),
+			healthy:   []bool{true},
+			expect:    []appsv1alpha1.ProbeState{appsv1alpha1.ProbeFailed},
+			changed:   []bool{true},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			var changed bool
+			pool := newProberPool(1, 1, func(pod types.NamespacedName) { changed = true })
+			pod := newTestPod(port, cs.startedAt)
+			pool.updatePod(pod, []appsv1alpha1.ContainerProbe{cs.probe})
+			key := probeKey{podUID: pod.UID, probeName: cs.probe.Name}
+			for i := range cs.healthy {
+				healthy, changed = cs.healthy[i], false
+				period, ok := pool.probe(key)
+				assert.True(t, ok)
+				assert.Equal(t, 5*time.Second, period)
+				assert.Equal(t, cs.expect[i], pool.targets[key].state.State, "probe %d", i)
+				assert.Equal(t, cs.changed[i], changed, "probe %d", i)
+			}
+		})
+	}
+}
+
+func TestProberPoolUpdatePod(t *testing.T) {
+	pool := newProberPool(2, 1, nil)
+	pod := newTestPod(8080, time.Now().Add(-time.Minute))
+	httpGet := corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)}}
+	probe := newTestProbe(httpGet, 1)
+	key := probeKey{podUID: pod.UID, probeName: probe.Name}
+
+	pool.updatePod(pod, []appsv1alpha1.ContainerProbe{probe})
+	pool.targets[key].state.State = appsv1alpha1.ProbeSucceeded
+	status := pool.getPodProbeStatus(pod)
+	assert.Len(t, status.ProbeStates, 1)
+	assert.Equal(t, appsv1alpha1.ProbeSucceeded, status.ProbeStates[0].State)
+
+	// the state is kept when only the period is changed
+	probe.Probe.PeriodSeconds = 10
+	pool.updatePod(pod, []appsv1alpha1.ContainerProbe{probe})
+	assert.Equal(t, appsv1alpha1.ProbeSucceeded, pool.targets[key].state.State)
+	assert.Equal(t, int32(10), pool.targets[key].spec.PeriodSeconds)
+
+	// the state is reset when the container restarted
+	pod.Status.ContainerStatuses[0].ContainerID = "containerd://main-2"
+	pool.updatePod(pod, []appsv1alpha1.ContainerProbe{probe})
+	assert.Equal(t, appsv1alpha1.ProbeUnknown, pool.targets[key].state.State)
+	assert.Len(t, pool.getPodProbeStatus(pod).ProbeStates, 0)
+
+	// the probe is removed when it is no longer desired
+	pool.updatePod(pod, nil)
+	assert.Len(t, pool.targets, 0)
+	assert.Len(t, pool.pods, 0)
+	_, ok := pool.probe(key)
+	assert.False(t, ok)
+
+	pool.updatePod(pod, []appsv1alpha1.ContainerProbe{probe})
+	pool.removePod(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+	assert.Len(t, pool.targets, 0)
+	assert.Len(t, pool.pods, 0)
+}
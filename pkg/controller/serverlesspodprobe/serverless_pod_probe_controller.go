@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlesspodprobe
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/podprobemarker"
+	"github.com/openkruise/kruise/pkg/util/ratelimiter"
+)
+
+func init() {
+	flag.IntVar(&concurrentReconciles, "serverlesspodprobe-workers", concurrentReconciles, "Max concurrent workers for ServerlessPodProbe controller.")
+	flag.IntVar(&proberShards, "serverlesspodprobe-prober-shards", proberShards, "Number of shards of the prober pool that runs the PodProbeMarker probes of serverless pods.")
+	flag.IntVar(&proberConcurrency, "serverlesspodprobe-prober-concurrency", proberConcurrency, "Max concurrent probes per shard of the serverless pod prober pool.")
+}
+
+var (
+	concurrentReconciles = 3
+	proberShards         = 4
+	proberConcurrency    = 10
+	controllerKind       = appsv1alpha1.SchemeGroupVersion.WithKind("PodProbeMarker")
+)
+
+// Add creates a new ServerlessPodProbe Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	if !utildiscovery.DiscoverGVK(controllerKind) || !utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerGate) ||
+		!utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerServerlessProber) {
+		return nil
+	}
+	changed := make(chan event.TypedGenericEvent[*corev1.Pod], 1024)
+	pool := newProberPool(proberShards, proberConcurrency, func(pod types.NamespacedName) {
+		changed <- event.TypedGenericEvent[*corev1.Pod]{Object: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name}}}
+	})
+	if err := mgr.Add(pool); err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, pool), changed)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, pool *proberPool) *ReconcileServerlessPodProbe {
+	cli := utilclient.NewClientFromManager(mgr, "ServerlessPodProbe-controller")
+	return &ReconcileServerlessPodProbe{
+		Client: cli,
+		pool:   pool,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler, changed <-chan event.TypedGenericEvent[*corev1.Pod]) error {
+	// Create a new controller
+	c, err := controller.New("ServerlessPodProbe-controller", mgr, controller.Options{
+		Reconciler: r, MaxConcurrentReconciles: concurrentReconciles, CacheSyncTimeout: util.GetControllerCacheSyncTimeout(),
+		RateLimiter: ratelimiter.DefaultControllerRateLimiter[reconcile.Request]()})
+	if err != nil {
+		return err
+	}
+
+	// watch for changes to pod
+	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Pod{}, &enqueueRequestForPod{reader: mgr.GetClient()})); err != nil {
+		return err
+	}
+	// watch for changes to PodProbeMarker
+	if err = c.Watch(source.Kind(mgr.GetCache(), &appsv1alpha1.PodProbeMarker{}, &enqueueRequestForPodProbeMarker{reader: mgr.GetClient()})); err != nil {
+		return err
+	}
+	// watch for the probe states changed in the prober pool
+	if err = c.Watch(source.Channel(changed, &handler.TypedEnqueueRequestForObject[*corev1.Pod]{})); err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileServerlessPodProbe{}
+
+// ReconcileServerlessPodProbe runs the PodProbeMarker probes of the pods on virtual-kubelet nodes in kruise-manager,
+// since there is no kruise-daemon on these nodes to run them.
+type ReconcileServerlessPodProbe struct {
+	client.Client
+	pool *proberPool
+}
+
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=podprobemarkers,verbs=get;list;watch
+
+// Reconcile syncs the probes of the serverless pod into the prober pool, and writes the probe states
+// into the pod conditions, labels and annotations
+func (r *ReconcileServerlessPodProbe) Reconcile(_ context.Context, req ctrl.Request) (ctrl.Result, error) {
+	requeueAfter, err := r.syncServerlessPod(req.NamespacedName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *ReconcileServerlessPodProbe) syncServerlessPod(key types.NamespacedName) (time.Duration, error) {
+	pod := &corev1.Pod{}
+	if err := r.Get(context.TODO(), key, pod); err != nil {
+		if errors.IsNotFound(err) {
+			r.pool.removePod(key)
+			return 0, nil
+		}
+		return 0, err
+	}
+	if !kubecontroller.IsPodActive(pod) || pod.Status.PodIP == "" || !isServerlessPod(r.Client, pod) {
+		r.pool.removePod(key)
+		return 0, nil
+	}
+
+	ppms, err := podprobemarker.GetPodProbeMarkerForPod(r.Client, pod)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get PodProbeMarker for serverless pod", "pod", klog.KObj(pod))
+		return 0, err
+	}
+	var probes []appsv1alpha1.ContainerProbe
+	for _, ppm := range ppms {
+		if !ppm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, probe := range ppm.Spec.Probes {
+			// exec and grpc probes can only be run by kruise-daemon
+			if probe.Probe.HTTPGet == nil && probe.Probe.TCPSocket == nil {
+				klog.V(4).InfoS("Serverless pod probe is not supported, only httpGet and tcpSocket are supported", "pod", klog.KObj(pod), "podProbeMarker", klog.KObj(ppm), "probe", probe.Name)
+				continue
+			}
+			probes = append(probes, appsv1alpha1.ContainerProbe{
+				Name:          fmt.Sprintf("%s#%s", ppm.Name, probe.Name),
+				ContainerName: probe.ContainerName,
+				Probe:         probe.Probe,
+			})
+		}
+	}
+	r.pool.updatePod(pod, probes)
+
+	status := r.pool.getPodProbeStatus(pod)
+	if len(status.ProbeStates) == 0 {
+		return 0, nil
+	}
+	return podprobemarker.PatchPodProbeStatus(r.Client, pod, status)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlesspodprobe
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+var scheme *runtime.Scheme
+
+func init() {
+	scheme = runtime.NewScheme()
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	utilruntime.Must(appsv1alpha1.AddToScheme(scheme))
+}
+
+func TestReconcileServerlessPodProbe(t *testing.T) {
+	virtualNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "virtual-kubelet",
+		Labels: map[string]string{util.VirtualKubeletLabelKey: util.VirtualKubeletLabelValue},
+	}}
+	normalNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+	ppm := &appsv1alpha1.PodProbeMarker{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ppm-1"},
+		Spec: appsv1alpha1.PodProbeMarkerSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Probes: []appsv1alpha1.PodContainerProbe{
+				{
+					Name:          "healthy",
+					ContainerName: "main",
+					Probe: appsv1alpha1.ContainerProbeSpec{Probe: corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)},
+					}}},
+					PodConditionType: "game.kruise.io/healthy",
+					MarkerPolicy: []appsv1alpha1.ProbeMarkerPolicy{
+						{State: appsv1alpha1.ProbeSucceeded, Labels: map[string]string{"server-healthy": "true"}},
+						{State: appsv1alpha1.ProbeFailed, Labels: map[string]string{"server-healthy": "false"}},
+					},
+				},
+				{
+					Name:          "exec",
+					ContainerName: "main",
+					Probe: appsv1alpha1.ContainerProbeSpec{Probe: corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+						Exec: &corev1.ExecAction{Command: []string{"/healthy.sh"}},
+					}}},
+					PodConditionType: "game.kruise.io/exec",
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name         string
+		nodeName     string
+		probeState   appsv1alpha1.ProbeState
+		expectProbes []string
+		expectCond   corev1.ConditionStatus
+	}{
+		{
+			name:         "serverless pod succeeded",
+			nodeName:     virtualNode.Name,
+			probeState:   appsv1alpha1.ProbeSucceeded,
+			expectProbes: []string{"ppm-1#healthy"},
+			expectCond:   corev1.ConditionTrue,
+		},
+		{
+			name:         "serverless pod failed",
+			nodeName:     virtualNode.Name,
+			probeState:   appsv1alpha1.ProbeFailed,
+			expectProbes: []string{"ppm-1#healthy"},
+			expectCond:   corev1.ConditionFalse,
+		},
+		{
+			name:     "pod on normal node is probed by kruise-daemon",
+			nodeName: normalNode.Name,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			pod := newTestPod(8080, time.Now().Add(-time.Minute))
+			pod.Labels = map[string]string{"app": "test"}
+			pod.Spec.NodeName = cs.nodeName
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(virtualNode, normalNode, ppm.DeepCopy(), pod).
+				Build()
+			pool := newProberPool(1, 1, nil)
+			r := &ReconcileServerlessPodProbe{Client: fakeClient, pool: pool}
+			key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			var probes []string
+			for k := range pool.targets {
+				probes = append(probes, k.probeName)
+			}
+			assert.Equal(t, cs.expectProbes, probes)
+			if len(cs.expectProbes) == 0 {
+				return
+			}
+
+			// the probe result is written to pod conditions in the next reconcile
+			pool.targets[probeKey{podUID: pod.UID, probeName: "ppm-1#healthy"}].state.State = cs.probeState
+			_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			newPod := &corev1.Pod{}
+			assert.NoError(t, fakeClient.Get(context.TODO(), key, newPod))
+			condition := util.GetCondition(newPod, "game.kruise.io/healthy")
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, cs.expectCond, condition.Status)
+			}
+
+			// the probes are removed from the pool after the pod is deleted
+			assert.NoError(t, fakeClient.Delete(context.TODO(), newPod, client.GracePeriodSeconds(0)))
+			_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Len(t, pool.targets, 0)
+		})
+	}
+}
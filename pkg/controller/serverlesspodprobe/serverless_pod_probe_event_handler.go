@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serverlesspodprobe
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsalphav1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+)
+
+var _ handler.TypedEventHandler[*corev1.Pod, reconcile.Request] = &enqueueRequestForPod{}
+
+type enqueueRequestForPod struct {
+	reader client.Reader
+}
+
+func (p *enqueueRequestForPod) Create(ctx context.Context, evt event.TypedCreateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if isServerlessPod(p.reader, evt.Object) {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: evt.Object.Namespace, Name: evt.Object.Name}})
+	}
+}
+
+func (p *enqueueRequestForPod) Delete(ctx context.Context, evt event.TypedDeleteEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	// the probes of the deleted pod are removed from the prober pool in reconcile
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: evt.Object.Namespace, Name: evt.Object.Name}})
+}
+
+func (p *enqueueRequestForPod) Generic(ctx context.Context, evt event.TypedGenericEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForPod) Update(ctx context.Context, evt event.TypedUpdateEvent[*corev1.Pod], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	newPod, oldPod := evt.ObjectNew, evt.ObjectOld
+	if newPod.Spec.NodeName == "" {
+		return
+	}
+	if oldPod.Spec.NodeName == newPod.Spec.NodeName &&
+		oldPod.Status.PodIP == newPod.Status.PodIP &&
+		kubecontroller.IsPodActive(oldPod) == kubecontroller.IsPodActive(newPod) &&
+		reflect.DeepEqual(oldPod.Labels, newPod.Labels) &&
+		reflect.DeepEqual(oldPod.Status.ContainerStatuses, newPod.Status.ContainerStatuses) {
+		return
+	}
+	if isServerlessPod(p.reader, newPod) {
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: newPod.Namespace, Name: newPod.Name}})
+	}
+}
+
+var _ handler.TypedEventHandler[*appsalphav1.PodProbeMarker, reconcile.Request] = &enqueueRequestForPodProbeMarker{}
+
+type enqueueRequestForPodProbeMarker struct {
+	reader client.Reader
+}
+
+func (p *enqueueRequestForPodProbeMarker) Create(ctx context.Context, evt event.TypedCreateEvent[*appsalphav1.PodProbeMarker], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	p.queue(q, evt.Object)
+}
+
+func (p *enqueueRequestForPodProbeMarker) Delete(ctx context.Context, evt event.TypedDeleteEvent[*appsalphav1.PodProbeMarker], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	p.queue(q, evt.Object)
+}
+
+func (p *enqueueRequestForPodProbeMarker) Generic(ctx context.Context, evt event.TypedGenericEvent[*appsalphav1.PodProbeMarker], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+}
+
+func (p *enqueueRequestForPodProbeMarker) Update(ctx context.Context, evt event.TypedUpdateEvent[*appsalphav1.PodProbeMarker], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldObj, newObj := evt.ObjectOld, evt.ObjectNew
+	if reflect.DeepEqual(oldObj.Spec, newObj.Spec) && oldObj.DeletionTimestamp.IsZero() == newObj.DeletionTimestamp.IsZero() {
+		return
+	}
+	p.queue(q, newObj)
+	if !reflect.DeepEqual(oldObj.Spec.Selector, newObj.Spec.Selector) {
+		p.queue(q, oldObj)
+	}
+}
+
+// queue enqueues the serverless pods matched by the PodProbeMarker
+func (p *enqueueRequestForPodProbeMarker) queue(q workqueue.TypedRateLimitingInterface[reconcile.Request], ppm *appsalphav1.PodProbeMarker) {
+	selector, err := util.ValidatedLabelSelectorAsSelector(ppm.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return
+	}
+	podList := &corev1.PodList{}
+	if err = p.reader.List(context.TODO(), podList, &client.ListOptions{Namespace: ppm.Namespace, LabelSelector: selector}, utilclient.DisableDeepCopy); err != nil {
+		klog.ErrorS(err, "Failed to list pods of PodProbeMarker", "podProbeMarker", klog.KObj(ppm))
+		return
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if isServerlessPod(p.reader, pod) {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}})
+		}
+	}
+}
+
+// isServerlessPod returns true if the pod is scheduled to a virtual-kubelet node
+func isServerlessPod(reader client.Reader, pod *corev1.Pod) bool {
+	if pod.Spec.NodeName == "" {
+		return false
+	}
+	node := &corev1.Node{}
+	if err := reader.Get(context.TODO(), client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		return false
+	}
+	return node.Labels[util.VirtualKubeletLabelKey] == util.VirtualKubeletLabelValue
+}
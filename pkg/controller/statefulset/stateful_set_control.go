@@ -858,7 +858,10 @@ func (ssc *defaultStatefulSetControl) inPlaceUpdatePod(
 		opts.GracePeriodSeconds = set.Spec.UpdateStrategy.RollingUpdate.InPlaceUpdateStrategy.GracePeriodSeconds
 	}
 
-	if ssc.inplaceControl.CanUpdateInPlace(oldRevision, updateRevision, opts) {
+	// Pods marked to clean up ephemeral containers should be recreated, unless the policy is InPlaceOnly
+	recreateForCleanup := set.Spec.UpdateStrategy.RollingUpdate.PodUpdatePolicy == appsv1beta1.InPlaceIfPossiblePodUpdateStrategyType &&
+		inplaceupdate.IsEphemeralContainersCleanupMarked(pod)
+	if !recreateForCleanup && ssc.inplaceControl.CanUpdateInPlace(oldRevision, updateRevision, opts) {
 		state := lifecycle.GetPodLifecycleState(pod)
 		switch state {
 		case "", appspub.LifecycleStatePreparingNormal, appspub.LifecycleStateNormal:
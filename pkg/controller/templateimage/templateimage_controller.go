@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templateimage
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/docker/distribution/reference"
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util"
+	utilclient "github.com/openkruise/kruise/pkg/util/client"
+	utildiscovery "github.com/openkruise/kruise/pkg/util/discovery"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+)
+
+const (
+	controllerName = "templateimage-controller"
+
+	// maxTemplateImages limits the size of the ConfigMap of template images
+	maxTemplateImages = 5000
+)
+
+var nodeImageKind = appsv1beta1.SchemeGroupVersion.WithKind("NodeImage")
+
+// Add creates a new TemplateImage Controller and adds it to the Manager. It collects the images referenced
+// by the pod templates of workloads into the ConfigMap read by kruise-daemon, so that they are protected
+// from image garbage collection and available for the next scaling up.
+func Add(mgr manager.Manager) error {
+	if !utildiscovery.DiscoverGVK(nodeImageKind) || !utilfeature.DefaultFeatureGate.Enabled(features.KruiseDaemon) {
+		return nil
+	}
+	r := &ReconcileTemplateImage{Client: utilclient.NewClientFromManager(mgr, controllerName)}
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r,
+		MaxConcurrentReconciles: 1, CacheSyncTimeout: util.GetControllerCacheSyncTimeout()})
+	if err != nil {
+		return err
+	}
+
+	if err = watchTemplate(c, mgr, &appsv1beta1.CloneSet{}, func(o *appsv1beta1.CloneSet) *v1.PodSpec { return &o.Spec.Template.Spec }); err != nil {
+		return err
+	}
+	if err = watchTemplate(c, mgr, &appsv1beta1.StatefulSet{}, func(o *appsv1beta1.StatefulSet) *v1.PodSpec { return &o.Spec.Template.Spec }); err != nil {
+		return err
+	}
+	if err = watchTemplate(c, mgr, &appsv1beta1.DaemonSet{}, func(o *appsv1beta1.DaemonSet) *v1.PodSpec { return &o.Spec.Template.Spec }); err != nil {
+		return err
+	}
+	if err = watchTemplate(c, mgr, &apps.Deployment{}, func(o *apps.Deployment) *v1.PodSpec { return &o.Spec.Template.Spec }); err != nil {
+		return err
+	}
+	if err = watchTemplate(c, mgr, &apps.StatefulSet{}, func(o *apps.StatefulSet) *v1.PodSpec { return &o.Spec.Template.Spec }); err != nil {
+		return err
+	}
+
+	// recreate the ConfigMap if it is deleted or modified by others
+	return c.Watch(source.Kind(mgr.GetCache(), &v1.ConfigMap{}, handler.TypedEnqueueRequestsFromMapFunc(enqueueTemplateImages[*v1.ConfigMap]),
+		predicate.NewTypedPredicateFuncs(func(cm *v1.ConfigMap) bool {
+			return cm.Namespace == util.GetKruiseDaemonConfigNamespace() && cm.Name == appsv1beta1.TemplateImagesConfigMapName
+		})))
+}
+
+func watchTemplate[T client.Object](c controller.Controller, mgr manager.Manager, obj T, getPodSpec func(T) *v1.PodSpec) error {
+	return c.Watch(source.Kind(mgr.GetCache(), obj, handler.TypedEnqueueRequestsFromMapFunc(enqueueTemplateImages[T]),
+		predicate.TypedFuncs[T]{
+			UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+				return !reflect.DeepEqual(getPodSpecImages(getPodSpec(e.ObjectOld)), getPodSpecImages(getPodSpec(e.ObjectNew)))
+			},
+		}))
+}
+
+func enqueueTemplateImages[T client.Object](_ context.Context, _ T) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: util.GetKruiseDaemonConfigNamespace(), Name: appsv1beta1.TemplateImagesConfigMapName}}}
+}
+
+var _ reconcile.Reconciler = &ReconcileTemplateImage{}
+
+// ReconcileTemplateImage maintains the ConfigMap of the images referenced by the pod templates of workloads
+type ReconcileTemplateImage struct {
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets;statefulsets;daemonsets,verbs=get;list;watch
+
+func (r *ReconcileTemplateImage) Reconcile(_ context.Context, request reconcile.Request) (reconcile.Result, error) {
+	images := sets.New[string]()
+	cloneSets := &appsv1beta1.CloneSetList{}
+	if err := r.List(context.TODO(), cloneSets); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range cloneSets.Items {
+		images.Insert(getPodSpecImages(&cloneSets.Items[i].Spec.Template.Spec)...)
+	}
+	advancedStatefulSets := &appsv1beta1.StatefulSetList{}
+	if err := r.List(context.TODO(), advancedStatefulSets); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range advancedStatefulSets.Items {
+		images.Insert(getPodSpecImages(&advancedStatefulSets.Items[i].Spec.Template.Spec)...)
+	}
+	advancedDaemonSets := &appsv1beta1.DaemonSetList{}
+	if err := r.List(context.TODO(), advancedDaemonSets); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range advancedDaemonSets.Items {
+		images.Insert(getPodSpecImages(&advancedDaemonSets.Items[i].Spec.Template.Spec)...)
+	}
+	deployments := &apps.DeploymentList{}
+	if err := r.List(context.TODO(), deployments); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range deployments.Items {
+		images.Insert(getPodSpecImages(&deployments.Items[i].Spec.Template.Spec)...)
+	}
+	statefulSets := &apps.StatefulSetList{}
+	if err := r.List(context.TODO(), statefulSets); err != nil {
+		return reconcile.Result{}, err
+	}
+	for i := range statefulSets.Items {
+		images.Insert(getPodSpecImages(&statefulSets.Items[i].Spec.Template.Spec)...)
+	}
+
+	list := sets.List(images)
+	if len(list) > maxTemplateImages {
+		klog.InfoS("Too many images referenced by workload templates, some of them are not protected from image garbage collection",
+			"count", len(list), "limit", maxTemplateImages)
+		list = list[:maxTemplateImages]
+	}
+	data, _ := json.Marshal(list)
+	return reconcile.Result{}, r.syncConfigMap(request.NamespacedName, string(data))
+}
+
+func (r *ReconcileTemplateImage) syncConfigMap(key types.NamespacedName, data string) error {
+	cm := &v1.ConfigMap{}
+	if err := r.Get(context.TODO(), key, cm); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{appsv1beta1.TemplateImagesKey: data},
+		}
+		klog.V(3).InfoS("Creating ConfigMap of template images", "configMap", key)
+		return r.Create(context.TODO(), cm)
+	}
+	if cm.Data[appsv1beta1.TemplateImagesKey] == data {
+		return nil
+	}
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[appsv1beta1.TemplateImagesKey] = data
+	klog.V(3).InfoS("Updating ConfigMap of template images", "configMap", key)
+	return r.Update(context.TODO(), cm)
+}
+
+// getPodSpecImages returns the normalized images of containers and init containers in pod spec,
+// e.g. nginx is normalized to nginx:latest.
+func getPodSpecImages(spec *v1.PodSpec) []string {
+	var images []string
+	for _, containers := range [][]v1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			named, err := reference.ParseNormalizedNamed(containers[i].Image)
+			if err != nil {
+				continue
+			}
+			images = append(images, reference.FamiliarString(reference.TagNameOnly(named)))
+		}
+	}
+	return images
+}
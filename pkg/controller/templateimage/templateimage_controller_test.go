@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templateimage
+
+import (
+	"context"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1.AddToScheme(scheme))
+	utilruntime.Must(apps.AddToScheme(scheme))
+	utilruntime.Must(appsv1beta1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestReconcile(t *testing.T) {
+	key := types.NamespacedName{Namespace: util.GetKruiseDaemonConfigNamespace(), Name: appsv1beta1.TemplateImagesConfigMapName}
+	cases := []struct {
+		name     string
+		objects  []client.Object
+		expected string
+	}{
+		{
+			name:     "no workload",
+			expected: `[]`,
+		},
+		{
+			name: "collect and normalize images of workloads",
+			objects: []client.Object{
+				&appsv1beta1.CloneSet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cs"},
+					Spec: appsv1beta1.CloneSetSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+						InitContainers: []v1.Container{{Name: "init", Image: "busybox"}},
+						Containers:     []v1.Container{{Name: "main", Image: "docker.io/library/nginx:1.0"}},
+					}}},
+				},
+				&apps.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deploy"},
+					Spec: apps.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: "main", Image: "nginx:1.0"}, {Name: "sidecar", Image: "registry.example.com/app/sidecar:v2"}},
+					}}},
+				},
+			},
+			expected: `["busybox:latest","nginx:1.0","registry.example.com/app/sidecar:v2"]`,
+		},
+		{
+			name: "update the existing ConfigMap",
+			objects: []client.Object{
+				&v1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+					Data:       map[string]string{appsv1beta1.TemplateImagesKey: `["nginx:1.0"]`},
+				},
+				&appsv1beta1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sts"},
+					Spec: appsv1beta1.StatefulSetSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: "main", Image: "redis:7"}},
+					}}},
+				},
+			},
+			expected: `["redis:7"]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ReconcileTemplateImage{Client: fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(tc.objects...).Build()}
+			if _, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("failed to reconcile: %v", err)
+			}
+			cm := &v1.ConfigMap{}
+			if err := r.Get(context.TODO(), key, cm); err != nil {
+				t.Fatalf("failed to get ConfigMap: %v", err)
+			}
+			if got := cm.Data[appsv1beta1.TemplateImagesKey]; got != tc.expected {
+				t.Fatalf("expected images %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadspread
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	kubecontroller "k8s.io/kubernetes/pkg/controller"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+const (
+	defaultRebalanceMaxEvictionsPerInterval = 1
+	defaultRebalanceIntervalSeconds         = 60
+)
+
+// rebalanceSubsets evicts the surplus Pods of the subsets whose active Pods are more than their maxReplicas,
+// so that the target workload recreates them and the webhook injects them into the subsets that still miss replicas.
+// Evictions go through the eviction subresource, so PodDisruptionBudget and PodUnavailableBudget are respected.
+// To rebalance gradually, nothing is evicted until the previous batch has been replaced by ready Pods and
+// IntervalSeconds has passed since LastRebalanceTime.
+// return the LastRebalanceTime that should be recorded in status.
+func (r *ReconcileWorkloadSpread) rebalanceSubsets(ws *appsv1beta1.WorkloadSpread,
+	subsetPodMap map[string][]*corev1.Pod, workloadReplicas int32) (*metav1.Time, error) {
+	strategy := ws.Spec.RebalanceStrategy
+	if strategy == nil {
+		return ws.Status.LastRebalanceTime, nil
+	}
+	maxEvictions, interval := getRebalanceLimits(strategy)
+
+	currentTime := time.Now()
+	if ws.Status.LastRebalanceTime != nil {
+		nextRebalance := ws.Status.LastRebalanceTime.Add(interval)
+		if nextRebalance.After(currentTime) {
+			durationStore.Push(getWorkloadSpreadKey(ws), nextRebalance.Sub(currentTime))
+			return ws.Status.LastRebalanceTime, nil
+		}
+	}
+
+	if !isReadyForRebalance(ws, subsetPodMap) {
+		klog.V(4).InfoS("WorkloadSpread is waiting for Pods to be ready before rebalancing", "workloadSpread", klog.KObj(ws))
+		return ws.Status.LastRebalanceTime, nil
+	}
+
+	victims := getRebalanceVictims(ws, subsetPodMap, workloadReplicas, maxEvictions)
+	if len(victims) == 0 {
+		return ws.Status.LastRebalanceTime, nil
+	}
+
+	var evicted int
+	for _, victim := range victims {
+		if err := r.evictPodForRebalance(ws, victim.pod, victim.subset); err != nil {
+			if errors.IsTooManyRequests(err) || errors.IsForbidden(err) {
+				// the disruption budget is exhausted, try again in next interval.
+				klog.V(3).InfoS("WorkloadSpread rebalance eviction was rejected by disruption budget", "workloadSpread", klog.KObj(ws), "pod", klog.KObj(victim.pod), "reason", err.Error())
+				r.recorder.Eventf(ws, corev1.EventTypeNormal, "RebalanceEvictionBlocked",
+					"Failed to evict Pod %s/%s from subset %s for rebalancing: %s", victim.pod.Namespace, victim.pod.Name, victim.subset, err.Error())
+				durationStore.Push(getWorkloadSpreadKey(ws), interval)
+				break
+			}
+			if evicted > 0 {
+				now := metav1.NewTime(currentTime)
+				return &now, err
+			}
+			return ws.Status.LastRebalanceTime, err
+		}
+		evicted++
+	}
+
+	if evicted == 0 {
+		return ws.Status.LastRebalanceTime, nil
+	}
+	durationStore.Push(getWorkloadSpreadKey(ws), interval)
+	now := metav1.NewTime(currentTime)
+	return &now, nil
+}
+
+func (r *ReconcileWorkloadSpread) evictPodForRebalance(ws *appsv1beta1.WorkloadSpread, pod *corev1.Pod, subsetName string) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		},
+	}
+	if err := r.SubResource("eviction").Create(context.TODO(), pod, eviction); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	r.recorder.Eventf(ws, corev1.EventTypeNormal, "RebalanceEvicted",
+		"Evicted Pod %s/%s from subset %s for rebalancing", pod.Namespace, pod.Name, subsetName)
+	klog.V(3).InfoS("WorkloadSpread evicted Pod for rebalancing successfully", "workloadSpread", klog.KObj(ws), "pod", klog.KObj(pod), "subsetName", subsetName)
+	return nil
+}
+
+type rebalanceVictim struct {
+	pod    *corev1.Pod
+	subset string
+}
+
+// getRebalanceVictims returns at most maxEvictions Pods that should be evicted. The number of victims is also
+// limited by the total missing replicas of the subsets, so that every evicted Pod has a subset to land in.
+// A subset without maxReplicas can take any number of Pods.
+func getRebalanceVictims(ws *appsv1beta1.WorkloadSpread, subsetPodMap map[string][]*corev1.Pod,
+	workloadReplicas int32, maxEvictions int) []rebalanceVictim {
+	var missing int
+	var hasUnlimited bool
+	var surplus []rebalanceVictim
+	for i := range ws.Spec.Subsets {
+		subset := &ws.Spec.Subsets[i]
+		if subset.MaxReplicas == nil {
+			hasUnlimited = true
+			continue
+		}
+		subsetMaxReplicas, err := intstr.GetScaledValueFromIntOrPercent(subset.MaxReplicas, int(workloadReplicas), true)
+		if err != nil || subsetMaxReplicas < 0 {
+			klog.ErrorS(err, "Failed to get maxReplicas value from subset of WorkloadSpread", "subsetName", subset.Name, "workloadSpread", klog.KObj(ws))
+			return nil
+		}
+
+		activePods := make([]*corev1.Pod, 0, len(subsetPodMap[subset.Name]))
+		for _, pod := range subsetPodMap[subset.Name] {
+			if kubecontroller.IsPodActive(pod) {
+				activePods = append(activePods, pod)
+			}
+		}
+
+		if len(activePods) < subsetMaxReplicas {
+			missing += subsetMaxReplicas - len(activePods)
+			continue
+		}
+		// evict the least healthy Pods first, just like the deletion-cost does.
+		indexes := sortDeleteIndexes(activePods)
+		for j := 0; j < len(activePods)-subsetMaxReplicas; j++ {
+			surplus = append(surplus, rebalanceVictim{pod: activePods[indexes[j]], subset: subset.Name})
+		}
+	}
+
+	limit := maxEvictions
+	if !hasUnlimited && missing < limit {
+		limit = missing
+	}
+	if len(surplus) < limit {
+		limit = len(surplus)
+	}
+	return surplus[:limit]
+}
+
+// isReadyForRebalance returns false if some Pods are still being created, deleted or are not ready yet,
+// which means the last rebalance may be still in progress.
+func isReadyForRebalance(ws *appsv1beta1.WorkloadSpread, subsetPodMap map[string][]*corev1.Pod) bool {
+	for _, subsetStatus := range ws.Status.SubsetStatuses {
+		if len(subsetStatus.CreatingPods) > 0 || len(subsetStatus.DeletingPods) > 0 {
+			return false
+		}
+	}
+	for _, pods := range subsetPodMap {
+		for _, pod := range pods {
+			if kubecontroller.IsPodActive(pod) && !util.IsRunningAndReady(pod) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func getRebalanceLimits(strategy *appsv1beta1.WorkloadSpreadRebalanceStrategy) (int, time.Duration) {
+	maxEvictions := defaultRebalanceMaxEvictionsPerInterval
+	if strategy.MaxEvictionsPerInterval != nil {
+		maxEvictions = int(*strategy.MaxEvictionsPerInterval)
+	}
+	intervalSeconds := int32(defaultRebalanceIntervalSeconds)
+	if strategy.IntervalSeconds != nil {
+		intervalSeconds = *strategy.IntervalSeconds
+	}
+	return maxEvictions, time.Duration(intervalSeconds) * time.Second
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadspread
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func newRebalancePods(prefix string, num int) []*corev1.Pod {
+	pods := make([]*corev1.Pod, num)
+	for i := range pods {
+		pod := podDemo.DeepCopy()
+		pod.Name = fmt.Sprintf("%s-%d", prefix, i)
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		pods[i] = pod
+	}
+	return pods
+}
+
+func newRebalanceWorkloadSpread() *appsv1beta1.WorkloadSpread {
+	ws := workloadSpreadDemo.DeepCopy()
+	ws.Spec.Subsets = []appsv1beta1.WorkloadSpreadSubset{
+		{
+			Name:        "subset-a",
+			MaxReplicas: &intstr.IntOrString{Type: intstr.Int, IntVal: 2},
+		},
+		{
+			Name:        "subset-b",
+			MaxReplicas: &intstr.IntOrString{Type: intstr.Int, IntVal: 4},
+		},
+	}
+	ws.Spec.RebalanceStrategy = &appsv1beta1.WorkloadSpreadRebalanceStrategy{
+		MaxEvictionsPerInterval: pointer.Int32Ptr(2),
+	}
+	ws.Status.SubsetStatuses = nil
+	return ws
+}
+
+func TestRebalanceSubsets(t *testing.T) {
+	cases := []struct {
+		name              string
+		getWorkloadSpread func() *appsv1beta1.WorkloadSpread
+		getSubsetPodMap   func() map[string][]*corev1.Pod
+		expectEvicted     int
+		expectRebalanced  bool
+	}{
+		{
+			name: "rebalance strategy is nil",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				ws := newRebalanceWorkloadSpread()
+				ws.Spec.RebalanceStrategy = nil
+				return ws
+			},
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{"subset-a": newRebalancePods("a", 5)}
+			},
+		},
+		{
+			name:              "evict surplus pods limited by maxEvictionsPerInterval",
+			getWorkloadSpread: newRebalanceWorkloadSpread,
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{"subset-a": newRebalancePods("a", 5)}
+			},
+			expectEvicted:    2,
+			expectRebalanced: true,
+		},
+		{
+			name:              "evict surplus pods limited by missing replicas",
+			getWorkloadSpread: newRebalanceWorkloadSpread,
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{
+					"subset-a": newRebalancePods("a", 5),
+					"subset-b": newRebalancePods("b", 3),
+				}
+			},
+			expectEvicted:    1,
+			expectRebalanced: true,
+		},
+		{
+			name: "last subset is unlimited",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				ws := newRebalanceWorkloadSpread()
+				ws.Spec.Subsets[1].MaxReplicas = nil
+				return ws
+			},
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{
+					"subset-a": newRebalancePods("a", 5),
+					"subset-b": newRebalancePods("b", 10),
+				}
+			},
+			expectEvicted:    2,
+			expectRebalanced: true,
+		},
+		{
+			name:              "no subset misses replicas",
+			getWorkloadSpread: newRebalanceWorkloadSpread,
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{
+					"subset-a": newRebalancePods("a", 5),
+					"subset-b": newRebalancePods("b", 4),
+				}
+			},
+		},
+		{
+			name: "interval has not passed",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				ws := newRebalanceWorkloadSpread()
+				ws.Status.LastRebalanceTime = &metav1.Time{Time: time.Now().Add(-10 * time.Second)}
+				return ws
+			},
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{"subset-a": newRebalancePods("a", 5)}
+			},
+		},
+		{
+			name: "interval has passed",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				ws := newRebalanceWorkloadSpread()
+				ws.Status.LastRebalanceTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+				return ws
+			},
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{"subset-a": newRebalancePods("a", 5)}
+			},
+			expectEvicted:    2,
+			expectRebalanced: true,
+		},
+		{
+			name:              "wait for not ready pods",
+			getWorkloadSpread: newRebalanceWorkloadSpread,
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				pods := newRebalancePods("a", 5)
+				pods[0].Status.Conditions = nil
+				return map[string][]*corev1.Pod{"subset-a": pods}
+			},
+		},
+		{
+			name: "wait for creating pods",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				ws := newRebalanceWorkloadSpread()
+				ws.Status.SubsetStatuses = []appsv1beta1.WorkloadSpreadSubsetStatus{
+					{Name: "subset-b", CreatingPods: map[string]metav1.Time{"b-0": metav1.Now()}},
+				}
+				return ws
+			},
+			getSubsetPodMap: func() map[string][]*corev1.Pod {
+				return map[string][]*corev1.Pod{"subset-a": newRebalancePods("a", 5)}
+			},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ws := cs.getWorkloadSpread()
+			subsetPodMap := cs.getSubsetPodMap()
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			var total int
+			for _, pods := range subsetPodMap {
+				for _, pod := range pods {
+					builder.WithObjects(pod.DeepCopy())
+					total++
+				}
+			}
+			fakeClient := builder.Build()
+			reconciler := ReconcileWorkloadSpread{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(10),
+			}
+
+			lastRebalanceTime, err := reconciler.rebalanceSubsets(ws, subsetPodMap, 6)
+			if err != nil {
+				t.Fatalf("rebalance subsets failed: %s", err.Error())
+			}
+
+			podList := &corev1.PodList{}
+			if err = fakeClient.List(context.TODO(), podList); err != nil {
+				t.Fatalf("list pods failed: %s", err.Error())
+			}
+			if evicted := total - len(podList.Items); evicted != cs.expectEvicted {
+				t.Fatalf("expect %d evicted pods, but got %d", cs.expectEvicted, evicted)
+			}
+			rebalanced := lastRebalanceTime != ws.Status.LastRebalanceTime
+			if rebalanced != cs.expectRebalanced {
+				t.Fatalf("expect rebalanced %v, but got %v", cs.expectRebalanced, rebalanced)
+			}
+		})
+	}
+}
@@ -203,6 +203,7 @@ type ReconcileWorkloadSpread struct {
 // +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
 
 func (r *ReconcileWorkloadSpread) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
 	ws := &appsv1beta1.WorkloadSpread{}
@@ -384,8 +385,8 @@ func (r *ReconcileWorkloadSpread) filterWorkload(ws *appsv1beta1.WorkloadSpread,
 
 // syncWorkloadSpread is the main logic of the WorkloadSpread controller. Firstly, we get Pods from workload managed by
 // WorkloadSpread and then classify these Pods to each corresponding subset. Secondly, we set Pod deletion-cost annotation
-// value by compare the number of subset's Pods with the subset's maxReplicas, and then we consider rescheduling failed Pods.
-// Lastly, we update the WorkloadSpread's Status and clean up scheduled failed Pods. controller should collaborate with webhook
+// value by compare the number of subset's Pods with the subset's maxReplicas, and then we consider rescheduling failed Pods
+// and evicting surplus Pods for rebalancing. Lastly, we update the WorkloadSpread's Status and clean up scheduled failed Pods. controller should collaborate with webhook
 // to maintain WorkloadSpread status together. The controller is responsible for calculating the real status, and the webhook
 // mainly counts missingReplicas and records the creation or deletion entry of Pod into map.
 func (r *ReconcileWorkloadSpread) syncWorkloadSpread(ws *appsv1beta1.WorkloadSpread) error {
@@ -420,11 +421,18 @@ func (r *ReconcileWorkloadSpread) syncWorkloadSpread(ws *appsv1beta1.WorkloadSpr
 		return nil
 	}
 
-	// update status
+	// evict surplus Pods from over-full subsets if rebalance is enabled
+	var rebalanceErr error
+	status.LastRebalanceTime, rebalanceErr = r.rebalanceSubsets(ws, subsetPodMap, workloadReplicas)
+
+	// update status, even if some evictions failed, to record the LastRebalanceTime of the evicted ones
 	err = r.UpdateWorkloadSpreadStatus(ws, status)
 	if err != nil {
 		return err
 	}
+	if rebalanceErr != nil {
+		return rebalanceErr
+	}
 
 	// clean up unschedulable Pods
 	return r.cleanupUnscheduledPods(ws, scheduleFailedPodMap)
@@ -0,0 +1,364 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlaunch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	kubeletcontainer "k8s.io/kubernetes/pkg/kubelet/container"
+	utilexec "k8s.io/utils/exec"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/client"
+	daemonruntime "github.com/openkruise/kruise/pkg/daemon/criruntime"
+	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
+	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+)
+
+var (
+	workers = 5
+
+	probeTimeout = 3 * time.Second
+	// the dependencies are probed with backoff from probeInterval to maxProbeInterval
+	probeInterval    = 2 * time.Second
+	maxProbeInterval = time.Minute
+	// a warning event is recorded if the dependencies have not been satisfied after the pod started for dependencyWaitTimeout
+	dependencyWaitTimeout = 5 * time.Minute
+)
+
+// Controller probes the file and tcpSocket launch dependencies of containers in the pods on this node,
+// and reports the containers whose dependencies have been satisfied into pod annotation.
+type Controller struct {
+	queue            workqueue.RateLimitingInterface
+	probeRateLimiter workqueue.RateLimiter
+	runtimeClient    runtimeclient.Client
+	podLister        corelisters.PodLister
+	runtimeFactory   daemonruntime.Factory
+	eventRecorder    record.EventRecorder
+
+	// warnedPods records the reasons of warning events that have been recorded for each pod,
+	// so that a warning is recorded only once for a pod instead of every probing.
+	warnedLock sync.Mutex
+	warnedPods map[types.UID]sets.Set[string]
+}
+
+// NewController returns the Controller for container launch dependencies probing
+func NewController(opts daemonoptions.Options) (*Controller, error) {
+	if opts.PodInformer == nil {
+		return nil, fmt.Errorf("containerlaunch Controller can not run without pod informer")
+	}
+
+	genericClient := client.GetGenericClientWithName("kruise-daemon-containerlaunch")
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: genericClient.KubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(opts.Scheme, v1.EventSource{Component: "kruise-daemon-containerlaunch", Host: opts.NodeName})
+
+	queue := workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewItemExponentialFailureRateLimiter(500*time.Millisecond, 30*time.Second),
+		"container_launch_dependency",
+	)
+
+	c := &Controller{
+		queue:            queue,
+		probeRateLimiter: workqueue.NewItemExponentialFailureRateLimiter(probeInterval, maxProbeInterval),
+		runtimeClient:    opts.RuntimeClient,
+		podLister:        corelisters.NewPodLister(opts.PodInformer.GetIndexer()),
+		runtimeFactory:   opts.RuntimeFactory,
+		eventRecorder:    recorder,
+		warnedPods:       map[types.UID]sets.Set[string]{},
+	}
+
+	opts.PodInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pod, ok := obj.(*v1.Pod)
+			if ok && len(getContainersToProbe(pod)) > 0 {
+				queue.Add(pod.Namespace + "/" + pod.Name)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			pod := newObj.(*v1.Pod)
+			if len(getContainersToProbe(pod)) > 0 {
+				queue.Add(pod.Namespace + "/" + pod.Name)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*v1.Pod); ok {
+				c.forgetWarnings(pod.UID)
+			}
+		},
+	})
+
+	return c, nil
+}
+
+// getContainersToProbe returns the containers in pod which wait for file or tcpSocket dependencies
+// that have not been reported as satisfied.
+func getContainersToProbe(pod *v1.Pod) map[string][]appspub.ContainerLaunchDependency {
+	if pod.DeletionTimestamp != nil || pod.Annotations[appspub.ContainerLaunchPriorityCompletedKey] == "true" {
+		return nil
+	}
+	if _, ok := pod.Annotations[appspub.ContainerLaunchDependenciesKey]; !ok {
+		return nil
+	}
+	dependencies, err := appspub.GetContainerLaunchDependencies(pod)
+	if err != nil {
+		return nil
+	}
+	probedNames, _ := appspub.GetContainerLaunchDependenciesProbed(pod)
+	probed := sets.New[string](probedNames...)
+
+	containers := map[string][]appspub.ContainerLaunchDependency{}
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if probed.Has(c.Name) || !utilcontainerlaunchpriority.IsDependencyBarrier(c) {
+			continue
+		}
+		for _, dep := range dependencies[c.Name] {
+			if dep.IsProbeRequired() {
+				containers[c.Name] = append(containers[c.Name], dep)
+			}
+		}
+	}
+	return containers
+}
+
+func (c *Controller) Run(stop <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting containerlaunch Controller")
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stop)
+	}
+
+	klog.Info("Started containerlaunch Controller successfully")
+	<-stop
+}
+
+// processNextWorkItem will read a single work item off the workqueue and
+// attempt to process it, by calling the syncHandler.
+func (c *Controller) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	requeue, err := c.sync(key.(string))
+	if err != nil {
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	if requeue {
+		c.queue.AddAfter(key, c.probeRateLimiter.When(key))
+	} else {
+		c.probeRateLimiter.Forget(key)
+	}
+	return true
+}
+
+func (c *Controller) sync(key string) (requeue bool, err error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		klog.InfoS("Invalid key", "key", key)
+		return false, nil
+	}
+
+	pod, err := c.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		klog.ErrorS(err, "Failed to get Pod from lister", "namespace", namespace, "name", name)
+		return false, err
+	}
+
+	containers := getContainersToProbe(pod)
+	if len(containers) == 0 {
+		return false, nil
+	}
+
+	probedNames, _ := appspub.GetContainerLaunchDependenciesProbed(pod)
+	var satisfied []string
+	for containerName, dependencies := range containers {
+		ok := true
+		for i := range dependencies {
+			if ok = c.probe(pod, &dependencies[i]); !ok {
+				break
+			}
+		}
+		if ok {
+			satisfied = append(satisfied, containerName)
+		}
+	}
+	if len(satisfied) == 0 {
+		c.warnIfWaitingTooLong(pod, containers, satisfied)
+		return true, nil
+	}
+
+	probedNames = append(probedNames, satisfied...)
+	klog.InfoS("Reporting containers whose launch dependencies are satisfied", "namespace", namespace, "name", name, "containers", satisfied)
+	mergePatch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				appspub.ContainerLaunchDependenciesProbedKey: probeNamesJSON(probedNames),
+			},
+		},
+	})
+	newPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name}}
+	if err := c.runtimeClient.Status().Patch(context.TODO(), newPod, runtimeclient.RawPatch(types.StrategicMergePatchType, mergePatch)); err != nil {
+		return false, fmt.Errorf("failed to patch pod: %v", err)
+	}
+	if len(satisfied) < len(containers) {
+		c.warnIfWaitingTooLong(pod, containers, satisfied)
+		return true, nil
+	}
+	return false, nil
+}
+
+// warnIfWaitingTooLong records a warning event if the containers have been waiting for dependencies over dependencyWaitTimeout.
+func (c *Controller) warnIfWaitingTooLong(pod *v1.Pod, containers map[string][]appspub.ContainerLaunchDependency, satisfied []string) {
+	startTime := pod.CreationTimestamp
+	if pod.Status.StartTime != nil {
+		startTime = *pod.Status.StartTime
+	}
+	if time.Since(startTime.Time) < dependencyWaitTimeout {
+		return
+	}
+	waiting := sets.KeySet(containers).Delete(satisfied...)
+	c.warnOnce(pod, "LaunchDependenciesNotSatisfied",
+		"Containers %v have been waiting for launch dependencies for more than %v", sets.List(waiting), dependencyWaitTimeout)
+}
+
+// warnOnce records a warning event for the pod if it has not been recorded with the same reason before.
+func (c *Controller) warnOnce(pod *v1.Pod, reason, messageFmt string, args ...interface{}) {
+	c.warnedLock.Lock()
+	defer c.warnedLock.Unlock()
+	if c.warnedPods == nil {
+		c.warnedPods = map[types.UID]sets.Set[string]{}
+	}
+	if c.warnedPods[pod.UID].Has(reason) {
+		return
+	}
+	if c.warnedPods[pod.UID] == nil {
+		c.warnedPods[pod.UID] = sets.New[string]()
+	}
+	c.warnedPods[pod.UID].Insert(reason)
+	c.eventRecorder.Eventf(pod, v1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+func (c *Controller) forgetWarnings(uid types.UID) {
+	c.warnedLock.Lock()
+	defer c.warnedLock.Unlock()
+	delete(c.warnedPods, uid)
+}
+
+func probeNamesJSON(names []string) string {
+	b, _ := json.Marshal(sets.List(sets.New[string](names...)))
+	return string(b)
+}
+
+func (c *Controller) probe(pod *v1.Pod, dep *appspub.ContainerLaunchDependency) bool {
+	switch {
+	case dep.TCPSocket != nil:
+		return probeTCPSocket(pod, dep.TCPSocket.Port)
+	case dep.File != nil:
+		return c.probeFile(pod, dep.File)
+	}
+	return true
+}
+
+func probeTCPSocket(pod *v1.Pod, port int32) bool {
+	if pod.Status.PodIP == "" {
+		return false
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))), probeTimeout)
+	if err != nil {
+		klog.V(4).InfoS("TCP socket dependency is not satisfied", "pod", klog.KObj(pod), "port", port, "err", err)
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+func (c *Controller) probeFile(pod *v1.Pod, file *appspub.ContainerLaunchFileDependency) bool {
+	var status *v1.ContainerStatus
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == file.Container {
+			status = &pod.Status.ContainerStatuses[i]
+			break
+		}
+	}
+	if status == nil || status.State.Running == nil || status.ContainerID == "" {
+		return false
+	}
+
+	containerID := kubeletcontainer.ContainerID{}
+	if err := containerID.ParseString(status.ContainerID); err != nil {
+		klog.ErrorS(err, "Failed to parse containerID", "pod", klog.KObj(pod), "containerID", status.ContainerID)
+		return false
+	}
+	runtimeService := c.runtimeFactory.GetRuntimeServiceByName(containerID.Type)
+	if runtimeService == nil {
+		klog.InfoS("Not found runtime service in daemon", "pod", klog.KObj(pod), "runtimeName", containerID.Type)
+		return false
+	}
+
+	// the file is checked by `test -e` in the container, which exits with 1 if the file does not exist,
+	// other failures mean the command can not work in the container, e.g., the image has no `test` command
+	ctx, cancel := context.WithTimeout(context.TODO(), probeTimeout)
+	defer cancel()
+	_, _, err := runtimeService.ExecSync(ctx, containerID.ID, []string{"test", "-e", file.Path}, probeTimeout)
+	if err == nil {
+		return true
+	}
+	if exitErr, ok := err.(utilexec.ExitError); ok && exitErr.ExitStatus() == 1 {
+		klog.V(4).InfoS("File dependency is not satisfied", "pod", klog.KObj(pod), "container", file.Container, "path", file.Path)
+		return false
+	}
+	klog.InfoS("Failed to probe file dependency", "pod", klog.KObj(pod), "container", file.Container, "path", file.Path, "err", err)
+	c.warnOnce(pod, "FailedProbeLaunchDependency",
+		"Failed to probe file %s by `test -e` in container %s, which requires the `test` command in the container: %v", file.Path, file.Container, err)
+	return false
+}
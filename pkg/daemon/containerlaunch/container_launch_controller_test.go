@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlaunch
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	criapi "k8s.io/cri-api/pkg/apis"
+	critesting "k8s.io/cri-api/pkg/apis/testing"
+	utilexec "k8s.io/utils/exec"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	daemonruntime "github.com/openkruise/kruise/pkg/daemon/criruntime"
+	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+)
+
+func TestGetContainersToProbe(t *testing.T) {
+	newPod := func(annotations map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake", Annotations: annotations},
+			Spec: v1.PodSpec{Containers: []v1.Container{
+				{Name: "a"},
+				{Name: "b", Env: []v1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("b", "fake")}},
+				{Name: "c", Env: []v1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("c", "fake")}},
+			}},
+		}
+	}
+	dependencies := `{"b":[{"container":"a"}],"c":[{"container":"a"},{"tcpSocket":{"port":80}}]}`
+
+	tests := []struct {
+		name     string
+		pod      *v1.Pod
+		expected []string
+	}{
+		{
+			name: "no dependencies",
+			pod:  newPod(nil),
+		},
+		{
+			name:     "tcpSocket to probe",
+			pod:      newPod(map[string]string{appspub.ContainerLaunchDependenciesKey: dependencies}),
+			expected: []string{"c"},
+		},
+		{
+			name: "already probed",
+			pod: newPod(map[string]string{
+				appspub.ContainerLaunchDependenciesKey:       dependencies,
+				appspub.ContainerLaunchDependenciesProbedKey: `["c"]`,
+			}),
+		},
+		{
+			name: "launch completed",
+			pod: newPod(map[string]string{
+				appspub.ContainerLaunchDependenciesKey:      dependencies,
+				appspub.ContainerLaunchPriorityCompletedKey: "true",
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			containers := getContainersToProbe(tt.pod)
+			var names []string
+			for name, deps := range containers {
+				names = append(names, name)
+				assert.Len(t, deps, 1)
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestProbeTCPSocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	port := int32(listener.Addr().(*net.TCPAddr).Port)
+
+	pod := &v1.Pod{Status: v1.PodStatus{PodIP: "127.0.0.1"}}
+	assert.True(t, probeTCPSocket(pod, port))
+	assert.False(t, probeTCPSocket(&v1.Pod{}, port))
+
+	listener.Close()
+	assert.False(t, probeTCPSocket(pod, port))
+}
+
+func TestProbeBackoffAndWarning(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "fake",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			Annotations:       map[string]string{appspub.ContainerLaunchDependenciesKey: `{"b":[{"tcpSocket":{"port":80}}]}`},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "a"},
+			{Name: "b", Env: []v1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("b", "fake")}},
+		}},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(pod))
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{
+		queue:            workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		probeRateLimiter: workqueue.NewItemExponentialFailureRateLimiter(probeInterval, maxProbeInterval),
+		podLister:        corelisters.NewPodLister(indexer),
+		eventRecorder:    recorder,
+	}
+	defer c.queue.ShutDown()
+
+	// the pod has no IP, so the tcpSocket dependency can not be satisfied
+	key := "default/fake"
+	for i := 1; i <= 3; i++ {
+		c.queue.Add(key)
+		assert.True(t, c.processNextWorkItem())
+		assert.Equal(t, i, c.probeRateLimiter.NumRequeues(key))
+	}
+	assert.Equal(t, probeInterval*8, c.probeRateLimiter.When(key))
+
+	// the warning is recorded only once for the pod
+	assert.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.True(t, strings.HasPrefix(event, "Warning LaunchDependenciesNotSatisfied Containers [b]"), event)
+
+	// the backoff is reset once the pod has nothing to probe
+	assert.NoError(t, indexer.Delete(pod))
+	c.queue.Add(key)
+	assert.True(t, c.processNextWorkItem())
+	assert.Equal(t, 0, c.probeRateLimiter.NumRequeues(key))
+}
+
+type fakeRuntimeFactory struct {
+	daemonruntime.Factory
+	runtimeService criapi.RuntimeService
+}
+
+func (f *fakeRuntimeFactory) GetRuntimeServiceByName(runtimeName string) criapi.RuntimeService {
+	return f.runtimeService
+}
+
+func TestProbeFile(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "fake", UID: "fake-uid"},
+		Status: v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{
+			Name:        "a",
+			ContainerID: "containerd://a",
+			State:       v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+		}}},
+	}
+	file := &appspub.ContainerLaunchFileDependency{Container: "a", Path: "/ready"}
+	runtimeService := critesting.NewFakeRuntimeService()
+	recorder := record.NewFakeRecorder(10)
+	c := &Controller{runtimeFactory: &fakeRuntimeFactory{runtimeService: runtimeService}, eventRecorder: recorder}
+
+	assert.True(t, c.probeFile(pod, file))
+
+	// the file does not exist
+	runtimeService.InjectError("ExecSync", utilexec.CodeExitError{Err: fmt.Errorf("exited with 1"), Code: 1})
+	assert.False(t, c.probeFile(pod, file))
+	assert.Len(t, recorder.Events, 0)
+
+	// the `test` command can not be executed in the container, which is warned only once
+	for i := 0; i < 2; i++ {
+		runtimeService.InjectError("ExecSync", fmt.Errorf(`exec: "test": executable file not found in $PATH`))
+		assert.False(t, c.probeFile(pod, file))
+	}
+	assert.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.True(t, strings.HasPrefix(event, "Warning FailedProbeLaunchDependency Failed to probe file /ready"), event)
+
+	// the warning is recorded again for the pod after it has been forgotten
+	c.forgetWarnings(pod.UID)
+	runtimeService.InjectError("ExecSync", utilexec.CodeExitError{Err: fmt.Errorf("exited with 127"), Code: 127})
+	assert.False(t, c.probeFile(pod, file))
+	assert.Len(t, recorder.Events, 1)
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -48,6 +50,7 @@ import (
 	"github.com/openkruise/kruise/pkg/daemon/kuberuntime"
 	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
 	"github.com/openkruise/kruise/pkg/util"
+	utilcontainerrecreate "github.com/openkruise/kruise/pkg/util/containerrecreate"
 	"github.com/openkruise/kruise/pkg/util/expectations"
 )
 
@@ -56,6 +59,13 @@ const (
 	workers = 32
 
 	maxExpectationWaitDuration = 10 * time.Second
+
+	overrideObserveInterval        = time.Second
+	maxOverrideObserveWaitDuration = time.Minute
+
+	defaultCaptureTailLines = 50
+	maxCapturedLogBytes     = 16 * 1024
+	captureTimeout          = 5 * time.Second
 )
 
 var (
@@ -67,6 +77,7 @@ type Controller struct {
 	runtimeClient  runtimeclient.Client
 	crrInformer    cache.SharedIndexInformer
 	crrLister      listersalpha1.ContainerRecreateRequestLister
+	podLister      corelisters.PodLister
 	eventRecorder  record.EventRecorder
 	runtimeFactory daemonruntime.Factory
 }
@@ -114,11 +125,17 @@ func NewController(opts daemonoptions.Options) (*Controller, error) {
 		return nil
 	})
 
+	var podLister corelisters.PodLister
+	if opts.PodInformer != nil {
+		podLister = corelisters.NewPodLister(opts.PodInformer.GetIndexer())
+	}
+
 	return &Controller{
 		queue:          queue,
 		runtimeClient:  opts.RuntimeClient,
 		crrInformer:    informer,
 		crrLister:      listersalpha1.NewContainerRecreateRequestLister(informer.GetIndexer()),
+		podLister:      podLister,
 		eventRecorder:  recorder,
 		runtimeFactory: opts.RuntimeFactory,
 	}, nil
@@ -249,6 +266,34 @@ func (c *Controller) sync(key string) (retErr error) {
 		return c.updateCRRPhase(crr, appsv1alpha1.ContainerRecreateRequestRecreating)
 	}
 
+	if utilcontainerrecreate.HasOverride(crr) {
+		appliedTimeStr := crr.Annotations[appsv1alpha1.ContainerRecreateRequestOverrideAppliedKey]
+		if appliedTimeStr == "" {
+			klog.InfoS("CRR is waiting for override applied", "namespace", crr.Namespace, "name", crr.Name)
+			return nil
+		}
+
+		// the containers must not be recreated until the overridden Pod metadata is observed on this node,
+		// otherwise they may start with the env of the stale metadata
+		observed, err := c.isOverrideObserved(crr)
+		if err != nil {
+			return err
+		} else if !observed {
+			appliedTime, err := time.Parse(time.RFC3339, appliedTimeStr)
+			if err != nil {
+				klog.ErrorS(err, "CRR failed to parse override applied time", "namespace", crr.Namespace, "name", crr.Name, "appliedTimeStr", appliedTimeStr)
+				return c.completeCRRStatus(crr, fmt.Sprintf("failed to parse override applied time %s: %v", appliedTimeStr, err))
+			}
+			if time.Since(appliedTime) > maxOverrideObserveWaitDuration {
+				klog.InfoS("CRR has not observed the overridden Pod metadata for a long time", "namespace", crr.Namespace, "name", crr.Name)
+				return c.completeCRRStatus(crr, "overridden Pod metadata has not been observed")
+			}
+			klog.InfoS("CRR is waiting for overridden Pod metadata observed", "namespace", crr.Namespace, "name", crr.Name)
+			c.queue.AddAfter(crr.Namespace+"/"+crr.Spec.PodName, overrideObserveInterval)
+			return nil
+		}
+	}
+
 	if crr.Spec.Strategy.UnreadyGracePeriodSeconds != nil {
 		unreadyTimeStr := crr.Annotations[appsv1alpha1.ContainerRecreateRequestUnreadyAcquiredKey]
 		if unreadyTimeStr == "" {
@@ -273,6 +318,28 @@ func (c *Controller) sync(key string) (retErr error) {
 	return c.manage(crr)
 }
 
+// isOverrideObserved returns whether the env hash from the Pod metadata has been changed to the one expected by the override.
+func (c *Controller) isOverrideObserved(crr *appsv1alpha1.ContainerRecreateRequest) (bool, error) {
+	var pod *v1.Pod
+	var err error
+	if c.podLister != nil {
+		pod, err = c.podLister.Pods(crr.Namespace).Get(crr.Spec.PodName)
+	} else {
+		pod = &v1.Pod{}
+		err = c.runtimeClient.Get(context.TODO(), types.NamespacedName{Namespace: crr.Namespace, Name: crr.Spec.PodName}, pod)
+	}
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if string(pod.UID) != crr.Labels[appsv1alpha1.ContainerRecreateRequestPodUIDKey] {
+		return false, nil
+	}
+	return utilcontainerrecreate.IsOverrideObserved(crr, pod), nil
+}
+
 func (c *Controller) pickRecreateRequest(crrList []*appsv1alpha1.ContainerRecreateRequest) (*appsv1alpha1.ContainerRecreateRequest, error) {
 	sort.Sort(crrListByPhaseAndCreated(crrList))
 	var picked *appsv1alpha1.ContainerRecreateRequest
@@ -353,6 +420,12 @@ func (c *Controller) manage(crr *appsv1alpha1.ContainerRecreateRequest) error {
 			break
 		}
 
+		// capture the logs and state before running preStop hook and killing the container
+		if crr.Spec.Strategy.CaptureBeforeRecreate != nil && state.Captured == nil {
+			state.Captured = captureContainer(runtimeManager, crr, podStatus, kubeContainerStatus)
+			return c.patchCRRContainerRecreateStates(crr, newCRRContainerRecreateStates)
+		}
+
 		msg := fmt.Sprintf("Stopping container %s by ContainerRecreateRequest %s", state.Name, crr.Name)
 		err := runtimeManager.KillContainer(pod, kubeContainerStatus.ID, state.Name, msg, nil)
 		if err != nil {
@@ -424,6 +497,30 @@ func (c *Controller) completeCRRStatus(crr *appsv1alpha1.ContainerRecreateReques
 	return c.runtimeClient.Status().Update(context.TODO(), crr)
 }
 
+func captureContainer(runtimeManager kuberuntime.Runtime, crr *appsv1alpha1.ContainerRecreateRequest, podStatus *kubeletcontainer.PodStatus,
+	kubeContainerStatus *kubeletcontainer.Status) *appsv1alpha1.ContainerRecreateRequestCapturedState {
+	captured := &appsv1alpha1.ContainerRecreateRequestCapturedState{
+		CaptureTime:          metav1.Now(),
+		ContainerID:          kubeContainerStatus.ID.String(),
+		RestartCount:         int32(kubeContainerStatus.RestartCount),
+		LastTerminationState: getLastTerminationState(podStatus, kubeContainerStatus),
+	}
+
+	tailLines := int64(defaultCaptureTailLines)
+	if crr.Spec.Strategy.CaptureBeforeRecreate.TailLines != nil {
+		tailLines = *crr.Spec.Strategy.CaptureBeforeRecreate.TailLines
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), captureTimeout)
+	defer cancel()
+	logs, err := runtimeManager.GetContainerLogs(ctx, kubeContainerStatus.ID, tailLines, maxCapturedLogBytes)
+	if err != nil {
+		klog.ErrorS(err, "Failed to capture logs of container for CRR", "containerName", kubeContainerStatus.Name, "namespace", crr.Namespace, "name", crr.Name)
+		captured.Message = fmt.Sprintf("failed to capture logs: %v", err)
+	}
+	captured.Logs = string(logs)
+	return captured
+}
+
 func (c *Controller) newRuntimeManager(runtimeFactory daemonruntime.Factory, crr *appsv1alpha1.ContainerRecreateRequest) (kuberuntime.Runtime, error) {
 	var runtimeName string
 	for i := range crr.Spec.Containers {
@@ -132,6 +132,9 @@ func getCurrentCRRContainersRecreateStates(
 			}
 		}
 
+		if previousContainerRecreateState != nil {
+			currentState.Captured = previousContainerRecreateState.Captured
+		}
 		statuses = append(statuses, currentState)
 	}
 
@@ -220,3 +223,22 @@ func SpecPodNameIndexFunc(obj interface{}) ([]string, error) {
 	}
 	return []string{crr.Spec.PodName}, nil
 }
+
+// getLastTerminationState returns the state of the latest exited container with the name, except the current one.
+func getLastTerminationState(podStatus *kubeletcontainer.PodStatus, current *kubeletcontainer.Status) *v1.ContainerStateTerminated {
+	// container statuses are sorted by created time in descending order
+	for _, status := range podStatus.ContainerStatuses {
+		if status.Name != current.Name || status.ID == current.ID || status.State != kubeletcontainer.ContainerStateExited {
+			continue
+		}
+		return &v1.ContainerStateTerminated{
+			ExitCode:    int32(status.ExitCode),
+			Reason:      status.Reason,
+			Message:     status.Message,
+			StartedAt:   metav1.NewTime(status.StartedAt),
+			FinishedAt:  metav1.NewTime(status.FinishedAt),
+			ContainerID: status.ID.String(),
+		}
+	}
+	return nil
+}
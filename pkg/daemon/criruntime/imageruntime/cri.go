@@ -94,10 +94,43 @@ func (c *commonCRIImageService) ListImages(ctx context.Context) ([]ImageInfo, er
 	return c.listImagesV1alpha2(ctx)
 }
 
+// RemoveImage implements ImageService.RemoveImage.
+func (c *commonCRIImageService) RemoveImage(ctx context.Context, imageID string) error {
+	if c.useV1API() {
+		_, err := c.criImageClient.RemoveImage(ctx, &runtimeapi.RemoveImageRequest{Image: &runtimeapi.ImageSpec{Image: imageID}})
+		return err
+	}
+	_, err := c.criImageClientV1alpha2.RemoveImage(ctx, &runtimeapiv1alpha2.RemoveImageRequest{Image: &runtimeapiv1alpha2.ImageSpec{Image: imageID}})
+	return err
+}
+
+// ImageFsInfo implements ImageService.ImageFsInfo.
+func (c *commonCRIImageService) ImageFsInfo(ctx context.Context) (*ImageFsInfo, error) {
+	info := &ImageFsInfo{}
+	if c.useV1API() {
+		resp, err := c.criImageClient.ImageFsInfo(ctx, &runtimeapi.ImageFsInfoRequest{})
+		if err != nil {
+			return nil, err
+		}
+		for _, fs := range resp.GetImageFilesystems() {
+			info.UsedBytes += int64(fs.GetUsedBytes().GetValue())
+		}
+		return info, nil
+	}
+	resp, err := c.criImageClientV1alpha2.ImageFsInfo(ctx, &runtimeapiv1alpha2.ImageFsInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, fs := range resp.GetImageFilesystems() {
+		info.UsedBytes += int64(fs.GetUsedBytes().GetValue())
+	}
+	return info, nil
+}
+
 // PullImage implements ImageService.PullImage using v1 CRI client.
 func (c *commonCRIImageService) pullImageV1(ctx context.Context, imageName, tag string, pullSecrets []v1.Secret, sandboxConfig *appsv1beta1.SandboxConfig) (ImagePullStatusReader, error) {
 	registry := daemonutil.ParseRegistry(imageName)
-	fullImageName := imageName + ":" + tag
+	fullImageName := daemonutil.JoinImageNameTag(imageName, tag)
 	repoToPull, _, _, err := parsers.ParseImageName(fullImageName)
 	if err != nil {
 		return nil, err
@@ -209,6 +242,7 @@ func (c *commonCRIImageService) listImagesV1(ctx context.Context) ([]ImageInfo,
 			RepoTags:    img.GetRepoTags(),
 			RepoDigests: img.GetRepoDigests(),
 			Size:        int64(img.GetSize_()),
+			Pinned:      img.GetPinned(),
 		})
 	}
 	return collection, nil
@@ -217,7 +251,7 @@ func (c *commonCRIImageService) listImagesV1(ctx context.Context) ([]ImageInfo,
 // PullImage implements ImageService.PullImage using v1alpha2 CRI client.
 func (c *commonCRIImageService) pullImageV1alpha2(ctx context.Context, imageName, tag string, pullSecrets []v1.Secret, sandboxConfig *appsv1beta1.SandboxConfig) (ImagePullStatusReader, error) {
 	registry := daemonutil.ParseRegistry(imageName)
-	fullImageName := imageName + ":" + tag
+	fullImageName := daemonutil.JoinImageNameTag(imageName, tag)
 	repoToPull, _, _, err := parsers.ParseImageName(fullImageName)
 	if err != nil {
 		return nil, err
@@ -329,6 +363,7 @@ func (c *commonCRIImageService) listImagesV1alpha2(ctx context.Context) ([]Image
 			RepoTags:    img.GetRepoTags(),
 			RepoDigests: img.GetRepoDigests(),
 			Size:        int64(img.GetSize_()),
+			Pinned:      img.GetPinned(),
 		})
 	}
 	return collection, nil
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
@@ -89,6 +90,11 @@ import (
 //	return false
 // }
 
+const (
+	layerStatusPullComplete  = "Pull complete"
+	layerStatusAlreadyExists = "Already exists"
+)
+
 type layerProgress struct {
 	*JSONProgress
 	Status string `json:"status,omitempty"` // Extracting,Pull complete,Pulling fs layer,Verifying Checksum,Downloading
@@ -138,6 +144,15 @@ func newPullingProgress() *pullingProgress {
 }
 
 func (pp *pullingProgress) getProgressPercent() int32 {
+	current, total := pp.getBytes()
+	if total == int64(0) {
+		return 0
+	}
+	return int32(current * 100 / total)
+}
+
+// getBytes returns the downloaded and total bytes of all layers
+func (pp *pullingProgress) getBytes() (int64, int64) {
 	current := int64(0)
 	total := int64(0)
 	for _, layerProgress := range pp.Layers {
@@ -146,11 +161,27 @@ func (pp *pullingProgress) getProgressPercent() int32 {
 			total = total + layerProgress.Total
 		}
 	}
+	return current, total
+}
 
-	if total == int64(0) {
-		return 0
+// getLayers returns the number of completed and total layers
+func (pp *pullingProgress) getLayers() (int32, int32) {
+	completed := int32(0)
+	for _, layerProgress := range pp.Layers {
+		switch layerProgress.Status {
+		case layerStatusPullComplete, layerStatusAlreadyExists:
+			completed++
+		}
 	}
-	return int32(current * 100 / total)
+	return completed, int32(len(pp.Layers))
+}
+
+// newImagePullStatus returns the pulling status with the telemetry of progress
+func (pp *pullingProgress) newImagePullStatus() ImagePullStatus {
+	status := ImagePullStatus{Process: int(pp.getProgressPercent()), DetailInfo: util.DumpJSON(pp)}
+	status.DownloadedBytes, status.TotalBytes = pp.getBytes()
+	status.LayersCompleted, status.LayersTotal = pp.getLayers()
+	return status
 }
 
 type imagePullStatusReader struct {
@@ -209,7 +240,9 @@ func (r *imagePullStatusReader) mainloop() {
 			err := decoder.Decode(&jm)
 			if err == io.EOF {
 				klog.V(5).Info("runtime read eof")
-				r.seedPullStatus(ImagePullStatus{Process: 100, Finish: true})
+				status := progress.newImagePullStatus()
+				status.Process, status.Finish = 100, true
+				r.seedPullStatus(status)
 				return
 			}
 			if err != nil {
@@ -232,13 +265,22 @@ func (r *imagePullStatusReader) mainloop() {
 			} else if jm.Status != "" {
 				progress.TotalStatuses = append(progress.TotalStatuses, jm.Status)
 			}
-			currentProgress := progress.getProgressPercent()
-			r.seedPullStatus(ImagePullStatus{Process: int(currentProgress), DetailInfo: util.DumpJSON(progress)})
+			r.seedPullStatus(progress.newImagePullStatus())
 		}
 	}
 }
 
 func (c ImageInfo) ContainsImage(name string, tag string) bool {
+	if strings.Contains(tag, ":") {
+		// the tag is a digest, which is recorded in RepoDigests
+		for _, repoDigest := range c.RepoDigests {
+			imageRepo, digest, _ := daemonutil.NormalizeImageRefToNameTag(repoDigest)
+			if imageRepo == name && digest == tag {
+				return true
+			}
+		}
+		return false
+	}
 	for _, repoTag := range c.RepoTags {
 		// We should remove defaultDomain and officialRepoName in RepoTags by NormalizeImageRefToNameTag method,
 		// Because if the user needs to download the image from hub.docker.com, CRI.PullImage will automatically add these when downloading the image
@@ -251,6 +293,16 @@ func (c ImageInfo) ContainsImage(name string, tag string) bool {
 	return false
 }
 
+// RepoDigest returns the digest of the image in repository name, or empty if not found
+func (c ImageInfo) RepoDigest(name string) string {
+	for _, repoDigest := range c.RepoDigests {
+		if imageRepo, digest, _ := daemonutil.NormalizeImageRefToNameTag(repoDigest); imageRepo == name && strings.Contains(digest, ":") {
+			return digest
+		}
+	}
+	return ""
+}
+
 func determineImageClientAPIVersion(conn *grpc.ClientConn) (runtimeapi.ImageServiceClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -17,6 +17,8 @@ limitations under the License.
 package imageruntime
 
 import (
+	"encoding/json"
+	"io"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
@@ -201,6 +203,27 @@ func TestContainsImage(t *testing.T) {
 			},
 			Expect: true,
 		},
+		{
+			name:      "test_nginx@digest",
+			ImageName: "nginx",
+			Tag:       "sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63",
+			ImageInfos: []ImageInfo{{
+				RepoTags:    []string{"docker.io/library/nginx:latest"},
+				RepoDigests: []string{"docker.io/library/nginx@sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63"},
+			},
+			},
+			Expect: true,
+		},
+		{
+			name:      "test_nginx@digest_false",
+			ImageName: "nginx",
+			Tag:       "sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63",
+			ImageInfos: []ImageInfo{{
+				RepoDigests: []string{"docker.io/test/nginx@sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63"},
+			},
+			},
+			Expect: false,
+		},
 	}
 
 	for _, cs := range cases {
@@ -214,3 +237,38 @@ func TestContainsImage(t *testing.T) {
 		})
 	}
 }
+
+func TestImagePullStatusReaderTelemetry(t *testing.T) {
+	messages := []JSONMessage{
+		{ID: "layer-1", Status: "Downloading", Progress: &JSONProgress{Current: 50, Total: 100}},
+		{ID: "layer-2", Status: layerStatusAlreadyExists},
+		{ID: "layer-1", Status: layerStatusPullComplete, Progress: &JSONProgress{Current: 100, Total: 100}},
+		{ID: "layer-3", Status: "Downloading", Progress: &JSONProgress{Current: 100, Total: 300}},
+	}
+	pipeR, pipeW := io.Pipe()
+	go func() {
+		encoder := json.NewEncoder(pipeW)
+		for _, msg := range messages {
+			_ = encoder.Encode(msg)
+		}
+		pipeW.Close()
+	}()
+
+	reader := newImagePullStatusReader(pipeR)
+	defer reader.Close()
+	var status ImagePullStatus
+	for status = range reader.C() {
+		if status.Finish {
+			break
+		}
+	}
+	if status.Err != nil || status.Process != 100 {
+		t.Fatalf("unexpected finished status %+v", status)
+	}
+	if status.DownloadedBytes != 200 || status.TotalBytes != 400 {
+		t.Fatalf("expected 200/400 bytes, got %d/%d", status.DownloadedBytes, status.TotalBytes)
+	}
+	if status.LayersCompleted != 2 || status.LayersTotal != 3 {
+		t.Fatalf("expected 2/3 layers, got %d/%d", status.LayersCompleted, status.LayersTotal)
+	}
+}
@@ -33,6 +33,8 @@ type ImageInfo struct {
 	RepoTags []string `json:"RepoTags"`
 	// size of image's taking disk space.
 	Size int64 `json:"Size,omitempty"`
+	// pinned images are never removed by the garbage collection, e.g. the sandbox image.
+	Pinned bool `json:"Pinned,omitempty"`
 }
 
 type ImagePullStatus struct {
@@ -40,6 +42,13 @@ type ImagePullStatus struct {
 	Process    int
 	DetailInfo string
 	Finish     bool
+
+	// DownloadedBytes and TotalBytes are the bytes reported by the runtime, zero if it does not stream progress
+	DownloadedBytes int64
+	TotalBytes      int64
+	// LayersCompleted and LayersTotal are the layers reported by the runtime, zero if it does not stream progress
+	LayersCompleted int32
+	LayersTotal     int32
 }
 
 type ImagePullStatusReader interface {
@@ -47,7 +56,16 @@ type ImagePullStatusReader interface {
 	Close()
 }
 
+// ImageFsInfo is the usage of the image filesystems
+type ImageFsInfo struct {
+	// UsedBytes is the bytes used by images on all image filesystems
+	UsedBytes int64
+}
+
 type ImageService interface {
 	PullImage(ctx context.Context, imageName, tag string, pullSecrets []v1.Secret, sandboxConfig *appsv1beta1.SandboxConfig) (ImagePullStatusReader, error)
 	ListImages(ctx context.Context) ([]ImageInfo, error)
+	// RemoveImage removes the image by its ID
+	RemoveImage(ctx context.Context, imageID string) error
+	ImageFsInfo(ctx context.Context) (*ImageFsInfo, error)
 }
@@ -39,6 +39,7 @@ import (
 
 	kruiseapis "github.com/openkruise/kruise/apis"
 	"github.com/openkruise/kruise/pkg/client"
+	"github.com/openkruise/kruise/pkg/daemon/containerlaunch"
 	"github.com/openkruise/kruise/pkg/daemon/containermeta"
 	"github.com/openkruise/kruise/pkg/daemon/containerrecreate"
 	daemonruntime "github.com/openkruise/kruise/pkg/daemon/criruntime"
@@ -85,7 +86,7 @@ type daemon struct {
 }
 
 // NewDaemon create a daemon
-func NewDaemon(cfg *rest.Config, bindAddress string, MaxWorkersForPullImages int) (Daemon, error) {
+func NewDaemon(cfg *rest.Config, bindAddress string, MaxWorkersForPullImages int, imagePeer daemonoptions.ImagePeerOptions) (Daemon, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("cfg can not be nil")
 	}
@@ -135,6 +136,7 @@ func NewDaemon(cfg *rest.Config, bindAddress string, MaxWorkersForPullImages int
 		Healthz:        healthz,
 
 		MaxWorkersForPullImages: MaxWorkersForPullImages,
+		ImagePeer:               imagePeer,
 	}
 
 	puller, err := imagepuller.NewController(opts, secretManager, cfg)
@@ -169,6 +171,14 @@ func NewDaemon(cfg *rest.Config, bindAddress string, MaxWorkersForPullImages int
 		runnables = append(runnables, containerMetaController)
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.DaemonWatchingPod) && utilfeature.DefaultFeatureGate.Enabled(features.ContainerLaunchDependencyGate) {
+		containerLaunchController, err := containerlaunch.NewController(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to new containerlaunch controller: %v", err)
+		}
+		runnables = append(runnables, containerLaunchController)
+	}
+
 	return &daemon{
 		runtimeFactory: runtimeFactory,
 		podInformer:    podInformer,
@@ -0,0 +1,412 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+)
+
+const (
+	defaultImageGCIntervalSeconds = 300
+	maxRemovedImagesInStatus      = 20
+	imageGCTimeout                = 10 * time.Minute
+
+	// reasons of image removal
+	ImageGCReasonMaxAge                = "MaxAge"
+	ImageGCReasonMaxCountPerRepository = "MaxCountPerRepository"
+	ImageGCReasonImageFsUsage          = "ImageFsUsage"
+)
+
+var sandboxImage = flag.String("sandbox-image", "registry.k8s.io/pause",
+	"The sandbox image of the container runtime in the form of name or name:tag, which is never removed by image garbage collection.")
+
+// containerLister lists the containers on the node, it is implemented by the CRI runtime service
+type containerLister interface {
+	ListContainers(ctx context.Context, filter *runtimeapi.ContainerFilter) ([]*runtimeapi.Container, error)
+}
+
+// imageRecord records the usage of an image observed by kruise-daemon
+type imageRecord struct {
+	// lastUsed is the last time the image was used by containers, or first detected if never used
+	lastUsed time.Time
+}
+
+// imageGCManager removes the unused images on the node according to the GCPolicy in NodeImage
+type imageGCManager struct {
+	imageService    runtimeimage.ImageService
+	containerLister containerLister
+	clock           func() time.Time
+	// templateImages returns the images referenced by the pod templates of workloads
+	templateImages func() []string
+	// sandboxImage is the sandbox image of the container runtime, which is always kept
+	sandboxImage string
+
+	mu      sync.Mutex
+	records map[string]*imageRecord
+	lastGC  time.Time
+	status  *appsv1beta1.ImageGCStatus
+}
+
+func newImageGCManager(imageService runtimeimage.ImageService, containerLister containerLister) *imageGCManager {
+	return &imageGCManager{
+		imageService:    imageService,
+		containerLister: containerLister,
+		clock:           time.Now,
+		sandboxImage:    *sandboxImage,
+		records:         make(map[string]*imageRecord),
+	}
+}
+
+// getStatus returns the status of the latest garbage collection
+func (m *imageGCManager) getStatus() *appsv1beta1.ImageGCStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status.DeepCopy()
+}
+
+// shouldGarbageCollect returns true if the interval of the policy has elapsed since the latest garbage collection
+func (m *imageGCManager) shouldGarbageCollect(policy *appsv1beta1.ImageGCPolicy) bool {
+	if policy == nil {
+		return false
+	}
+	interval := time.Duration(defaultImageGCIntervalSeconds) * time.Second
+	if policy.IntervalSeconds != nil {
+		interval = time.Duration(*policy.IntervalSeconds) * time.Second
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.clock().Sub(m.lastGC) >= interval
+}
+
+// garbageCollect removes the unused images according to the GCPolicy of nodeImage
+func (m *imageGCManager) garbageCollect(ctx context.Context, nodeImage *appsv1beta1.NodeImage) error {
+	policy := nodeImage.Spec.GCPolicy
+	if policy == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock()
+	m.lastGC = now
+	removed, usedBytes, unused, err := m.doGarbageCollect(ctx, nodeImage, policy, now)
+
+	status := &appsv1beta1.ImageGCStatus{LastGCTime: &metav1.Time{Time: now}, ImageFsUsedBytes: usedBytes, UnusedImages: unused}
+	if m.status != nil {
+		status.RemovedImages = m.status.RemovedImages
+	} else if nodeImage.Status.GarbageCollection != nil {
+		status.RemovedImages = nodeImage.Status.GarbageCollection.RemovedImages
+	}
+	if unused == nil {
+		// keep the last used times if the images could not be listed
+		if m.status != nil {
+			status.UnusedImages = m.status.UnusedImages
+		} else if nodeImage.Status.GarbageCollection != nil {
+			status.UnusedImages = nodeImage.Status.GarbageCollection.UnusedImages
+		}
+	}
+	status.RemovedImages = append(removed, status.RemovedImages...)
+	if len(status.RemovedImages) > maxRemovedImagesInStatus {
+		status.RemovedImages = status.RemovedImages[:maxRemovedImagesInStatus]
+	}
+	if err != nil {
+		status.Message = err.Error()
+	}
+	m.status = status
+	return err
+}
+
+func (m *imageGCManager) doGarbageCollect(ctx context.Context, nodeImage *appsv1beta1.NodeImage, policy *appsv1beta1.ImageGCPolicy,
+	now time.Time) ([]appsv1beta1.RemovedImage, int64, map[string]metav1.Time, error) {
+	images, err := m.imageService.ListImages(ctx)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to list images: %v", err)
+	}
+	inUse, err := m.listImagesInUse(ctx)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to list containers: %v", err)
+	}
+	var persisted map[string]metav1.Time
+	if nodeImage.Status.GarbageCollection != nil {
+		persisted = nodeImage.Status.GarbageCollection.UnusedImages
+	}
+	var templateImages []string
+	if m.templateImages != nil {
+		templateImages = m.templateImages()
+	}
+
+	// 1. refresh the usage records of images
+	existing := sets.New[string]()
+	for i := range images {
+		image := &images[i]
+		existing.Insert(image.ID)
+		record, ok := m.records[image.ID]
+		if !ok {
+			record = &imageRecord{lastUsed: now}
+			// restore the last used time reported in status, e.g. after kruise-daemon restarts
+			if lastUsed, ok := persisted[image.ID]; ok && lastUsed.Time.Before(now) {
+				record.lastUsed = lastUsed.Time
+			}
+			m.records[image.ID] = record
+		}
+		if isImageInUse(image, inUse) {
+			record.lastUsed = now
+		}
+	}
+	for id := range m.records {
+		if !existing.Has(id) {
+			delete(m.records, id)
+		}
+	}
+
+	// 2. find the candidates, sorted by the last used time
+	var candidates []*runtimeimage.ImageInfo
+	for i := range images {
+		image := &images[i]
+		if image.Pinned || isImageInUse(image, inUse) || isImageKept(image, nodeImage, policy, templateImages) || m.isSandboxImage(image) {
+			continue
+		}
+		candidates = append(candidates, image)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return m.records[candidates[i].ID].lastUsed.Before(m.records[candidates[j].ID].lastUsed)
+	})
+
+	toRemove := make(map[string]string)
+	var removeOrder []*runtimeimage.ImageInfo
+	markRemove := func(image *runtimeimage.ImageInfo, reason string) {
+		if _, ok := toRemove[image.ID]; ok {
+			return
+		}
+		toRemove[image.ID] = reason
+		removeOrder = append(removeOrder, image)
+	}
+
+	// 3. the images unused for longer than MaxAgeSeconds
+	if policy.MaxAgeSeconds != nil {
+		maxAge := time.Duration(*policy.MaxAgeSeconds) * time.Second
+		for _, image := range candidates {
+			if now.Sub(m.records[image.ID].lastUsed) > maxAge {
+				markRemove(image, ImageGCReasonMaxAge)
+			}
+		}
+	}
+
+	// 4. the least recently used images beyond MaxCountPerRepository
+	if policy.MaxCountPerRepository != nil {
+		maxCount := int(*policy.MaxCountPerRepository)
+		candidateIDs := sets.New[string]()
+		for _, image := range candidates {
+			candidateIDs.Insert(image.ID)
+		}
+		repositories := make(map[string][]*runtimeimage.ImageInfo)
+		for i := range images {
+			for _, repo := range getImageRepositories(&images[i]) {
+				repositories[repo] = append(repositories[repo], &images[i])
+			}
+		}
+		for _, repoImages := range repositories {
+			if len(repoImages) <= maxCount {
+				continue
+			}
+			sort.SliceStable(repoImages, func(i, j int) bool {
+				return m.records[repoImages[i].ID].lastUsed.After(m.records[repoImages[j].ID].lastUsed)
+			})
+			for _, image := range repoImages[maxCount:] {
+				if candidateIDs.Has(image.ID) {
+					markRemove(image, ImageGCReasonMaxCountPerRepository)
+				}
+			}
+		}
+	}
+
+	// 5. the least recently used images until the usage of image filesystem is below ImageFsUsageTarget
+	var usedBytes int64
+	if fsInfo, err := m.imageService.ImageFsInfo(ctx); err != nil {
+		if policy.ImageFsUsageTarget != nil {
+			return nil, 0, nil, fmt.Errorf("failed to get image filesystem info: %v", err)
+		}
+	} else {
+		usedBytes = fsInfo.UsedBytes
+	}
+	if policy.ImageFsUsageTarget != nil {
+		expected := usedBytes
+		for _, image := range removeOrder {
+			expected -= image.Size
+		}
+		target := policy.ImageFsUsageTarget.Value()
+		for _, image := range candidates {
+			if expected <= target {
+				break
+			}
+			if _, ok := toRemove[image.ID]; ok {
+				continue
+			}
+			markRemove(image, ImageGCReasonImageFsUsage)
+			expected -= image.Size
+		}
+	}
+
+	// 6. remove the images
+	var removed []appsv1beta1.RemovedImage
+	var errs []error
+	for _, image := range removeOrder {
+		if err := m.imageService.RemoveImage(ctx, image.ID); err != nil {
+			klog.ErrorS(err, "Failed to remove image", "imageID", image.ID, "reason", toRemove[image.ID])
+			errs = append(errs, fmt.Errorf("failed to remove image %s: %v", image.ID, err))
+			continue
+		}
+		klog.InfoS("Removed image", "imageID", image.ID, "repoTags", image.RepoTags, "size", image.Size, "reason", toRemove[image.ID])
+		delete(m.records, image.ID)
+		usedBytes -= image.Size
+		removed = append(removed, appsv1beta1.RemovedImage{
+			Image:       getImageDisplayName(image),
+			ImageID:     image.ID,
+			Size:        image.Size,
+			Reason:      toRemove[image.ID],
+			RemovedTime: metav1.Time{Time: now},
+		})
+	}
+	if usedBytes < 0 {
+		usedBytes = 0
+	}
+
+	// 7. report the last used time of the remaining images not in use
+	unused := make(map[string]metav1.Time)
+	for i := range images {
+		image := &images[i]
+		if record, ok := m.records[image.ID]; ok && !isImageInUse(image, inUse) {
+			unused[image.ID] = metav1.Time{Time: record.lastUsed}
+		}
+	}
+	return removed, usedBytes, unused, utilerrors.NewAggregate(errs)
+}
+
+// listImagesInUse returns the image names and IDs of all containers on the node
+func (m *imageGCManager) listImagesInUse(ctx context.Context) (sets.Set[string], error) {
+	containers, err := m.containerLister.ListContainers(ctx, &runtimeapi.ContainerFilter{})
+	if err != nil {
+		return nil, err
+	}
+	inUse := sets.New[string]()
+	for _, c := range containers {
+		if c.GetImage().GetImage() != "" {
+			inUse.Insert(c.GetImage().GetImage())
+		}
+		if c.GetImageRef() != "" {
+			inUse.Insert(c.GetImageRef())
+		}
+	}
+	return inUse, nil
+}
+
+func isImageInUse(image *runtimeimage.ImageInfo, inUse sets.Set[string]) bool {
+	if inUse.Has(image.ID) {
+		return true
+	}
+	for _, ref := range append(append([]string{}, image.RepoTags...), image.RepoDigests...) {
+		if inUse.Has(ref) {
+			return true
+		}
+		// the image of container may be in the familiar form, e.g. nginx:latest
+		if named, err := reference.ParseNormalizedNamed(ref); err == nil && inUse.Has(reference.FamiliarString(named)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSandboxImage returns true if the image is the sandbox image of the container runtime
+func (m *imageGCManager) isSandboxImage(image *runtimeimage.ImageInfo) bool {
+	return m.sandboxImage != "" && matchImage(image, m.sandboxImage)
+}
+
+// isImageKept returns true if the image is in spec.images of NodeImage, in the KeepImages of policy
+// or referenced by the pod templates of workloads
+func isImageKept(image *runtimeimage.ImageInfo, nodeImage *appsv1beta1.NodeImage, policy *appsv1beta1.ImageGCPolicy, templateImages []string) bool {
+	for name, imageSpec := range nodeImage.Spec.Images {
+		for _, tagSpec := range imageSpec.Tags {
+			if image.ContainsImage(name, tagSpec.Tag) {
+				return true
+			}
+		}
+	}
+	for _, keep := range policy.KeepImages {
+		if matchImage(image, keep) {
+			return true
+		}
+	}
+	for _, ref := range templateImages {
+		if matchImage(image, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchImage returns true if the image matches ref, which is in the form of name or name:tag
+func matchImage(image *runtimeimage.ImageInfo, ref string) bool {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return false
+	}
+	if !reference.IsNameOnly(named) {
+		name, tag, err := daemonutil.NormalizeImageRefToNameTag(ref)
+		return err == nil && image.ContainsImage(name, tag)
+	}
+	name := reference.FamiliarName(named)
+	for _, repo := range getImageRepositories(image) {
+		if repo == name {
+			return true
+		}
+	}
+	return false
+}
+
+// getImageRepositories returns the familiar names of the repositories of the image
+func getImageRepositories(image *runtimeimage.ImageInfo) []string {
+	repos := sets.New[string]()
+	for _, repoTag := range image.RepoTags {
+		if name, _, err := daemonutil.NormalizeImageRefToNameTag(repoTag); err == nil {
+			repos.Insert(name)
+		}
+	}
+	return sets.List(repos)
+}
+
+func getImageDisplayName(image *runtimeimage.ImageInfo) string {
+	if len(image.RepoTags) > 0 {
+		return image.RepoTags[0]
+	}
+	return image.ID
+}
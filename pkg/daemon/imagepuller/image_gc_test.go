@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+)
+
+type fakeGCImageService struct {
+	images []runtimeimage.ImageInfo
+}
+
+func (f *fakeGCImageService) PullImage(ctx context.Context, imageName, tag string, pullSecrets []v1.Secret, sandboxConfig *appsv1beta1.SandboxConfig) (runtimeimage.ImagePullStatusReader, error) {
+	return nil, nil
+}
+
+func (f *fakeGCImageService) ListImages(ctx context.Context) ([]runtimeimage.ImageInfo, error) {
+	return append([]runtimeimage.ImageInfo{}, f.images...), nil
+}
+
+func (f *fakeGCImageService) RemoveImage(ctx context.Context, imageID string) error {
+	for i := range f.images {
+		if f.images[i].ID == imageID {
+			f.images = append(f.images[:i], f.images[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (f *fakeGCImageService) ImageFsInfo(ctx context.Context) (*runtimeimage.ImageFsInfo, error) {
+	var used int64
+	for _, image := range f.images {
+		used += image.Size
+	}
+	return &runtimeimage.ImageFsInfo{UsedBytes: used}, nil
+}
+
+type fakeContainerLister struct {
+	images []string
+}
+
+func (f *fakeContainerLister) ListContainers(ctx context.Context, filter *runtimeapi.ContainerFilter) ([]*runtimeapi.Container, error) {
+	var containers []*runtimeapi.Container
+	for _, image := range f.images {
+		containers = append(containers, &runtimeapi.Container{Image: &runtimeapi.ImageSpec{Image: image}})
+	}
+	return containers, nil
+}
+
+func TestImageGarbageCollect(t *testing.T) {
+	newImages := func() []runtimeimage.ImageInfo {
+		return []runtimeimage.ImageInfo{
+			{ID: "sha256:nginx1", RepoTags: []string{"docker.io/library/nginx:1.0"}, Size: 100},
+			{ID: "sha256:nginx2", RepoTags: []string{"docker.io/library/nginx:2.0"}, Size: 100},
+			{ID: "sha256:nginx3", RepoTags: []string{"docker.io/library/nginx:3.0"}, Size: 100},
+			{ID: "sha256:busybox", RepoTags: []string{"docker.io/library/busybox:latest"}, Size: 50},
+			{ID: "sha256:redis", RepoTags: []string{"docker.io/library/redis:7"}, Size: 200},
+			{ID: "sha256:pause", RepoTags: []string{"registry.k8s.io/pause:3.10"}, Size: 1},
+			{ID: "sha256:pinned", RepoTags: []string{"docker.io/library/agent:1.0"}, Size: 1, Pinned: true},
+		}
+	}
+	nodeImage := &appsv1beta1.NodeImage{
+		Spec: appsv1beta1.NodeImageSpec{
+			Images: map[string]appsv1beta1.ImageSpec{
+				"redis": {Tags: []appsv1beta1.ImageTagSpec{{Tag: "7"}}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name            string
+		policy          *appsv1beta1.ImageGCPolicy
+		inUse           []string
+		expectedRemoved map[string]string
+	}{
+		{
+			name:            "no image is expired",
+			policy:          &appsv1beta1.ImageGCPolicy{MaxAgeSeconds: ptr.To[int64](7200)},
+			expectedRemoved: map[string]string{},
+		},
+		{
+			name:   "remove expired images except the images in use, in spec, kept, pinned or sandbox",
+			policy: &appsv1beta1.ImageGCPolicy{MaxAgeSeconds: ptr.To[int64](1800), KeepImages: []string{"busybox"}},
+			inUse:  []string{"nginx:1.0"},
+			expectedRemoved: map[string]string{
+				"sha256:nginx2": ImageGCReasonMaxAge,
+				"sha256:nginx3": ImageGCReasonMaxAge,
+			},
+		},
+		{
+			name:   "remove the least recently used images beyond max count",
+			policy: &appsv1beta1.ImageGCPolicy{MaxCountPerRepository: ptr.To[int32](2)},
+			inUse:  []string{"docker.io/library/nginx:3.0"},
+			expectedRemoved: map[string]string{
+				"sha256:nginx1": ImageGCReasonMaxCountPerRepository,
+			},
+		},
+		{
+			name:   "remove images until image filesystem usage is below target",
+			policy: &appsv1beta1.ImageGCPolicy{ImageFsUsageTarget: resource.NewQuantity(360, resource.BinarySI), KeepImages: []string{"nginx:3.0"}},
+			expectedRemoved: map[string]string{
+				"sha256:nginx1": ImageGCReasonImageFsUsage,
+				"sha256:nginx2": ImageGCReasonImageFsUsage,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			imageService := &fakeGCImageService{images: newImages()}
+			m := newImageGCManager(imageService, &fakeContainerLister{images: tc.inUse})
+			now := time.Now()
+			m.clock = func() time.Time { return now }
+			// the images are detected one hour ago and nginx1 is used at first
+			for i, image := range imageService.images {
+				m.records[image.ID] = &imageRecord{lastUsed: now.Add(-time.Hour).Add(time.Duration(i) * time.Second)}
+			}
+
+			ni := nodeImage.DeepCopy()
+			ni.Spec.GCPolicy = tc.policy
+			if !m.shouldGarbageCollect(tc.policy) {
+				t.Fatalf("expected to garbage collect")
+			}
+			if err := m.garbageCollect(context.TODO(), ni); err != nil {
+				t.Fatalf("failed to garbage collect: %v", err)
+			}
+			if m.shouldGarbageCollect(tc.policy) {
+				t.Fatalf("expected not to garbage collect again within interval")
+			}
+
+			status := m.getStatus()
+			removed := map[string]string{}
+			for _, image := range status.RemovedImages {
+				removed[image.ImageID] = image.Reason
+			}
+			if !reflect.DeepEqual(removed, tc.expectedRemoved) {
+				t.Fatalf("expected removed %v, got %v", tc.expectedRemoved, removed)
+			}
+
+			var remaining []string
+			var usedBytes int64
+			for _, image := range imageService.images {
+				remaining = append(remaining, image.ID)
+				usedBytes += image.Size
+			}
+			sort.Strings(remaining)
+			for id := range tc.expectedRemoved {
+				if idx := sort.SearchStrings(remaining, id); idx < len(remaining) && remaining[idx] == id {
+					t.Fatalf("expected image %s to be removed", id)
+				}
+			}
+			if status.ImageFsUsedBytes != usedBytes {
+				t.Fatalf("expected imageFsUsedBytes %d, got %d", usedBytes, status.ImageFsUsedBytes)
+			}
+		})
+	}
+}
+
+func TestImageGarbageCollectTemplateImagesAndPersistedLastUsed(t *testing.T) {
+	imageService := &fakeGCImageService{images: []runtimeimage.ImageInfo{
+		{ID: "sha256:nginx1", RepoTags: []string{"docker.io/library/nginx:1.0"}, Size: 100},
+		{ID: "sha256:nginx2", RepoTags: []string{"docker.io/library/nginx:2.0"}, Size: 100},
+		{ID: "sha256:busybox", RepoTags: []string{"docker.io/library/busybox:latest"}, Size: 50},
+	}}
+	m := newImageGCManager(imageService, &fakeContainerLister{})
+	m.templateImages = func() []string { return []string{"nginx:1.0"} }
+	now := time.Now()
+	m.clock = func() time.Time { return now }
+
+	// kruise-daemon restarted, the records are restored from status
+	policy := &appsv1beta1.ImageGCPolicy{MaxAgeSeconds: ptr.To[int64](1800)}
+	nodeImage := &appsv1beta1.NodeImage{
+		Spec: appsv1beta1.NodeImageSpec{GCPolicy: policy},
+		Status: appsv1beta1.NodeImageStatus{GarbageCollection: &appsv1beta1.ImageGCStatus{
+			UnusedImages: map[string]metav1.Time{
+				"sha256:nginx1": {Time: now.Add(-time.Hour)},
+				"sha256:nginx2": {Time: now.Add(-time.Hour)},
+			},
+		}},
+	}
+	if err := m.garbageCollect(context.TODO(), nodeImage); err != nil {
+		t.Fatalf("failed to garbage collect: %v", err)
+	}
+
+	status := m.getStatus()
+	removed := map[string]string{}
+	for _, image := range status.RemovedImages {
+		removed[image.ImageID] = image.Reason
+	}
+	expectedRemoved := map[string]string{"sha256:nginx2": ImageGCReasonMaxAge}
+	if !reflect.DeepEqual(removed, expectedRemoved) {
+		t.Fatalf("expected removed %v, got %v", expectedRemoved, removed)
+	}
+	expectedUnused := map[string]metav1.Time{
+		"sha256:nginx1":  {Time: now.Add(-time.Hour)},
+		"sha256:busybox": {Time: now},
+	}
+	if !reflect.DeepEqual(status.UnusedImages, expectedUnused) {
+		t.Fatalf("expected unused images %v, got %v", expectedUnused, status.UnusedImages)
+	}
+}
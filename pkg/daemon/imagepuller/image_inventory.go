@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+)
+
+const (
+	imageInventoryInterval = time.Minute
+	imageInventoryTimeout  = 30 * time.Second
+)
+
+// imageInventory keeps the list of all images on the node reported in NodeImage status
+type imageInventory struct {
+	imageService runtimeimage.ImageService
+	clock        func() time.Time
+
+	mu        sync.Mutex
+	inventory *appsv1beta1.NodeImageInventory
+}
+
+func newImageInventory(imageService runtimeimage.ImageService) *imageInventory {
+	return &imageInventory{imageService: imageService, clock: time.Now}
+}
+
+// refresh lists the images on the node, it returns true if the inventory has changed
+func (i *imageInventory) refresh(ctx context.Context) (bool, error) {
+	infos, err := i.imageService.ListImages(ctx)
+	if err != nil {
+		return false, err
+	}
+	images := buildInventoryImages(infos)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.inventory != nil && reflect.DeepEqual(i.inventory.Images, images) {
+		return false, nil
+	}
+	now := metav1.NewTime(i.clock())
+	i.inventory = &appsv1beta1.NodeImageInventory{UpdateTime: &now, Images: images}
+	return true, nil
+}
+
+func (i *imageInventory) get() *appsv1beta1.NodeImageInventory {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inventory.DeepCopy()
+}
+
+// buildInventoryImages converts the images listed from the runtime into the compact form of inventory,
+// the images without any reference are skipped.
+func buildInventoryImages(infos []runtimeimage.ImageInfo) []appsv1beta1.InventoryImage {
+	images := make([]appsv1beta1.InventoryImage, 0, len(infos))
+	for _, info := range infos {
+		image := appsv1beta1.InventoryImage{
+			RepoTags:    familiarReferences(info.RepoTags),
+			RepoDigests: familiarReferences(info.RepoDigests),
+			Size:        info.Size,
+		}
+		if len(image.RepoTags) == 0 && len(image.RepoDigests) == 0 {
+			continue
+		}
+		images = append(images, image)
+	}
+	sort.Slice(images, func(a, b int) bool {
+		return firstReference(&images[a]) < firstReference(&images[b])
+	})
+	return images
+}
+
+func familiarReferences(refs []string) []string {
+	familiar := sets.New[string]()
+	for _, ref := range refs {
+		name, tag, err := daemonutil.NormalizeImageRefToNameTag(ref)
+		if err != nil {
+			continue
+		}
+		familiar.Insert(daemonutil.JoinImageNameTag(name, tag))
+	}
+	if familiar.Len() == 0 {
+		return nil
+	}
+	return sets.List(familiar)
+}
+
+func firstReference(image *appsv1beta1.InventoryImage) string {
+	if len(image.RepoTags) > 0 {
+		return image.RepoTags[0]
+	}
+	return image.RepoDigests[0]
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+)
+
+func TestImageInventory(t *testing.T) {
+	digest := "sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63"
+	imageService := &fakeGCImageService{images: []runtimeimage.ImageInfo{
+		{ID: "sha256:3", RepoTags: []string{"registry.example.com/app:v1", "registry.example.com/app:latest"}, Size: 300},
+		{ID: "sha256:1", RepoTags: []string{"docker.io/library/nginx:1.25"}, RepoDigests: []string{"docker.io/library/nginx@" + digest}, Size: 100},
+		{ID: "sha256:2", RepoDigests: []string{"docker.io/library/busybox@" + digest}, Size: 200},
+		{ID: "sha256:4", Size: 400},
+	}}
+	now := time.Now()
+	inventory := newImageInventory(imageService)
+	inventory.clock = func() time.Time { return now }
+
+	changed, err := inventory.refresh(context.TODO())
+	if err != nil || !changed {
+		t.Fatalf("expected inventory changed, got %v, err %v", changed, err)
+	}
+	expected := []appsv1beta1.InventoryImage{
+		{RepoDigests: []string{"busybox@" + digest}, Size: 200},
+		{RepoTags: []string{"nginx:1.25"}, RepoDigests: []string{"nginx@" + digest}, Size: 100},
+		{RepoTags: []string{"registry.example.com/app:latest", "registry.example.com/app:v1"}, Size: 300},
+	}
+	got := inventory.get()
+	if !reflect.DeepEqual(got.Images, expected) || !got.UpdateTime.Time.Equal(now) {
+		t.Fatalf("unexpected inventory %+v", got)
+	}
+
+	// the update time is kept if nothing changed
+	inventory.clock = func() time.Time { return now.Add(time.Hour) }
+	if changed, _ = inventory.refresh(context.TODO()); changed {
+		t.Fatalf("expected inventory not changed")
+	}
+	if got = inventory.get(); !got.UpdateTime.Time.Equal(now) {
+		t.Fatalf("expected update time not changed, got %v", got.UpdateTime)
+	}
+
+	imageService.images = imageService.images[1:]
+	if changed, _ = inventory.refresh(context.TODO()); !changed {
+		t.Fatalf("expected inventory changed")
+	}
+	if got = inventory.get(); len(got.Images) != 2 || !got.UpdateTime.Time.Equal(now.Add(time.Hour)) {
+		t.Fatalf("unexpected inventory %+v", got)
+	}
+}
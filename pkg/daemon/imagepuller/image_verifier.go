@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/distribution/reference"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+// imageVerifier resolves the image to the digest in the registry and verifies it with the policy
+type imageVerifier interface {
+	Verify(ctx context.Context, imageName, tag string, policy *appsv1beta1.ImageTagVerification, auths []daemonutil.AuthInfo) (string, error)
+}
+
+type registryVerifier struct {
+	client *registry.Client
+}
+
+func newRegistryVerifier(client *registry.Client) imageVerifier {
+	return &registryVerifier{client: client}
+}
+
+// Verify returns the digest of the image if it is in the allowlist and signed by any of the public keys
+func (v *registryVerifier) Verify(ctx context.Context, imageName, tag string, policy *appsv1beta1.ImageTagVerification, auths []daemonutil.AuthInfo) (string, error) {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return "", err
+	}
+	path := reference.Path(named)
+	remote := v.client.NewRegistryRemote(reference.Domain(named), auths)
+
+	digest := tag
+	if !imageverify.IsDigest(tag) {
+		_, desc, err := v.client.GetManifest(ctx, remote, path, tag)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve digest: %v", err)
+		}
+		digest = desc.Digest
+	}
+
+	if len(policy.AllowedDigests) > 0 {
+		var allowed bool
+		for _, d := range policy.AllowedDigests {
+			if d == digest {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return "", fmt.Errorf("digest %s is not in the allowlist", digest)
+		}
+	}
+
+	if len(policy.PublicKeys) > 0 {
+		keys, err := imageverify.ParsePublicKeys(policy.PublicKeys)
+		if err != nil {
+			return "", err
+		}
+		if err := v.client.VerifySignature(ctx, remote, path, digest, keys); err != nil {
+			return "", err
+		}
+	}
+	return digest, nil
+}
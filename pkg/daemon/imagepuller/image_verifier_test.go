@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+func TestRegistryVerifier(t *testing.T) {
+	manifest := []byte(`{"mediaType":"` + registry.MediaTypeOCIManifest + `","layers":[]}`)
+	digest := imageverify.CalculateDigest(manifest)
+	otherDigest := imageverify.CalculateDigest([]byte("other"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/library/nginx/manifests/1.0", "/v2/library/nginx/manifests/" + digest:
+			w.Header().Set("Content-Type", registry.MediaTypeOCIManifest)
+			w.Write(manifest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := registry.NewClient()
+	client.Endpoint = func(string) string { return server.URL }
+	verifier := newRegistryVerifier(client)
+
+	cases := []struct {
+		name      string
+		tag       string
+		policy    *appsv1beta1.ImageTagVerification
+		expectErr bool
+	}{
+		{name: "allowed tag", tag: "1.0", policy: &appsv1beta1.ImageTagVerification{AllowedDigests: []string{otherDigest, digest}}},
+		{name: "allowed digest", tag: digest, policy: &appsv1beta1.ImageTagVerification{AllowedDigests: []string{digest}}},
+		{name: "not allowed", tag: "1.0", policy: &appsv1beta1.ImageTagVerification{AllowedDigests: []string{otherDigest}}, expectErr: true},
+		{name: "tag not found", tag: "2.0", policy: &appsv1beta1.ImageTagVerification{AllowedDigests: []string{digest}}, expectErr: true},
+		{name: "unsigned", tag: "1.0", policy: &appsv1beta1.ImageTagVerification{PublicKeys: []string{"invalid"}}, expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := verifier.Verify(context.TODO(), "nginx", tc.tag, tc.policy, nil)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got digest %s", got)
+				}
+				return
+			}
+			if err != nil || got != digest {
+				t.Fatalf("expected digest %s, got %s, err %v", digest, got, err)
+			}
+		})
+	}
+}
+
+type fakeVerifier struct {
+	digest string
+}
+
+func (v *fakeVerifier) Verify(context.Context, string, string, *appsv1beta1.ImageTagVerification, []daemonutil.AuthInfo) (string, error) {
+	return v.digest, nil
+}
+
+type finishedStatusReader struct {
+	ch chan runtimeimage.ImagePullStatus
+}
+
+func (r *finishedStatusReader) C() <-chan runtimeimage.ImagePullStatus {
+	return r.ch
+}
+
+func (r *finishedStatusReader) Close() {}
+
+// recordingImageService records the references pulled, which are stored as images with the digest
+type recordingImageService struct {
+	fakeGCImageService
+	pulled []string
+}
+
+func (f *recordingImageService) PullImage(ctx context.Context, imageName, tag string, pullSecrets []v1.Secret, sandboxConfig *appsv1beta1.SandboxConfig) (runtimeimage.ImagePullStatusReader, error) {
+	ref := daemonutil.JoinImageNameTag(imageName, tag)
+	f.pulled = append(f.pulled, ref)
+	f.images = append(f.images, runtimeimage.ImageInfo{ID: ref, RepoDigests: []string{ref}})
+	ch := make(chan runtimeimage.ImagePullStatus, 1)
+	ch <- runtimeimage.ImagePullStatus{Process: 100, Finish: true}
+	return &finishedStatusReader{ch: ch}, nil
+}
+
+type noopStatusUpdater struct{}
+
+func (noopStatusUpdater) UpdateStatus(*appsv1beta1.ImageTagStatus) {}
+
+func TestPullVerifiedImageByDigest(t *testing.T) {
+	digest := imageverify.CalculateDigest([]byte("manifest"))
+	runtime := &recordingImageService{}
+	w := &pullWorker{
+		name:          "nginx",
+		tagSpec:       appsv1beta1.ImageTagSpec{Tag: "1.0", Verification: &appsv1beta1.ImageTagVerification{AllowedDigests: []string{digest}}},
+		runtime:       runtime,
+		verifier:      &fakeVerifier{digest: digest},
+		statusUpdater: noopStatusUpdater{},
+		active:        true,
+		stopCh:        make(chan struct{}),
+	}
+
+	newStatus := &appsv1beta1.ImageTagStatus{Tag: "1.0"}
+	if err := w.doPullImage(context.TODO(), newStatus, appsv1beta1.PullIfNotPresent); err != nil {
+		t.Fatalf("failed to pull: %v", err)
+	}
+	expected := []string{"nginx@" + digest}
+	if !reflect.DeepEqual(runtime.pulled, expected) || newStatus.Digest != digest {
+		t.Fatalf("expected to pull %v, got %v with digest %s", expected, runtime.pulled, newStatus.Digest)
+	}
+
+	// the image verified is already present
+	if err := w.doPullImage(context.TODO(), newStatus, appsv1beta1.PullIfNotPresent); err != nil {
+		t.Fatalf("failed to pull again: %v", err)
+	}
+	if len(runtime.pulled) != 1 {
+		t.Fatalf("expected not to pull again, got %v", runtime.pulled)
+	}
+}
@@ -18,15 +18,19 @@ package imagepuller
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"math/rand"
+	"net"
 	"net/http"
+	"os"
 	"reflect"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -43,8 +47,12 @@ import (
 	"github.com/openkruise/kruise/pkg/client"
 	kruiseclient "github.com/openkruise/kruise/pkg/client/clientset/versioned"
 	listersbeta1 "github.com/openkruise/kruise/pkg/client/listers/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/peer"
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
 	daemonoptions "github.com/openkruise/kruise/pkg/daemon/options"
 	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	utilimagejob "github.com/openkruise/kruise/pkg/util/imagejob"
 )
 
@@ -55,6 +63,18 @@ type Controller struct {
 	imagePullNodeInformer cache.SharedIndexInformer
 	imagePullNodeLister   listersbeta1.NodeImageLister
 	statusUpdater         *statusUpdater
+	gcManager             *imageGCManager
+	inventory             *imageInventory
+	// the images referenced by workload templates, which are protected from garbage collection
+	templateImagesInformer cache.SharedIndexInformer
+
+	// peer-to-peer distribution of images
+	peerListener   net.Listener
+	peerHandler    http.Handler
+	mirrorListener net.Listener
+	mirrorHandler  http.Handler
+	peerEndpoint   string
+	peerMirror     peer.MirrorRegistrar
 }
 
 // NewController returns the controller for image pulling
@@ -100,11 +120,56 @@ func NewController(opts daemonoptions.Options, secretManager daemonutil.SecretMa
 		workerLimitedPool = NewChanPool(opts.MaxWorkersForPullImages)
 		workerLimitedPool.Start()
 	}
-	puller, err := newRealPuller(opts.RuntimeFactory.GetImageService(), secretManager, recorder)
+	registryClient := registry.NewClient()
+	puller, err := newRealPuller(opts.RuntimeFactory.GetImageService(), secretManager, recorder, newRegistryVerifier(registryClient))
 	if err != nil {
 		return nil, fmt.Errorf("failed to new puller: %v", err)
 	}
 
+	var peerListener, mirrorListener net.Listener
+	var peerHandler, mirrorHandler http.Handler
+	var peerEndpoint string
+	var peerMirror peer.MirrorRegistrar
+	if opts.ImagePeer.Addr == "" && opts.ImagePeer.ContainerdHostsDir != "" {
+		// clean up the mirrors left when the peer-to-peer image distribution was enabled
+		if err := peer.RemoveContainerdMirrors(opts.ImagePeer.ContainerdHostsDir); err != nil {
+			klog.ErrorS(err, "Failed to remove containerd registry mirrors of image peer", "hostsDir", opts.ImagePeer.ContainerdHostsDir)
+		}
+	}
+	if opts.ImagePeer.Addr != "" {
+		if opts.ImagePeer.ContainerdHostsDir == "" {
+			return nil, fmt.Errorf("containerd hosts dir must be set for peer-to-peer image distribution")
+		}
+		store, err := peer.NewFileStore(opts.ImagePeer.CacheDir, opts.ImagePeer.CacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to new image peer store: %v", err)
+		}
+		if mirrorListener, err = listenPeerMirror(opts.ImagePeer.MirrorAddr); err != nil {
+			return nil, err
+		}
+		if peerListener, err = net.Listen("tcp", opts.ImagePeer.Addr); err != nil {
+			mirrorListener.Close()
+			return nil, fmt.Errorf("failed to listen image peer address: %v", err)
+		}
+		var tlsConfig *tls.Config
+		peerEndpoint, err = getPeerAdvertiseAddr(opts.ImagePeer, peerListener.Addr())
+		if err == nil {
+			tlsConfig, err = newPeerTLSConfig(peerEndpoint)
+		}
+		if err != nil {
+			peerListener.Close()
+			mirrorListener.Close()
+			return nil, err
+		}
+		peerListener = tls.NewListener(peerListener, tlsConfig)
+		auth := peer.NewServiceAccountAuthenticator(genericClient.KubeClient, peer.DefaultServiceAccountTokenFile)
+		peerMirror = peer.NewContainerdMirrorRegistrar(opts.ImagePeer.ContainerdHostsDir, mirrorListener.Addr().String())
+		puller.fetcher = peer.NewFetcher(store, registryClient, auth, peerMirror)
+		peerHandler = peer.NewHandler(store, auth)
+		mirrorHandler = peer.NewHandler(store, nil)
+		klog.InfoS("Enabled peer-to-peer image distribution", "endpoint", peerEndpoint, "cacheDir", opts.ImagePeer.CacheDir)
+	}
+
 	opts.Healthz.RegisterFunc("nodeImageInformerSynced", func(_ *http.Request) error {
 		if !informer.HasSynced() {
 			return fmt.Errorf("not synced")
@@ -112,16 +177,86 @@ func NewController(opts daemonoptions.Options, secretManager daemonutil.SecretMa
 		return nil
 	})
 
+	var gcManager *imageGCManager
+	var templateImagesInformer cache.SharedIndexInformer
+	if runtimeService := opts.RuntimeFactory.GetRuntimeService(); runtimeService != nil {
+		gcManager = newImageGCManager(opts.RuntimeFactory.GetImageService(), runtimeService)
+		templateImagesInformer = newTemplateImagesInformer(genericClient.KubeClient)
+		gcManager.templateImages = func() []string {
+			return getTemplateImages(templateImagesInformer.GetStore())
+		}
+	}
+
+	var inventory *imageInventory
+	if utilfeature.DefaultFeatureGate.Enabled(features.NodeImageInventory) {
+		inventory = newImageInventory(opts.RuntimeFactory.GetImageService())
+	}
+
 	return &Controller{
-		scheme:                opts.Scheme,
-		queue:                 queue,
-		puller:                puller,
-		imagePullNodeInformer: informer,
-		imagePullNodeLister:   listersbeta1.NewNodeImageLister(informer.GetIndexer()),
-		statusUpdater:         newStatusUpdater(genericClient.KruiseClient.AppsV1beta1().NodeImages()),
+		scheme:                 opts.Scheme,
+		queue:                  queue,
+		puller:                 puller,
+		imagePullNodeInformer:  informer,
+		imagePullNodeLister:    listersbeta1.NewNodeImageLister(informer.GetIndexer()),
+		statusUpdater:          newStatusUpdater(genericClient.KruiseClient.AppsV1beta1().NodeImages()),
+		gcManager:              gcManager,
+		inventory:              inventory,
+		templateImagesInformer: templateImagesInformer,
+		peerListener:           peerListener,
+		peerHandler:            peerHandler,
+		mirrorListener:         mirrorListener,
+		mirrorHandler:          mirrorHandler,
+		peerEndpoint:           peerEndpoint,
+		peerMirror:             peerMirror,
 	}, nil
 }
 
+// getPeerAdvertiseAddr returns the address peers use to reach the endpoint listening on addr
+func getPeerAdvertiseAddr(opts daemonoptions.ImagePeerOptions, addr net.Addr) (string, error) {
+	if opts.AdvertiseAddr != "" {
+		return opts.AdvertiseAddr, nil
+	}
+	nodeIP := os.Getenv("NODE_IP")
+	if nodeIP == "" {
+		return "", fmt.Errorf("either NODE_IP env or image peer advertise address must be set")
+	}
+	_, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(nodeIP, port), nil
+}
+
+// newPeerTLSConfig returns the TLS config with the certificate for the host of endpoint
+func newPeerTLSConfig(endpoint string) (*tls.Config, error) {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := peer.NewServerTLSConfig(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate of image peer endpoint: %v", err)
+	}
+	return tlsConfig, nil
+}
+
+// listenPeerMirror listens the address of the mirror endpoint, which must be a loopback address
+// as the mirror endpoint is not authenticated
+func listenPeerMirror(addr string) (net.Listener, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image peer mirror address %s: %v", addr, err)
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return nil, fmt.Errorf("image peer mirror address %s must be a loopback IP", addr)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen image peer mirror address: %v", err)
+	}
+	return listener, nil
+}
+
 func newNodeImageInformer(client kruiseclient.Interface, nodeName string) cache.SharedIndexInformer {
 	tweakListOptionsFunc := func(opt *metav1.ListOptions) {
 		opt.FieldSelector = "metadata.name=" + nodeName
@@ -169,6 +304,41 @@ func (c *Controller) Run(stop <-chan struct{}) {
 		}
 	}, time.Second, stop)
 
+	if c.gcManager != nil {
+		go c.templateImagesInformer.Run(stop)
+		go wait.Until(c.garbageCollectImages, time.Minute, stop)
+	}
+	if c.inventory != nil {
+		go wait.Until(c.refreshInventory, imageInventoryInterval, stop)
+	}
+
+	if c.peerListener != nil {
+		server := &http.Server{Handler: c.peerHandler}
+		go func() {
+			if err := server.Serve(c.peerListener); err != nil && err != http.ErrServerClosed {
+				klog.ErrorS(err, "Failed to serve image peer endpoint")
+			}
+		}()
+		defer server.Close()
+	}
+	if c.mirrorListener != nil {
+		server := &http.Server{Handler: c.mirrorHandler}
+		go func() {
+			if err := server.Serve(c.mirrorListener); err != nil && err != http.ErrServerClosed {
+				klog.ErrorS(err, "Failed to serve image peer mirror endpoint")
+			}
+		}()
+		defer server.Close()
+	}
+	if c.peerMirror != nil {
+		// the runtime should not pull through the mirror any more once the endpoint is closed
+		defer func() {
+			if err := c.peerMirror.Unregister(); err != nil {
+				klog.ErrorS(err, "Failed to remove containerd registry mirrors of image peer")
+			}
+		}()
+	}
+
 	klog.Info("Started puller controller successfully")
 	<-stop
 	if workerLimitedPool != nil {
@@ -176,6 +346,53 @@ func (c *Controller) Run(stop <-chan struct{}) {
 	}
 }
 
+// garbageCollectImages removes the unused images if the GCPolicy of NodeImage is set and its interval has elapsed
+func (c *Controller) garbageCollectImages() {
+	if !c.templateImagesInformer.HasSynced() {
+		klog.V(4).InfoS("Waiting for template images synced before image garbage collection")
+		return
+	}
+	nodeImages, err := c.imagePullNodeLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list NodeImage for image garbage collection")
+		return
+	}
+	for _, nodeImage := range nodeImages {
+		if nodeImage.DeletionTimestamp != nil || !c.gcManager.shouldGarbageCollect(nodeImage.Spec.GCPolicy) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), imageGCTimeout)
+		if err := c.gcManager.garbageCollect(ctx, nodeImage); err != nil {
+			klog.ErrorS(err, "Failed to garbage collect images", "nodeImage", nodeImage.Name)
+		}
+		cancel()
+		// report the result of garbage collection in status
+		enqueue(c.queue, nodeImage)
+	}
+}
+
+// refreshInventory lists all images on the node and reports them in status if changed
+func (c *Controller) refreshInventory() {
+	ctx, cancel := context.WithTimeout(context.Background(), imageInventoryTimeout)
+	defer cancel()
+	changed, err := c.inventory.refresh(ctx)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list images for inventory")
+		return
+	}
+	if !changed {
+		return
+	}
+	nodeImages, err := c.imagePullNodeLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list NodeImage for image inventory")
+		return
+	}
+	for _, nodeImage := range nodeImages {
+		enqueue(c.queue, nodeImage)
+	}
+}
+
 // processNextWorkItem will read a single work item off the workqueue and
 // attempt to process it, by calling the syncHandler.
 func (c *Controller) processNextWorkItem() bool {
@@ -261,6 +478,17 @@ func (c *Controller) sync(key string) (retErr error) {
 	if len(newStatus.ImageStatuses) == 0 {
 		newStatus.ImageStatuses = nil
 	}
+	if c.gcManager != nil && nodeImage.Spec.GCPolicy != nil {
+		newStatus.GarbageCollection = c.gcManager.getStatus()
+		// keep the status reported before kruise-daemon restarts until the next garbage collection
+		if newStatus.GarbageCollection == nil {
+			newStatus.GarbageCollection = nodeImage.Status.GarbageCollection
+		}
+	}
+	newStatus.PeerEndpoint = c.peerEndpoint
+	if c.inventory != nil {
+		newStatus.Inventory = c.inventory.get()
+	}
 
 	var limited bool
 	limited, retErr = c.statusUpdater.updateStatus(nodeImage, &newStatus)
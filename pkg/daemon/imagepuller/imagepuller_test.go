@@ -2,6 +2,7 @@ package imagepuller
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -88,6 +89,17 @@ func (f *fakeRuntime) ListImages(ctx context.Context) ([]imageruntime.ImageInfo,
 	return images, nil
 }
 
+func (f *fakeRuntime) RemoveImage(ctx context.Context, imageID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.images, imageID)
+	return nil
+}
+
+func (f *fakeRuntime) ImageFsInfo(ctx context.Context) (*imageruntime.ImageFsInfo, error) {
+	return &imageruntime.ImageFsInfo{}, nil
+}
+
 func (f *fakeRuntime) increaseProgress(image string, delta int) {
 	key := image
 	f.mu.Lock()
@@ -209,7 +221,7 @@ func realPullerSyncFn(t *testing.T, limitedPool bool) {
 	fakeRuntime := &fakeRuntime{images: make(map[string]*imageStatus)}
 	workerLimitedPool = NewChanPool(2)
 	workerLimitedPool.Start()
-	p, _ := newRealPuller(fakeRuntime, &secretManager, eventRecorder)
+	p, _ := newRealPuller(fakeRuntime, &secretManager, eventRecorder, nil)
 
 	nameSuffuix := "_NoLimitPool"
 	if limitedPool {
@@ -305,7 +317,7 @@ func TestRealPullerSyncWithLimitedPool(t *testing.T) {
 	r := &fakeRuntime{images: make(map[string]*imageStatus)}
 	workerLimitedPool = NewChanPool(2)
 	workerLimitedPool.Start()
-	p, _ := newRealPuller(r, &secretManager, eventRecorder)
+	p, _ := newRealPuller(r, &secretManager, eventRecorder, nil)
 
 	ref, _ := reference.GetReference(scheme, baseNodeImage)
 	err := p.Sync(baseNodeImage, ref)
@@ -355,3 +367,40 @@ func TestRealPullerSyncWithLimitedPool(t *testing.T) {
 
 	r.clean()
 }
+
+func TestGetImageRegistry(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	cases := map[string]string{
+		"nginx":                            "docker.io",
+		"bitnami/redis:7.0":                "docker.io",
+		"quay.io/coreos/etcd:v3.5":         "quay.io",
+		"localhost:5000/foo/bar@" + digest: "localhost:5000",
+		"INVALID":                          "unknown",
+	}
+	for image, expected := range cases {
+		if got := getImageRegistry(image); got != expected {
+			t.Errorf("expected registry %s of image %s, but got %s", expected, image, got)
+		}
+	}
+}
+
+func TestListenPeerMirror(t *testing.T) {
+	for addr, ok := range map[string]bool{
+		"127.0.0.1:0": true,
+		"[::1]:0":     true,
+		":0":          false,
+		"0.0.0.0:0":   false,
+		"localhost:0": false,
+		"127.0.0.1":   false,
+	} {
+		listener, err := listenPeerMirror(addr)
+		if err != nil && strings.Contains(err.Error(), "failed to listen") {
+			// the loopback of IPv6 may be unavailable
+			continue
+		}
+		assert.Equal(t, ok, err == nil, "address %s: %v", addr, err)
+		if listener != nil {
+			listener.Close()
+		}
+	}
+}
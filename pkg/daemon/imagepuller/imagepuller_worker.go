@@ -31,8 +31,10 @@ import (
 
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 	runtimeimage "github.com/openkruise/kruise/pkg/daemon/criruntime/imageruntime"
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/peer"
 	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
 	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/secret"
 )
 
 const (
@@ -60,17 +62,21 @@ type realPuller struct {
 	runtime       runtimeimage.ImageService
 	secretManager daemonutil.SecretManager
 	eventRecorder record.EventRecorder
+	// fetcher fetches the images from peers, nil if peer-to-peer distribution is disabled
+	fetcher  imageFetcher
+	verifier imageVerifier
 
 	workerPools map[string]workerPool
 }
 
 var _ puller = &realPuller{}
 
-func newRealPuller(runtime runtimeimage.ImageService, secretManager daemonutil.SecretManager, eventRecorder record.EventRecorder) (*realPuller, error) {
+func newRealPuller(runtime runtimeimage.ImageService, secretManager daemonutil.SecretManager, eventRecorder record.EventRecorder, verifier imageVerifier) (*realPuller, error) {
 	p := &realPuller{
 		runtime:       runtime,
 		secretManager: secretManager,
 		eventRecorder: eventRecorder,
+		verifier:      verifier,
 		workerPools:   make(map[string]workerPool),
 	}
 	return p, nil
@@ -96,7 +102,10 @@ func (p *realPuller) Sync(obj *appsv1beta1.NodeImage, ref *v1.ObjectReference) e
 		pool, ok := p.workerPools[imageName]
 		if !ok {
 			klog.V(3).InfoS("starting new workerpool", "imageName", imageName)
-			pool = newRealWorkerPool(imageName, p.runtime, p.secretManager, p.eventRecorder)
+			realPool := newRealWorkerPool(imageName, p.runtime, p.secretManager, p.eventRecorder)
+			realPool.fetcher = p.fetcher
+			realPool.verifier = p.verifier
+			pool = realPool
 			p.workerPools[imageName] = pool
 		}
 		var imageStatus *appsv1beta1.ImageStatus
@@ -120,6 +129,11 @@ func (p *realPuller) GetStatus(imageName string) *appsv1beta1.ImageStatus {
 	return pool.GetStatus()
 }
 
+// imageFetcher fetches the image into the local cache served to peers
+type imageFetcher interface {
+	Fetch(ctx context.Context, imageName, tag string, peers []string, auths []daemonutil.AuthInfo) (*peer.FetchResult, error)
+}
+
 type imageStatusUpdater interface {
 	UpdateStatus(*appsv1beta1.ImageTagStatus)
 }
@@ -137,6 +151,8 @@ type realWorkerPool struct {
 	runtime       runtimeimage.ImageService
 	secretManager daemonutil.SecretManager
 	eventRecorder record.EventRecorder
+	fetcher       imageFetcher
+	verifier      imageVerifier
 	pullWorkers   map[string]*pullWorker
 	tagStatuses   map[string]*appsv1beta1.ImageTagStatus
 	active        bool
@@ -218,7 +234,7 @@ func (w *realWorkerPool) Sync(spec *appsv1beta1.ImageSpec, status *appsv1beta1.I
 		_, ok := w.pullWorkers[tagSpec.Tag]
 
 		if !ok {
-			worker := newPullWorker(w.name, tagSpec, spec.SandboxConfig, secrets, w.runtime, w, ref, w.eventRecorder)
+			worker := newPullWorker(w.name, tagSpec, spec.SandboxConfig, secrets, w.runtime, w.fetcher, w.verifier, w, ref, w.eventRecorder)
 			w.pullWorkers[tagSpec.Tag] = worker
 		}
 	}
@@ -266,8 +282,8 @@ func (w *realWorkerPool) UpdateStatus(status *appsv1beta1.ImageTagStatus) {
 	w.tagStatuses[status.Tag] = status
 }
 
-func newPullWorker(name string, tagSpec appsv1beta1.ImageTagSpec, sandboxConfig *appsv1beta1.SandboxConfig, secrets []v1.Secret, runtime runtimeimage.ImageService, statusUpdater imageStatusUpdater, ref *v1.ObjectReference, eventRecorder record.EventRecorder) *pullWorker {
-	image := name + ":" + tagSpec.Tag
+func newPullWorker(name string, tagSpec appsv1beta1.ImageTagSpec, sandboxConfig *appsv1beta1.SandboxConfig, secrets []v1.Secret, runtime runtimeimage.ImageService, fetcher imageFetcher, verifier imageVerifier, statusUpdater imageStatusUpdater, ref *v1.ObjectReference, eventRecorder record.EventRecorder) *pullWorker {
+	image := daemonutil.JoinImageNameTag(name, tagSpec.Tag)
 	klog.V(5).InfoS("new pull worker", "image", image)
 	o := &pullWorker{
 		name:          name,
@@ -275,6 +291,8 @@ func newPullWorker(name string, tagSpec appsv1beta1.ImageTagSpec, sandboxConfig
 		sandboxConfig: sandboxConfig,
 		secrets:       secrets,
 		runtime:       runtime,
+		fetcher:       fetcher,
+		verifier:      verifier,
 		statusUpdater: statusUpdater,
 		ref:           ref,
 		eventRecorder: eventRecorder,
@@ -317,6 +335,8 @@ type pullWorker struct {
 	sandboxConfig *appsv1beta1.SandboxConfig
 	secrets       []v1.Secret
 	runtime       runtimeimage.ImageService
+	fetcher       imageFetcher
+	verifier      imageVerifier
 	statusUpdater imageStatusUpdater
 	ref           *v1.ObjectReference
 	eventRecorder record.EventRecorder
@@ -326,7 +346,7 @@ type pullWorker struct {
 }
 
 func (w *pullWorker) ImageRef() string {
-	return fmt.Sprintf("%v:%v", w.name, w.tagSpec.Tag)
+	return daemonutil.JoinImageNameTag(w.name, w.tagSpec.Tag)
 }
 
 func (w *pullWorker) Stop() {
@@ -421,8 +441,14 @@ func (w *pullWorker) Run() {
 			continue
 		}
 
-		if imageInfo, err := w.getImageInfo(pullContext); err == nil {
+		ref := w.tagSpec.Tag
+		if newStatus.Digest != "" {
+			// the verified image is pulled by digest
+			ref = newStatus.Digest
+		}
+		if imageInfo, err := w.getImageInfo(pullContext, ref); err == nil {
 			newStatus.ImageID = fmt.Sprintf("%v@%v", w.name, imageInfo.ID)
+			newStatus.Digest = imageInfo.RepoDigest(w.name)
 		}
 		w.finishPulling(newStatus, appsv1beta1.ImagePhaseSucceeded, "")
 		if w.ref != nil && w.eventRecorder != nil {
@@ -443,18 +469,19 @@ func (w *pullWorker) Run() {
 	}
 }
 
-func (w *pullWorker) getImageInfo(ctx context.Context) (*runtimeimage.ImageInfo, error) {
+// getImageInfo returns the image of w.name with the tag or digest ref
+func (w *pullWorker) getImageInfo(ctx context.Context, ref string) (*runtimeimage.ImageInfo, error) {
 	imageInfos, err := w.runtime.ListImages(ctx)
 	if err != nil {
 		klog.V(5).ErrorS(err, "List images failed")
 		return nil, err
 	}
 	for _, info := range imageInfos {
-		if info.ContainsImage(w.name, w.tagSpec.Tag) {
+		if info.ContainsImage(w.name, ref) {
 			return &info, nil
 		}
 	}
-	return nil, fmt.Errorf("image %v:%v not found", w.name, w.tagSpec.Tag)
+	return nil, fmt.Errorf("image %v not found", daemonutil.JoinImageNameTag(w.name, ref))
 }
 
 // Pulling image and update process in status
@@ -464,12 +491,46 @@ func (w *pullWorker) doPullImage(ctx context.Context, newStatus *appsv1beta1.Ima
 
 	klog.InfoS("Worker is starting to pull image", "name", w.name, "tag", tag, "version", w.tagSpec.Version)
 
-	if info, _ := w.getImageInfo(ctx); info != nil && imagePullPolicy == appsv1beta1.PullIfNotPresent {
+	var verifiedDigest string
+	if w.verifier != nil && w.tagSpec.Verification != nil {
+		auths := secret.AuthInfos(ctx, w.name, tag, w.secrets)
+		if verifiedDigest, err = w.verifier.Verify(ctx, w.name, tag, w.tagSpec.Verification, auths); err != nil {
+			return fmt.Errorf("image verification failed: %v", err)
+		}
+		klog.InfoS("Verified image before pulling", "name", w.name, "tag", tag, "digest", verifiedDigest)
+	}
+	// pull the verified image by digest, so that the content pulled can not be changed by moving the tag after verification
+	pullRef := tag
+	if verifiedDigest != "" {
+		pullRef = verifiedDigest
+		newStatus.Digest = verifiedDigest
+	}
+
+	if info, _ := w.getImageInfo(ctx, pullRef); info != nil && imagePullPolicy == appsv1beta1.PullIfNotPresent {
 		klog.InfoS("Image is already exists", "name", w.name, "tag", tag)
 		newStatus.Progress = 100
 		return nil
 	}
 
+	stats := &appsv1beta1.ImagePullStats{Registry: getImageRegistry(w.name)}
+	newStatus.PullStats = stats
+	defer func() {
+		stats.Duration = &metav1.Duration{Duration: time.Since(startTime.Time)}
+		// the CRI runtime does not stream the progress, take the size of image as the downloaded bytes
+		if err == nil && stats.DownloadedBytes == 0 {
+			if info, _ := w.getImageInfo(ctx, pullRef); info != nil {
+				stats.DownloadedBytes = info.Size
+			}
+		}
+		if err == nil || w.IsActive() {
+			observeImagePull(stats, err)
+		}
+	}()
+
+	if w.fetcher != nil && w.tagSpec.PeerToPeer != nil {
+		w.fetchFromPeers(ctx, pullRef)
+	}
+
 	// make it asynchronous for CRI runtime will block in pulling image
 	var statusReader runtimeimage.ImagePullStatusReader
 	pullChan := make(chan struct{})
@@ -480,7 +541,7 @@ func (w *pullWorker) doPullImage(ctx context.Context, newStatus *appsv1beta1.Ima
 	readerCh := make(chan runtimeimage.ImagePullStatusReader, 1)
 	errCh := make(chan error, 1)
 	go func() {
-		statusReader, err := w.runtime.PullImage(ctx, w.name, tag, w.secrets, w.sandboxConfig)
+		statusReader, err := w.runtime.PullImage(ctx, w.name, pullRef, w.secrets, w.sandboxConfig)
 		readerCh <- statusReader
 		errCh <- err
 		close(pullChan)
@@ -536,6 +597,8 @@ func (w *pullWorker) doPullImage(ctx context.Context, newStatus *appsv1beta1.Ima
 			progress = progressStatus.Process
 			progressInfo = progressStatus.DetailInfo
 			newStatus.Progress = int32(progressStatus.Process)
+			stats.DownloadedBytes, stats.TotalBytes = progressStatus.DownloadedBytes, progressStatus.TotalBytes
+			stats.LayersCompleted, stats.LayersTotal = progressStatus.LayersCompleted, progressStatus.LayersTotal
 			klog.V(5).InfoS("Pulling image", "name", w.name, "tag", tag, "cost", time.Since(startTime.Time), "progress", progress, "detail", progressInfo)
 			if progressStatus.Finish {
 				if progressStatus.Err == nil {
@@ -548,6 +611,22 @@ func (w *pullWorker) doPullImage(ctx context.Context, newStatus *appsv1beta1.Ima
 	}
 }
 
+// fetchFromPeers fetches the image from peers into the local cache, which is registered as the registry mirror
+// of the container runtime and serves the other nodes. The container runtime pulls from the registry as usual
+// if the image is not in the cache, so the error is only logged.
+func (w *pullWorker) fetchFromPeers(ctx context.Context, ref string) {
+	startTime := time.Now()
+	auths := secret.AuthInfos(ctx, w.name, w.tagSpec.Tag, w.secrets)
+	result, err := w.fetcher.Fetch(ctx, w.name, ref, w.tagSpec.PeerToPeer.Peers, auths)
+	if err != nil {
+		klog.ErrorS(err, "Failed to fetch image from peers", "name", w.name, "tag", ref, "peers", w.tagSpec.PeerToPeer.Peers)
+		return
+	}
+	observePeerFetch(result)
+	klog.InfoS("Fetched image for peer-to-peer distribution", "name", w.name, "tag", ref, "digest", result.Digest,
+		"peerBytes", result.PeerBytes, "registryBytes", result.RegistryBytes, "cost", time.Since(startTime))
+}
+
 func (w *pullWorker) finishPulling(newStatus *appsv1beta1.ImageTagStatus, phase appsv1beta1.ImagePullPhase, message string) {
 	newStatus.Phase = phase
 	now := metav1.Now()
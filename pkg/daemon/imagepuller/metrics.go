@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/peer"
+)
+
+const (
+	pullResultSucceeded = "succeeded"
+	pullResultFailed    = "failed"
+
+	fetchSourcePeer     = "peer"
+	fetchSourceRegistry = "registry"
+)
+
+var (
+	ImagePullDurationMetrics = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "image_pull_duration_seconds",
+			Help: "Duration of each attempt of pulling image by kruise-daemon",
+			// 1s ~ 68min
+			Buckets: prometheus.ExponentialBuckets(1, 2, 13),
+		}, []string{"registry", "result"},
+	)
+
+	ImagePullBytesMetrics = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "image_pull_downloaded_bytes",
+			Help: "Bytes downloaded by each successful attempt of pulling image by kruise-daemon",
+			// 1MiB ~ 16GiB
+			Buckets: prometheus.ExponentialBuckets(1<<20, 4, 8),
+		}, []string{"registry"},
+	)
+
+	ImagePeerFetchBytesMetrics = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "image_peer_fetch_bytes_total",
+			Help: "Bytes fetched into the peer-to-peer cache of kruise-daemon by source",
+		}, []string{"source"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(ImagePullDurationMetrics)
+	metrics.Registry.MustRegister(ImagePullBytesMetrics)
+	metrics.Registry.MustRegister(ImagePeerFetchBytesMetrics)
+}
+
+func observeImagePull(stats *appsv1beta1.ImagePullStats, err error) {
+	if stats == nil || stats.Duration == nil {
+		return
+	}
+	result := pullResultSucceeded
+	if err != nil {
+		result = pullResultFailed
+	}
+	ImagePullDurationMetrics.WithLabelValues(stats.Registry, result).Observe(stats.Duration.Seconds())
+	if err == nil && stats.DownloadedBytes > 0 {
+		ImagePullBytesMetrics.WithLabelValues(stats.Registry).Observe(float64(stats.DownloadedBytes))
+	}
+}
+
+func observePeerFetch(result *peer.FetchResult) {
+	ImagePeerFetchBytesMetrics.WithLabelValues(fetchSourcePeer).Add(float64(result.PeerBytes))
+	ImagePeerFetchBytesMetrics.WithLabelValues(fetchSourceRegistry).Add(float64(result.RegistryBytes))
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultServiceAccountTokenFile is the token of the service account mounted into kruise-daemon
+	DefaultServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// PeerAudience is the audience of the tokens presented to peers, so that they can not be used
+	// to access the apiserver or any other service
+	PeerAudience = "kruise-daemon-image-peer"
+
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	peerTokenExpirationSeconds   = int64(600)
+
+	authCacheTTL     = 5 * time.Minute
+	maxAuthCacheSize = 1024
+)
+
+// Authenticator authenticates the requests between peers
+type Authenticator interface {
+	// Token returns the bearer token presented to peers
+	Token(ctx context.Context) (string, error)
+	// Authenticate returns an error if the token is not presented by a trusted peer
+	Authenticate(ctx context.Context, token string) error
+}
+
+// NewServiceAccountAuthenticator returns the Authenticator which presents the short-lived token of the service account
+// of this kruise-daemon bound to PeerAudience, and only trusts the peers authenticated by TokenReview as the same service
+// account with the audience. The service account is learned by reviewing the token in tokenFile.
+func NewServiceAccountAuthenticator(client kubernetes.Interface, tokenFile string) Authenticator {
+	return &serviceAccountAuthenticator{client: client, tokenFile: tokenFile, authenticated: make(map[[sha256.Size]byte]time.Time)}
+}
+
+type serviceAccountAuthenticator struct {
+	client    kubernetes.Interface
+	tokenFile string
+
+	mu sync.Mutex
+	// username is the service account of this kruise-daemon, which is learned by reviewing its own token
+	username string
+	// token is the token requested for peers, which is requested again after refreshTime
+	token       string
+	refreshTime time.Time
+	// authenticated is the expiration time of the tokens authenticated recently
+	authenticated map[[sha256.Size]byte]time.Time
+}
+
+func (a *serviceAccountAuthenticator) Token(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	token, refreshTime := a.token, a.refreshTime
+	a.mu.Unlock()
+	now := time.Now()
+	if token != "" && now.Before(refreshTime) {
+		return token, nil
+	}
+
+	username, err := a.getUsername(ctx)
+	if err != nil {
+		return "", err
+	}
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if !ok || !strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		return "", fmt.Errorf("user %s is not a service account", username)
+	}
+	expirationSeconds := peerTokenExpirationSeconds
+	tr, err := a.client.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{PeerAudience},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to request token for peers: %v", err)
+	}
+	// request a new one when 80% of the lifetime has passed, in case of clock skew between nodes
+	lifetime := tr.Status.ExpirationTimestamp.Time.Sub(now)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.token = tr.Status.Token
+	a.refreshTime = now.Add(lifetime * 4 / 5)
+	return a.token, nil
+}
+
+func (a *serviceAccountAuthenticator) Authenticate(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("no token")
+	}
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+	a.mu.Lock()
+	expiration, ok := a.authenticated[key]
+	a.mu.Unlock()
+	if ok && now.Before(expiration) {
+		return nil
+	}
+
+	username, err := a.getUsername(ctx)
+	if err != nil {
+		return err
+	}
+	peerUsername, err := a.review(ctx, token, []string{PeerAudience})
+	if err != nil {
+		return err
+	}
+	if peerUsername != username {
+		return fmt.Errorf("user %s is not trusted", peerUsername)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.authenticated) >= maxAuthCacheSize {
+		for k, t := range a.authenticated {
+			if !now.Before(t) {
+				delete(a.authenticated, k)
+			}
+		}
+	}
+	if len(a.authenticated) < maxAuthCacheSize {
+		a.authenticated[key] = now.Add(authCacheTTL)
+	}
+	return nil
+}
+
+// getUsername returns the service account of this kruise-daemon
+func (a *serviceAccountAuthenticator) getUsername(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	username := a.username
+	a.mu.Unlock()
+	if username != "" {
+		return username, nil
+	}
+
+	// read the file every time as the projected token is rotated by kubelet
+	data, err := os.ReadFile(a.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %v", err)
+	}
+	if username, err = a.review(ctx, strings.TrimSpace(string(data)), nil); err != nil {
+		return "", fmt.Errorf("failed to review service account token: %v", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.username = username
+	return username, nil
+}
+
+// review returns the username of the token, which must be valid for all the audiences if any
+func (a *serviceAccountAuthenticator) review(ctx context.Context, token string, audiences []string) (string, error) {
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: audiences},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	if !review.Status.Authenticated {
+		return "", fmt.Errorf("token is not authenticated: %s", review.Status.Error)
+	}
+	for _, audience := range audiences {
+		if !sets.New[string](review.Status.Audiences...).Has(audience) {
+			return "", fmt.Errorf("token is not for audience %s", audience)
+		}
+	}
+	return review.Status.User.Username, nil
+}
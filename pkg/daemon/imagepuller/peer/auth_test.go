@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestServiceAccountAuthenticator(t *testing.T) {
+	type tokenInfo struct {
+		username  string
+		audiences []string
+	}
+	tokens := map[string]tokenInfo{
+		"self-token":     {username: "system:serviceaccount:kruise-system:kruise-daemon", audiences: []string{"https://kubernetes.default.svc"}},
+		"peer-token":     {username: "system:serviceaccount:kruise-system:kruise-daemon", audiences: []string{PeerAudience}},
+		"api-token":      {username: "system:serviceaccount:kruise-system:kruise-daemon", audiences: []string{"https://kubernetes.default.svc"}},
+		"other-token":    {username: "system:serviceaccount:default:default", audiences: []string{PeerAudience}},
+		"requested-peer": {username: "system:serviceaccount:kruise-system:kruise-daemon", audiences: []string{PeerAudience}},
+	}
+	var reviews, requests int
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+		info, ok := tokens[review.Spec.Token]
+		if !ok {
+			return true, review, nil
+		}
+		// the token is authenticated only if it is valid for one of the audiences requested
+		audiences := info.audiences
+		if len(review.Spec.Audiences) > 0 {
+			audiences = nil
+			for _, audience := range review.Spec.Audiences {
+				for _, a := range info.audiences {
+					if a == audience {
+						audiences = append(audiences, audience)
+					}
+				}
+			}
+			if len(audiences) == 0 {
+				return true, review, nil
+			}
+		}
+		review.Status.Authenticated = true
+		review.Status.User.Username = info.username
+		review.Status.Audiences = audiences
+		return true, review, nil
+	})
+	client.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		create := action.(clienttesting.CreateAction)
+		if create.GetSubresource() != "token" || create.GetNamespace() != "kruise-system" {
+			return true, nil, fmt.Errorf("unexpected token request %s/%s", create.GetNamespace(), create.GetSubresource())
+		}
+		requests++
+		tr := create.GetObject().(*authenticationv1.TokenRequest).DeepCopy()
+		if len(tr.Spec.Audiences) != 1 || tr.Spec.Audiences[0] != PeerAudience {
+			return true, nil, fmt.Errorf("unexpected audiences %v", tr.Spec.Audiences)
+		}
+		tr.Status.Token = "requested-peer"
+		tr.Status.ExpirationTimestamp = metav1.NewTime(time.Now().Add(time.Duration(*tr.Spec.ExpirationSeconds) * time.Second))
+		return true, tr, nil
+	})
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("self-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	auth := NewServiceAccountAuthenticator(client, tokenFile)
+	if token, err := auth.Token(context.TODO()); err != nil || token != "requested-peer" {
+		t.Fatalf("expected the token requested for peers, got %q, %v", token, err)
+	}
+	// the token requested is reused before it is about to expire
+	if token, err := auth.Token(context.TODO()); err != nil || token != "requested-peer" || requests != 1 {
+		t.Fatalf("expected the token to be reused, got %q, %v and %d requests", token, err, requests)
+	}
+
+	cases := []struct {
+		token   string
+		trusted bool
+	}{
+		{token: "", trusted: false},
+		{token: "unknown-token", trusted: false},
+		{token: "other-token", trusted: false},
+		{token: "api-token", trusted: false},
+		{token: "self-token", trusted: false},
+		{token: "peer-token", trusted: true},
+		{token: "requested-peer", trusted: true},
+	}
+	for _, tc := range cases {
+		if err := auth.Authenticate(context.TODO(), tc.token); (err == nil) != tc.trusted {
+			t.Fatalf("token %q: expected trusted %v, got error %v", tc.token, tc.trusted, err)
+		}
+	}
+
+	// the authenticated token is cached
+	reviewed := reviews
+	if err := auth.Authenticate(context.TODO(), "peer-token"); err != nil || reviews != reviewed {
+		t.Fatalf("expected the cached result without review, got error %v and %d reviews", err, reviews-reviewed)
+	}
+}
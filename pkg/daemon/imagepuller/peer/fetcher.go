@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"k8s.io/klog/v2"
+
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+// FetchResult is the result of fetching an image
+type FetchResult struct {
+	// Digest of the manifest of the image
+	Digest string
+	// PeerBytes is the number of bytes downloaded from peers
+	PeerBytes int64
+	// RegistryBytes is the number of bytes downloaded from the registry
+	RegistryBytes int64
+}
+
+// Fetcher fetches images into the store, from peers first and then from the registry
+type Fetcher struct {
+	store      Store
+	client     *registry.Client
+	peerClient *http.Client
+	auth       Authenticator
+	mirror     MirrorRegistrar
+}
+
+// NewFetcher returns a Fetcher which fetches images into store, the requests to peers are authenticated by auth.
+// The store is registered by mirror for the container runtime to pull the fetched images from it.
+func NewFetcher(store Store, client *registry.Client, auth Authenticator, mirror MirrorRegistrar) *Fetcher {
+	// the self-signed certificates of peers can not be verified, see NewServerTLSConfig
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12} // #nosec G402 the content is verified by digest
+	peerClient := &http.Client{Transport: transport, Timeout: 30 * time.Minute}
+	return &Fetcher{store: store, client: client, peerClient: peerClient, auth: auth, mirror: mirror}
+}
+
+// source is a peer or the registry to fetch content from
+type source struct {
+	*registry.Remote
+	disabled bool
+}
+
+// Fetch downloads the manifests and layers of imageName:tag into the store. The tag is always resolved by the registry,
+// and every piece of content is fetched by digest from the peers in order and then from the registry, whose digest
+// is verified when stored, so that peers can never change the content of the image.
+func (f *Fetcher) Fetch(ctx context.Context, imageName, tag string, peers []string, auths []daemonutil.AuthInfo) (*FetchResult, error) {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return nil, err
+	}
+	domain, path := reference.Domain(named), reference.Path(named)
+	// the image fetched is useless if the container runtime pulls it from the registry again
+	if f.mirror != nil {
+		if err := f.mirror.Register(domain); err != nil {
+			return nil, fmt.Errorf("failed to register the mirror of %s: %v", domain, err)
+		}
+	}
+
+	var sources []*source
+	if len(peers) > 0 {
+		if token, err := f.auth.Token(ctx); err != nil {
+			klog.ErrorS(err, "Failed to get token for peers, fetching from registry only", "image", imageName)
+		} else {
+			for _, p := range peers {
+				sources = append(sources, &source{Remote: &registry.Remote{
+					Name:          p,
+					BaseURL:       "https://" + p,
+					Query:         url.Values{nsQueryParameter: []string{domain}},
+					Anonymous:     true,
+					Authorization: "Bearer " + token,
+					HTTPClient:    f.peerClient,
+				}})
+			}
+		}
+	}
+	registrySource := &source{Remote: f.client.NewRegistryRemote(domain, auths)}
+	sources = append(sources, registrySource)
+
+	result := &FetchResult{}
+	manifestSources := sources
+	if !imageverify.IsDigest(tag) {
+		manifestSources = []*source{registrySource}
+	}
+	data, top, err := f.fetchManifest(ctx, manifestSources, path, tag, result)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &registry.Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest of %s:%s: %v", imageName, tag, err)
+	}
+	if m.IsIndex() {
+		child := selectPlatformManifest(m.Manifests)
+		if child == nil {
+			return nil, fmt.Errorf("no manifest of %s:%s matches platform %s/%s", imageName, tag, runtime.GOOS, runtime.GOARCH)
+		}
+		if data, _, err = f.fetchManifest(ctx, sources, path, child.Digest, result); err != nil {
+			return nil, err
+		}
+		m = &registry.Manifest{}
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest %s: %v", child.Digest, err)
+		}
+	}
+
+	blobs := m.Layers
+	if m.Config != nil {
+		blobs = append([]registry.Descriptor{*m.Config}, blobs...)
+	}
+	for _, blob := range blobs {
+		if err := f.fetchBlob(ctx, sources, path, blob, result); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Digest = top.Digest
+	return result, nil
+}
+
+func (f *Fetcher) fetchManifest(ctx context.Context, sources []*source, path, ref string, result *FetchResult) ([]byte, *registry.Descriptor, error) {
+	var lastErr error
+	for _, s := range sources {
+		if s.disabled {
+			continue
+		}
+		data, desc, err := f.client.GetManifest(ctx, s.Remote, path, ref)
+		if err != nil {
+			lastErr = s.fail(err)
+			continue
+		}
+		if _, err := f.store.PutBlob(desc.Digest, bytes.NewReader(data)); err != nil {
+			return nil, nil, err
+		}
+		result.add(s, desc.Size)
+		klog.V(4).InfoS("Fetched image manifest", "path", path, "ref", ref, "source", s.Name, "digest", desc.Digest)
+		return data, desc, nil
+	}
+	return nil, nil, fmt.Errorf("failed to fetch manifest %s/%s: %v", path, ref, lastErr)
+}
+
+func (f *Fetcher) fetchBlob(ctx context.Context, sources []*source, path string, desc registry.Descriptor, result *FetchResult) error {
+	if f.store.HasBlob(desc.Digest) {
+		return nil
+	}
+	var lastErr error
+	for _, s := range sources {
+		if s.disabled {
+			continue
+		}
+		resp, err := f.client.Get(ctx, s.Remote, path, "blobs/"+desc.Digest, "")
+		if err != nil {
+			lastErr = s.fail(err)
+			continue
+		}
+		size, err := f.store.PutBlob(desc.Digest, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = s.fail(fmt.Errorf("failed to download blob %s: %v", desc.Digest, err))
+			continue
+		}
+		result.add(s, size)
+		klog.V(4).InfoS("Fetched image blob", "path", path, "digest", desc.Digest, "source", s.Name, "size", size)
+		return nil
+	}
+	return fmt.Errorf("failed to fetch blob %s of %s: %v", desc.Digest, path, lastErr)
+}
+
+// fail records the failure of a peer and stops using it for the rest of the image
+func (s *source) fail(err error) error {
+	if s.Anonymous {
+		klog.V(3).InfoS("Failed to fetch from peer, falling back", "peer", s.Name, "err", err)
+		s.disabled = true
+	}
+	return err
+}
+
+func (r *FetchResult) add(s *source, size int64) {
+	if s.Anonymous {
+		r.PeerBytes += size
+	} else {
+		r.RegistryBytes += size
+	}
+}
+
+func selectPlatformManifest(manifests []registry.PlatformManifest) *registry.Descriptor {
+	for i := range manifests {
+		p := manifests[i].Platform
+		if p != nil && p.OS == runtime.GOOS && p.Architecture == runtime.GOARCH {
+			return &manifests[i].Descriptor
+		}
+	}
+	return nil
+}
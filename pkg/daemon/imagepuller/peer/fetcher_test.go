@@ -0,0 +1,375 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+// fakeRegistry is a local registry stand-in which requires bearer token
+type fakeRegistry struct {
+	server   *httptest.Server
+	blobs    map[string][]byte
+	tags     map[string]string
+	requests int32
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	r := &fakeRegistry{blobs: map[string][]byte{}, tags: map[string]string{}}
+	config := r.addBlob([]byte(`{"architecture":"` + runtime.GOARCH + `"}`))
+	layer1 := r.addBlob([]byte(strings.Repeat("layer1", 1000)))
+	layer2 := r.addBlob([]byte(strings.Repeat("layer2", 2000)))
+	m, _ := json.Marshal(&registry.Manifest{
+		MediaType: registry.MediaTypeOCIManifest,
+		Config:    &registry.Descriptor{MediaType: "application/vnd.oci.image.config.v1+json", Digest: config, Size: int64(len(r.blobs[config]))},
+		Layers: []registry.Descriptor{
+			{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: layer1, Size: int64(len(r.blobs[layer1]))},
+			{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: layer2, Size: int64(len(r.blobs[layer2]))},
+		},
+	})
+	manifestDigest := r.addBlob(m)
+	index, _ := json.Marshal(map[string]interface{}{
+		"mediaType": registry.MediaTypeOCIIndex,
+		"manifests": []interface{}{
+			map[string]interface{}{"mediaType": registry.MediaTypeOCIManifest, "digest": imageverify.CalculateDigest([]byte("other")), "platform": map[string]string{"os": "other", "architecture": "other"}},
+			map[string]interface{}{"mediaType": registry.MediaTypeOCIManifest, "digest": manifestDigest, "platform": map[string]string{"os": runtime.GOOS, "architecture": runtime.GOARCH}},
+		},
+	})
+	r.tags["1.0"] = r.addBlob(index)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, req *http.Request) {
+		if user, pass, ok := req.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"token":"secret"}`)
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake",scope="repository:library/nginx:pull"`, r.server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		atomic.AddInt32(&r.requests, 1)
+		path := strings.TrimPrefix(req.URL.Path, "/v2/library/nginx/")
+		var digest string
+		if ref, ok := strings.CutPrefix(path, "manifests/"); ok {
+			digest = ref
+			if d, ok := r.tags[ref]; ok {
+				digest = d
+			}
+		} else if ref, ok := strings.CutPrefix(path, "blobs/"); ok {
+			digest = ref
+		}
+		data, ok := r.blobs[digest]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if strings.HasPrefix(path, "manifests/") {
+			w.Header().Set("Content-Type", registry.DetectManifestMediaType(data))
+		}
+		w.Write(data)
+	})
+	r.server = httptest.NewServer(mux)
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *fakeRegistry) addBlob(data []byte) string {
+	digest := imageverify.CalculateDigest(data)
+	r.blobs[digest] = data
+	return digest
+}
+
+type fakeAuthenticator struct {
+	token string
+}
+
+func (a *fakeAuthenticator) Token(context.Context) (string, error) {
+	return a.token, nil
+}
+
+func (a *fakeAuthenticator) Authenticate(_ context.Context, token string) error {
+	if token != a.token {
+		return fmt.Errorf("invalid token")
+	}
+	return nil
+}
+
+type testNode struct {
+	store   Store
+	fetcher *Fetcher
+	server  *httptest.Server
+}
+
+func newTestNode(t *testing.T, fake *fakeRegistry) *testNode {
+	store, err := NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := registry.NewClient()
+	client.Endpoint = func(string) string { return fake.server.URL }
+	auth := &fakeAuthenticator{token: "node-token"}
+	fetcher := NewFetcher(store, client, auth, nil)
+	server := httptest.NewTLSServer(NewHandler(store, auth))
+	t.Cleanup(server.Close)
+	return &testNode{store: store, fetcher: fetcher, server: server}
+}
+
+func (n *testNode) endpoint() string {
+	return strings.TrimPrefix(n.server.URL, "https://")
+}
+
+func TestFetchFromPeers(t *testing.T) {
+	fake := newFakeRegistry(t)
+	auths := []daemonutil.AuthInfo{{Username: "user", Password: "pass"}}
+	seed := newTestNode(t, fake)
+
+	// the seed node fetches from registry
+	result, err := seed.fetcher.Fetch(context.TODO(), "nginx", "1.0", nil, auths)
+	if err != nil {
+		t.Fatalf("failed to fetch from registry: %v", err)
+	}
+	if result.PeerBytes != 0 || result.RegistryBytes == 0 || result.Digest != fake.tags["1.0"] {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	registryRequests := atomic.LoadInt32(&fake.requests)
+
+	// the child node resolves the tag by registry and fetches the others from the seed, the unreachable peer is skipped
+	child := newTestNode(t, fake)
+	result, err = child.fetcher.Fetch(context.TODO(), "docker.io/library/nginx", "1.0", []string{"127.0.0.1:1", seed.endpoint()}, auths)
+	if err != nil {
+		t.Fatalf("failed to fetch from peers: %v", err)
+	}
+	if result.PeerBytes == 0 || result.RegistryBytes != int64(len(fake.blobs[fake.tags["1.0"]])) || result.Digest != fake.tags["1.0"] {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if requests := atomic.LoadInt32(&fake.requests); requests != registryRequests+1 {
+		t.Fatalf("expected only the tag to be resolved by registry, got %d requests", requests-registryRequests)
+	}
+
+	// the tag can not be resolved without registry
+	if _, err = newTestNode(t, fake).fetcher.Fetch(context.TODO(), "nginx", "1.0", []string{child.endpoint()}, nil); err == nil {
+		t.Fatalf("expected error to resolve the tag without credential")
+	}
+
+	// the grandchild node is served by the child
+	grandchild := newTestNode(t, fake)
+	if _, err = grandchild.fetcher.Fetch(context.TODO(), "nginx", "1.0", []string{child.endpoint()}, auths); err != nil {
+		t.Fatalf("failed to fetch from child: %v", err)
+	}
+	for digest, data := range fake.blobs {
+		rc, _, err := grandchild.store.GetBlob(digest)
+		if err != nil {
+			t.Fatalf("expected blob %s to be fetched: %v", digest, err)
+		}
+		got, _ := io.ReadAll(rc)
+		rc.Close()
+		if string(got) != string(data) {
+			t.Fatalf("unexpected content of blob %s", digest)
+		}
+	}
+}
+
+func TestFetchFallbackToRegistry(t *testing.T) {
+	fake := newFakeRegistry(t)
+	auths := []daemonutil.AuthInfo{{Username: "user", Password: "pass"}}
+
+	// the peer does not hold the image
+	empty := newTestNode(t, fake)
+	node := newTestNode(t, fake)
+	result, err := node.fetcher.Fetch(context.TODO(), "nginx", "1.0", []string{empty.endpoint()}, auths)
+	if err != nil {
+		t.Fatalf("failed to fetch: %v", err)
+	}
+	if result.PeerBytes != 0 || result.RegistryBytes == 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	// wrong credential
+	node = newTestNode(t, fake)
+	if _, err = node.fetcher.Fetch(context.TODO(), "nginx", "1.0", nil, []daemonutil.AuthInfo{{Username: "user", Password: "wrong"}}); err == nil {
+		t.Fatalf("expected error with wrong credential")
+	}
+
+	// nothing is fetched if the mirror can not be registered
+	node = newTestNode(t, fake)
+	node.fetcher.mirror = &fakeMirrorRegistrar{err: fmt.Errorf("not managed")}
+	registryRequests := atomic.LoadInt32(&fake.requests)
+	if _, err = node.fetcher.Fetch(context.TODO(), "nginx", "1.0", nil, auths); err == nil {
+		t.Fatalf("expected error when the mirror is not registered")
+	}
+	if requests := atomic.LoadInt32(&fake.requests); requests != registryRequests {
+		t.Fatalf("expected no request to registry, got %d", requests-registryRequests)
+	}
+}
+
+func TestFetchFromMaliciousPeer(t *testing.T) {
+	fake := newFakeRegistry(t)
+	auths := []daemonutil.AuthInfo{{Username: "user", Password: "pass"}}
+
+	// the peer serves the same forged manifest for any reference
+	forged := []byte(`{"schemaVersion":2,"mediaType":"` + registry.MediaTypeOCIManifest + `","layers":[]}`)
+	var peerRequests int32
+	peer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&peerRequests, 1)
+		if strings.Contains(req.URL.Path, "/manifests/") {
+			w.Header().Set("Content-Type", registry.MediaTypeOCIManifest)
+			w.Write(forged)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer peer.Close()
+
+	node := newTestNode(t, fake)
+	result, err := node.fetcher.Fetch(context.TODO(), "nginx", "1.0", []string{strings.TrimPrefix(peer.URL, "https://")}, auths)
+	if err != nil {
+		t.Fatalf("failed to fetch: %v", err)
+	}
+	if result.Digest != fake.tags["1.0"] || result.PeerBytes != 0 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if atomic.LoadInt32(&peerRequests) == 0 {
+		t.Fatalf("expected the peer to be requested by digest")
+	}
+	if node.store.HasBlob(imageverify.CalculateDigest(forged)) {
+		t.Fatalf("expected the forged manifest not to be stored")
+	}
+}
+
+type fakeMirrorRegistrar struct {
+	err error
+}
+
+func (m *fakeMirrorRegistrar) Register(string) error {
+	return m.err
+}
+
+func (m *fakeMirrorRegistrar) Unregister() error {
+	return m.err
+}
+
+func TestHandler(t *testing.T) {
+	store, err := NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(`{"mediaType":"` + registry.MediaTypeDockerManifest + `"}`)
+	digest := imageverify.CalculateDigest(data)
+	if _, err := store.PutBlob(digest, strings.NewReader(string(data))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.PutBlob(digest, strings.NewReader("tampered")); err == nil {
+		t.Fatalf("expected digest mismatch")
+	}
+	// the mirror handler for the container runtime of this node
+	server := httptest.NewServer(NewHandler(store, nil))
+	defer server.Close()
+
+	cases := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{path: "/v2/", status: http.StatusOK},
+		{path: "/v2/app/manifests/v1?ns=registry.example.com", status: http.StatusNotFound},
+		{path: "/v2/app/manifests/" + digest + "?ns=registry.example.com", status: http.StatusOK, contentType: registry.MediaTypeDockerManifest},
+		{path: "/v2/app/manifests/v1", status: http.StatusNotFound},
+		{path: "/v2/app/manifests/v2?ns=registry.example.com", status: http.StatusNotFound},
+		{path: "/v2/app/blobs/" + digest, status: http.StatusOK, contentType: "application/octet-stream"},
+		{path: "/v2/app/blobs/sha256:invalid", status: http.StatusNotFound},
+		{path: "/v2/../tags/registry.example.com/app/manifests/v1", status: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		resp, err := http.Get(server.URL + tc.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.status {
+			t.Fatalf("%s: expected status %d, got %d", tc.path, tc.status, resp.StatusCode)
+		}
+		if tc.contentType != "" && resp.Header.Get("Content-Type") != tc.contentType {
+			t.Fatalf("%s: expected content type %s, got %s", tc.path, tc.contentType, resp.Header.Get("Content-Type"))
+		}
+	}
+
+	// the requests to the peer handler must be authenticated, even from this node
+	handler := NewHandler(store, &fakeAuthenticator{token: "node-token"})
+	for token, status := range map[string]int{"": http.StatusUnauthorized, "wrong": http.StatusUnauthorized, "node-token": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/v2/app/blobs/"+digest, nil)
+		req.RemoteAddr = "127.0.0.1:34567"
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != status {
+			t.Fatalf("token %q: expected status %d, got %d", token, status, recorder.Code)
+		}
+	}
+}
+
+func TestFileStoreEviction(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFileStore(dir, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	put := func(content string) string {
+		data := []byte(strings.Repeat(content, 100))
+		digest := imageverify.CalculateDigest(data)
+		if _, err := store.PutBlob(digest, strings.NewReader(string(data))); err != nil {
+			t.Fatal(err)
+		}
+		return digest
+	}
+	a, b := put("a"), put("b")
+	// a is used after b, so b is the least recently used one
+	if !store.HasBlob(a) {
+		t.Fatalf("expected blob a to be stored")
+	}
+	c := put("c")
+	if !store.HasBlob(a) || store.HasBlob(b) || !store.HasBlob(c) {
+		t.Fatalf("expected only blob b to be evicted")
+	}
+
+	// the usage is restored after restart
+	store, err = NewFileStore(dir, 150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.HasBlob(a) || !store.HasBlob(c) {
+		t.Fatalf("expected blob a to be evicted after restart")
+	}
+}
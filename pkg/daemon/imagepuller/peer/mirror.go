@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
+)
+
+const (
+	hostsFileName = "hosts.toml"
+	// managedHostsHeader marks the hosts.toml written by kruise-daemon, the other ones are never overwritten
+	managedHostsHeader = "# Managed by kruise-daemon for peer-to-peer image distribution, DO NOT EDIT."
+
+	defaultDockerRegistryServer = "https://registry-1.docker.io"
+)
+
+// MirrorRegistrar registers the local peer endpoint as the registry mirror of the container runtime,
+// so that the runtime pulls the content fetched from peers instead of the registry.
+type MirrorRegistrar interface {
+	// Register ensures the container runtime pulls the images of registry through the mirror
+	Register(registry string) error
+	// Unregister removes all the mirrors registered, so that the runtime pulls from the registries directly
+	Unregister() error
+}
+
+// NewContainerdMirrorRegistrar returns the MirrorRegistrar writing the hosts.toml of registries under hostsDir,
+// which should be the config_path of the CRI registry in containerd config. The mirror only has the pull capability,
+// so that containerd always resolves the tags by the registry and pulls the content by digest from the mirror, and
+// the content not in the mirror is pulled from the registry as usual, for containerd tries the hosts in order.
+func NewContainerdMirrorRegistrar(hostsDir, mirrorEndpoint string) MirrorRegistrar {
+	return &containerdMirrorRegistrar{hostsDir: hostsDir, mirrorEndpoint: mirrorEndpoint}
+}
+
+type containerdMirrorRegistrar struct {
+	hostsDir       string
+	mirrorEndpoint string
+
+	mu sync.Mutex
+}
+
+func (c *containerdMirrorRegistrar) Register(reg string) error {
+	if reg == "" || strings.ContainsAny(reg, "/\\") || strings.Contains(reg, "..") {
+		return fmt.Errorf("invalid registry %q", reg)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	server := "https://" + reg
+	if reg == registry.DefaultRegistry {
+		server = defaultDockerRegistryServer
+	}
+	content := []byte(fmt.Sprintf("%s\nserver = %q\n\n[host.%q]\n  capabilities = [\"pull\"]\n",
+		managedHostsHeader, server, "http://"+c.mirrorEndpoint))
+
+	path := filepath.Join(c.hostsDir, reg, hostsFileName)
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		if bytes.Equal(existing, content) {
+			return nil
+		}
+		if !bytes.HasPrefix(existing, []byte(managedHostsHeader)) {
+			return fmt.Errorf("%s is not managed by kruise-daemon", path)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), hostsFileName+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(content); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+	klog.InfoS("Registered peer endpoint as containerd registry mirror", "registry", reg, "mirror", c.mirrorEndpoint, "path", path)
+	return nil
+}
+
+func (c *containerdMirrorRegistrar) Unregister() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return RemoveContainerdMirrors(c.hostsDir)
+}
+
+// RemoveContainerdMirrors removes the hosts.toml managed by kruise-daemon under hostsDir, it is used to clean up
+// the mirrors when kruise-daemon stops or the peer-to-peer image distribution is disabled.
+func RemoveContainerdMirrors(hostsDir string) error {
+	entries, err := os.ReadDir(hostsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(hostsDir, entry.Name(), hostsFileName)
+		existing, err := os.ReadFile(path)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if !bytes.HasPrefix(existing, []byte(managedHostsHeader)) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+			continue
+		}
+		// remove the registry directory only if it is left empty
+		_ = os.Remove(filepath.Dir(path))
+		klog.InfoS("Removed containerd registry mirror of peer endpoint", "registry", entry.Name(), "path", path)
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestContainerdMirrorRegistrar(t *testing.T) {
+	dir := t.TempDir()
+	registrar := NewContainerdMirrorRegistrar(dir, "127.0.0.1:10223")
+
+	if err := registrar.Register("docker.io"); err != nil {
+		t.Fatalf("failed to register: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "docker.io", "hosts.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := managedHostsHeader + `
+server = "https://registry-1.docker.io"
+
+[host."http://127.0.0.1:10223"]
+  capabilities = ["pull"]
+`
+	if string(data) != expected {
+		t.Fatalf("unexpected hosts.toml:\n%s", data)
+	}
+	// registering again is a no-op
+	if err := registrar.Register("docker.io"); err != nil {
+		t.Fatalf("failed to register again: %v", err)
+	}
+
+	// the stale hosts.toml managed by kruise-daemon is updated
+	if err := NewContainerdMirrorRegistrar(dir, "127.0.0.1:10224").Register("docker.io"); err != nil {
+		t.Fatalf("failed to update: %v", err)
+	}
+	if data, _ = os.ReadFile(filepath.Join(dir, "docker.io", "hosts.toml")); !strings.Contains(string(data), "127.0.0.1:10224") {
+		t.Fatalf("expected hosts.toml to be updated:\n%s", data)
+	}
+
+	// the hosts.toml configured by others is never overwritten
+	custom := []byte(`server = "https://registry.example.com"` + "\n")
+	if err := os.MkdirAll(filepath.Join(dir, "registry.example.com"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "registry.example.com", "hosts.toml"), custom, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := registrar.Register("registry.example.com"); err == nil {
+		t.Fatalf("expected error for the hosts.toml not managed")
+	}
+	if data, _ = os.ReadFile(filepath.Join(dir, "registry.example.com", "hosts.toml")); string(data) != string(custom) {
+		t.Fatalf("expected hosts.toml not to be overwritten:\n%s", data)
+	}
+
+	if err := registrar.Register("../etc"); err == nil {
+		t.Fatalf("expected error for invalid registry")
+	}
+
+	// only the hosts.toml managed by kruise-daemon are removed
+	if err := registrar.Unregister(); err != nil {
+		t.Fatalf("failed to unregister: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "docker.io")); !os.IsNotExist(err) {
+		t.Fatalf("expected hosts.toml of docker.io to be removed, got %v", err)
+	}
+	if data, _ = os.ReadFile(filepath.Join(dir, "registry.example.com", "hosts.toml")); string(data) != string(custom) {
+		t.Fatalf("expected hosts.toml not managed to be kept:\n%s", data)
+	}
+	if err := RemoveContainerdMirrors(filepath.Join(dir, "not-exist")); err != nil {
+		t.Fatalf("expected no error for the hosts dir not exist: %v", err)
+	}
+}
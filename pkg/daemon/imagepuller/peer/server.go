@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/klog/v2"
+
+	"github.com/openkruise/kruise/pkg/daemon/imagepuller/registry"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+const (
+	// nsQueryParameter is the query parameter carrying the registry of the repository,
+	// which is the same as the one used by containerd when pulling from a mirror.
+	nsQueryParameter = "ns"
+)
+
+// NewHandler returns the http handler serving the manifests and blobs in store by digest with the read-only
+// API of OCI distribution, so that it can be used by peers and configured as a pull-only registry mirror.
+// It responds 404 for the content not in store, which makes the clients fall back to the registry.
+// The requests must carry the bearer token authenticated by auth, a nil auth is only for the mirror endpoint
+// listening on the loopback address, which is requested by the container runtime of this node.
+func NewHandler(store Store, auth Authenticator) http.Handler {
+	return &handler{store: store, auth: auth}
+}
+
+type handler struct {
+	store Store
+	auth  Authenticator
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if h.auth != nil {
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if err := h.auth.Authenticate(r.Context(), token); err != nil {
+			klog.V(4).InfoS("Rejected unauthenticated request from peer", "remote", r.RemoteAddr, "err", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+	w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if path == r.URL.Path {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if path == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if idx := strings.LastIndex(path, "/manifests/"); idx > 0 {
+		h.serveManifest(w, r, path[idx+len("/manifests/"):])
+		return
+	}
+	if idx := strings.LastIndex(path, "/blobs/"); idx > 0 {
+		h.serveBlob(w, r, path[idx+len("/blobs/"):], "application/octet-stream")
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// serveManifest serves the manifest by digest only, the tags are always resolved by the registry
+// so that the content of a tag can not be changed by peers.
+func (h *handler) serveManifest(w http.ResponseWriter, r *http.Request, ref string) {
+	if !imageverify.IsDigest(ref) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	h.serveBlob(w, r, ref, "")
+}
+
+func (h *handler) serveBlob(w http.ResponseWriter, r *http.Request, digest, mediaType string) {
+	rc, size, err := h.store.GetBlob(digest)
+	if err != nil {
+		klog.V(5).InfoS("Blob not found in peer cache", "digest", digest, "err", err)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	defer rc.Close()
+
+	var body io.Reader = rc
+	if mediaType == "" {
+		// the manifest requested by digest, detect its media type from the content
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		mediaType = registry.DetectManifestMediaType(data)
+		body = bytes.NewReader(data)
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	w.Header().Set("Docker-Content-Digest", digest)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		klog.V(4).InfoS("Failed to serve blob to peer", "digest", digest, "remote", r.RemoteAddr, "err", err)
+	}
+}
+
+// NewServerTLSConfig returns the TLS config of the peer endpoint with a self-signed certificate generated for host.
+// The peers can not verify the certificate, so the token presented to peers is bound to PeerAudience and short-lived,
+// and all the content fetched from peers is verified by digest.
+func NewServerTLSConfig(host string) (*tls.Config, error) {
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey(host, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package peer
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+// Store is the local cache of image manifests and layers served to peers
+type Store interface {
+	// GetBlob returns the content and size of the blob
+	GetBlob(digest string) (io.ReadCloser, int64, error)
+	// HasBlob returns true if the blob exists
+	HasBlob(digest string) bool
+	// PutBlob writes the blob and verifies its digest, it returns the size of the blob
+	PutBlob(digest string, r io.Reader) (int64, error)
+}
+
+type fileStore struct {
+	dir string
+	// maxSize is the limit of the total size of blobs, no limit if it is not positive
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+	// lru holds the blobs from the most recently used to the least recently used
+	lru   *list.List
+	blobs map[string]*list.Element
+}
+
+type blobEntry struct {
+	digest string
+	size   int64
+}
+
+// NewFileStore returns a Store which keeps the blobs under dir. The least recently used blobs are evicted
+// once the total size of blobs exceeds maxSize, which is not limited if it is not positive.
+func NewFileStore(dir string, maxSize int64) (Store, error) {
+	for _, sub := range []string{"blobs", "tmp"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, err
+		}
+	}
+	s := &fileStore{dir: dir, maxSize: maxSize, lru: list.New(), blobs: make(map[string]*list.Element)}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.evictLocked("")
+	return s, nil
+}
+
+// load cleans the temporary files left by the last run and restores the blobs, whose modification time
+// is the last time they are used.
+func (s *fileStore) load() error {
+	tmpFiles, err := os.ReadDir(filepath.Join(s.dir, "tmp"))
+	if err != nil {
+		return err
+	}
+	for _, f := range tmpFiles {
+		_ = os.RemoveAll(filepath.Join(s.dir, "tmp", f.Name()))
+	}
+
+	blobFiles, err := os.ReadDir(filepath.Join(s.dir, "blobs", imageverify.DigestAlgorithm))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	type blobFile struct {
+		blobEntry
+		modTime time.Time
+	}
+	var files []blobFile
+	for _, f := range blobFiles {
+		info, err := f.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, blobFile{blobEntry: blobEntry{digest: imageverify.DigestAlgorithm + ":" + f.Name(), size: info.Size()}, modTime: info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	for i := range files {
+		s.blobs[files[i].digest] = s.lru.PushBack(&files[i].blobEntry)
+		s.size += files[i].size
+	}
+	return nil
+}
+
+// touch marks the blob as the most recently used
+func (s *fileStore) touch(digest, path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.blobs[digest]; ok {
+		s.lru.MoveToFront(e)
+	}
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+// evictLocked removes the least recently used blobs except the one in use, until the total size is under the limit
+func (s *fileStore) evictLocked(inUse string) {
+	if s.maxSize <= 0 {
+		return
+	}
+	for e := s.lru.Back(); e != nil && s.size > s.maxSize; {
+		prev := e.Prev()
+		entry := e.Value.(*blobEntry)
+		if entry.digest != inUse {
+			path, _ := s.blobPath(entry.digest)
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				klog.ErrorS(err, "Failed to evict blob from peer cache", "digest", entry.digest)
+			} else {
+				klog.V(4).InfoS("Evicted blob from peer cache", "digest", entry.digest, "size", entry.size)
+				s.lru.Remove(e)
+				delete(s.blobs, entry.digest)
+				s.size -= entry.size
+			}
+		}
+		e = prev
+	}
+}
+
+func (s *fileStore) blobPath(digest string) (string, error) {
+	hexPart, err := imageverify.ParseDigest(digest)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, "blobs", imageverify.DigestAlgorithm, hexPart), nil
+}
+
+func (s *fileStore) GetBlob(digest string) (io.ReadCloser, int64, error) {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	s.touch(digest, path)
+	return f, info.Size(), nil
+}
+
+func (s *fileStore) HasBlob(digest string) bool {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return false
+	}
+	if _, err = os.Stat(path); err != nil {
+		return false
+	}
+	s.touch(digest, path)
+	return true
+}
+
+func (s *fileStore) PutBlob(digest string, r io.Reader) (int64, error) {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return 0, err
+	}
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, "tmp"), "blob-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if actual := imageverify.DigestAlgorithm + ":" + hex.EncodeToString(hash.Sum(nil)); actual != digest {
+		return 0, fmt.Errorf("digest mismatch, expected %s, got %s", digest, actual)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.blobs[digest]; ok {
+		s.lru.MoveToFront(e)
+	} else {
+		s.blobs[digest] = s.lru.PushFront(&blobEntry{digest: digest, size: size})
+		s.size += size
+	}
+	s.evictLocked(digest)
+	return size, nil
+}
@@ -0,0 +1,310 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+const (
+	MediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	MediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+
+	// DefaultRegistry is the registry of the image without domain
+	DefaultRegistry = "docker.io"
+
+	maxManifestSize           int64 = 4 << 20
+	defaultDockerRegistryHost       = "registry-1.docker.io"
+)
+
+var manifestAcceptTypes = strings.Join([]string{MediaTypeOCIIndex, MediaTypeDockerManifestList, MediaTypeOCIManifest, MediaTypeDockerManifest}, ", ")
+
+// Descriptor describes the content of a blob
+type Descriptor struct {
+	MediaType   string            `json:"mediaType,omitempty"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is the image manifest or the index of manifests
+type Manifest struct {
+	MediaType string             `json:"mediaType,omitempty"`
+	Config    *Descriptor        `json:"config,omitempty"`
+	Layers    []Descriptor       `json:"layers,omitempty"`
+	Manifests []PlatformManifest `json:"manifests,omitempty"`
+}
+
+// PlatformManifest is the manifest of a platform in the index
+type PlatformManifest struct {
+	Descriptor
+	Platform *Platform `json:"platform,omitempty"`
+}
+
+// Platform describes the platform of the image
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// IsIndex returns true if the manifest is an index of manifests
+func (m *Manifest) IsIndex() bool {
+	return m.MediaType == MediaTypeOCIIndex || m.MediaType == MediaTypeDockerManifestList || len(m.Manifests) > 0
+}
+
+// Remote is a registry or a peer serving the read-only API of OCI distribution
+type Remote struct {
+	Name    string
+	BaseURL string
+	Query   url.Values
+	// Anonymous remotes are never authorized by challenge, e.g. the peers
+	Anonymous bool
+	Auths     []daemonutil.AuthInfo
+	// Authorization is the Authorization header of requests, it is set by the challenge of registry
+	Authorization string
+	// HTTPClient overrides the http client of Client for the remote, e.g. the peers with self-signed certificates
+	HTTPClient *http.Client
+}
+
+// Client requests the content from remotes
+type Client struct {
+	httpClient *http.Client
+	// Endpoint returns the base url of the registry
+	Endpoint func(registry string) string
+}
+
+// NewClient returns a Client
+func NewClient() *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Minute},
+		Endpoint: func(registry string) string {
+			if registry == DefaultRegistry {
+				registry = defaultDockerRegistryHost
+			}
+			return "https://" + registry
+		},
+	}
+}
+
+// NewRegistryRemote returns the Remote of registry, the requests are authorized with auths
+func (c *Client) NewRegistryRemote(registry string, auths []daemonutil.AuthInfo) *Remote {
+	return &Remote{Name: registry, BaseURL: c.Endpoint(registry), Auths: auths}
+}
+
+// GetManifest returns the content and descriptor of the manifest of ref, which is a tag or digest,
+// the digest of the content is verified if ref is a digest.
+func (c *Client) GetManifest(ctx context.Context, remote *Remote, path, ref string) ([]byte, *Descriptor, error) {
+	resp, err := c.Get(ctx, remote, path, "manifests/"+ref, manifestAcceptTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	digest := imageverify.CalculateDigest(data)
+	if imageverify.IsDigest(ref) && ref != digest {
+		return nil, nil, fmt.Errorf("digest mismatch of manifest, expected %s, got %s", ref, digest)
+	}
+	mediaType := strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	if mediaType == "" || mediaType == "application/octet-stream" || mediaType == "application/json" {
+		mediaType = DetectManifestMediaType(data)
+	}
+	return data, &Descriptor{MediaType: mediaType, Digest: digest, Size: int64(len(data))}, nil
+}
+
+// Get requests the content from remote, and authorizes the request with the registry if challenged
+func (c *Client) Get(ctx context.Context, remote *Remote, path, suffix, accept string) (*http.Response, error) {
+	u := fmt.Sprintf("%s/v2/%s/%s", remote.BaseURL, path, suffix)
+	if len(remote.Query) > 0 {
+		u += "?" + remote.Query.Encode()
+	}
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if remote.Authorization != "" {
+			req.Header.Set("Authorization", remote.Authorization)
+		}
+		if remote.HTTPClient != nil {
+			return remote.HTTPClient.Do(req)
+		}
+		return c.httpClient.Do(req)
+	}
+
+	resp, err := do()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && !remote.Anonymous {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authorize(ctx, remote, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = do(); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, remote.Name)
+	}
+	return resp, nil
+}
+
+// authorize gets the Authorization header for the challenge of registry
+func (c *Client) authorize(ctx context.Context, remote *Remote, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	auths := remote.Auths
+	if len(auths) == 0 {
+		auths = []daemonutil.AuthInfo{{}}
+	}
+	var lastErr error
+	for _, auth := range auths {
+		var authorization string
+		switch scheme {
+		case "basic":
+			if auth.Username == "" {
+				lastErr = fmt.Errorf("registry %s requires basic auth", remote.Name)
+				continue
+			}
+			authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password))
+		case "bearer":
+			token, err := c.fetchToken(ctx, params, auth)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			authorization = "Bearer " + token
+		default:
+			return fmt.Errorf("unsupported auth challenge %q from %s", challenge, remote.Name)
+		}
+		remote.Authorization = authorization
+		return nil
+	}
+	return lastErr
+}
+
+func (c *Client) fetchToken(ctx context.Context, params map[string]string, auth daemonutil.AuthInfo) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if auth.Username != "" {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from token realm %s", resp.StatusCode, realm.Host)
+	}
+	tokenResp := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	if tokenResp.AccessToken != "" {
+		return tokenResp.AccessToken, nil
+	}
+	return "", fmt.Errorf("empty token from realm %s", realm.Host)
+}
+
+// parseChallenge parses the WWW-Authenticate header, e.g. Bearer realm="https://auth.docker.io/token",service="registry.docker.io"
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := make(map[string]string)
+	for _, part := range splitChallengeParams(rest) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return strings.ToLower(scheme), params
+}
+
+// splitChallengeParams splits the params by the commas outside of quotes
+func splitChallengeParams(s string) []string {
+	var parts []string
+	var quoted bool
+	start := 0
+	for i, c := range s {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// DetectManifestMediaType returns the media type of the manifest from its content
+func DetectManifestMediaType(data []byte) string {
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return "application/octet-stream"
+	}
+	if m.MediaType != "" {
+		return m.MediaType
+	}
+	if len(m.Manifests) > 0 {
+		return MediaTypeOCIIndex
+	}
+	return MediaTypeOCIManifest
+}
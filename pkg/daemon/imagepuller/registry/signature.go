@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+// VerifySignature verifies that the image of digest in the repository path is signed by any of the keys,
+// the signatures are stored in the same repository in the format of cosign.
+func (c *Client) VerifySignature(ctx context.Context, remote *Remote, path, digest string, keys []crypto.PublicKey) error {
+	data, _, err := c.GetManifest(ctx, remote, path, imageverify.SignatureTag(digest))
+	if err != nil {
+		return fmt.Errorf("failed to get signatures of %s: %v", digest, err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return fmt.Errorf("failed to parse signatures of %s: %v", digest, err)
+	}
+
+	var lastErr = fmt.Errorf("no signature found")
+	for _, layer := range m.Layers {
+		encoded, ok := layer.Annotations[imageverify.SignatureAnnotation]
+		if !ok {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			lastErr = fmt.Errorf("invalid signature encoding: %v", err)
+			continue
+		}
+		payload, err := c.getBlob(ctx, remote, path, layer.Digest)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := imageverify.VerifyPayload(payload, signature, digest, keys); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("no valid signature of %s: %v", digest, lastErr)
+}
+
+func (c *Client) getBlob(ctx context.Context, remote *Remote, path, digest string) ([]byte, error) {
+	resp, err := c.Get(ctx, remote, path, "blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+	if actual := imageverify.CalculateDigest(data); actual != digest {
+		return nil, fmt.Errorf("digest mismatch of blob, expected %s, got %s", digest, actual)
+	}
+	return data, nil
+}
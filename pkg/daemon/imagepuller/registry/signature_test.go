@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openkruise/kruise/pkg/util/imageverify"
+)
+
+type fakeRepository struct {
+	blobs map[string][]byte
+	tags  map[string]string
+}
+
+func (r *fakeRepository) addBlob(data []byte) string {
+	digest := imageverify.CalculateDigest(data)
+	r.blobs[digest] = data
+	return digest
+}
+
+func (r *fakeRepository) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, "/v2/app/")
+	ref := path[strings.Index(path, "/")+1:]
+	if d, ok := r.tags[ref]; ok {
+		ref = d
+	}
+	data, ok := r.blobs[ref]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if strings.HasPrefix(path, "manifests/") {
+		w.Header().Set("Content-Type", DetectManifestMediaType(data))
+	}
+	w.Write(data)
+}
+
+// sign stores the cosign signature of digest signed by key in the repository
+func (r *fakeRepository) sign(t *testing.T, key *ecdsa.PrivateKey, digest, signedDigest string) {
+	payload := r.addBlob([]byte(`{"critical":{"identity":{"docker-reference":"app"},"image":{"docker-manifest-digest":"` + signedDigest + `"},"type":"cosign container image signature"},"optional":null}`))
+	hash := sha256.Sum256(r.blobs[payload])
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := json.Marshal(&Manifest{
+		MediaType: MediaTypeOCIManifest,
+		Layers: []Descriptor{{
+			MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+			Digest:      payload,
+			Size:        int64(len(r.blobs[payload])),
+			Annotations: map[string]string{imageverify.SignatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+		}},
+	})
+	r.tags[imageverify.SignatureTag(digest)] = r.addBlob(m)
+}
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerifySignature(t *testing.T) {
+	signer, signerPEM := generateKey(t)
+	other, otherPEM := generateKey(t)
+
+	repo := &fakeRepository{blobs: map[string][]byte{}, tags: map[string]string{}}
+	signed := repo.addBlob([]byte(`{"mediaType":"` + MediaTypeOCIManifest + `","layers":[]}`))
+	repo.tags["signed"] = signed
+	repo.sign(t, signer, signed, signed)
+	unsigned := repo.addBlob([]byte(`{"mediaType":"` + MediaTypeOCIManifest + `"}`))
+	repo.tags["unsigned"] = unsigned
+	byOther := repo.addBlob([]byte(`{"mediaType":"` + MediaTypeDockerManifest + `"}`))
+	repo.sign(t, other, byOther, byOther)
+	replayed := repo.addBlob([]byte(`{"mediaType":"` + MediaTypeDockerManifest + `","layers":[]}`))
+	repo.sign(t, signer, replayed, signed)
+
+	server := httptest.NewServer(repo)
+	defer server.Close()
+	client := NewClient()
+	client.Endpoint = func(string) string { return server.URL }
+	remote := client.NewRegistryRemote("registry.example.com", nil)
+
+	_, desc, err := client.GetManifest(context.TODO(), remote, "app", "signed")
+	if err != nil || desc.Digest != signed || desc.MediaType != MediaTypeOCIManifest {
+		t.Fatalf("failed to resolve tag, desc %+v, err %v", desc, err)
+	}
+	if _, _, err = client.GetManifest(context.TODO(), remote, "app", unsigned); err != nil {
+		t.Fatalf("failed to get manifest by digest: %v", err)
+	}
+
+	keys, err := imageverify.ParsePublicKeys([]string{otherPEM, signerPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signerKeys, _ := imageverify.ParsePublicKeys([]string{signerPEM})
+	cases := []struct {
+		name      string
+		digest    string
+		keys      []crypto.PublicKey
+		expectErr bool
+	}{
+		{name: "signed", digest: signed, keys: keys},
+		{name: "unsigned", digest: unsigned, keys: keys, expectErr: true},
+		{name: "signed by other key", digest: byOther, keys: signerKeys, expectErr: true},
+		{name: "signature of another digest", digest: replayed, keys: keys, expectErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := client.VerifySignature(context.TODO(), remote, "app", tc.digest, tc.keys)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+		})
+	}
+
+	if _, err := imageverify.ParsePublicKeys([]string{"invalid"}); err == nil {
+		t.Fatalf("expected error for invalid public key")
+	}
+}
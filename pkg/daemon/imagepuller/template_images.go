@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepuller
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+)
+
+// newTemplateImagesInformer returns the informer of the ConfigMap that contains the images referenced by
+// the pod templates of workloads, which is maintained by kruise-manager.
+func newTemplateImagesInformer(client kubernetes.Interface) cache.SharedIndexInformer {
+	namespace := util.GetKruiseDaemonConfigNamespace()
+	tweakListOptionsFunc := func(opt *metav1.ListOptions) {
+		opt.FieldSelector = "metadata.name=" + appsv1beta1.TemplateImagesConfigMapName
+	}
+
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				tweakListOptionsFunc(&options)
+				return client.CoreV1().ConfigMaps(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				tweakListOptionsFunc(&options)
+				return client.CoreV1().ConfigMaps(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&v1.ConfigMap{},
+		0, // do not resync
+		cache.Indexers{},
+	)
+}
+
+// getTemplateImages returns the images in the ConfigMap of template images
+func getTemplateImages(store cache.Store) []string {
+	obj, exists, err := store.GetByKey(util.GetKruiseDaemonConfigNamespace() + "/" + appsv1beta1.TemplateImagesConfigMapName)
+	if err != nil || !exists {
+		return nil
+	}
+	cm, ok := obj.(*v1.ConfigMap)
+	if !ok {
+		return nil
+	}
+	var images []string
+	if err := json.Unmarshal([]byte(cm.Data[appsv1beta1.TemplateImagesKey]), &images); err != nil {
+		klog.ErrorS(err, "Failed to parse template images", "configMap", klog.KObj(cm))
+		return nil
+	}
+	return images
+}
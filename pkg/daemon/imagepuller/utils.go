@@ -22,6 +22,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/docker/distribution/reference"
 	"golang.org/x/time/rate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -31,6 +32,16 @@ import (
 	"github.com/openkruise/kruise/pkg/util"
 )
 
+// getImageRegistry returns the registry domain of the image, e.g. docker.io for bitnami/redis,
+// which is used as the registry label of metrics.
+func getImageRegistry(imageName string) string {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return "unknown"
+	}
+	return reference.Domain(named)
+}
+
 func logNewImages(oldObj, newObj *appsv1beta1.NodeImage) {
 	oldImages := make(map[string]struct{})
 	if oldObj != nil {
@@ -18,6 +18,7 @@ limitations under the License.
 package kuberuntime
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sort"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/cri-client/pkg/logs"
 	"k8s.io/klog/v2"
 	kubelettypes "k8s.io/kubelet/pkg/types"
 	kubeletcontainer "k8s.io/kubernetes/pkg/kubelet/container"
@@ -124,6 +126,25 @@ func (m *genericRuntimeManager) RunInContainer(ctx context.Context, id kubeletco
 	return append(stdout, stderr...), err
 }
 
+// GetContainerLogs reads the tail of logs from the CRI log path of the container.
+func (m *genericRuntimeManager) GetContainerLogs(ctx context.Context, containerID kubeletcontainer.ContainerID, tailLines, limitBytes int64) ([]byte, error) {
+	resp, err := m.runtimeService.ContainerStatus(ctx, containerID.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("run ContainerStatus for %s error: %v", containerID.ID, err)
+	}
+	if resp.GetStatus() == nil || resp.GetStatus().GetLogPath() == "" {
+		return nil, fmt.Errorf("no log path found for container %s", containerID.ID)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := klog.FromContext(ctx)
+	opts := logs.NewLogOptions(&v1.PodLogOptions{TailLines: &tailLines, LimitBytes: &limitBytes}, time.Now())
+	if err = logs.ReadLogs(ctx, &logger, resp.GetStatus().GetLogPath(), containerID.ID, opts, m.runtimeService, buf, buf); err != nil {
+		return nil, fmt.Errorf("read logs of container %s error: %v", containerID.ID, err)
+	}
+	return buf.Bytes(), nil
+}
+
 // KillContainer kills a container through the following steps:
 // * Run the pre-stop lifecycle hooks (if applicable).
 // * Stop the container.
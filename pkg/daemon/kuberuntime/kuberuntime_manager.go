@@ -41,6 +41,9 @@ type Runtime interface {
 	// * Run the pre-stop lifecycle hooks (if applicable).
 	// * Stop the container.
 	KillContainer(pod *v1.Pod, containerID kubeletcontainer.ContainerID, containerName string, message string, gracePeriodOverride *int64) error
+	// GetContainerLogs reads the tail of logs from the CRI log path of the container,
+	// which is limited by tailLines and limitBytes.
+	GetContainerLogs(ctx context.Context, containerID kubeletcontainer.ContainerID, tailLines, limitBytes int64) ([]byte, error)
 }
 
 func NewGenericRuntime(
@@ -35,4 +35,23 @@ type Options struct {
 	Healthz        *daemonutil.Healthz
 
 	MaxWorkersForPullImages int
+
+	ImagePeer ImagePeerOptions
+}
+
+// ImagePeerOptions configures the peer-to-peer distribution of images
+type ImagePeerOptions struct {
+	// Addr is the address the peer endpoint binds to, empty to disable peer-to-peer distribution
+	Addr string
+	// AdvertiseAddr is the address peers use to reach the endpoint, defaults to the node IP and the port of Addr
+	AdvertiseAddr string
+	// MirrorAddr is the loopback address the registry mirror endpoint for the container runtime of this node binds to
+	MirrorAddr string
+	// CacheDir is the directory of the image layers served to peers
+	CacheDir string
+	// CacheSize is the limit of the total size of cached image layers, the least recently used ones are evicted beyond it
+	CacheSize int64
+	// ContainerdHostsDir is the config_path of the CRI registry in containerd config, where the peer endpoint
+	// is registered as the registry mirror
+	ContainerdHostsDir string
 }
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podprobe
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/probe"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+// grpcProber checks the container with the gRPC health checking protocol, it is the same as the gRPC probe
+// of kubelet except that TLS is supported.
+type grpcProber struct{}
+
+// Probe calls the Check of grpc.health.v1.Health of service. Any failure is considered as a probe failure
+// to mimic grpc_health_probe tool behavior, so the error is always nil.
+func (p grpcProber) Probe(host, service string, port int, tlsConfig *appsv1alpha1.GRPCProbeTLS, timeout time.Duration) (probe.Result, string, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		serverName := tlsConfig.ServerName
+		if serverName == "" {
+			serverName = host
+		}
+		creds = credentials.NewTLS(&tls.Config{
+			ServerName:         serverName,
+			InsecureSkipVerify: tlsConfig.InsecureSkipVerify, // #nosec G402 it is configured by the user explicitly
+			MinVersion:         tls.VersionTLS12,
+		})
+	}
+
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := grpc.NewClient(addr,
+		grpc.WithUserAgent(userAgent("probe")),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return probe.ProbeDialer().DialContext(ctx, "tcp", addr)
+		}),
+	)
+	if err != nil {
+		return probe.Failure, fmt.Sprintf("error: failed to connect service at %q: %v", addr, err), nil
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resp, err := grpchealth.NewHealthClient(conn).Check(ctx, &grpchealth.HealthCheckRequest{Service: service}, grpc.WaitForReady(true))
+	if err != nil {
+		klog.V(4).InfoS("GRPC-Probe failed", "addr", addr, "service", service, "err", err)
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			return probe.Failure, fmt.Sprintf("error: this server does not implement the grpc health protocol (grpc.health.v1.Health): %s", status.Convert(err).Message()), nil
+		case codes.DeadlineExceeded:
+			return probe.Failure, fmt.Sprintf("timeout: health rpc did not complete within %v", timeout), nil
+		}
+		return probe.Failure, fmt.Sprintf("error: health rpc probe failed: %v", err), nil
+	}
+	if resp.GetStatus() != grpchealth.HealthCheckResponse_SERVING {
+		return probe.Failure, fmt.Sprintf("service unhealthy (responded with %q)", resp.GetStatus().String()), nil
+	}
+	return probe.Success, "service healthy", nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podprobe
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	grpchealth "google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/kubernetes/pkg/probe"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+func TestGRPCProbe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("serving", grpchealth.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus("not-serving", grpchealth.HealthCheckResponse_NOT_SERVING)
+	grpchealth.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+	_, portStr, _ := net.SplitHostPort(listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	cases := []struct {
+		name    string
+		service *string
+		port    int
+		tls     *appsv1alpha1.GRPCProbeTLS
+		expect  probe.Result
+	}{
+		{
+			name:   "overall health of server",
+			port:   port,
+			expect: probe.Success,
+		},
+		{
+			name:    "service serving",
+			service: ptr.To("serving"),
+			port:    port,
+			expect:  probe.Success,
+		},
+		{
+			name:    "service not serving",
+			service: ptr.To("not-serving"),
+			port:    port,
+			expect:  probe.Failure,
+		},
+		{
+			name:    "service not found",
+			service: ptr.To("not-found"),
+			port:    port,
+			expect:  probe.Failure,
+		},
+		{
+			name:   "tls handshake failed with plaintext server",
+			port:   port,
+			tls:    &appsv1alpha1.GRPCProbeTLS{InsecureSkipVerify: true},
+			expect: probe.Failure,
+		},
+		{
+			name:   "connection refused",
+			port:   1,
+			expect: probe.Failure,
+		},
+	}
+
+	p := prober{}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			spec := &appsv1alpha1.ContainerProbeSpec{
+				Probe: corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						GRPC: &corev1.GRPCAction{Port: int32(cs.port), Service: cs.service},
+					},
+					TimeoutSeconds: 1,
+				},
+				GRPCTLS: cs.tls,
+			}
+			key := probeKey{podIP: "127.0.0.1", containerName: "main", probeName: "ppm#grpc"}
+			container := &runtimeapi.ContainerStatus{Id: "container-id", State: runtimeapi.ContainerState_CONTAINER_RUNNING}
+			start := time.Now()
+			result, msg, err := p.runProbe(spec, key, container, container.Id)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != cs.expect {
+				t.Fatalf("expect %s, but get %s: %s", cs.expect, result, msg)
+			}
+			if time.Since(start) > 3*time.Second {
+				t.Fatalf("probe did not respect the timeout")
+			}
+		})
+	}
+}
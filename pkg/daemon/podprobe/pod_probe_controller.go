@@ -258,6 +258,9 @@ func (c *Controller) sync() error {
 			key.probeName = probe.Name
 			validWorkers[key] = struct{}{}
 			if worker, ok := c.workers[key]; ok {
+				if !reflect.DeepEqual(probe.DerivedStates, worker.getDerivedStates()) {
+					worker.updateDerivedStates(probe.DerivedStates)
+				}
 				if !reflect.DeepEqual(probe.Probe, worker.getProbeSpec()) {
 					klog.InfoS("NodePodProbe pod container probe changed",
 						"podUID", key.podUID, "containerName", key.containerName, "from", commonutil.DumpJSON(worker.getProbeSpec()), "to", commonutil.DumpJSON(probe.Probe))
@@ -266,6 +269,7 @@ func (c *Controller) sync() error {
 				continue
 			}
 			w := newWorker(c, key, &probe.Probe)
+			w.updateDerivedStates(probe.DerivedStates)
 			c.workers[key] = w
 			klog.InfoS("NodePodProbe run pod container probe spec worker", "podUID", key.podUID, "containerName", key.containerName, "probeName", key.probeName, "probeSpec", commonutil.DumpJSON(probe.Probe))
 			go w.run()
@@ -342,13 +346,15 @@ func (c *Controller) syncUpdateNodePodProbeStatus() error {
 		if !validSets.Has(fmt.Sprintf("%s/%s", update.Key.podUID, update.Key.probeName)) {
 			continue
 		}
-		//record probe result in pod event
-		ref := &corev1.ObjectReference{Kind: "Pod", Namespace: update.Key.podNs, Name: update.Key.podName, UID: types.UID(update.Key.podUID),
-			APIVersion: corev1.SchemeGroupVersion.String()}
-		if update.State == appsv1alpha1.ProbeSucceeded {
-			c.eventRecorder.Event(ref, corev1.EventTypeNormal, EventKruiseProbeSucceeded, update.Msg)
-		} else {
-			c.eventRecorder.Event(ref, corev1.EventTypeNormal, EventKruiseProbeFailed, update.Msg)
+		//record probe result in pod event, the batched updates of the recent results are not recorded
+		if prev := getProbeState(&npp.Status, update.Key); prev == nil || prev.State != update.State || prev.Message != update.Msg {
+			ref := &corev1.ObjectReference{Kind: "Pod", Namespace: update.Key.podNs, Name: update.Key.podName, UID: types.UID(update.Key.podUID),
+				APIVersion: corev1.SchemeGroupVersion.String()}
+			if update.State == appsv1alpha1.ProbeSucceeded {
+				c.eventRecorder.Event(ref, corev1.EventTypeNormal, EventKruiseProbeSucceeded, update.Msg)
+			} else {
+				c.eventRecorder.Event(ref, corev1.EventTypeNormal, EventKruiseProbeFailed, update.Msg)
+			}
 		}
 		// update probe result in status
 		updateNodePodProbeStatus(update, newStatus)
@@ -413,6 +419,31 @@ func (c *Controller) fetchLatestPodContainer(podUID, name string) (*runtimeapi.C
 	return containerStatus, err
 }
 
+// getReportedProbeState returns the state of the probe in the status of NodePodProbe, or nil if not found
+func (c *Controller) getReportedProbeState(key probeKey) *appsv1alpha1.ContainerProbeState {
+	npp, err := c.nodePodProbeLister.Get(c.nodeName)
+	if err != nil {
+		return nil
+	}
+	return getProbeState(&npp.Status, key)
+}
+
+func getProbeState(status *appsv1alpha1.NodePodProbeStatus, key probeKey) *appsv1alpha1.ContainerProbeState {
+	for i := range status.PodProbeStatuses {
+		probeStatus := &status.PodProbeStatuses[i]
+		if probeStatus.UID != key.podUID {
+			continue
+		}
+		for j := range probeStatus.ProbeStates {
+			if probeStatus.ProbeStates[j].Name == key.probeName {
+				return &probeStatus.ProbeStates[j]
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
 func updateNodePodProbeStatus(update Update, newStatus *appsv1alpha1.NodePodProbeStatus) {
 	var probeStatus *appsv1alpha1.PodProbeStatus
 	for i := range newStatus.PodProbeStatuses {
@@ -431,6 +462,8 @@ func updateNodePodProbeStatus(update Update, newStatus *appsv1alpha1.NodePodProb
 			probeStatus.ProbeStates[i].State = update.State
 			probeStatus.ProbeStates[i].Message = update.Msg
 			probeStatus.ProbeStates[i].LastProbeTime = update.LastProbeTime
+			probeStatus.ProbeStates[i].Latency = probeLatency(update)
+			probeStatus.ProbeStates[i].RecentResults = update.RecentResults
 			if obj.State != update.State {
 				probeStatus.ProbeStates[i].LastTransitionTime = metav1.Now()
 			}
@@ -443,5 +476,15 @@ func updateNodePodProbeStatus(update Update, newStatus *appsv1alpha1.NodePodProb
 		LastProbeTime:      update.LastProbeTime,
 		LastTransitionTime: metav1.Now(),
 		Message:            update.Msg,
+		Latency:            probeLatency(update),
+		RecentResults:      update.RecentResults,
 	})
 }
+
+// probeLatency returns nil if the container has not been probed yet
+func probeLatency(update Update) *metav1.Duration {
+	if len(update.RecentResults) == 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: update.Latency}
+}
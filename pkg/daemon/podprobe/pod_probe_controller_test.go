@@ -368,6 +368,59 @@ func TestUpdateNodePodProbeStatus(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "test5, update pod probe status with latency and recent results",
+			getUpdate: func() Update {
+				return Update{
+					Key:     probeKey{"", "pod-1", "pod-1-uid", "2.2.2.2", "main", "ppm-1#healthy"},
+					State:   appsv1alpha1.ProbeSucceeded,
+					Latency: 20 * time.Millisecond,
+					RecentResults: []appsv1alpha1.ProbeResult{
+						{State: appsv1alpha1.ProbeFailed, Latency: metav1.Duration{Duration: time.Second}},
+						{State: appsv1alpha1.ProbeSucceeded, Latency: metav1.Duration{Duration: 20 * time.Millisecond}},
+					},
+				}
+			},
+			getNodePodProbe: func() *appsv1alpha1.NodePodProbe {
+				demo := demoNodePodProbe.DeepCopy()
+				demo.Status = appsv1alpha1.NodePodProbeStatus{
+					PodProbeStatuses: []appsv1alpha1.PodProbeStatus{
+						{
+							Name: "pod-1",
+							UID:  "pod-1-uid",
+							ProbeStates: []appsv1alpha1.ContainerProbeState{
+								{
+									Name:  "ppm-1#healthy",
+									State: appsv1alpha1.ProbeFailed,
+								},
+							},
+						},
+					},
+				}
+				return demo
+			},
+			expectNodePodProbeStatus: func() appsv1alpha1.NodePodProbeStatus {
+				return appsv1alpha1.NodePodProbeStatus{
+					PodProbeStatuses: []appsv1alpha1.PodProbeStatus{
+						{
+							Name: "pod-1",
+							UID:  "pod-1-uid",
+							ProbeStates: []appsv1alpha1.ContainerProbeState{
+								{
+									Name:    "ppm-1#healthy",
+									State:   appsv1alpha1.ProbeSucceeded,
+									Latency: &metav1.Duration{Duration: 20 * time.Millisecond},
+									RecentResults: []appsv1alpha1.ProbeResult{
+										{State: appsv1alpha1.ProbeFailed, Latency: metav1.Duration{Duration: time.Second}},
+										{State: appsv1alpha1.ProbeSucceeded, Latency: metav1.Duration{Duration: 20 * time.Millisecond}},
+									},
+								},
+							},
+						},
+					},
+				}
+			},
+		},
 	}
 
 	for _, cs := range cases {
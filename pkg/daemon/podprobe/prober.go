@@ -42,11 +42,12 @@ import (
 
 const maxProbeMessageLength = 1024
 
-// Prober helps to check the probe(exec, http, tcp) of a container.
+// Prober helps to check the probe(exec, http, tcp, grpc) of a container.
 type prober struct {
 	exec           execprobe.Prober
 	http           httpprobe.Prober
 	tcp            tcpprobe.Prober
+	grpc           grpcProber
 	runtimeService criapi.RuntimeService
 }
 
@@ -58,6 +59,7 @@ func newProber(runtimeService criapi.RuntimeService) *prober {
 		exec:           execprobe.New(),
 		http:           httpprobe.New(followNonLocalRedirects),
 		tcp:            tcpprobe.New(),
+		grpc:           grpcProber{},
 		runtimeService: runtimeService,
 	}
 }
@@ -80,7 +82,6 @@ func (pb *prober) runProbe(p *appsv1alpha1.ContainerProbeSpec, probeKey probeKey
 		timeSecond = 1
 	}
 	timeout := time.Duration(timeSecond) * time.Second
-	// TODO: support grpc prober
 	switch {
 	case p.Exec != nil:
 		return pb.exec.Probe(pb.newExecInContainer(containerID, p.Exec.Command, timeout))
@@ -106,6 +107,13 @@ func (pb *prober) runProbe(p *appsv1alpha1.ContainerProbeSpec, probeKey probeKey
 		}
 		klog.InfoS("TCP-Probe Host", "host", host, "port", port, "timeout", timeout)
 		return pb.tcp.Probe(host, port, timeout)
+	case p.GRPC != nil:
+		var service string
+		if p.GRPC.Service != nil {
+			service = *p.GRPC.Service
+		}
+		klog.V(4).InfoS("GRPC-Probe", "host", probeKey.podIP, "service", service, "port", p.GRPC.Port, "tls", p.GRPCTLS != nil, "timeout", timeout)
+		return pb.grpc.Probe(probeKey.podIP, service, int(p.GRPC.Port), p.GRPCTLS, timeout)
 	}
 
 	klog.InfoS("Failed to find probe builder for container", "containerName", containerRuntimeStatus.Metadata.Name)
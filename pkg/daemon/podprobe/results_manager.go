@@ -18,6 +18,7 @@ package podprobe
 
 import (
 	"sync"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
@@ -27,6 +28,9 @@ import (
 
 const maxSyncProbeTime = 600
 
+// recentResultsBatchPeriod is the max delay of reporting the recent results whose derived state does not change
+const recentResultsBatchPeriod = time.Minute
+
 // Update is an enum of the types of updates sent over the Updates channel.
 type Update struct {
 	ContainerID   string
@@ -34,11 +38,15 @@ type Update struct {
 	State         appsv1alpha1.ProbeState
 	Msg           string
 	LastProbeTime metav1.Time
+	// Latency of the last probe
+	Latency time.Duration
+	// RecentResults of the probes regardless of the thresholds
+	RecentResults []appsv1alpha1.ProbeResult
 }
 
 // resultManager implementation, store container probe result
 type resultManager struct {
-	// map of container ID -> probe Result
+	// map of container ID/probe name -> probe Result
 	cache *sync.Map
 	queue workqueue.RateLimitingInterface
 }
@@ -51,6 +59,11 @@ func newResultManager(queue workqueue.RateLimitingInterface) *resultManager {
 	}
 }
 
+// cacheKey returns the key of the result in cache, a container might be probed by several probes
+func cacheKey(id string, key probeKey) string {
+	return id + "/" + key.probeName
+}
+
 func (m *resultManager) listResults() []Update {
 	var results []Update
 	listFunc := func(key, value any) bool {
@@ -63,13 +76,45 @@ func (m *resultManager) listResults() []Update {
 
 func (m *resultManager) set(id string, key probeKey, result appsv1alpha1.ProbeState, msg string) {
 	currentTime := metav1.Now()
-	prev, exists := m.cache.Load(id)
+	prev, exists := m.cache.Load(cacheKey(id, key))
 	if !exists || prev.(Update).State != result || prev.(Update).Msg != msg || currentTime.Sub(prev.(Update).LastProbeTime.Time).Seconds() >= maxSyncProbeTime {
-		m.cache.Store(id, Update{id, key, result, msg, currentTime})
+		update := Update{ContainerID: id, Key: key, State: result, Msg: msg, LastProbeTime: currentTime}
+		if exists {
+			update.Latency = prev.(Update).Latency
+			update.RecentResults = prev.(Update).RecentResults
+		}
+		m.cache.Store(cacheKey(id, key), update)
+		m.queue.Add("updateStatus")
+	}
+}
+
+func (m *resultManager) get(id string, key probeKey) (Update, bool) {
+	update, exists := m.cache.Load(cacheKey(id, key))
+	if !exists {
+		return Update{}, false
+	}
+	return update.(Update), true
+}
+
+// setRecentResults records the latency and the recent results of the probe, the status is updated immediately only if
+// the derived state changed, otherwise the results are batched into the status update at most once a recentResultsBatchPeriod.
+func (m *resultManager) setRecentResults(id string, key probeKey, latency time.Duration, results []appsv1alpha1.ProbeResult, changed bool) {
+	prev, exists := m.cache.Load(cacheKey(id, key))
+	if !exists {
+		return
+	}
+	update := prev.(Update)
+	update.Latency = latency
+	update.RecentResults = results
+	m.cache.Store(cacheKey(id, key), update)
+	if changed {
 		m.queue.Add("updateStatus")
+	} else {
+		// the delaying queue keeps the earliest time, so the status is not delayed by the subsequent probes
+		m.queue.AddAfter("updateStatus", recentResultsBatchPeriod)
 	}
 }
 
-func (m *resultManager) remove(id string) {
-	m.cache.Delete(id)
+func (m *resultManager) remove(id string, key probeKey) {
+	m.cache.Delete(cacheKey(id, key))
 }
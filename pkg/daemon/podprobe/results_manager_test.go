@@ -23,9 +23,11 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
 	appsalphav1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	listersalpha1 "github.com/openkruise/kruise/pkg/client/listers/apps/v1alpha1"
 	"github.com/openkruise/kruise/pkg/util"
 )
 
@@ -297,7 +299,7 @@ func TestResultManagerSet(t *testing.T) {
 			r := newResultManager(updateQueue)
 			prev := cs.getPrev()
 			if prev != nil {
-				r.cache.Store(prev.ContainerID, Update{prev.ContainerID, prev.Key, prev.State, prev.Msg, prev.LastProbeTime})
+				r.cache.Store(cacheKey(prev.ContainerID, prev.Key), Update{ContainerID: prev.ContainerID, Key: prev.Key, State: prev.State, Msg: prev.Msg, LastProbeTime: prev.LastProbeTime})
 			}
 			cur := cs.getCur()
 			r.set(cur.ContainerID, cur.Key, cur.State, cur.Msg)
@@ -322,3 +324,118 @@ func TestResultManagerSet(t *testing.T) {
 		})
 	}
 }
+
+func TestResultManagerSetRecentResults(t *testing.T) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "update_node_pod_probe_status")
+	r := newResultManager(queue)
+	healthy := probeKey{podNs: "default", podName: "test-pod", podUID: "pod-uid", containerName: "main", probeName: "ppm#healthy"}
+	idle := probeKey{podNs: "default", podName: "test-pod", podUID: "pod-uid", containerName: "main", probeName: "ppm#idle"}
+
+	// the probes of the same container are recorded separately
+	r.set("container-id", healthy, appsalphav1.ProbeSucceeded, "")
+	r.set("container-id", idle, appsalphav1.ProbeFailed, "")
+	if len(r.listResults()) != 2 {
+		t.Fatalf("expect 2 results, but get %s", util.DumpJSON(r.listResults()))
+	}
+	queue.Get()
+	queue.Done("updateStatus")
+
+	results := []appsalphav1.ProbeResult{{State: appsalphav1.ProbeSucceeded}}
+	r.setRecentResults("container-id", healthy, time.Millisecond, results, false)
+	if queue.Len() != 0 {
+		t.Fatalf("expect no immediate status update if the derived state not changed")
+	}
+	results = append(results, appsalphav1.ProbeResult{State: appsalphav1.ProbeFailed})
+	r.setRecentResults("container-id", healthy, time.Second, results, true)
+	if queue.Len() != 1 {
+		t.Fatalf("expect status update if the derived state changed")
+	}
+
+	// the recent results are kept when the state changes
+	r.set("container-id", healthy, appsalphav1.ProbeFailed, "failed")
+	obj, _ := r.cache.Load(cacheKey("container-id", healthy))
+	update := obj.(Update)
+	if update.State != appsalphav1.ProbeFailed || update.Latency != time.Second || len(update.RecentResults) != 2 {
+		t.Fatalf("unexpected result %s", util.DumpJSON(update))
+	}
+
+	r.remove("container-id", healthy)
+	if len(r.listResults()) != 1 || r.listResults()[0].Key != idle {
+		t.Fatalf("expect only the idle probe, but get %s", util.DumpJSON(r.listResults()))
+	}
+}
+
+func TestWorkerRecordResult(t *testing.T) {
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "update_node_pod_probe_status")
+	key := probeKey{podNs: "default", podName: "test-pod", podUID: "pod-uid", containerName: "main", probeName: "ppm#healthy"}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	c := &Controller{result: newResultManager(queue), nodeName: "node1", nodePodProbeLister: listersalpha1.NewNodePodProbeLister(indexer)}
+	w := &worker{key: key, containerID: "container-id", probeController: c}
+	w.updateDerivedStates([]appsalphav1.ProbeDerivedState{{Name: "Degraded", FailureThreshold: 3, WindowSeconds: 60}})
+	c.result.set(w.containerID, key, appsalphav1.ProbeSucceeded, "")
+	report := func() {
+		indexer.Update(&appsalphav1.NodePodProbe{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Status: appsalphav1.NodePodProbeStatus{PodProbeStatuses: []appsalphav1.PodProbeStatus{{
+				UID:         key.podUID,
+				ProbeStates: []appsalphav1.ContainerProbeState{{Name: key.probeName, State: appsalphav1.ProbeSucceeded, RecentResults: w.recentResults}},
+			}}},
+		})
+	}
+	report()
+	drain := func() int {
+		n := queue.Len()
+		for queue.Len() > 0 {
+			item, _ := queue.Get()
+			queue.Done(item)
+		}
+		return n
+	}
+	drain()
+
+	for i := 0; i < appsalphav1.MaxRecentProbeResults+2; i++ {
+		w.recordResult(appsalphav1.ProbeSucceeded, time.Duration(i)*time.Millisecond)
+	}
+	if drain() != 0 {
+		t.Fatalf("expect no immediate status update when the result is stable")
+	}
+	if len(w.recentResults) != appsalphav1.MaxRecentProbeResults {
+		t.Fatalf("expect %d recent results, but get %d", appsalphav1.MaxRecentProbeResults, len(w.recentResults))
+	}
+
+	// the failures below the threshold of the derived state are batched
+	for i := 0; i < 2; i++ {
+		w.recordResult(appsalphav1.ProbeFailed, time.Second)
+		if drain() != 0 {
+			t.Fatalf("expect no immediate status update for the failure %d", i)
+		}
+	}
+	w.recordResult(appsalphav1.ProbeFailed, time.Second)
+	if drain() != 1 {
+		t.Fatalf("expect status update when the derived state changed")
+	}
+	report()
+	w.recordResult(appsalphav1.ProbeFailed, time.Second)
+	w.recordResult(appsalphav1.ProbeSucceeded, 2*time.Millisecond)
+	if drain() != 0 {
+		t.Fatalf("expect no immediate status update when the derived state is reported")
+	}
+
+	last := w.recentResults[len(w.recentResults)-1]
+	if last.State != appsalphav1.ProbeSucceeded || w.recentResults[0].State != appsalphav1.ProbeSucceeded {
+		t.Fatalf("unexpected recent results %s", util.DumpJSON(w.recentResults))
+	}
+	obj, _ := c.result.cache.Load(cacheKey(w.containerID, key))
+	if update := obj.(Update); update.Latency != 2*time.Millisecond || len(update.RecentResults) != appsalphav1.MaxRecentProbeResults {
+		t.Fatalf("unexpected result %s", util.DumpJSON(update))
+	}
+
+	// the failures of the probe without derived states are always batched
+	w.updateDerivedStates(nil)
+	for i := 0; i < appsalphav1.MaxRecentProbeResults; i++ {
+		w.recordResult(appsalphav1.ProbeFailed, time.Second)
+	}
+	if drain() != 0 {
+		t.Fatalf("expect no immediate status update without derived states")
+	}
+}
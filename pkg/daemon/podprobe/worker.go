@@ -22,12 +22,14 @@ import (
 	"reflect"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 	"k8s.io/klog/v2"
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/podprobemarker"
 )
 
 // worker handles the periodic probing of its assigned container. Each worker has a go-routine
@@ -55,6 +57,10 @@ type worker struct {
 	lastResult appsv1alpha1.ProbeState
 	// How many times in a row the probe has returned the same result.
 	resultRun int
+	// The recent results regardless of the thresholds, the latest is the last one.
+	recentResults []appsv1alpha1.ProbeResult
+	// The derived states of PodProbeMarker, the recent results are reported immediately when the derived state changes.
+	derivedStates []appsv1alpha1.ProbeDerivedState
 }
 
 // Creates and starts a new probe worker.
@@ -89,7 +95,7 @@ func (w *worker) run() {
 		// Clean up.
 		probeTicker.Stop()
 		if w.containerID != "" {
-			w.probeController.result.remove(w.containerID)
+			w.probeController.result.remove(w.containerID, w.key)
 		}
 		w.probeController.removeWorker(w.key)
 	}()
@@ -128,10 +134,11 @@ func (w *worker) doProbe() (keepGoing bool) {
 
 	if w.containerID != container.Id {
 		if w.containerID != "" {
-			w.probeController.result.remove(w.containerID)
+			w.probeController.result.remove(w.containerID, w.key)
 		}
 		klog.V(5).InfoS("Pod container Id changed", "namespace", w.key.podNs, "podName", w.key.podName, "from", w.containerID, "to", container.Id)
 		w.containerID = container.Id
+		w.recentResults = nil
 		w.probeController.result.set(w.containerID, w.key, w.initialValue, "")
 	}
 	if container.State != runtimeapi.ContainerState_CONTAINER_RUNNING {
@@ -153,12 +160,16 @@ func (w *worker) doProbe() (keepGoing bool) {
 
 	// the full container environment here, OR we must make a call to the CRI in order to get those environment
 	// values from the running container.
+	start := time.Now()
 	result, msg, err := w.probeController.prober.probe(w.spec, w.key, container, w.containerID)
 	if err != nil {
 		klog.ErrorS(err, "Pod do container probe spec failed",
 			"namespace", w.key.podNs, "podName", w.key.podName, "containerName", w.key.containerName, "probeName", w.key.probeName, "spec", util.DumpJSON(w.spec))
 		return true
 	}
+	latency := time.Since(start)
+	// record the result after the state is set, so that the derived state is evaluated with the latest state
+	defer w.recordResult(result, latency)
 	if w.lastResult == result {
 		w.resultRun++
 	} else {
@@ -183,10 +194,49 @@ func (w *worker) doProbe() (keepGoing bool) {
 	return true
 }
 
+// recordResult records the latency and result of the probe regardless of the thresholds, so that the flapping
+// of the probe can be seen in status. The recent results are reported immediately only if their derived state
+// is different from the one of the results in status, otherwise they are reported in batches.
+func (w *worker) recordResult(result appsv1alpha1.ProbeState, latency time.Duration) {
+	results := make([]appsv1alpha1.ProbeResult, 0, appsv1alpha1.MaxRecentProbeResults)
+	if len(w.recentResults) >= appsv1alpha1.MaxRecentProbeResults {
+		results = append(results, w.recentResults[len(w.recentResults)-appsv1alpha1.MaxRecentProbeResults+1:]...)
+	} else {
+		results = append(results, w.recentResults...)
+	}
+	results = append(results, appsv1alpha1.ProbeResult{
+		State:     result,
+		ProbeTime: metav1.Now(),
+		Latency:   metav1.Duration{Duration: latency},
+	})
+	w.recentResults = results
+
+	changed := false
+	if update, ok := w.probeController.result.get(w.containerID, w.key); ok && len(w.derivedStates) > 0 {
+		// both are derived with the current state, for the state changes are always reported
+		now := time.Now()
+		derived, _ := podprobemarker.GetDerivedState(w.derivedStates, &appsv1alpha1.ContainerProbeState{State: update.State, RecentResults: results}, now)
+		var reportedDerived appsv1alpha1.ProbeState
+		if reported := w.probeController.getReportedProbeState(w.key); reported != nil {
+			reportedDerived, _ = podprobemarker.GetDerivedState(w.derivedStates, &appsv1alpha1.ContainerProbeState{State: update.State, RecentResults: reported.RecentResults}, now)
+		}
+		changed = derived != reportedDerived
+	}
+	w.probeController.result.setRecentResults(w.containerID, w.key, latency, results, changed)
+}
+
 func (w *worker) getProbeSpec() *appsv1alpha1.ContainerProbeSpec {
 	return w.spec
 }
 
+func (w *worker) getDerivedStates() []appsv1alpha1.ProbeDerivedState {
+	return w.derivedStates
+}
+
+func (w *worker) updateDerivedStates(derivedStates []appsv1alpha1.ProbeDerivedState) {
+	w.derivedStates = derivedStates
+}
+
 func (w *worker) updateProbeSpec(spec *appsv1alpha1.ContainerProbeSpec) {
 	if !reflect.DeepEqual(w.spec.ProbeHandler, spec.ProbeHandler) {
 		if w.containerID != "" {
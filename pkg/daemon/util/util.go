@@ -77,6 +77,15 @@ func NormalizeImageRefToNameTag(ref string) (string, string, error) {
 	return reference.FamiliarName(namedRef), getAPITagFromNamedRef(namedRef), nil
 }
 
+// JoinImageNameTag returns the image reference of the name and the tag returned by NormalizeImageRefToNameTag,
+// which is joined by "@" if the tag is a digest.
+func JoinImageNameTag(name, tag string) string {
+	if strings.Contains(tag, ":") {
+		return name + "@" + tag
+	}
+	return name + ":" + tag
+}
+
 // getAPITagFromNamedRef returns a tag from the specified reference.
 // This function is necessary as long as the docker "server" api expects
 // digests to be sent as tags and makes a distinction between the name
@@ -116,6 +116,27 @@ func TestNormalizeImageRefToNameTag(t *testing.T) {
 	}
 }
 
+func TestJoinImageNameTag(t *testing.T) {
+	digest := "sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63"
+	cases := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{name: "short name", ref: "ubuntu", expected: "ubuntu:latest"},
+		{name: "custom registry with tag", ref: "myregistry:5000/my/image:v1", expected: "myregistry:5000/my/image:v1"},
+		{name: "name with digest", ref: fmt.Sprintf("myregistry:5000/my/image@%s", digest), expected: fmt.Sprintf("myregistry:5000/my/image@%s", digest)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, tag, err := NormalizeImageRefToNameTag(tc.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, JoinImageNameTag(name, tag))
+		})
+	}
+}
+
 func TestNormalizeImageRef(t *testing.T) {
 	digest := "sha256:f2b6de562150a257551639c432c6999337533816574519989a3f244195a63e63"
 	cases := []struct {
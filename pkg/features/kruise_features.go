@@ -161,6 +161,20 @@ const (
 	// node affinity, then the pods on the nodes that have undergone
 	// this reduction will not be counted in the maxUnavailable.
 	DaemonSetPruneIneligibleNodes featuregate.Feature = "DaemonSetPruneIneligibleNodes"
+
+	// NodeImageInventory enables kruise-daemon to report all images on the node in NodeImage status.
+	NodeImageInventory featuregate.Feature = "NodeImageInventory"
+
+	// PodProbeMarkerServerlessProber enables kruise-manager to run the httpGet and tcpSocket probes of PodProbeMarker
+	// for the pods on virtual-kubelet nodes, which takes precedence over EnablePodProbeMarkerOnServerless.
+	PodProbeMarkerServerlessProber featuregate.Feature = "PodProbeMarkerServerlessProber"
+
+	// ContainerRestartJobGate enables ContainerRestartJob to restart containers across the pods of a workload.
+	ContainerRestartJobGate featuregate.Feature = "ContainerRestartJobGate"
+
+	// ContainerLaunchDependencyGate enables containers in pod to be launched by the dependencies defined in
+	// apps.kruise.io/container-launch-dependencies annotation, which are checked by kruise-manager and kruise-daemon.
+	ContainerLaunchDependencyGate featuregate.Feature = "ContainerLaunchDependencyGate"
 )
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
@@ -204,6 +218,11 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	DefaultHostNetworkHostPortsInPodTemplates: {Default: false, PreRelease: featuregate.Alpha},
 
 	DaemonSetPruneIneligibleNodes: {Default: false, PreRelease: featuregate.Alpha},
+	NodeImageInventory:            {Default: false, PreRelease: featuregate.Alpha},
+
+	PodProbeMarkerServerlessProber: {Default: false, PreRelease: featuregate.Alpha},
+	ContainerRestartJobGate:        {Default: false, PreRelease: featuregate.Alpha},
+	ContainerLaunchDependencyGate:  {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func init() {
@@ -232,6 +251,7 @@ func SetDefaultFeatureGates() {
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", SidecarSetPatchPodMetadataDefaultsAllowed))
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", EnhancedLivenessProbeGate))
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", EnablePodProbeMarkerOnServerless))
+		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", ContainerLaunchDependencyGate))
 	}
 	if !utilfeature.DefaultFeatureGate.Enabled(KruiseDaemon) {
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", PreDownloadImageForInPlaceUpdate))
@@ -242,6 +262,9 @@ func SetDefaultFeatureGates() {
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", SidecarTerminator))
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", ImagePullJobGate))
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", EnhancedLivenessProbeGate))
+		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", NodeImageInventory))
+		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", ContainerRestartJobGate))
+		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=false", ContainerLaunchDependencyGate))
 	}
 	if utilfeature.DefaultFeatureGate.Enabled(PreDownloadImageForInPlaceUpdate) || utilfeature.DefaultFeatureGate.Enabled(PreDownloadImageForDaemonSetUpdate) {
 		_ = utilfeature.DefaultMutableFeatureGate.Set(fmt.Sprintf("%s=true", ImagePullJobGate))
@@ -87,6 +87,24 @@ func GetWSWatchCustomWorkloadWhiteList(client client.Reader) (WSCustomWorkloadWh
 	return whiteList, nil
 }
 
+func GetResourceDistributionWhiteList(client client.Reader) (*ResourceDistributionWhiteList, error) {
+	whiteList := &ResourceDistributionWhiteList{}
+	data, err := getKruiseConfiguration(client)
+	if err != nil {
+		return nil, err
+	} else if len(data) == 0 {
+		return whiteList, nil
+	}
+	value, ok := data[RDResourceWhiteList]
+	if !ok {
+		return whiteList, nil
+	}
+	if err = json.Unmarshal([]byte(value), whiteList); err != nil {
+		return nil, err
+	}
+	return whiteList, nil
+}
+
 func getKruiseConfiguration(c client.Reader) (map[string]string, error) {
 	cfg := &corev1.ConfigMap{}
 	err := c.Get(context.TODO(), client.ObjectKey{Namespace: util.GetKruiseNamespace(), Name: KruiseConfigurationName}, cfg)
@@ -250,3 +250,38 @@ func TestGetWSWatchCustomWorkloadWhiteList(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestGetResourceDistributionWhiteList(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	t.Run("Success: key exists", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: KruiseConfigurationName},
+			Data:       map[string]string{RDResourceWhiteList: `{"resources":[{"group":"rbac.authorization.k8s.io","version":"v1","kind":"Role"}]}`},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()
+
+		result, err := GetResourceDistributionWhiteList(fakeClient)
+		assert.NoError(t, err)
+		assert.True(t, result.IsValid(schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "Role"}))
+		assert.False(t, result.IsValid(schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}))
+	})
+
+	t.Run("Success: configmap not found", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		result, err := GetResourceDistributionWhiteList(fakeClient)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Resources)
+	})
+
+	t.Run("Error: invalid json", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: KruiseConfigurationName},
+			Data:       map[string]string{RDResourceWhiteList: `{"invalid`},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()
+		_, err := GetResourceDistributionWhiteList(fakeClient)
+		assert.Error(t, err)
+	})
+}
@@ -28,6 +28,7 @@ const (
 	SidecarSetPatchPodMetadataWhiteListKey = "SidecarSet_PatchPodMetadata_WhiteList"
 	PPSWatchCustomWorkloadWhiteList        = "PPS_Watch_Custom_Workload_WhiteList"
 	WSWatchCustomWorkloadWhiteList         = "WorkloadSpread_Watch_Custom_Workload_WhiteList"
+	RDResourceWhiteList                    = "ResourceDistribution_Resource_WhiteList"
 )
 
 type SidecarSetPatchMetadataWhiteList struct {
@@ -75,6 +76,9 @@ func (p *CustomWorkloadWhiteList) IsDefaultSupport(apiVersion, kind string) bool
 	if (gv.Group == v1alpha1.GroupVersion.Group || gv.Group == appsv1.GroupName) && kind == "StatefulSet" {
 		return true
 	}
+	if gv.Group == v1alpha1.GroupVersion.Group && kind == "CloneSet" {
+		return true
+	}
 	return false
 }
 
@@ -88,3 +92,18 @@ type CustomWorkload struct {
 	// ReplicasPath is the replicas field path of this type of workload, such as "spec.replicas"
 	ReplicasPath string `json:"replicasPath,omitempty"`
 }
+
+// ResourceDistributionWhiteList is the list of the namespaced kinds that can be distributed by ResourceDistribution,
+// besides the ones supported by default.
+type ResourceDistributionWhiteList struct {
+	Resources []schema.GroupVersionKind `json:"resources,omitempty"`
+}
+
+func (p *ResourceDistributionWhiteList) IsValid(gk schema.GroupKind) bool {
+	for _, resource := range p.Resources {
+		if resource.GroupKind() == gk {
+			return true
+		}
+	}
+	return false
+}
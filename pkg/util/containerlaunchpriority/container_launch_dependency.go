@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlaunchpriority
+
+import (
+	"fmt"
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+const (
+	// dependencyKeyPrefix is the prefix of barrier keys for the containers launched by dependencies
+	dependencyKeyPrefix = "d_"
+)
+
+func GetDependencyKey(containerName string) string {
+	return dependencyKeyPrefix + containerName
+}
+
+func GenerateDependencyEnv(containerName, podName string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: appspub.ContainerLaunchBarrierEnvName,
+		ValueFrom: &v1.EnvVarSource{
+			ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: podName + "-barrier"},
+				Key:                  GetDependencyKey(containerName),
+			},
+		},
+	}
+}
+
+// IsDependencyBarrier returns true if the container waits for launch dependencies in barrier.
+func IsDependencyBarrier(c *v1.Container) bool {
+	for _, e := range c.Env {
+		if e.Name == appspub.ContainerLaunchBarrierEnvName && e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil {
+			return e.ValueFrom.ConfigMapKeyRef.Key == GetDependencyKey(c.Name)
+		}
+	}
+	return false
+}
+
+// ValidateDependencies checks the dependencies refer to the containers in pod and have no cycle.
+func ValidateDependencies(dependencies appspub.ContainerLaunchDependencies, pod *v1.Pod) error {
+	containerNames := sets.New[string]()
+	for i := range pod.Spec.Containers {
+		containerNames.Insert(pod.Spec.Containers[i].Name)
+	}
+
+	graph := make(map[string][]string, len(dependencies))
+	for name, deps := range dependencies {
+		if !containerNames.Has(name) {
+			return fmt.Errorf("container %s not found in pod", name)
+		}
+		for i, dep := range deps {
+			var target string
+			var count int
+			if dep.Container != "" {
+				count++
+				target = dep.Container
+				switch dep.Condition {
+				case "", appspub.ContainerLaunchConditionStarted, appspub.ContainerLaunchConditionReady:
+				default:
+					return fmt.Errorf("dependency %d of container %s has unknown condition %s", i, name, dep.Condition)
+				}
+			}
+			if dep.File != nil {
+				count++
+				target = dep.File.Container
+				if !filepath.IsAbs(dep.File.Path) {
+					return fmt.Errorf("dependency %d of container %s has non-absolute file path %s", i, name, dep.File.Path)
+				}
+			}
+			if dep.TCPSocket != nil {
+				count++
+				if dep.TCPSocket.Port <= 0 || dep.TCPSocket.Port > 65535 {
+					return fmt.Errorf("dependency %d of container %s has invalid port %d", i, name, dep.TCPSocket.Port)
+				}
+			}
+			if count != 1 {
+				return fmt.Errorf("dependency %d of container %s should have exactly one of container, file and tcpSocket", i, name)
+			} else if dep.Container == "" && dep.Condition != "" {
+				return fmt.Errorf("dependency %d of container %s has condition %s without container", i, name, dep.Condition)
+			}
+			if target == "" {
+				continue
+			}
+			if !containerNames.Has(target) {
+				return fmt.Errorf("dependency %d of container %s refers to container %s not found in pod", i, name, target)
+			} else if target == name {
+				return fmt.Errorf("dependency %d of container %s refers to itself", i, name)
+			}
+			graph[name] = append(graph[name], target)
+		}
+	}
+
+	// detect cycles by depth-first search
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	states := make(map[string]int, len(graph))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch states[name] {
+		case visiting:
+			return fmt.Errorf("dependencies have a cycle at container %s", name)
+		case visited:
+			return nil
+		}
+		states[name] = visiting
+		for _, target := range graph[name] {
+			if err := visit(target); err != nil {
+				return err
+			}
+		}
+		states[name] = visited
+		return nil
+	}
+	for _, c := range pod.Spec.Containers {
+		if err := visit(c.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsDependenciesSatisfied returns true if the dependencies of the container have been satisfied.
+// The file and tcpSocket dependencies are satisfied only if the container has been reported as probed by kruise-daemon.
+func IsDependenciesSatisfied(pod *v1.Pod, name string, dependencies []appspub.ContainerLaunchDependency, probed sets.Set[string]) bool {
+	for i := range dependencies {
+		dep := &dependencies[i]
+		if dep.IsProbeRequired() {
+			if !probed.Has(name) {
+				return false
+			}
+			continue
+		}
+
+		var status *v1.ContainerStatus
+		for j := range pod.Status.ContainerStatuses {
+			if pod.Status.ContainerStatuses[j].Name == dep.Container {
+				status = &pod.Status.ContainerStatuses[j]
+				break
+			}
+		}
+		if status == nil {
+			return false
+		}
+		if dep.Condition == appspub.ContainerLaunchConditionStarted {
+			if status.Started == nil || !*status.Started {
+				return false
+			}
+		} else if !status.Ready {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerlaunchpriority
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	appspub "github.com/openkruise/kruise/apis/apps/pub"
+)
+
+func newDependencyTestPod(names ...string) *v1.Pod {
+	pod := &v1.Pod{}
+	for _, name := range names {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name})
+	}
+	return pod
+}
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies appspub.ContainerLaunchDependencies
+		expectErr    bool
+	}{
+		{
+			name: "valid graph",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app":     {{Container: "sidecar"}, {TCPSocket: &appspub.ContainerLaunchTCPSocketDependency{Port: 8080}}},
+				"sidecar": {{File: &appspub.ContainerLaunchFileDependency{Container: "init", Path: "/tmp/ready"}}},
+			},
+		},
+		{
+			name:         "container not found",
+			dependencies: appspub.ContainerLaunchDependencies{"foo": {{Container: "app"}}},
+			expectErr:    true,
+		},
+		{
+			name:         "target not found",
+			dependencies: appspub.ContainerLaunchDependencies{"app": {{Container: "foo"}}},
+			expectErr:    true,
+		},
+		{
+			name:         "self reference",
+			dependencies: appspub.ContainerLaunchDependencies{"app": {{Container: "app"}}},
+			expectErr:    true,
+		},
+		{
+			name: "multiple kinds in one dependency",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app": {{Container: "sidecar", TCPSocket: &appspub.ContainerLaunchTCPSocketDependency{Port: 80}}},
+			},
+			expectErr: true,
+		},
+		{
+			name:         "empty dependency",
+			dependencies: appspub.ContainerLaunchDependencies{"app": {{}}},
+			expectErr:    true,
+		},
+		{
+			name:         "unknown condition",
+			dependencies: appspub.ContainerLaunchDependencies{"app": {{Container: "sidecar", Condition: "Foo"}}},
+			expectErr:    true,
+		},
+		{
+			name: "relative file path",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app": {{File: &appspub.ContainerLaunchFileDependency{Container: "sidecar", Path: "tmp/ready"}}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid port",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app": {{TCPSocket: &appspub.ContainerLaunchTCPSocketDependency{Port: 70000}}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "condition of file",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app": {{File: &appspub.ContainerLaunchFileDependency{Container: "sidecar", Path: "/ready"}, Condition: appspub.ContainerLaunchConditionStarted}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "condition of tcpSocket",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app": {{TCPSocket: &appspub.ContainerLaunchTCPSocketDependency{Port: 80}, Condition: appspub.ContainerLaunchConditionReady}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "cycle",
+			dependencies: appspub.ContainerLaunchDependencies{
+				"app":     {{Container: "sidecar"}},
+				"sidecar": {{Container: "init"}},
+				"init":    {{File: &appspub.ContainerLaunchFileDependency{Container: "app", Path: "/ready"}}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDependencies(tt.dependencies, newDependencyTestPod("init", "sidecar", "app"))
+			assert.Equal(t, tt.expectErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestIsDependenciesSatisfied(t *testing.T) {
+	pod := newDependencyTestPod("sidecar", "app")
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{
+		{Name: "sidecar", Started: ptr.To(true), Ready: false},
+	}
+
+	tests := []struct {
+		name         string
+		dependencies []appspub.ContainerLaunchDependency
+		probed       sets.Set[string]
+		expected     bool
+	}{
+		{
+			name:     "no dependencies",
+			expected: true,
+		},
+		{
+			name:         "started condition satisfied",
+			dependencies: []appspub.ContainerLaunchDependency{{Container: "sidecar", Condition: appspub.ContainerLaunchConditionStarted}},
+			expected:     true,
+		},
+		{
+			name:         "ready condition not satisfied",
+			dependencies: []appspub.ContainerLaunchDependency{{Container: "sidecar"}},
+			expected:     false,
+		},
+		{
+			name:         "tcpSocket not probed",
+			dependencies: []appspub.ContainerLaunchDependency{{TCPSocket: &appspub.ContainerLaunchTCPSocketDependency{Port: 80}}},
+			probed:       sets.New[string](),
+			expected:     false,
+		},
+		{
+			name:         "tcpSocket probed",
+			dependencies: []appspub.ContainerLaunchDependency{{TCPSocket: &appspub.ContainerLaunchTCPSocketDependency{Port: 80}}},
+			probed:       sets.New[string]("app"),
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsDependenciesSatisfied(pod, "app", tt.dependencies, tt.probed))
+		})
+	}
+}
@@ -19,6 +19,7 @@ package containerlaunchpriority
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 
@@ -33,6 +34,10 @@ const (
 func GetContainerPriority(c *v1.Container) *int {
 	for _, e := range c.Env {
 		if e.Name == appspub.ContainerLaunchBarrierEnvName {
+			// the barrier of launch dependencies has no priority
+			if strings.HasPrefix(e.ValueFrom.ConfigMapKeyRef.Key, dependencyKeyPrefix) {
+				return nil
+			}
 			p, _ := strconv.Atoi(e.ValueFrom.ConfigMapKeyRef.Key[priorityStartIndex:])
 			return &p
 		}
@@ -144,3 +144,22 @@ func IsContainerReferenceToMeta(c *v1.Container, path, key string) bool {
 	}
 	return false
 }
+
+// GetEnvReferenceToMeta returns the path and key of Pod metadata that the env of container refers to,
+// the path is either `metadata.labels` or `metadata.annotations`.
+func GetEnvReferenceToMeta(c *v1.Container, name string) (path, key string, ok bool) {
+	for i := range c.Env {
+		if c.Env[i].Name != name {
+			continue
+		}
+		if c.Env[i].Value != "" || c.Env[i].ValueFrom == nil || c.Env[i].ValueFrom.FieldRef == nil {
+			return "", "", false
+		}
+		path, key, ok = fieldpath.SplitMaybeSubscriptedPath(c.Env[i].ValueFrom.FieldRef.FieldPath)
+		if !ok || (path != "metadata.annotations" && path != "metadata.labels") {
+			return "", "", false
+		}
+		return path, key, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrecreate
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+	utilcontainermeta "github.com/openkruise/kruise/pkg/util/containermeta"
+)
+
+// HasOverride returns whether the CRR overrides the env of containers, which has to be applied
+// to the Pod metadata by kruise-manager before kruise-daemon recreates the containers.
+func HasOverride(crr *appsv1alpha1.ContainerRecreateRequest) bool {
+	for i := range crr.Spec.Containers {
+		if crr.Spec.Containers[i].Override != nil && len(crr.Spec.Containers[i].Override.Env) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOverrideObserved returns whether the Pod metadata that the overridden env refer to has been changed
+// to the override values, by comparing the env hash from metadata of each overridden container.
+func IsOverrideObserved(crr *appsv1alpha1.ContainerRecreateRequest, pod *v1.Pod) bool {
+	hasher := utilcontainermeta.NewEnvFromMetadataHasher()
+	for i := range crr.Spec.Containers {
+		c := &crr.Spec.Containers[i]
+		if c.Override == nil || len(c.Override.Env) == 0 {
+			continue
+		}
+		podContainer := util.GetContainer(c.Name, pod)
+		if podContainer == nil {
+			return false
+		}
+
+		expected := &metav1.ObjectMeta{Annotations: make(map[string]string, len(pod.Annotations)), Labels: make(map[string]string, len(pod.Labels))}
+		for key, value := range pod.Annotations {
+			expected.Annotations[key] = value
+		}
+		for key, value := range pod.Labels {
+			expected.Labels[key] = value
+		}
+		for j := range c.Override.Env {
+			env := &c.Override.Env[j]
+			path, key, ok := utilcontainermeta.GetEnvReferenceToMeta(podContainer, env.Name)
+			if !ok {
+				return false
+			}
+			if path == "metadata.annotations" {
+				expected.Annotations[key] = env.Value
+			} else {
+				expected.Labels[key] = env.Value
+			}
+		}
+		if hasher.GetExpectHash(podContainer, pod) != hasher.GetExpectHash(podContainer, expected) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package containerrecreate
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+func TestIsOverrideObserved(t *testing.T) {
+	newPod := func(annotations, labels map[string]string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations, Labels: labels},
+			Spec: v1.PodSpec{Containers: []v1.Container{{
+				Name: "main",
+				Env: []v1.EnvVar{
+					{Name: "LOG_LEVEL", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations['log-level']"}}},
+					{Name: "PROFILE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['profile']"}}},
+				},
+			}}},
+		}
+	}
+	newCRR := func(override *appsv1alpha1.ContainerRecreateRequestContainerOverride) *appsv1alpha1.ContainerRecreateRequest {
+		return &appsv1alpha1.ContainerRecreateRequest{
+			Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+				Containers: []appsv1alpha1.ContainerRecreateRequestContainer{{Name: "main", Override: override}},
+			},
+		}
+	}
+	override := &appsv1alpha1.ContainerRecreateRequestContainerOverride{Env: []appsv1alpha1.ContainerRecreateRequestEnvOverride{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "PROFILE", Value: "on"},
+	}}
+
+	cases := []struct {
+		name           string
+		crr            *appsv1alpha1.ContainerRecreateRequest
+		pod            *v1.Pod
+		expectOverride bool
+		expectObserved bool
+	}{
+		{
+			name:           "no override",
+			crr:            newCRR(nil),
+			pod:            newPod(map[string]string{"log-level": "info"}, nil),
+			expectOverride: false,
+			expectObserved: true,
+		},
+		{
+			name:           "metadata not overridden yet",
+			crr:            newCRR(override),
+			pod:            newPod(map[string]string{"log-level": "info"}, nil),
+			expectOverride: true,
+			expectObserved: false,
+		},
+		{
+			name:           "metadata partially overridden",
+			crr:            newCRR(override),
+			pod:            newPod(map[string]string{"log-level": "debug"}, nil),
+			expectOverride: true,
+			expectObserved: false,
+		},
+		{
+			name:           "metadata overridden",
+			crr:            newCRR(override),
+			pod:            newPod(map[string]string{"log-level": "debug", "other": "x"}, map[string]string{"profile": "on"}),
+			expectOverride: true,
+			expectObserved: true,
+		},
+		{
+			name: "env not from metadata",
+			crr: newCRR(&appsv1alpha1.ContainerRecreateRequestContainerOverride{Env: []appsv1alpha1.ContainerRecreateRequestEnvOverride{
+				{Name: "NOT_EXIST", Value: "x"},
+			}}),
+			pod:            newPod(nil, nil),
+			expectOverride: true,
+			expectObserved: false,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			if got := HasOverride(cs.crr); got != cs.expectOverride {
+				t.Fatalf("expect HasOverride %v, got %v", cs.expectOverride, got)
+			}
+			if got := IsOverrideObserved(cs.crr, cs.pod); got != cs.expectObserved {
+				t.Fatalf("expect IsOverrideObserved %v, got %v", cs.expectObserved, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enhancedlivenessprobe
+
+import (
+	"encoding/json"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	// ProbeNamePrefix is the prefix of the names of the liveness probes in NodePodProbe.
+	// The names of the probes of PodProbeMarker are podProbeMarker.Name#probe.Name, and the upper case
+	// letters never appear in the name of PodProbeMarker, so they will not conflict with each other.
+	ProbeNamePrefix = "EnhancedLivenessProbe#"
+)
+
+// ContainerLivenessProbe is the native livenessProbe of the container backed up in the annotation
+// apps.kruise.io/container-probe-context
+type ContainerLivenessProbe struct {
+	Name          string       `json:"name"`
+	LivenessProbe corev1.Probe `json:"livenessProbe"`
+}
+
+// UsingEnhancedLivenessProbe returns true if the enhanced liveness probe of the pod is enabled
+func UsingEnhancedLivenessProbe(pod *corev1.Pod) bool {
+	return pod.Annotations[appsv1beta1.AnnotationUsingEnhancedLiveness] == "true"
+}
+
+// GetContainerLivenessProbes returns the liveness probes backed up in the pod annotation
+func GetContainerLivenessProbes(pod *corev1.Pod) ([]ContainerLivenessProbe, error) {
+	raw := pod.Annotations[appsv1beta1.AnnotationNativeContainerProbeContext]
+	if raw == "" {
+		return nil, nil
+	}
+	var probes []ContainerLivenessProbe
+	if err := json.Unmarshal([]byte(raw), &probes); err != nil {
+		return nil, err
+	}
+	return probes, nil
+}
+
+// GetProbeName returns the name of the liveness probe of the container in NodePodProbe
+func GetProbeName(containerName string) string {
+	return ProbeNamePrefix + containerName
+}
+
+// ParseProbeName returns the container name of the liveness probe in NodePodProbe,
+// and false if the probe is not an enhanced liveness probe
+func ParseProbeName(name string) (string, bool) {
+	return strings.CutPrefix(name, ProbeNamePrefix)
+}
+
+// IsEnhancedLivenessProbe returns true if the probe in NodePodProbe is an enhanced liveness probe
+func IsEnhancedLivenessProbe(name string) bool {
+	return strings.HasPrefix(name, ProbeNamePrefix)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageverify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// DigestAlgorithm is the only algorithm of digest supported
+const DigestAlgorithm = "sha256"
+
+// CalculateDigest returns the sha256 digest of data
+func CalculateDigest(data []byte) string {
+	hash := sha256.Sum256(data)
+	return DigestAlgorithm + ":" + hex.EncodeToString(hash[:])
+}
+
+// ParseDigest returns the hex part of a sha256 digest
+func ParseDigest(digest string) (string, error) {
+	hexPart, ok := strings.CutPrefix(digest, DigestAlgorithm+":")
+	if !ok || len(hexPart) != sha256.Size*2 {
+		return "", fmt.Errorf("unsupported digest %s", digest)
+	}
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return "", fmt.Errorf("invalid digest %s: %v", digest, err)
+	}
+	return hexPart, nil
+}
+
+// IsDigest returns true if ref is a digest instead of a tag
+func IsDigest(ref string) bool {
+	return strings.HasPrefix(ref, DigestAlgorithm+":")
+}
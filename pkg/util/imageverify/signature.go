@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageverify
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SignatureAnnotation is the annotation of the layer in the signature manifest carrying the base64 encoded signature
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// SignatureTagSuffix is the suffix of the tag of the signature manifest, which is the digest of the image with ":" replaced by "-"
+	SignatureTagSuffix = ".sig"
+)
+
+// simpleSigning is the payload signed by cosign
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// ParsePublicKeys parses the PEM encoded public keys, which can be ECDSA, RSA or Ed25519 keys
+func ParsePublicKeys(pems []string) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for i, data := range pems {
+		block, _ := pem.Decode([]byte(data))
+		if block == nil {
+			return nil, fmt.Errorf("public key %d is not PEM encoded", i)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %d: %v", i, err)
+		}
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("unsupported type %T of public key %d", key, i)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SignatureTag returns the tag of the cosign signature manifest of the digest
+func SignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + SignatureTagSuffix
+}
+
+// VerifyPayload verifies the signature of the payload and that the payload is signed for the digest
+func VerifyPayload(payload, signature []byte, digest string, keys []crypto.PublicKey) error {
+	if !verifyWithKeys(payload, signature, keys) {
+		return fmt.Errorf("signature does not match any public key")
+	}
+	p := &simpleSigning{}
+	if err := json.Unmarshal(payload, p); err != nil {
+		return fmt.Errorf("invalid signature payload: %v", err)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for %s", p.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+func verifyWithKeys(payload, signature []byte, keys []crypto.PublicKey) bool {
+	hash := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imageverify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerifyPayload(t *testing.T) {
+	signer, signerPEM := generateKey(t)
+	_, otherPEM := generateKey(t)
+	digest := CalculateDigest([]byte("manifest"))
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"app"},"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"},"optional":null}`)
+	hash := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, signer, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := ParsePublicKeys([]string{otherPEM, signerPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeys, _ := ParsePublicKeys([]string{otherPEM})
+	if err := VerifyPayload(payload, signature, digest, keys); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+	if err := VerifyPayload(payload, signature, digest, otherKeys); err == nil {
+		t.Fatalf("expected error for the signature of other key")
+	}
+	if err := VerifyPayload(payload, signature, CalculateDigest([]byte("other")), keys); err == nil {
+		t.Fatalf("expected error for the signature of another digest")
+	}
+	if _, err := ParsePublicKeys([]string{"invalid"}); err == nil {
+		t.Fatalf("expected error for invalid public key")
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	digest := CalculateDigest([]byte("data"))
+	if hexPart, err := ParseDigest(digest); err != nil || digest != DigestAlgorithm+":"+hexPart {
+		t.Fatalf("failed to parse digest %s: %v", digest, err)
+	}
+	for _, invalid := range []string{"sha256:invalid", "sha512:" + digest[len("sha256:"):], "latest"} {
+		if _, err := ParseDigest(invalid); err == nil {
+			t.Fatalf("expected error for %s", invalid)
+		}
+	}
+	if !IsDigest(digest) || IsDigest("latest") {
+		t.Fatalf("unexpected result of IsDigest")
+	}
+}
@@ -305,6 +305,11 @@ func (c *realControl) updateNextBatch(pod *v1.Pod, opts *UpdateOptions) (bool, e
 	return updated, err
 }
 
+// IsEphemeralContainersCleanupMarked returns true if the pod should be recreated to clean up its ephemeral containers.
+func IsEphemeralContainersCleanupMarked(pod *v1.Pod) bool {
+	return pod.Annotations[appspub.EphemeralContainersCleanupKey] == "true"
+}
+
 func (c *realControl) CanUpdateInPlace(oldRevision, newRevision *apps.ControllerRevision, opts *UpdateOptions) bool {
 	opts = SetOptionsDefaults(opts)
 	return opts.CalculateSpec(oldRevision, newRevision, opts) != nil
@@ -18,7 +18,10 @@ package util
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 // DumpJSON returns the JSON encoding
@@ -48,3 +51,80 @@ func IsJSONObjectEqual(o1, o2 interface{}) bool {
 
 	return reflect.DeepEqual(om1, om2)
 }
+
+// ParseJSONPointer parses a JSON pointer (RFC 6901), e.g. /metadata/labels/app, into its reference tokens.
+func ParseJSONPointer(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("json pointer %q must start with /", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return nil, fmt.Errorf("json pointer %q has invalid escape in %q", path, token)
+		}
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// GetJSONPointerValue returns the value referenced by tokens in the decoded JSON object obj.
+func GetJSONPointerValue(obj interface{}, tokens []string) (interface{}, bool) {
+	current := obj
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// SetJSONPointerValue sets value into the decoded JSON object obj at the position referenced by tokens.
+// The missing objects in the middle are created, but the referenced array elements must exist.
+func SetJSONPointerValue(obj map[string]interface{}, tokens []string, value interface{}) error {
+	if len(tokens) == 0 {
+		return fmt.Errorf("json pointer must not be empty")
+	}
+	var current interface{} = obj
+	for i, token := range tokens {
+		last := i == len(tokens)-1
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if last {
+				node[token] = value
+				return nil
+			}
+			next, ok := node[token]
+			if !ok || next == nil {
+				next = map[string]interface{}{}
+				node[token] = next
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return fmt.Errorf("array index %q out of range at /%s", token, strings.Join(tokens[:i], "/"))
+			}
+			if last {
+				node[index] = value
+				return nil
+			}
+			current = node[index]
+		default:
+			return fmt.Errorf("value at /%s is neither an object nor an array", strings.Join(tokens[:i], "/"))
+		}
+	}
+	return nil
+}
@@ -100,3 +100,72 @@ func TestIsJSONEqual(t *testing.T) {
 		t.Fatalf("expect t1 not json equal to t3")
 	}
 }
+
+func TestJSONPointer(t *testing.T) {
+	cases := []struct {
+		name        string
+		path        string
+		value       interface{}
+		expectParse bool
+		expectSet   bool
+		expectObj   string
+	}{
+		{
+			name:        "escaped label key",
+			path:        "/metadata/labels/kruise.io~1app",
+			value:       "foo",
+			expectParse: true,
+			expectSet:   true,
+			expectObj:   `{"metadata":{"labels":{"kruise.io/app":"foo"}},"spec":{"containers":[{"name":"main"}]}}`,
+		},
+		{
+			name:        "array element",
+			path:        "/spec/containers/0/resources",
+			value:       map[string]interface{}{"limits": map[string]interface{}{"cpu": "1"}},
+			expectParse: true,
+			expectSet:   true,
+			expectObj:   `{"spec":{"containers":[{"name":"main","resources":{"limits":{"cpu":"1"}}}]}}`,
+		},
+		{
+			name:        "array index out of range",
+			path:        "/spec/containers/1/resources",
+			expectParse: true,
+		},
+		{
+			name: "not start with slash",
+			path: "spec/nodeName",
+		},
+		{
+			name: "invalid escape",
+			path: "/metadata/labels/a~2b",
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			obj := map[string]interface{}{
+				"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "main"}}},
+			}
+			tokens, err := ParseJSONPointer(cs.path)
+			if (err == nil) != cs.expectParse {
+				t.Fatalf("expect parse %v, but got error %v", cs.expectParse, err)
+			}
+			if err != nil {
+				return
+			}
+			err = SetJSONPointerValue(obj, tokens, cs.value)
+			if (err == nil) != cs.expectSet {
+				t.Fatalf("expect set %v, but got error %v", cs.expectSet, err)
+			}
+			if err != nil {
+				return
+			}
+			if DumpJSON(obj) != cs.expectObj {
+				t.Fatalf("expect %s, but got %s", cs.expectObj, DumpJSON(obj))
+			}
+			if value, ok := GetJSONPointerValue(obj, tokens); !ok || !reflect.DeepEqual(value, cs.value) {
+				t.Fatalf("expect value %v, but got %v", cs.value, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podprobemarker
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
+	"github.com/openkruise/kruise/pkg/util/requeueduration"
+)
+
+// PatchPodProbeStatus writes the probe states of pod into the pod conditions, and patches the pod labels and annotations
+// according to the marker policies of PodProbeMarkers. If some markers are waiting for the probe states to be stable or
+// the derived states to expire, it returns the duration after which the pod should be patched again.
+func PatchPodProbeStatus(c client.Client, pod *corev1.Pod, status appsv1alpha1.PodProbeStatus) (time.Duration, error) {
+	// map[probe.name]->probeState
+	currentConditions := make(map[string]*corev1.PodCondition)
+	for i := range pod.Status.Conditions {
+		condition := &pod.Status.Conditions[i]
+		currentConditions[string(condition.Type)] = condition
+	}
+	type metadata struct {
+		Labels      map[string]interface{} `json:"labels,omitempty"`
+		Annotations map[string]interface{} `json:"annotations,omitempty"`
+	}
+	// patch labels or annotations in pod
+	probeMetadata := metadata{
+		Labels:      map[string]interface{}{},
+		Annotations: map[string]interface{}{},
+	}
+	// pod status condition record probe result
+	var probeConditions []corev1.PodCondition
+	var err error
+	requeueAfter := &requeueduration.Duration{}
+	validConditionTypes := sets.NewString()
+	for i := range status.ProbeStates {
+		probeState := status.ProbeStates[i]
+		// the enhanced liveness probes are handled by the enhancedlivenessprobe controller
+		if probeState.State == "" || enhancedlivenessprobe.IsEnhancedLivenessProbe(probeState.Name) {
+			continue
+		}
+		// fetch podProbeMarker
+		ppmName, probeName := strings.Split(probeState.Name, "#")[0], strings.Split(probeState.Name, "#")[1]
+		ppm := &appsv1alpha1.PodProbeMarker{}
+		err = c.Get(context.TODO(), client.ObjectKey{Namespace: pod.Namespace, Name: ppmName}, ppm)
+		if err != nil {
+			// when NodePodProbe is deleted, should delete probes from NodePodProbe.spec
+			if errors.IsNotFound(err) {
+				continue
+			}
+			klog.ErrorS(err, "Failed to get PodProbeMarker", "podProbeMarkerName", ppmName, "pod", klog.KObj(pod))
+			return 0, err
+		} else if !ppm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		var policy []appsv1alpha1.ProbeMarkerPolicy
+		var conditionType string
+		var derivedStates []appsv1alpha1.ProbeDerivedState
+		var scoreMarker *appsv1alpha1.ProbeScoreMarker
+		for _, probe := range ppm.Spec.Probes {
+			if probe.Name == probeName {
+				policy = probe.MarkerPolicy
+				conditionType = probe.PodConditionType
+				derivedStates = probe.DerivedStates
+				scoreMarker = probe.ScoreMarker
+				break
+			}
+		}
+		if conditionType != "" && validConditionTypes.Has(conditionType) {
+			klog.InfoS("PodProbeMarker pod condition was conflict", "podProbeMarkerName", ppmName, "pod", klog.KObj(pod), "conditionType", conditionType)
+			// patch pod condition
+		} else if conditionType != "" {
+			validConditionTypes.Insert(conditionType)
+			var conStatus corev1.ConditionStatus
+			if probeState.State == appsv1alpha1.ProbeSucceeded {
+				conStatus = corev1.ConditionTrue
+			} else {
+				conStatus = corev1.ConditionFalse
+			}
+			probeConditions = append(probeConditions, corev1.PodCondition{
+				Type:               corev1.PodConditionType(conditionType),
+				Status:             conStatus,
+				LastProbeTime:      probeState.LastProbeTime,
+				LastTransitionTime: probeState.LastTransitionTime,
+				Message:            probeState.Message,
+			})
+		}
+		if scoreMarker != nil {
+			applyScoreMarker(scoreMarker, &probeState, probeMetadata.Labels, probeMetadata.Annotations)
+		}
+		if len(policy) == 0 {
+			continue
+		}
+		// the derived state takes precedence over Succeeded, and the derived state is debounced by its window
+		now := time.Now()
+		state, since := probeState.State, probeState.LastTransitionTime.Time
+		if derivedState, expireAfter := GetDerivedState(derivedStates, &probeState, now); derivedState != "" {
+			state, since = derivedState, time.Time{}
+			requeueAfter.Update(expireAfter)
+		}
+		if stableAfter := applyMarkerPolicy(policy, state, since, now, probeMetadata.Labels, probeMetadata.Annotations); stableAfter > 0 {
+			klog.V(4).InfoS("PodProbeMarker waited for the probe state to be stable", "pod", klog.KObj(pod), "probeName", probeState.Name, "state", state, "after", stableAfter)
+			requeueAfter.Update(stableAfter)
+		}
+	}
+	// probe condition no changed, continue
+	if len(probeConditions) == 0 && len(probeMetadata.Labels) == 0 && len(probeMetadata.Annotations) == 0 {
+		return requeueAfter.Get(), nil
+	}
+	//update pod metadata and status condition
+	podClone := pod.DeepCopy()
+	if err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err = c.Get(context.TODO(), types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, podClone); err != nil {
+			klog.ErrorS(err, "Failed to get updated pod from client", "pod", klog.KObj(pod))
+			return err
+		}
+		oldStatus := podClone.Status.DeepCopy()
+		for i := range probeConditions {
+			condition := probeConditions[i]
+			util.SetPodConditionIfMsgChanged(podClone, condition)
+		}
+		oldMetadata := podClone.ObjectMeta.DeepCopy()
+		if podClone.Annotations == nil {
+			podClone.Annotations = map[string]string{}
+		}
+		for k, v := range probeMetadata.Labels {
+			// delete the label
+			if v == nil {
+				delete(podClone.Labels, k)
+				// patch the label
+			} else {
+				podClone.Labels[k] = v.(string)
+			}
+		}
+		for k, v := range probeMetadata.Annotations {
+			// delete the annotation
+			if v == nil {
+				delete(podClone.Annotations, k)
+				// patch the annotation
+			} else {
+				podClone.Annotations[k] = v.(string)
+			}
+		}
+		if reflect.DeepEqual(oldStatus.Conditions, podClone.Status.Conditions) && reflect.DeepEqual(oldMetadata.Labels, podClone.Labels) &&
+			reflect.DeepEqual(oldMetadata.Annotations, podClone.Annotations) {
+			return nil
+		}
+		// todo: resolve https://github.com/openkruise/kruise/issues/1597
+		return c.Status().Update(context.TODO(), podClone)
+	}); err != nil {
+		klog.ErrorS(err, "PodProbeMarker patched pod status failed", "pod", klog.KObj(podClone))
+		return 0, err
+	}
+	klog.V(3).InfoS("PodProbeMarker updated pod metadata and conditions success", "pod", klog.KObj(podClone), "metaData",
+		util.DumpJSON(probeMetadata), "conditions", util.DumpJSON(probeConditions))
+	return requeueAfter.Get(), nil
+}
+
+// GetDerivedState returns the first derived state matched by the recent results of the Succeeded probe,
+// and the duration after which the derived state expires if no more failures happen.
+func GetDerivedState(derivedStates []appsv1alpha1.ProbeDerivedState, probeState *appsv1alpha1.ContainerProbeState, now time.Time) (appsv1alpha1.ProbeState, time.Duration) {
+	if probeState.State != appsv1alpha1.ProbeSucceeded {
+		return "", 0
+	}
+	for _, derived := range derivedStates {
+		if derived.FailureThreshold <= 0 {
+			continue
+		}
+		window := time.Duration(derived.WindowSeconds) * time.Second
+		var failures []time.Time
+		for _, result := range probeState.RecentResults {
+			if result.State == appsv1alpha1.ProbeFailed && result.ProbeTime.Time.After(now.Add(-window)) {
+				failures = append(failures, result.ProbeTime.Time)
+			}
+		}
+		if len(failures) < int(derived.FailureThreshold) {
+			continue
+		}
+		// the state expires when the oldest of the latest FailureThreshold failures leaves the window
+		sort.Slice(failures, func(i, j int) bool { return failures[i].After(failures[j]) })
+		return derived.Name, failures[derived.FailureThreshold-1].Add(window).Sub(now)
+	}
+	return "", 0
+}
+
+// applyMarkerPolicy patches the markers of the policy matched by the state, and removes the markers of the other policies.
+// If the state has not been kept for the MinStableSeconds of the matched policy, nothing is changed and it returns
+// the duration after which the state becomes stable.
+func applyMarkerPolicy(policy []appsv1alpha1.ProbeMarkerPolicy, state appsv1alpha1.ProbeState, since, now time.Time,
+	labels, annotations map[string]interface{}) time.Duration {
+	// matchedPolicy is when policy.state is equal to the state, otherwise oppositePolicies
+	// 1. If policy[0].state = Succeeded, policy[1].state = Failed. state = Succeeded.
+	// So policy[0] is matchedPolicy, policy[1] is oppositePolicy
+	// 2. If policy[0].state = Succeeded, and policy[1] does not exist. state = Succeeded.
+	// So policy[0] is matchedPolicy, oppositePolicy is nil
+	// 3. If policy[0].state = Succeeded, and policy[1] does not exist. state = Failed.
+	// So policy[0] is oppositePolicy, matchedPolicy is nil
+	var matchedPolicy *appsv1alpha1.ProbeMarkerPolicy
+	var oppositePolicies []*appsv1alpha1.ProbeMarkerPolicy
+	for j := range policy {
+		if policy[j].State == state {
+			matchedPolicy = &policy[j]
+		} else {
+			oppositePolicies = append(oppositePolicies, &policy[j])
+		}
+	}
+	if matchedPolicy != nil && matchedPolicy.MinStableSeconds > 0 && !since.IsZero() {
+		if stableAt := since.Add(time.Duration(matchedPolicy.MinStableSeconds) * time.Second); now.Before(stableAt) {
+			return stableAt.Sub(now)
+		}
+	}
+	for _, oppositePolicy := range oppositePolicies {
+		for k := range oppositePolicy.Labels {
+			labels[k] = nil
+		}
+		for k := range oppositePolicy.Annotations {
+			annotations[k] = nil
+		}
+	}
+	if matchedPolicy != nil {
+		for k, v := range matchedPolicy.Labels {
+			labels[k] = v
+		}
+		for k, v := range matchedPolicy.Annotations {
+			annotations[k] = v
+		}
+	}
+	return 0
+}
+
+// applyScoreMarker writes the score returned by the Succeeded probe into the labels and annotations of marker,
+// the default score is written or the markers are removed if the probe is not Succeeded or the score is invalid.
+func applyScoreMarker(marker *appsv1alpha1.ProbeScoreMarker, probeState *appsv1alpha1.ContainerProbeState, labels, annotations map[string]interface{}) {
+	var score interface{}
+	if marker.DefaultScore != nil {
+		score = strconv.Itoa(int(*marker.DefaultScore))
+	}
+	if probeState.State == appsv1alpha1.ProbeSucceeded {
+		if value, err := strconv.ParseInt(strings.TrimSpace(probeState.Message), 10, 32); err == nil {
+			score = strconv.FormatInt(value, 10)
+		} else {
+			klog.V(4).InfoS("PodProbeMarker got invalid probe score", "probeName", probeState.Name, "message", probeState.Message)
+		}
+	}
+	for _, k := range marker.Labels {
+		// negative scores are not valid label values
+		if score != nil && len(validation.IsValidLabelValue(score.(string))) != 0 {
+			labels[k] = nil
+			continue
+		}
+		labels[k] = score
+	}
+	for _, k := range marker.Annotations {
+		annotations[k] = score
+	}
+}
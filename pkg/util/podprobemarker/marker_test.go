@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podprobemarker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+func TestGetDerivedState(t *testing.T) {
+	now := time.Now()
+	results := func(states ...appsv1alpha1.ProbeState) []appsv1alpha1.ProbeResult {
+		// the results are probed every 10 seconds, and the latest is now
+		var objs []appsv1alpha1.ProbeResult
+		for i, state := range states {
+			objs = append(objs, appsv1alpha1.ProbeResult{
+				State:     state,
+				ProbeTime: metav1.NewTime(now.Add(-time.Duration(len(states)-1-i) * 10 * time.Second)),
+			})
+		}
+		return objs
+	}
+	derivedStates := []appsv1alpha1.ProbeDerivedState{
+		{Name: "Critical", FailureThreshold: 3, WindowSeconds: 30},
+		{Name: "Degraded", FailureThreshold: 2, WindowSeconds: 60},
+	}
+	succeeded, failed := appsv1alpha1.ProbeSucceeded, appsv1alpha1.ProbeFailed
+
+	cases := []struct {
+		name        string
+		state       appsv1alpha1.ProbeState
+		results     []appsv1alpha1.ProbeResult
+		expect      appsv1alpha1.ProbeState
+		expectAfter time.Duration
+	}{
+		{
+			name:    "no failures",
+			state:   succeeded,
+			results: results(succeeded, succeeded, succeeded),
+		},
+		{
+			name:    "failures below threshold",
+			state:   succeeded,
+			results: results(succeeded, failed, succeeded, succeeded),
+		},
+		{
+			name:        "degraded",
+			state:       succeeded,
+			results:     results(failed, succeeded, failed, succeeded, succeeded),
+			expect:      "Degraded",
+			expectAfter: 20 * time.Second,
+		},
+		{
+			name:        "critical takes precedence",
+			state:       succeeded,
+			results:     results(succeeded, failed, failed, failed),
+			expect:      "Critical",
+			expectAfter: 10 * time.Second,
+		},
+		{
+			name:    "failures out of window",
+			state:   succeeded,
+			results: results(failed, failed, failed, succeeded, succeeded, succeeded, succeeded, succeeded, succeeded),
+		},
+		{
+			name:    "failed probe is not derived",
+			state:   failed,
+			results: results(failed, failed, failed),
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			probeState := &appsv1alpha1.ContainerProbeState{Name: "ppm#healthy", State: cs.state, RecentResults: cs.results}
+			state, after := GetDerivedState(derivedStates, probeState, now)
+			assert.Equal(t, cs.expect, state)
+			assert.Equal(t, cs.expectAfter, after)
+		})
+	}
+}
+
+func TestApplyMarkerPolicy(t *testing.T) {
+	now := time.Now()
+	policy := []appsv1alpha1.ProbeMarkerPolicy{
+		{
+			State:  appsv1alpha1.ProbeSucceeded,
+			Labels: map[string]string{"ready": "true"},
+			// hysteresis, recover slowly
+			MinStableSeconds: 60,
+		},
+		{
+			State:       appsv1alpha1.ProbeFailed,
+			Labels:      map[string]string{"ready": "false"},
+			Annotations: map[string]string{"controller.kubernetes.io/pod-deletion-cost": "-10"},
+		},
+		{
+			State:       "Degraded",
+			Annotations: map[string]string{"controller.kubernetes.io/pod-deletion-cost": "-5", "degraded": "true"},
+		},
+	}
+
+	cases := []struct {
+		name              string
+		state             appsv1alpha1.ProbeState
+		since             time.Time
+		expectLabels      map[string]interface{}
+		expectAnnotations map[string]interface{}
+		expectAfter       time.Duration
+	}{
+		{
+			name:              "failed",
+			state:             appsv1alpha1.ProbeFailed,
+			since:             now,
+			expectLabels:      map[string]interface{}{"ready": "false"},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": "-10", "degraded": nil},
+		},
+		{
+			name:              "succeeded but not stable",
+			state:             appsv1alpha1.ProbeSucceeded,
+			since:             now.Add(-20 * time.Second),
+			expectLabels:      map[string]interface{}{},
+			expectAnnotations: map[string]interface{}{},
+			expectAfter:       40 * time.Second,
+		},
+		{
+			name:              "succeeded and stable",
+			state:             appsv1alpha1.ProbeSucceeded,
+			since:             now.Add(-time.Minute),
+			expectLabels:      map[string]interface{}{"ready": "true"},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": nil, "degraded": nil},
+		},
+		{
+			name:              "degraded",
+			state:             "Degraded",
+			expectLabels:      map[string]interface{}{"ready": nil},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": "-5", "degraded": "true"},
+		},
+		{
+			name:              "unknown",
+			state:             appsv1alpha1.ProbeUnknown,
+			since:             now,
+			expectLabels:      map[string]interface{}{"ready": nil},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": nil, "degraded": nil},
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			labels, annotations := map[string]interface{}{}, map[string]interface{}{}
+			after := applyMarkerPolicy(policy, cs.state, cs.since, now, labels, annotations)
+			assert.Equal(t, cs.expectAfter, after)
+			assert.Equal(t, cs.expectLabels, labels)
+			assert.Equal(t, cs.expectAnnotations, annotations)
+		})
+	}
+}
+
+func TestApplyScoreMarker(t *testing.T) {
+	cases := []struct {
+		name              string
+		marker            *appsv1alpha1.ProbeScoreMarker
+		probeState        *appsv1alpha1.ContainerProbeState
+		expectLabels      map[string]interface{}
+		expectAnnotations map[string]interface{}
+	}{
+		{
+			name:              "valid score",
+			marker:            &appsv1alpha1.ProbeScoreMarker{Labels: []string{"weight"}, Annotations: []string{"controller.kubernetes.io/pod-deletion-cost"}},
+			probeState:        &appsv1alpha1.ContainerProbeState{State: appsv1alpha1.ProbeSucceeded, Message: "100\n"},
+			expectLabels:      map[string]interface{}{"weight": "100"},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": "100"},
+		},
+		{
+			name:              "negative score is not written into labels",
+			marker:            &appsv1alpha1.ProbeScoreMarker{Labels: []string{"weight"}, Annotations: []string{"controller.kubernetes.io/pod-deletion-cost"}},
+			probeState:        &appsv1alpha1.ContainerProbeState{State: appsv1alpha1.ProbeSucceeded, Message: "-100"},
+			expectLabels:      map[string]interface{}{"weight": nil},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": "-100"},
+		},
+		{
+			name:              "invalid score",
+			marker:            &appsv1alpha1.ProbeScoreMarker{Annotations: []string{"controller.kubernetes.io/pod-deletion-cost"}},
+			probeState:        &appsv1alpha1.ContainerProbeState{State: appsv1alpha1.ProbeSucceeded, Message: "ok"},
+			expectLabels:      map[string]interface{}{},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": nil},
+		},
+		{
+			name:              "failed probe with default score",
+			marker:            &appsv1alpha1.ProbeScoreMarker{Annotations: []string{"controller.kubernetes.io/pod-deletion-cost"}, DefaultScore: ptr.To(int32(-1000))},
+			probeState:        &appsv1alpha1.ContainerProbeState{State: appsv1alpha1.ProbeFailed, Message: "connection refused"},
+			expectLabels:      map[string]interface{}{},
+			expectAnnotations: map[string]interface{}{"controller.kubernetes.io/pod-deletion-cost": "-1000"},
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			labels, annotations := map[string]interface{}{}, map[string]interface{}{}
+			applyScoreMarker(cs.marker, cs.probeState, labels, annotations)
+			assert.Equal(t, cs.expectLabels, labels)
+			assert.Equal(t, cs.expectAnnotations, annotations)
+		})
+	}
+}
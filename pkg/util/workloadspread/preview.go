@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadspread
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+const (
+	// PreviewPath is the path of the WorkloadSpread preview endpoint served by kruise-manager.
+	PreviewPath = "/debug/workloadspread/preview"
+
+	// maxPreviewCount limits the number of creations or deletions that can be previewed in one request.
+	maxPreviewCount = 1000
+)
+
+// PreviewResult shows where the next Pods would be created or deleted for a WorkloadSpread.
+type PreviewResult struct {
+	// Version is the workload version whose subset statuses are used for creations.
+	Version string `json:"version"`
+	// Creations are the decisions for the next creations in order.
+	Creations []PreviewDecision `json:"creations"`
+	// Deletions are the decisions for the next deletions in order.
+	Deletions []PreviewDecision `json:"deletions"`
+}
+
+// PreviewDecision is the subset chosen for a Pod and the reason why it was chosen.
+type PreviewDecision struct {
+	// Subset is empty if no subset would be chosen.
+	Subset string `json:"subset,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Preview simulates the next creations and deletions of the target workload based on the current
+// status of the WorkloadSpread. It neither updates the WorkloadSpread nor mutates any Pod.
+func (h *Handler) Preview(ws *appsv1beta1.WorkloadSpread, creations, deletions int) (*PreviewResult, error) {
+	result := &PreviewResult{Version: VersionIgnored}
+	if ws.Spec.TargetReference == nil {
+		return nil, fmt.Errorf("WorkloadSpread %s/%s has no target reference", ws.Namespace, ws.Name)
+	}
+
+	if ws.Spec.TargetReference.Kind == controllerKindSts.Kind {
+		for i := 0; i < creations; i++ {
+			result.Creations = append(result.Creations, PreviewDecision{Reason: "StatefulSet Pods are assigned to subsets by ordinal"})
+		}
+		for i := 0; i < deletions; i++ {
+			result.Deletions = append(result.Deletions, PreviewDecision{Reason: "StatefulSet Pods are deleted by ordinal"})
+		}
+		return result, nil
+	}
+
+	gvk := schema.FromAPIVersionAndKind(ws.Spec.TargetReference.APIVersion, ws.Spec.TargetReference.Kind)
+	key := types.NamespacedName{Namespace: ws.Namespace, Name: ws.Spec.TargetReference.Name}
+	object := GenerateEmptyWorkloadObject(gvk, key)
+	if err := h.Get(context.TODO(), key, object); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+	} else {
+		version, err := GetWorkloadVersion(h.Client, object)
+		if err != nil {
+			return nil, err
+		}
+		result.Version = version
+	}
+
+	subsetStatuses := make([]appsv1beta1.WorkloadSpreadSubsetStatus, 0, len(ws.Spec.Subsets))
+	for _, subsetStatus := range ws.Status.VersionedSubsetStatuses[result.Version] {
+		subsetStatuses = append(subsetStatuses, *subsetStatus.DeepCopy())
+	}
+	if len(subsetStatuses) == 0 {
+		var err error
+		if subsetStatuses, err = h.initializedSubsetStatuses(ws); err != nil {
+			return nil, err
+		}
+	}
+	result.Creations = previewCreations(subsetStatuses, creations)
+
+	replicas, err := h.getWorkloadReplicas(ws)
+	if err != nil {
+		return nil, err
+	}
+	result.Deletions = previewDeletions(ws, replicas, deletions)
+	return result, nil
+}
+
+// previewCreations chooses subsets with findSuitableSubset just like the webhook does, and consumes
+// the missing replicas of the chosen subset for each simulated creation.
+func previewCreations(subsetStatuses []appsv1beta1.WorkloadSpreadSubsetStatus, creations int) []PreviewDecision {
+	decisions := make([]PreviewDecision, 0, creations)
+	for i := 0; i < creations; i++ {
+		decision := PreviewDecision{}
+		j, skipped := findSuitableSubset(subsetStatuses, true)
+		if j < 0 {
+			decision.Reason = "no subset is suitable, the Pod will not be injected by WorkloadSpread"
+		} else if subset := &subsetStatuses[j]; subset.MissingReplicas == -1 {
+			decision.Subset = subset.Name
+			decision.Reason = fmt.Sprintf("subset %s has no maxReplicas", subset.Name)
+		} else {
+			decision.Subset = subset.Name
+			decision.Reason = fmt.Sprintf("subset %s still misses %d replicas", subset.Name, subset.MissingReplicas)
+			subset.MissingReplicas--
+		}
+		if len(skipped) > 0 {
+			decision.Reason = fmt.Sprintf("%s (skipped: %v)", decision.Reason, skipped)
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+// previewDeletions follows the deletion-cost set by the WorkloadSpread controller: the Pods exceeding maxReplicas
+// are deleted preferentially from back subset to front subset, then the other Pods from back subset to front subset.
+func previewDeletions(ws *appsv1beta1.WorkloadSpread, workloadReplicas int32, deletions int) []PreviewDecision {
+	decisions := make([]PreviewDecision, 0, deletions)
+	switch ws.Spec.TargetReference.Kind {
+	case controllerKindRS.Kind, controllerKindDep.Kind, controllerKruiseKindCS.Kind:
+	default:
+		for i := 0; i < deletions; i++ {
+			decisions = append(decisions, PreviewDecision{Reason: fmt.Sprintf("deletion order of %s is decided by the workload itself", ws.Spec.TargetReference.Kind)})
+		}
+		return decisions
+	}
+
+	replicas := make(map[string]int32, len(ws.Status.SubsetStatuses))
+	for _, subsetStatus := range ws.Status.SubsetStatuses {
+		replicas[subsetStatus.Name] = subsetStatus.Replicas
+	}
+	maxReplicas := make([]int32, len(ws.Spec.Subsets))
+	for i, subset := range ws.Spec.Subsets {
+		maxReplicas[i] = math.MaxInt32
+		if subset.MaxReplicas != nil {
+			value, err := intstrutil.GetScaledValueFromIntOrPercent(subset.MaxReplicas, int(workloadReplicas), true)
+			if err != nil {
+				klog.ErrorS(err, "Failed to get maxReplicas value from subset of WorkloadSpread", "subsetName", subset.Name, "workloadSpread", klog.KObj(ws))
+				continue
+			}
+			maxReplicas[i] = int32(value)
+		}
+	}
+
+	for i := 0; i < deletions; i++ {
+		decision := PreviewDecision{Reason: "no Pod left in subsets"}
+		found := false
+		for j := len(ws.Spec.Subsets) - 1; j >= 0; j-- {
+			name := ws.Spec.Subsets[j].Name
+			if replicas[name] > maxReplicas[j] {
+				decision = PreviewDecision{Subset: name,
+					Reason: fmt.Sprintf("subset %s has %d replicas exceeding maxReplicas %d", name, replicas[name], maxReplicas[j])}
+				replicas[name]--
+				found = true
+				break
+			}
+		}
+		for j := len(ws.Spec.Subsets) - 1; j >= 0 && !found; j-- {
+			name := ws.Spec.Subsets[j].Name
+			if replicas[name] > 0 {
+				decision = PreviewDecision{Subset: name,
+					Reason: fmt.Sprintf("subset %s is the last subset that still has replicas", name)}
+				replicas[name]--
+				found = true
+			}
+		}
+		decisions = append(decisions, decision)
+	}
+	return decisions
+}
+
+func isSubsetSchedulable(subset *appsv1beta1.WorkloadSpreadSubsetStatus) bool {
+	for _, condition := range subset.Conditions {
+		if condition.Type == appsv1beta1.SubsetSchedulable && condition.Status == metav1.ConditionFalse {
+			return false
+		}
+	}
+	return true
+}
+
+// PreviewHandler serves the preview of a WorkloadSpread over HTTP, the request looks like:
+// GET /debug/workloadspread/preview?namespace=default&name=ws-demo&creations=3&deletions=1
+type PreviewHandler struct {
+	Handler *Handler
+}
+
+var _ http.Handler = &PreviewHandler{}
+
+// NewPreviewHandler returns a PreviewHandler using the given client.
+func NewPreviewHandler(c client.Client) *PreviewHandler {
+	return &PreviewHandler{Handler: NewWorkloadSpreadHandler(c)}
+}
+
+func (p *PreviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	namespace, name := query.Get("namespace"), query.Get("name")
+	if namespace == "" || name == "" {
+		http.Error(w, "namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	creations, err := parsePreviewCount(query.Get("creations"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid creations: %v", err), http.StatusBadRequest)
+		return
+	}
+	deletions, err := parsePreviewCount(query.Get("deletions"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid deletions: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ws := &appsv1beta1.WorkloadSpread{}
+	if err = p.Handler.Get(r.Context(), types.NamespacedName{Namespace: namespace, Name: name}, ws); err != nil {
+		if errors.IsNotFound(err) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result, err := p.Handler.Preview(ws, creations, deletions)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(result); err != nil {
+		klog.ErrorS(err, "Failed to write preview of WorkloadSpread", "workloadSpread", klog.KObj(ws))
+	}
+}
+
+func parsePreviewCount(value string) (int, error) {
+	if value == "" {
+		return 1, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	if count < 0 || count > maxPreviewCount {
+		return 0, fmt.Errorf("must be in [0, %d]", maxPreviewCount)
+	}
+	return count, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadspread
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func newPreviewWorkloadSpread() *appsv1beta1.WorkloadSpread {
+	ws := workloadSpreadDemo.DeepCopy()
+	ws.Spec.Subsets = []appsv1beta1.WorkloadSpreadSubset{
+		{Name: "subset-a", MaxReplicas: &intstr.IntOrString{Type: intstr.Int, IntVal: 2}},
+		{Name: "subset-b", MaxReplicas: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}},
+		{Name: "subset-c"},
+	}
+	ws.Status.SubsetStatuses = []appsv1beta1.WorkloadSpreadSubsetStatus{
+		{Name: "subset-a", Replicas: 3, MissingReplicas: 0},
+		{Name: "subset-b", Replicas: 0, MissingReplicas: 1},
+		{Name: "subset-c", Replicas: 1, MissingReplicas: -1},
+	}
+	ws.Status.VersionedSubsetStatuses = map[string][]appsv1beta1.WorkloadSpreadSubsetStatus{
+		VersionIgnored: ws.Status.SubsetStatuses,
+	}
+	return ws
+}
+
+func TestPreview(t *testing.T) {
+	cases := []struct {
+		name            string
+		getWS           func() *appsv1beta1.WorkloadSpread
+		creations       int
+		deletions       int
+		expectCreations []string
+		expectDeletions []string
+	}{
+		{
+			name:            "creations fill missing replicas in order",
+			getWS:           newPreviewWorkloadSpread,
+			creations:       3,
+			expectCreations: []string{"subset-b", "subset-c", "subset-c"},
+			expectDeletions: []string{},
+		},
+		{
+			name: "unschedulable subset is skipped",
+			getWS: func() *appsv1beta1.WorkloadSpread {
+				ws := newPreviewWorkloadSpread()
+				ws.Status.VersionedSubsetStatuses[VersionIgnored][1].Conditions = []metav1.Condition{
+					{Type: appsv1beta1.SubsetSchedulable, Status: metav1.ConditionFalse},
+				}
+				return ws
+			},
+			creations:       1,
+			expectCreations: []string{"subset-c"},
+			expectDeletions: []string{},
+		},
+		{
+			name:            "deletions prefer pods exceeding maxReplicas, then back subsets",
+			getWS:           newPreviewWorkloadSpread,
+			deletions:       4,
+			expectCreations: []string{},
+			expectDeletions: []string{"subset-a", "subset-c", "subset-a", "subset-a"},
+		},
+		{
+			name: "no pod left",
+			getWS: func() *appsv1beta1.WorkloadSpread {
+				ws := newPreviewWorkloadSpread()
+				ws.Status.SubsetStatuses = nil
+				return ws
+			},
+			deletions:       1,
+			expectCreations: []string{},
+			expectDeletions: []string{""},
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			ws := cs.getWS()
+			origin := ws.DeepCopy()
+			h := Handler{fake.NewClientBuilder().WithScheme(scheme).WithObjects(cloneSetDemo.DeepCopy(), ws.DeepCopy()).Build()}
+			result, err := h.Preview(ws, cs.creations, cs.deletions)
+			if err != nil {
+				t.Fatalf("preview failed: %s", err.Error())
+			}
+			if !reflect.DeepEqual(ws, origin) {
+				t.Fatalf("preview should not modify the WorkloadSpread")
+			}
+			if subsets := getPreviewSubsets(result.Creations); !reflect.DeepEqual(subsets, cs.expectCreations) {
+				t.Fatalf("expect creations %v, but got %v", cs.expectCreations, subsets)
+			}
+			if subsets := getPreviewSubsets(result.Deletions); !reflect.DeepEqual(subsets, cs.expectDeletions) {
+				t.Fatalf("expect deletions %v, but got %v", cs.expectDeletions, subsets)
+			}
+		})
+	}
+}
+
+func TestPreviewHandler(t *testing.T) {
+	ws := newPreviewWorkloadSpread()
+	handler := NewPreviewHandler(fake.NewClientBuilder().WithScheme(scheme).WithObjects(cloneSetDemo.DeepCopy(), ws).Build())
+
+	cases := []struct {
+		name       string
+		url        string
+		expectCode int
+	}{
+		{name: "missing name", url: PreviewPath + "?namespace=default", expectCode: http.StatusBadRequest},
+		{name: "invalid creations", url: PreviewPath + "?namespace=default&name=test-ws&creations=-1", expectCode: http.StatusBadRequest},
+		{name: "not found", url: PreviewPath + "?namespace=default&name=not-found", expectCode: http.StatusNotFound},
+		{name: "ok", url: PreviewPath + "?namespace=default&name=test-ws&creations=2&deletions=0", expectCode: http.StatusOK},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, cs.url, nil))
+			if recorder.Code != cs.expectCode {
+				t.Fatalf("expect code %d, but got %d: %s", cs.expectCode, recorder.Code, recorder.Body.String())
+			}
+			if recorder.Code != http.StatusOK {
+				return
+			}
+			result := &PreviewResult{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+				t.Fatalf("failed to decode preview result: %s", err.Error())
+			}
+			if len(result.Creations) != 2 || len(result.Deletions) != 0 {
+				t.Fatalf("unexpected preview result: %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func getPreviewSubsets(decisions []PreviewDecision) []string {
+	subsets := make([]string, 0, len(decisions))
+	for _, decision := range decisions {
+		subsets = append(subsets, decision.Subset)
+	}
+	return subsets
+}
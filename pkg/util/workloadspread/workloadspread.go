@@ -688,28 +688,38 @@ func getSpecificSubset(subsetStatuses []appsv1beta1.WorkloadSpreadSubsetStatus,
 }
 
 func (h *Handler) getSuitableSubset(subsetStatuses []appsv1beta1.WorkloadSpreadSubsetStatus) *appsv1beta1.WorkloadSpreadSubsetStatus {
+	if i, _ := findSuitableSubset(subsetStatuses, false); i >= 0 {
+		return &subsetStatuses[i]
+	}
+	return nil
+}
+
+// findSuitableSubset returns the index of the first subset that is schedulable and still misses replicas,
+// and, if withReasons is true, the reasons why the subsets in front of it are skipped. It returns -1 if no subset is suitable.
+func findSuitableSubset(subsetStatuses []appsv1beta1.WorkloadSpreadSubsetStatus, withReasons bool) (int, []string) {
+	var skipped []string
 	for i := range subsetStatuses {
 		subset := &subsetStatuses[i]
-		canSchedule := true
-		for _, condition := range subset.Conditions {
-			if condition.Type == appsv1beta1.SubsetSchedulable && condition.Status == metav1.ConditionFalse {
-				canSchedule = false
-				break
+		if !isSubsetSchedulable(subset) {
+			if withReasons {
+				skipped = append(skipped, fmt.Sprintf("%s is unschedulable", subset.Name))
 			}
+			continue
 		}
-
-		if canSchedule && (subset.MissingReplicas > 0 || subset.MissingReplicas == -1) {
+		if subset.MissingReplicas > 0 || subset.MissingReplicas == -1 {
 			// TODO simulation schedule
 			// scheduleStrategy.Type = Adaptive
 			// Webhook will simulate a schedule in order to check whether Pod can run in this subset,
 			// which does a generic predicates by the cache of nodes and pods in kruise manager.
 			// There may be some errors between simulation schedule and kubernetes scheduler with small probability.
 
-			return subset
+			return i, skipped
+		}
+		if withReasons {
+			skipped = append(skipped, fmt.Sprintf("%s is full", subset.Name))
 		}
 	}
-
-	return nil
+	return -1, skipped
 }
 
 func (h *Handler) isReferenceEqual(target *appsv1beta1.TargetReference, owner *metav1.OwnerReference, namespace string) (bool, error) {
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	v1 "k8s.io/api/core/v1"
@@ -29,7 +30,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	kubecontroller "k8s.io/kubernetes/pkg/controller"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -37,11 +40,16 @@ import (
 	"github.com/openkruise/kruise/pkg/controller/sidecarterminator"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
+	utilcontainermeta "github.com/openkruise/kruise/pkg/util/containermeta"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
 
 const (
 	minDeadlineSeconds = 3
+
+	defaultCaptureTailLines = 50
+	maxCaptureTailLines     = 500
 )
 
 // ContainerRecreateRequestHandler handles ContainerRecreateRequest
@@ -116,6 +124,19 @@ func (h *ContainerRecreateRequestHandler) Handle(ctx context.Context, req admiss
 	default:
 		return admission.Errored(http.StatusBadRequest, fmt.Errorf("unknown failurePolicy %s", obj.Spec.Strategy.FailurePolicy))
 	}
+	if err := setDefaultsAndValidateCapture(obj.Spec.Strategy.CaptureBeforeRecreate); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if obj.Spec.Strategy.CaptureBeforeRecreate != nil {
+		// the captured logs are visible to anyone who can read the CRR,
+		// so the requester must be allowed to read the logs of the Pod
+		allowed, err := webhookutil.CanGetPodLogs(ctx, h.Client, req.UserInfo, obj.Namespace, obj.Spec.PodName)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to review access to logs of Pod %s: %v", obj.Spec.PodName, err))
+		} else if !allowed {
+			return admission.Errored(http.StatusForbidden, fmt.Errorf("user %s is not allowed to get logs of Pod %s to capture before recreate", req.UserInfo.Username, obj.Spec.PodName))
+		}
+	}
 
 	pod := &v1.Pod{}
 	if err := h.Client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Spec.PodName}, pod); err != nil {
@@ -190,6 +211,12 @@ func injectPodIntoContainerRecreateRequest(obj *appsv1alpha1.ContainerRecreateRe
 		if podContainer == nil {
 			return fmt.Errorf("container %s not found in Pod", c.Name)
 		}
+		if err := validateContainerOverride(c, podContainer); err != nil {
+			return err
+		}
+		if c.Override != nil && obj.Spec.TTLSecondsAfterFinished == nil {
+			return fmt.Errorf("ttlSecondsAfterFinished is required to revert the override of %s", c.Name)
+		}
 		podContainerStatus := util.GetContainerStatus(c.Name, pod)
 		if podContainerStatus == nil {
 			return fmt.Errorf("not found %s containerStatus in Pod Status", c.Name)
@@ -217,3 +244,43 @@ func injectPodIntoContainerRecreateRequest(obj *appsv1alpha1.ContainerRecreateRe
 
 	return nil
 }
+
+func setDefaultsAndValidateCapture(capture *appsv1alpha1.ContainerRecreateRequestCaptureStrategy) error {
+	if capture == nil {
+		return nil
+	}
+	if capture.TailLines == nil {
+		capture.TailLines = ptr.To(int64(defaultCaptureTailLines))
+	}
+	if *capture.TailLines <= 0 || *capture.TailLines > maxCaptureTailLines {
+		return fmt.Errorf("captureBeforeRecreate.tailLines must be in range [1, %d]", maxCaptureTailLines)
+	}
+	return nil
+}
+
+func validateContainerOverride(c *appsv1alpha1.ContainerRecreateRequestContainer, podContainer *v1.Container) error {
+	if c.Override == nil {
+		return nil
+	}
+	if len(c.Override.Env) == 0 {
+		return fmt.Errorf("override of %s can not be empty", c.Name)
+	}
+	envNames := sets.NewString()
+	for _, env := range c.Override.Env {
+		if envNames.Has(env.Name) {
+			return fmt.Errorf("can not override env %s in %s multi times", env.Name, c.Name)
+		}
+		envNames.Insert(env.Name)
+
+		path, _, ok := utilcontainermeta.GetEnvReferenceToMeta(podContainer, env.Name)
+		if !ok {
+			return fmt.Errorf("env %s in %s must be from metadata.annotations or metadata.labels to be overridden", env.Name, c.Name)
+		}
+		if path == "metadata.labels" {
+			if errs := validation.IsValidLabelValue(env.Value); len(errs) > 0 {
+				return fmt.Errorf("invalid value of env %s in %s for label: %s", env.Name, c.Name, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	"github.com/openkruise/kruise/pkg/util"
@@ -133,3 +134,111 @@ func TestInjectPodIntoContainerRecreateRequest_VirtualKubeletLabel(t *testing.T)
 		})
 	}
 }
+
+func TestInjectPodIntoContainerRecreateRequest_Override(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: types.UID("pod-uid-123")},
+		Spec: v1.PodSpec{
+			NodeName: "test-node",
+			Containers: []v1.Container{{
+				Name: "main",
+				Env: []v1.EnvVar{
+					{Name: "LOG_LEVEL", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.annotations['log-level']"}}},
+					{Name: "PROFILE", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['profile']"}}},
+					{Name: "STATIC", Value: "foo"},
+				},
+			}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "main", ContainerID: "docker://abc123"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		ttl       *int32
+		env       []appsv1alpha1.ContainerRecreateRequestEnvOverride
+		expectErr bool
+	}{
+		{
+			name: "override env from annotations and labels",
+			ttl:  ptr.To(int32(600)),
+			env:  []appsv1alpha1.ContainerRecreateRequestEnvOverride{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "PROFILE", Value: "on"}},
+		},
+		{
+			name:      "ttl is required",
+			env:       []appsv1alpha1.ContainerRecreateRequestEnvOverride{{Name: "LOG_LEVEL", Value: "debug"}},
+			expectErr: true,
+		},
+		{
+			name:      "env with static value",
+			ttl:       ptr.To(int32(600)),
+			env:       []appsv1alpha1.ContainerRecreateRequestEnvOverride{{Name: "STATIC", Value: "bar"}},
+			expectErr: true,
+		},
+		{
+			name:      "env not found",
+			ttl:       ptr.To(int32(600)),
+			env:       []appsv1alpha1.ContainerRecreateRequestEnvOverride{{Name: "NOT_FOUND", Value: "bar"}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid label value",
+			ttl:       ptr.To(int32(600)),
+			env:       []appsv1alpha1.ContainerRecreateRequestEnvOverride{{Name: "PROFILE", Value: "on off"}},
+			expectErr: true,
+		},
+		{
+			name:      "duplicated env",
+			ttl:       ptr.To(int32(600)),
+			env:       []appsv1alpha1.ContainerRecreateRequestEnvOverride{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "LOG_LEVEL", Value: "info"}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crr := &appsv1alpha1.ContainerRecreateRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-crr", Namespace: "default", Labels: map[string]string{}},
+				Spec: appsv1alpha1.ContainerRecreateRequestSpec{
+					PodName: "test-pod",
+					Containers: []appsv1alpha1.ContainerRecreateRequestContainer{
+						{Name: "main", Override: &appsv1alpha1.ContainerRecreateRequestContainerOverride{Env: tt.env}},
+					},
+					Strategy:                &appsv1alpha1.ContainerRecreateRequestStrategy{},
+					TTLSecondsAfterFinished: tt.ttl,
+				},
+			}
+			err := injectPodIntoContainerRecreateRequest(crr, pod, nil)
+			if tt.expectErr != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestSetDefaultsAndValidateCapture(t *testing.T) {
+	capture := &appsv1alpha1.ContainerRecreateRequestCaptureStrategy{}
+	if err := setDefaultsAndValidateCapture(capture); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if capture.TailLines == nil || *capture.TailLines != defaultCaptureTailLines {
+		t.Fatalf("expected default tailLines %d, got %v", defaultCaptureTailLines, capture.TailLines)
+	}
+
+	tests := []struct {
+		tailLines int64
+		expectErr bool
+	}{
+		{tailLines: 1},
+		{tailLines: maxCaptureTailLines},
+		{tailLines: 0, expectErr: true},
+		{tailLines: maxCaptureTailLines + 1, expectErr: true},
+	}
+	for _, tt := range tests {
+		err := setDefaultsAndValidateCapture(&appsv1alpha1.ContainerRecreateRequestCaptureStrategy{TailLines: ptr.To(tt.tailLines)})
+		if (err != nil) != tt.expectErr {
+			t.Fatalf("tailLines %d: expected error %v, got %v", tt.tailLines, tt.expectErr, err)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+	"github.com/openkruise/kruise/pkg/features"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
+)
+
+// ContainerRestartJobCreateUpdateHandler handles ContainerRestartJob
+type ContainerRestartJobCreateUpdateHandler struct {
+	Client client.Client
+	// Decoder decodes objects
+	Decoder admission.Decoder
+}
+
+var _ admission.Handler = &ContainerRestartJobCreateUpdateHandler{}
+
+// Handle handles admission requests.
+func (h *ContainerRestartJobCreateUpdateHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.KruiseDaemon) {
+		return admission.Errored(http.StatusForbidden, fmt.Errorf("feature-gate %s is not enabled", features.KruiseDaemon))
+	}
+	if !utilfeature.DefaultFeatureGate.Enabled(features.ContainerRestartJobGate) {
+		return admission.Errored(http.StatusForbidden, fmt.Errorf("feature-gate %s is not enabled", features.ContainerRestartJobGate))
+	}
+
+	obj := &appsv1alpha1.ContainerRestartJob{}
+	if err := h.Decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	allErrs := validateContainerRestartJobSpec(&obj.Spec, field.NewPath("spec"))
+	if req.Operation == admissionv1.Update {
+		oldObj := &appsv1alpha1.ContainerRestartJob{}
+		if err := h.Decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		allErrs = append(allErrs, validateContainerRestartJobSpecUpdate(&obj.Spec, &oldObj.Spec, field.NewPath("spec"))...)
+	}
+	if len(allErrs) > 0 {
+		klog.ErrorS(allErrs.ToAggregate(), "Error validate ContainerRestartJob", "namespace", obj.Namespace, "name", obj.Name)
+		return admission.Errored(http.StatusUnprocessableEntity, allErrs.ToAggregate())
+	}
+
+	if req.Operation == admissionv1.Create && obj.Spec.Strategy != nil && obj.Spec.Strategy.CaptureBeforeRecreate != nil {
+		// the logs captured by the ContainerRecreateRequests of this job are visible to anyone who can read them,
+		// so the requester must be allowed to read the logs of Pods in the namespace
+		allowed, err := webhookutil.CanGetPodLogs(ctx, h.Client, req.UserInfo, obj.Namespace, "")
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, fmt.Errorf("failed to review access to logs of Pods: %v", err))
+		} else if !allowed {
+			return admission.Errored(http.StatusForbidden, fmt.Errorf("user %s is not allowed to get logs of Pods to capture before recreate", req.UserInfo.Username))
+		}
+	}
+	return admission.ValidationResponse(true, "allowed")
+}
+
+func validateContainerRestartJobSpec(spec *appsv1alpha1.ContainerRestartJobSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch {
+	case spec.Selector == nil && spec.TargetReference == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "one of selector and targetRef must be set"))
+	case spec.Selector != nil && spec.TargetReference != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "selector and targetRef can not be set at the same time"))
+	case spec.Selector != nil:
+		selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), spec.Selector, err.Error()))
+		} else if selector.Empty() {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), spec.Selector, "empty selector is not allowed"))
+		}
+	default:
+		if spec.TargetReference.APIVersion == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "apiVersion"), ""))
+		}
+		if spec.TargetReference.Kind == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "kind"), ""))
+		}
+		if spec.TargetReference.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("targetRef", "name"), ""))
+		}
+	}
+
+	if len(spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("containers"), ""))
+	}
+	names := sets.New[string]()
+	for i, c := range spec.Containers {
+		if c.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("containers").Index(i).Child("name"), ""))
+		} else if names.Has(c.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("containers").Index(i).Child("name"), c.Name))
+		}
+		names.Insert(c.Name)
+	}
+
+	if spec.MaxUnavailable != nil {
+		value, err := intstr.GetScaledValueFromIntOrPercent(spec.MaxUnavailable, 100, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUnavailable"), spec.MaxUnavailable, err.Error()))
+		} else if value <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxUnavailable"), spec.MaxUnavailable, "must be positive"))
+		}
+	}
+	if spec.ActiveDeadlineSeconds != nil && *spec.ActiveDeadlineSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("activeDeadlineSeconds"), *spec.ActiveDeadlineSeconds, "must be positive"))
+	}
+	if spec.TTLSecondsAfterFinished != nil && *spec.TTLSecondsAfterFinished < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttlSecondsAfterFinished"), *spec.TTLSecondsAfterFinished, "must be non-negative"))
+	}
+	return allErrs
+}
+
+// validateContainerRestartJobSpecUpdate only allows paused, maxUnavailable and ttlSecondsAfterFinished to be updated
+func validateContainerRestartJobSpecUpdate(spec, oldSpec *appsv1alpha1.ContainerRestartJobSpec, fldPath *field.Path) field.ErrorList {
+	newCopy := spec.DeepCopy()
+	newCopy.Paused = oldSpec.Paused
+	newCopy.MaxUnavailable = oldSpec.MaxUnavailable
+	newCopy.TTLSecondsAfterFinished = oldSpec.TTLSecondsAfterFinished
+	if !apiequality.Semantic.DeepEqual(newCopy, oldSpec) {
+		return field.ErrorList{field.Forbidden(fldPath, "updates to spec for fields other than 'paused', 'maxUnavailable' and 'ttlSecondsAfterFinished' are forbidden")}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
+)
+
+func newTestSpec() *appsv1alpha1.ContainerRestartJobSpec {
+	return &appsv1alpha1.ContainerRestartJobSpec{
+		Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+		Containers: []appsv1alpha1.ContainerRestartJobContainer{{Name: "main"}},
+	}
+}
+
+func TestValidateContainerRestartJobSpec(t *testing.T) {
+	cases := []struct {
+		name      string
+		getSpec   func() *appsv1alpha1.ContainerRestartJobSpec
+		expectErr bool
+	}{
+		{
+			name:    "valid selector",
+			getSpec: newTestSpec,
+		},
+		{
+			name: "valid targetRef",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.Selector = nil
+				spec.TargetReference = &appsv1alpha1.TargetReference{APIVersion: "apps.kruise.io/v1alpha1", Kind: "CloneSet", Name: "cs"}
+				spec.MaxUnavailable = ptr.To(intstr.FromString("20%"))
+				return spec
+			},
+		},
+		{
+			name: "neither selector nor targetRef",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.Selector = nil
+				return spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "both selector and targetRef",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.TargetReference = &appsv1alpha1.TargetReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "deploy"}
+				return spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "empty selector",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.Selector = &metav1.LabelSelector{}
+				return spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "duplicated containers",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.Containers = append(spec.Containers, appsv1alpha1.ContainerRestartJobContainer{Name: "main"})
+				return spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "no containers",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.Containers = nil
+				return spec
+			},
+			expectErr: true,
+		},
+		{
+			name: "zero maxUnavailable",
+			getSpec: func() *appsv1alpha1.ContainerRestartJobSpec {
+				spec := newTestSpec()
+				spec.MaxUnavailable = ptr.To(intstr.FromInt32(0))
+				return spec
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			errs := validateContainerRestartJobSpec(cs.getSpec(), field.NewPath("spec"))
+			assert.Equal(t, cs.expectErr, len(errs) > 0, "errors: %v", errs)
+		})
+	}
+}
+
+func TestValidateContainerRestartJobSpecUpdate(t *testing.T) {
+	oldSpec := newTestSpec()
+
+	spec := newTestSpec()
+	spec.Paused = true
+	spec.MaxUnavailable = ptr.To(intstr.FromInt32(3))
+	spec.TTLSecondsAfterFinished = ptr.To(int32(60))
+	assert.Len(t, validateContainerRestartJobSpecUpdate(spec, oldSpec, field.NewPath("spec")), 0)
+
+	spec = newTestSpec()
+	spec.Containers = []appsv1alpha1.ContainerRestartJobContainer{{Name: "sidecar"}}
+	assert.Len(t, validateContainerRestartJobSpecUpdate(spec, oldSpec, field.NewPath("spec")), 1)
+}
@@ -18,6 +18,7 @@ package validating
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -30,6 +31,8 @@ import (
 	"github.com/openkruise/kruise/pkg/webhook/util/convertor"
 )
 
+const maxOutputTailLines = 1000
+
 // EphemeralJobCreateUpdateHandler handles EphemeralJob
 type EphemeralJobCreateUpdateHandler struct {
 	// Decoder decodes objects
@@ -69,5 +72,22 @@ func validate(obj *appsv1alpha1.EphemeralJob) error {
 	hostUsers := true
 	// don't validate EphemeralContainer TargetContainerName
 	allErrs := validateEphemeralContainers(ecs, field.NewPath("ephemeralContainers"), validation.PodValidationOptions{}, hostUsers)
+	if obj.Spec.TargetContainerName != "" {
+		allErrs = append(allErrs, validation.ValidateDNS1123Label(obj.Spec.TargetContainerName, field.NewPath("spec", "targetContainerName"))...)
+	}
+	if obj.Spec.CleanupPolicy != nil {
+		switch obj.Spec.CleanupPolicy.Type {
+		case "", appsv1alpha1.EphemeralJobCleanupNone, appsv1alpha1.EphemeralJobCleanupOnNextUpdate, appsv1alpha1.EphemeralJobCleanupRecreate:
+		default:
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "cleanupPolicy", "type"), obj.Spec.CleanupPolicy.Type,
+				[]string{string(appsv1alpha1.EphemeralJobCleanupNone), string(appsv1alpha1.EphemeralJobCleanupOnNextUpdate), string(appsv1alpha1.EphemeralJobCleanupRecreate)}))
+		}
+	}
+	if obj.Spec.OutputCollection != nil && obj.Spec.OutputCollection.TailLines != nil {
+		if tailLines := *obj.Spec.OutputCollection.TailLines; tailLines <= 0 || tailLines > maxOutputTailLines {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "outputCollection", "tailLines"), tailLines,
+				fmt.Sprintf("must be in range [1, %d]", maxOutputTailLines)))
+		}
+	}
 	return allErrs.ToAggregate()
 }
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
@@ -168,3 +170,91 @@ func TestEphemeralJobCreateUpdateHandler_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCleanupPolicy(t *testing.T) {
+	tests := []struct {
+		policyType alpha1.EphemeralJobCleanupPolicyType
+		wantErr    bool
+	}{
+		{policyType: ""},
+		{policyType: alpha1.EphemeralJobCleanupNone},
+		{policyType: alpha1.EphemeralJobCleanupOnNextUpdate},
+		{policyType: alpha1.EphemeralJobCleanupRecreate},
+		{policyType: "Delete", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		obj := &alpha1.EphemeralJob{
+			Spec: alpha1.EphemeralJobSpec{
+				Template: alpha1.EphemeralContainerTemplateSpec{EphemeralContainers: []v1.EphemeralContainer{{
+					EphemeralContainerCommon: v1.EphemeralContainerCommon{
+						Name:                     "debugger",
+						Image:                    "busybox",
+						ImagePullPolicy:          v1.PullIfNotPresent,
+						TerminationMessagePolicy: v1.TerminationMessageReadFile,
+					},
+				}}},
+				CleanupPolicy: &alpha1.EphemeralJobCleanupPolicy{Type: tt.policyType},
+			},
+		}
+		if err := validate(obj); (err != nil) != tt.wantErr {
+			t.Errorf("validate() with cleanup policy %q error = %v, wantErr %v", tt.policyType, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateOutputCollection(t *testing.T) {
+	tests := []struct {
+		name                string
+		targetContainerName string
+		outputCollection    *alpha1.EphemeralJobOutputCollection
+		wantErr             bool
+	}{
+		{
+			name:             "default tailLines",
+			outputCollection: &alpha1.EphemeralJobOutputCollection{},
+		},
+		{
+			name:                "valid tailLines and targetContainerName",
+			targetContainerName: "main",
+			outputCollection:    &alpha1.EphemeralJobOutputCollection{TailLines: ptr.To(int64(maxOutputTailLines))},
+		},
+		{
+			name:             "zero tailLines",
+			outputCollection: &alpha1.EphemeralJobOutputCollection{TailLines: ptr.To(int64(0))},
+			wantErr:          true,
+		},
+		{
+			name:             "too large tailLines",
+			outputCollection: &alpha1.EphemeralJobOutputCollection{TailLines: ptr.To(int64(maxOutputTailLines + 1))},
+			wantErr:          true,
+		},
+		{
+			name:                "invalid targetContainerName",
+			targetContainerName: "Main_Container",
+			wantErr:             true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &alpha1.EphemeralJob{
+				Spec: alpha1.EphemeralJobSpec{
+					Template: alpha1.EphemeralContainerTemplateSpec{EphemeralContainers: []v1.EphemeralContainer{{
+						EphemeralContainerCommon: v1.EphemeralContainerCommon{
+							Name:                     "debugger",
+							Image:                    "busybox",
+							ImagePullPolicy:          v1.PullIfNotPresent,
+							TerminationMessagePolicy: v1.TerminationMessageReadFile,
+						},
+					}}},
+					TargetContainerName: tt.targetContainerName,
+					OutputCollection:    tt.outputCollection,
+				},
+			}
+			if err := validate(obj); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -35,6 +36,7 @@ import (
 	daemonutil "github.com/openkruise/kruise/pkg/daemon/util"
 	"github.com/openkruise/kruise/pkg/features"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
+	"github.com/openkruise/kruise/pkg/util/imageverify"
 )
 
 // ImagePullJobCreateUpdateHandler handles ImagePullJob
@@ -209,6 +211,24 @@ func validateV1beta1(obj *appsv1beta1.ImagePullJob) error {
 		}
 	}
 
+	if obj.Spec.PeerToPeer != nil && obj.Spec.PeerToPeer.FanOut != nil && *obj.Spec.PeerToPeer.FanOut < 1 {
+		return fmt.Errorf("peerToPeer.fanOut must be greater than 0")
+	}
+
+	if v := obj.Spec.Verification; v != nil {
+		if len(v.PublicKeys) == 0 && v.AllowedDigestsConfigMap == "" {
+			return fmt.Errorf("verification must set publicKeys or allowedDigestsConfigMap")
+		}
+		if _, err := imageverify.ParsePublicKeys(v.PublicKeys); err != nil {
+			return fmt.Errorf("invalid verification.publicKeys: %v", err)
+		}
+		if v.AllowedDigestsConfigMap != "" {
+			if errs := validation.IsDNS1123Subdomain(v.AllowedDigestsConfigMap); len(errs) > 0 {
+				return fmt.Errorf("invalid verification.allowedDigestsConfigMap: %s", strings.Join(errs, ", "))
+			}
+		}
+	}
+
 	if obj.Spec.PullPolicy == nil {
 		obj.Spec.PullPolicy = &appsv1beta1.PullPolicy{}
 	}
@@ -17,6 +17,11 @@ limitations under the License.
 package validating
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"strings"
 	"testing"
 
@@ -219,3 +224,98 @@ func TestValidateParallelismV1beta1(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePeerToPeerV1beta1(t *testing.T) {
+	tests := []struct {
+		name        string
+		peerToPeer  *appsv1beta1.ImagePullJobPeerToPeer
+		expectError bool
+	}{
+		{
+			name:       "default fanOut",
+			peerToPeer: &appsv1beta1.ImagePullJobPeerToPeer{},
+		},
+		{
+			name:       "valid fanOut",
+			peerToPeer: &appsv1beta1.ImagePullJobPeerToPeer{FanOut: ptr.To[int32](5)},
+		},
+		{
+			name:        "invalid zero fanOut",
+			peerToPeer:  &appsv1beta1.ImagePullJobPeerToPeer{FanOut: ptr.To[int32](0)},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &appsv1beta1.ImagePullJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+				Spec: appsv1beta1.ImagePullJobSpec{
+					Image: "nginx:latest",
+					ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+						PeerToPeer:       tt.peerToPeer,
+						CompletionPolicy: appsv1beta1.CompletionPolicy{Type: appsv1beta1.Always},
+					},
+				},
+			}
+			if err := validateV1beta1(obj); (err != nil) != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestValidateVerificationV1beta1(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	tests := []struct {
+		name         string
+		verification *appsv1beta1.ImagePullJobVerification
+		expectError  bool
+	}{
+		{
+			name:         "valid public keys",
+			verification: &appsv1beta1.ImagePullJobVerification{PublicKeys: []string{publicKey}},
+		},
+		{
+			name:         "valid allowlist",
+			verification: &appsv1beta1.ImagePullJobVerification{AllowedDigestsConfigMap: "allowed-digests"},
+		},
+		{
+			name:         "empty verification",
+			verification: &appsv1beta1.ImagePullJobVerification{},
+			expectError:  true,
+		},
+		{
+			name:         "invalid public key",
+			verification: &appsv1beta1.ImagePullJobVerification{PublicKeys: []string{"invalid"}},
+			expectError:  true,
+		},
+		{
+			name:         "invalid configmap name",
+			verification: &appsv1beta1.ImagePullJobVerification{AllowedDigestsConfigMap: "Invalid_Name"},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &appsv1beta1.ImagePullJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-job", Namespace: "default"},
+				Spec: appsv1beta1.ImagePullJobSpec{
+					Image: "nginx:latest",
+					ImagePullJobTemplate: appsv1beta1.ImagePullJobTemplate{
+						PinDigest:        true,
+						Verification:     tt.verification,
+						CompletionPolicy: appsv1beta1.CompletionPolicy{Type: appsv1beta1.Always},
+					},
+				},
+			}
+			if err := validateV1beta1(obj); (err != nil) != tt.expectError {
+				t.Errorf("expected error: %v, got error: %v", tt.expectError, err)
+			}
+		})
+	}
+}
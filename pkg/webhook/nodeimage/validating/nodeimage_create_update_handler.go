@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/docker/distribution/reference"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -149,5 +150,29 @@ func validateV1beta1(obj *appsv1beta1.NodeImage) error {
 		}
 	}
 
+	return validateGCPolicy(obj.Spec.GCPolicy)
+}
+
+func validateGCPolicy(policy *appsv1beta1.ImageGCPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	for _, image := range policy.KeepImages {
+		if _, err := reference.ParseNormalizedNamed(image); err != nil {
+			return fmt.Errorf("gcPolicy.keepImages %s is invalid: %v", image, err)
+		}
+	}
+	if policy.MaxAgeSeconds != nil && *policy.MaxAgeSeconds < 0 {
+		return fmt.Errorf("gcPolicy.maxAgeSeconds can not be less than 0")
+	}
+	if policy.MaxCountPerRepository != nil && *policy.MaxCountPerRepository < 1 {
+		return fmt.Errorf("gcPolicy.maxCountPerRepository can not be less than 1")
+	}
+	if policy.ImageFsUsageTarget != nil && policy.ImageFsUsageTarget.Sign() < 0 {
+		return fmt.Errorf("gcPolicy.imageFsUsageTarget can not be less than 0")
+	}
+	if policy.IntervalSeconds != nil && *policy.IntervalSeconds <= 0 {
+		return fmt.Errorf("gcPolicy.intervalSeconds must be greater than 0")
+	}
 	return nil
 }
@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -147,16 +150,57 @@ func validatePersistentPodStateSpec(obj *appsv1beta1.PersistentPodState, fldPath
 
 	apiVersion, kind := spec.TargetReference.APIVersion, spec.TargetReference.Kind
 	if !whiteList.ValidateAPIVersionAndKind(apiVersion, kind) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetRef"), spec.TargetReference, "targetRef.Kind must be StatefulSet or in PPS_Watch_Custom_Workload_WhiteList"))
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("targetRef"), spec.TargetReference, "targetRef.Kind must be StatefulSet, CloneSet or in PPS_Watch_Custom_Workload_WhiteList"))
+	}
+	if spec.PodIdentityLabel != "" {
+		allErrs = append(allErrs, metavalidation.ValidateLabelName(spec.PodIdentityLabel, fldPath.Child("podIdentityLabel"))...)
 	}
 
-	if spec.RequiredPersistentTopology == nil && len(spec.PreferredPersistentTopology) == 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath, spec, "TopologyConstraint and TopologyPreference cannot be empty at the same time"))
+	if spec.RequiredPersistentTopology == nil && len(spec.PreferredPersistentTopology) == 0 && len(spec.PersistentPodFields) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec, "TopologyConstraint, TopologyPreference and PersistentPodFields cannot be empty at the same time"))
 	}
+	allErrs = append(allErrs, validatePersistentPodFields(spec.PersistentPodFields, fldPath.Child("persistentPodFields"))...)
+
+	return allErrs
+}
 
+var (
+	persistentPodFieldPathPrefixes = []string{"/metadata/labels/", "/metadata/annotations/", "/spec/", "/status/"}
+	restorePodFieldPathPrefixes    = []string{"/metadata/labels/", "/metadata/annotations/", "/spec/"}
+)
+
+func validatePersistentPodFields(fields []appsv1beta1.PersistentPodField, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	paths := sets.NewString()
+	for i, item := range fields {
+		idxPath := fldPath.Index(i)
+		if paths.Has(item.Path) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("path"), item.Path))
+		}
+		paths.Insert(item.Path)
+		// the saved value is restored into path itself if restorePath is empty
+		if item.RestorePath == "" {
+			allErrs = append(allErrs, validatePodFieldPath(item.Path, restorePodFieldPathPrefixes, idxPath.Child("path"))...)
+			continue
+		}
+		allErrs = append(allErrs, validatePodFieldPath(item.Path, persistentPodFieldPathPrefixes, idxPath.Child("path"))...)
+		allErrs = append(allErrs, validatePodFieldPath(item.RestorePath, restorePodFieldPathPrefixes, idxPath.Child("restorePath"))...)
+	}
 	return allErrs
 }
 
+func validatePodFieldPath(path string, prefixes []string, fldPath *field.Path) field.ErrorList {
+	if _, err := util.ParseJSONPointer(path); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, path, err.Error())}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) && len(path) > len(prefix) {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Invalid(fldPath, path, fmt.Sprintf("must be a field in %s", strings.Join(prefixes, ", ")))}
+}
+
 func validatePerConflict(pps *appsv1beta1.PersistentPodState, others []appsv1beta1.PersistentPodState, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
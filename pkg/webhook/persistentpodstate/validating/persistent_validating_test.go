@@ -120,6 +120,58 @@ func TestValidatingPer(t *testing.T) {
 			},
 			expectErrList: 1,
 		},
+		{
+			name: "valid per, only PersistentPodFields",
+			per: func() *appsv1beta1.PersistentPodState {
+				pps := ppsDemo.DeepCopy()
+				pps.Spec.RequiredPersistentTopology = nil
+				pps.Spec.PreferredPersistentTopology = nil
+				pps.Spec.PersistentPodFields = []appsv1beta1.PersistentPodField{
+					{Path: "/metadata/labels/app"},
+					{Path: "/spec/containers/0/resources"},
+					{Path: "/status/podIP", RestorePath: "/metadata/annotations/kruise.io~1static-ip"},
+				}
+				return pps
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "valid per, targetRef CloneSet",
+			per: func() *appsv1beta1.PersistentPodState {
+				pps := ppsDemo.DeepCopy()
+				pps.Spec.TargetReference = appsv1beta1.TargetReference{
+					APIVersion: "apps.kruise.io/v1alpha1",
+					Kind:       "CloneSet",
+					Name:       "test",
+				}
+				return pps
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "invalid per, invalid PodIdentityLabel",
+			per: func() *appsv1beta1.PersistentPodState {
+				pps := ppsDemo.DeepCopy()
+				pps.Spec.PodIdentityLabel = "invalid label/key"
+				return pps
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "invalid per, invalid PersistentPodFields",
+			per: func() *appsv1beta1.PersistentPodState {
+				pps := ppsDemo.DeepCopy()
+				pps.Spec.PersistentPodFields = []appsv1beta1.PersistentPodField{
+					{Path: "metadata/labels/app"},
+					{Path: "/metadata/name"},
+					{Path: "/status/podIP"},
+					{Path: "/spec/nodeName", RestorePath: "/status/hostIP"},
+					{Path: "/spec/nodeName"},
+				}
+				return pps
+			},
+			expectErrList: 5,
+		},
 	}
 
 	decoder := admission.NewDecoder(scheme)
@@ -2,6 +2,7 @@ package mutating
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -11,7 +12,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/features"
 	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 // start containers based on priority order
@@ -26,6 +29,11 @@ func (h *PodCreateHandler) containerLaunchPriorityInitialization(_ context.Conte
 		return true, nil
 	}
 
+	// launch dependencies can not be used with launch priority
+	if _, ok := pod.Annotations[appspub.ContainerLaunchDependenciesKey]; ok && utilfeature.DefaultFeatureGate.Enabled(features.ContainerLaunchDependencyGate) {
+		return h.containerLaunchDependencyInitialization(pod)
+	}
+
 	// if ordered flag has been set, then just process ordered logic and skip check for priority
 	if pod.Annotations[appspub.ContainerLaunchPriorityKey] == appspub.ContainerLaunchOrdered {
 		priority := make([]int, len(pod.Spec.Containers))
@@ -51,6 +59,36 @@ func (h *PodCreateHandler) containerLaunchPriorityInitialization(_ context.Conte
 	return false, nil
 }
 
+// start containers after their dependencies satisfied
+func (h *PodCreateHandler) containerLaunchDependencyInitialization(pod *corev1.Pod) (skip bool, err error) {
+	dependencies, err := appspub.GetContainerLaunchDependencies(pod)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %v", appspub.ContainerLaunchDependenciesKey, err)
+	}
+	if _, priorityFlag, _ := h.getPriority(pod); priorityFlag || pod.Annotations[appspub.ContainerLaunchPriorityKey] != "" {
+		return false, fmt.Errorf("%s can not be used with container launch priority", appspub.ContainerLaunchDependenciesKey)
+	}
+	if err := utilcontainerlaunchpriority.ValidateDependencies(dependencies, pod); err != nil {
+		return false, fmt.Errorf("invalid %s: %v", appspub.ContainerLaunchDependenciesKey, err)
+	}
+
+	// Generate name for pods that only have generateName field
+	if len(pod.Name) == 0 && len(pod.GenerateName) > 0 {
+		pod.Name = storagenames.SimpleNameGenerator.GenerateName(pod.GenerateName)
+	}
+	skip = true
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if len(dependencies[c.Name]) == 0 {
+			continue
+		}
+		c.Env = append(c.Env, utilcontainerlaunchpriority.GenerateDependencyEnv(c.Name, pod.Name))
+		skip = false
+	}
+	klog.V(3).InfoS("Injected container launch dependencies for Pod", "namespace", pod.Namespace, "name", pod.Name)
+	return skip, nil
+}
+
 // the return []int is priority for each container in the pod, ordered as container
 // order list in pod spec.
 // the priorityFlag indicates whether this pod needs to launch containers with priority.
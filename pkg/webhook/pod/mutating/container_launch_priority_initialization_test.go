@@ -28,8 +28,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	appspub "github.com/openkruise/kruise/apis/apps/pub"
+	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilcontainerlaunchpriority "github.com/openkruise/kruise/pkg/util/containerlaunchpriority"
+	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 )
 
 func TestContainerLaunchPriorityInitialization(t *testing.T) {
@@ -99,3 +101,99 @@ func TestContainerLaunchPriorityInitialization(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerLaunchDependencyInitialization(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, features.ContainerLaunchDependencyGate, true)()
+
+	cases := []struct {
+		name               string
+		pod                *corev1.Pod
+		expectedErr        bool
+		expectedSkip       bool
+		expectedContainers []corev1.Container
+	}{
+		{
+			name: "inject dependency env",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "fake",
+					Annotations: map[string]string{appspub.ContainerLaunchDependenciesKey: `{"b":[{"container":"a"}],"c":[{"tcpSocket":{"port":8080}}]}`},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a"},
+						{Name: "b"},
+						{Name: "c"},
+					},
+				},
+			},
+			expectedSkip: false,
+			expectedContainers: []corev1.Container{
+				{Name: "a"},
+				{Name: "b", Env: []corev1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("b", "fake")}},
+				{Name: "c", Env: []corev1.EnvVar{utilcontainerlaunchpriority.GenerateDependencyEnv("c", "fake")}},
+			},
+		},
+		{
+			name: "cyclic dependencies",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "fake",
+					Annotations: map[string]string{appspub.ContainerLaunchDependenciesKey: `{"a":[{"container":"b"}],"b":[{"container":"a"}]}`},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a"},
+						{Name: "b"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "used with launch priority",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "fake",
+					Annotations: map[string]string{
+						appspub.ContainerLaunchDependenciesKey: `{"b":[{"container":"a"}]}`,
+						appspub.ContainerLaunchPriorityKey:     appspub.ContainerLaunchOrdered,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "a"},
+						{Name: "b"},
+					},
+				},
+			},
+			expectedErr: true,
+		},
+	}
+
+	h := &PodCreateHandler{}
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Create,
+		Resource:  metav1.GroupVersionResource{Resource: "pods", Version: "v1"},
+	}}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			skip, err := h.containerLaunchPriorityInitialization(context.TODO(), req, tc.pod)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if skip != tc.expectedSkip {
+				t.Fatalf("expected skip %v, got %v", tc.expectedSkip, skip)
+			}
+			if !reflect.DeepEqual(tc.expectedContainers, tc.pod.Spec.Containers) {
+				t.Fatalf("expected containers\n%v\ngot\n%v", util.DumpJSON(tc.expectedContainers), util.DumpJSON(tc.pod.Spec.Containers))
+			}
+		})
+	}
+}
@@ -12,13 +12,9 @@ import (
 
 	alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/enhancedlivenessprobe"
 )
 
-type containerLivenessProbe struct {
-	Name          string   `json:"name"`
-	LivenessProbe v1.Probe `json:"livenessProbe"`
-}
-
 func (h *PodCreateHandler) enhancedLivenessProbeWhenPodCreate(ctx context.Context, req admission.Request, pod *v1.Pod) (skip bool, err error) {
 
 	if len(req.AdmissionRequest.SubResource) > 0 ||
@@ -51,13 +47,13 @@ func (h *PodCreateHandler) enhancedLivenessProbeWhenPodCreate(ctx context.Contex
 // 1. the json string of the pod containers native livenessProbe configurations.
 // 2. the error reason of the function.
 func removeAndBackUpPodContainerLivenessProbe(pod *v1.Pod) (string, error) {
-	containersLivenessProbe := []containerLivenessProbe{}
+	containersLivenessProbe := []enhancedlivenessprobe.ContainerLivenessProbe{}
 	for index := range pod.Spec.Containers {
 		getContainer := &pod.Spec.Containers[index]
 		if getContainer.LivenessProbe == nil {
 			continue
 		}
-		containersLivenessProbe = append(containersLivenessProbe, containerLivenessProbe{
+		containersLivenessProbe = append(containersLivenessProbe, enhancedlivenessprobe.ContainerLivenessProbe{
 			Name:          getContainer.Name,
 			LivenessProbe: *getContainer.LivenessProbe,
 		})
@@ -84,5 +80,5 @@ func removeAndBackUpPodContainerLivenessProbe(pod *v1.Pod) (string, error) {
 // return one parameter:
 // 1. the native container livenessprobe is enabled when the alpha1.AnnotationUsingEnhancedLiveness is true.
 func usingEnhancedLivenessProbe(pod *v1.Pod) bool {
-	return pod.Annotations[alpha1.AnnotationUsingEnhancedLiveness] == "true"
+	return enhancedlivenessprobe.UsingEnhancedLivenessProbe(pod)
 }
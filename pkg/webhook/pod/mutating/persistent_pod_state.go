@@ -18,6 +18,8 @@ package mutating
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -63,15 +65,32 @@ func (h *PodCreateHandler) persistentPodStateMutatingPod(ctx context.Context, re
 	}
 
 	// when data is NotFound, indicates that the pod is created for the first time and the scenario does not require persistent pod state
-	podState, ok := persistentPodState.Status.PodStates[pod.Name]
-	if !ok || len(podState.NodeTopologyLabels) == 0 {
+	podStateKey := GetPodStateKey(persistentPodState.Spec, pod)
+	if podStateKey == "" {
 		return true, nil
 	}
+	podState, ok := persistentPodState.Status.PodStates[podStateKey]
+	if !ok {
+		return true, nil
+	}
+
+	// restore PersistentPodState fields in pod, before node affinity is injected
+	restored := restorePersistentPodFields(persistentPodState.Spec, podState, pod)
+	if restored {
+		klog.V(3).InfoS("restore fields in pod for PersistentPodState", "fields", util.DumpJSON(podState.Fields), "namespace", pod.Namespace, "name", pod.Name)
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[InjectedPersistentPodStateKey] = persistentPodState.Name
+	}
 
+	if len(podState.NodeTopologyLabels) == 0 {
+		return !restored, nil
+	}
 	// inject PersistentPodState node affinity in pod
 	nodeSelector, preference := createNodeAffinity(persistentPodState.Spec, podState)
 	if len(nodeSelector) == 0 && len(preference) == 0 {
-		return true, nil
+		return !restored, nil
 	}
 
 	klog.V(3).InfoS("inject node affinity in pod for PersistentPodState",
@@ -108,6 +127,54 @@ func (h *PodCreateHandler) persistentPodStateMutatingPod(ctx context.Context, re
 	return false, nil
 }
 
+// restorePersistentPodFields writes the saved fields of podState back into pod, and returns whether pod is changed.
+// The fields can not be restored are skipped, e.g. the array element does not exist in the new pod.
+func restorePersistentPodFields(spec appsv1beta1.PersistentPodStateSpec, podState appsv1beta1.PodState, pod *corev1.Pod) bool {
+	if len(spec.PersistentPodFields) == 0 || len(podState.Fields) == 0 {
+		return false
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(util.DumpJSON(pod)), &obj); err != nil {
+		klog.ErrorS(err, "Failed to decode pod", "namespace", pod.Namespace, "name", pod.Name)
+		return false
+	}
+	var changed bool
+	for _, field := range spec.PersistentPodFields {
+		saved, ok := podState.Fields[field.Path]
+		if !ok {
+			continue
+		}
+		restorePath := field.RestorePath
+		if restorePath == "" {
+			restorePath = field.Path
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(saved), &value); err != nil {
+			klog.ErrorS(err, "Failed to decode persistent pod field", "path", field.Path, "namespace", pod.Namespace, "name", pod.Name)
+			continue
+		}
+		tokens, err := util.ParseJSONPointer(restorePath)
+		if err == nil {
+			err = util.SetJSONPointerValue(obj, tokens, value)
+		}
+		if err != nil {
+			klog.ErrorS(err, "Failed to restore persistent pod field", "path", restorePath, "namespace", pod.Namespace, "name", pod.Name)
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return false
+	}
+	newPod := &corev1.Pod{}
+	if err := json.Unmarshal([]byte(util.DumpJSON(obj)), newPod); err != nil {
+		klog.ErrorS(err, "Failed to restore persistent pod fields", "namespace", pod.Namespace, "name", pod.Name)
+		return false
+	}
+	*pod = *newPod
+	return true
+}
+
 // return two parameters:
 // 1. required nodeSelector
 // 2. preferred []PreferredSchedulingTerm
@@ -145,6 +212,27 @@ func createNodeAffinity(spec appsv1beta1.PersistentPodStateSpec, podState appsv1
 	return nodeSelector, preferences
 }
 
+// GetPodIdentityLabel returns the key of the label which identifies the pods of PersistentPodState across recreation,
+// empty means the pods are identified by their names.
+func GetPodIdentityLabel(spec appsv1beta1.PersistentPodStateSpec) string {
+	if spec.PodIdentityLabel != "" {
+		return spec.PodIdentityLabel
+	}
+	if strings.HasPrefix(spec.TargetReference.APIVersion, appsv1beta1.GroupVersion.Group+"/") && spec.TargetReference.Kind == "CloneSet" {
+		return appsv1beta1.CloneSetInstanceID
+	}
+	return ""
+}
+
+// GetPodStateKey returns the key of pod in PersistentPodState status.podStates,
+// empty means the pod has no identity label and can not be persisted.
+func GetPodStateKey(spec appsv1beta1.PersistentPodStateSpec, pod *corev1.Pod) string {
+	if label := GetPodIdentityLabel(spec); label != "" {
+		return pod.Labels[label]
+	}
+	return pod.Name
+}
+
 func SelectorPersistentPodState(reader client.Reader, ref appsv1beta1.TargetReference, ns string) *appsv1beta1.PersistentPodState {
 	ppsList := &appsv1beta1.PersistentPodStateList{}
 	if err := reader.List(context.TODO(), ppsList, &client.ListOptions{Namespace: ns}, utilclient.DisableDeepCopy); err != nil {
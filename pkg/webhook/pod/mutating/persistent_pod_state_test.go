@@ -199,6 +199,86 @@ func TestPersistentPodStateMutatingPod(t *testing.T) {
 				return demo
 			},
 		},
+		{
+			name: "matched PersistentPodState, and restore persistent fields",
+			getPod: func() *corev1.Pod {
+				demo := podDemo.DeepCopy()
+				demo.Labels["app"] = "nginx"
+				return demo
+			},
+			getPodState: func() *appsv1beta1.PersistentPodState {
+				pps := ppsDemo.DeepCopy()
+				pps.Spec.RequiredPersistentTopology = nil
+				pps.Spec.PreferredPersistentTopology = nil
+				pps.Spec.PersistentPodFields = []appsv1beta1.PersistentPodField{
+					{Path: "/metadata/labels/version"},
+					{Path: "/status/podIP", RestorePath: "/metadata/annotations/kruise.io~1static-ip"},
+					{Path: "/spec/containers/0/env"},
+					{Path: "/spec/containers/1/env"},
+				}
+				pps.Status.PodStates = map[string]appsv1beta1.PodState{
+					"test-pod": {
+						NodeName: "node-1",
+						Fields: map[string]string{
+							"/metadata/labels/version": `"v2"`,
+							"/status/podIP":            `"10.0.0.1"`,
+							"/spec/containers/0/env":   `[{"name":"VPA","value":"on"}]`,
+							"/spec/containers/1/env":   `[{"name":"VPA","value":"on"}]`,
+						},
+					},
+				}
+				return pps
+			},
+			exceptPod: func() *corev1.Pod {
+				demo := podDemo.DeepCopy()
+				demo.Labels["app"] = "nginx"
+				demo.Labels["version"] = "v2"
+				demo.Annotations["kruise.io/static-ip"] = "10.0.0.1"
+				demo.Annotations[InjectedPersistentPodStateKey] = ppsDemo.Name
+				demo.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "VPA", Value: "on"}}
+				return demo
+			},
+		},
+		{
+			name: "matched CloneSet PersistentPodState by instance id",
+			getPod: func() *corev1.Pod {
+				demo := podDemo.DeepCopy()
+				demo.Name = "test-cloneset-x7k2p"
+				demo.OwnerReferences[0].APIVersion = "apps.kruise.io/v1alpha1"
+				demo.OwnerReferences[0].Kind = "CloneSet"
+				demo.OwnerReferences[0].Name = "test-cloneset"
+				demo.Labels[appsv1beta1.CloneSetInstanceID] = "abcde"
+				demo.Spec.Affinity = nil
+				return demo
+			},
+			getPodState: func() *appsv1beta1.PersistentPodState {
+				pps := ppsDemo.DeepCopy()
+				pps.Spec.TargetReference = appsv1beta1.TargetReference{
+					APIVersion: "apps.kruise.io/v1alpha1",
+					Kind:       "CloneSet",
+					Name:       "test-cloneset",
+				}
+				pps.Spec.PreferredPersistentTopology = nil
+				pps.Status.PodStates = map[string]appsv1beta1.PodState{
+					"abcde": pps.Status.PodStates["test-pod"],
+				}
+				return pps
+			},
+			exceptPod: func() *corev1.Pod {
+				demo := podDemo.DeepCopy()
+				demo.Name = "test-cloneset-x7k2p"
+				demo.OwnerReferences[0].APIVersion = "apps.kruise.io/v1alpha1"
+				demo.OwnerReferences[0].Kind = "CloneSet"
+				demo.OwnerReferences[0].Name = "test-cloneset"
+				demo.Labels[appsv1beta1.CloneSetInstanceID] = "abcde"
+				demo.Spec.Affinity = nil
+				demo.Annotations[InjectedPersistentPodStateKey] = ppsDemo.Name
+				demo.Spec.NodeSelector = map[string]string{
+					RequiredPodStateNodeAffinityAZLabels: "cn-beijing-a",
+				}
+				return demo
+			},
+		},
 		{
 			name: "no matched PersistentPodState",
 			getPod: func() *corev1.Pod {
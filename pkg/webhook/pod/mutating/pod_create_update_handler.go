@@ -109,7 +109,9 @@ func (h *PodCreateHandler) Handle(ctx context.Context, req admission.Request) ad
 		}
 	}
 
-	if utilfeature.DefaultFeatureGate.Enabled(features.EnablePodProbeMarkerOnServerless) {
+	// the probes are run by kruise-manager instead of the serverless providers
+	if utilfeature.DefaultFeatureGate.Enabled(features.EnablePodProbeMarkerOnServerless) &&
+		!utilfeature.DefaultFeatureGate.Enabled(features.PodProbeMarkerServerlessProber) {
 		if skip, err := h.podProbeMarkerMutatingPod(ctx, req, obj); err != nil {
 			return admission.Errored(http.StatusInternalServerError, err)
 		} else if !skip {
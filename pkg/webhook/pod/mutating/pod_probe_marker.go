@@ -69,6 +69,8 @@ func (h *PodCreateHandler) podProbeMarkerMutatingPod(ctx context.Context, req ad
 			}
 			// No need to pass in marker related fields
 			probe.MarkerPolicy = nil
+			probe.DerivedStates = nil
+			probe.ScoreMarker = nil
 			matchedProbes = append(matchedProbes, probe)
 			matchedProbeKey.Insert(key)
 			matchedConditions.Insert(probe.PodConditionType)
@@ -145,8 +145,8 @@ func validatePodProbeMarkerSpec(obj *appsv1alpha1.PodProbeMarker, fldPath *field
 		}
 		uniqueProbe.Insert(probe.Name)
 		allErrs = append(allErrs, validateProbe(&probe.Probe, fldPath.Child("probe"))...)
-		if probe.PodConditionType == "" && len(probe.MarkerPolicy) == 0 {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("probes"), probe, "podConditionType and markerPolicy cannot be empty at the same time"))
+		if probe.PodConditionType == "" && len(probe.MarkerPolicy) == 0 && probe.ScoreMarker == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("probes"), probe, "podConditionType, markerPolicy and scoreMarker cannot be empty at the same time"))
 			return allErrs
 		}
 		if probe.PodConditionType != "" && uniqueConditionType.Has(probe.PodConditionType) {
@@ -156,6 +156,15 @@ func validatePodProbeMarkerSpec(obj *appsv1alpha1.PodProbeMarker, fldPath *field
 			uniqueConditionType.Insert(probe.PodConditionType)
 			allErrs = append(allErrs, metavalidation.ValidateLabelName(probe.PodConditionType, fldPath)...)
 		}
+		derivedStates := sets.NewString()
+		for _, derived := range probe.DerivedStates {
+			if derivedStates.Has(string(derived.Name)) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("derivedStates"), derived.Name, "derived state name must be unique."))
+				return allErrs
+			}
+			derivedStates.Insert(string(derived.Name))
+			allErrs = append(allErrs, validateProbeDerivedState(&derived, fldPath.Child("derivedStates"))...)
+		}
 		uniquePolicy := sets.NewString()
 		for _, policy := range probe.MarkerPolicy {
 			if uniquePolicy.Has(string(policy.State)) {
@@ -163,7 +172,10 @@ func validatePodProbeMarkerSpec(obj *appsv1alpha1.PodProbeMarker, fldPath *field
 				return allErrs
 			}
 			uniquePolicy.Insert(string(policy.State))
-			allErrs = append(allErrs, validateProbeMarkerPolicy(&policy, fldPath.Child("markerPolicy"))...)
+			allErrs = append(allErrs, validateProbeMarkerPolicy(&policy, derivedStates, fldPath.Child("markerPolicy"))...)
+		}
+		if probe.ScoreMarker != nil {
+			allErrs = append(allErrs, validateProbeScoreMarker(probe.ScoreMarker, fldPath.Child("scoreMarker"))...)
 		}
 	}
 
@@ -190,6 +202,9 @@ func validateProbe(probe *appsv1alpha1.ContainerProbeSpec, fldPath *field.Path)
 		return allErrs
 	}
 	allErrs = append(allErrs, validateHandler(&probe.ProbeHandler, fldPath)...)
+	if probe.GRPCTLS != nil && probe.GRPC == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("grpcTLS"), "grpcTLS is only allowed with grpc handler"))
+	}
 	allErrs = append(allErrs, validation.ValidateNonnegativeField(int64(probe.InitialDelaySeconds), fldPath.Child("initialDelaySeconds"))...)
 	allErrs = append(allErrs, validation.ValidateNonnegativeField(int64(probe.TimeoutSeconds), fldPath.Child("timeoutSeconds"))...)
 	allErrs = append(allErrs, validation.ValidateNonnegativeField(int64(probe.PeriodSeconds), fldPath.Child("periodSeconds"))...)
@@ -225,6 +240,14 @@ func validateHandler(handler *corev1.ProbeHandler, fldPath *field.Path) field.Er
 			allErrors = append(allErrors, validateTCPSocketAction(handler.TCPSocket, fldPath.Child("tcpSocket"))...)
 		}
 	}
+	if handler.GRPC != nil {
+		if numHandlers > 0 {
+			allErrors = append(allErrors, field.Forbidden(fldPath.Child("grpc"), "may not specify more than 1 handler type"))
+		} else {
+			numHandlers++
+			allErrors = append(allErrors, validateGRPCAction(handler.GRPC, fldPath.Child("grpc"))...)
+		}
+	}
 
 	if numHandlers == 0 {
 		allErrors = append(allErrors, field.Required(fldPath, "must specify a handler type"))
@@ -251,6 +274,14 @@ func validateTCPSocketAction(tcp *corev1.TCPSocketAction, fldPath *field.Path) f
 	return allErrors
 }
 
+func validateGRPCAction(grpc *corev1.GRPCAction, fldPath *field.Path) field.ErrorList {
+	allErrors := field.ErrorList{}
+	for _, msg := range validationutil.IsValidPortNum(int(grpc.Port)) {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("port"), grpc.Port, msg))
+	}
+	return allErrors
+}
+
 var supportedHTTPSchemes = sets.New(corev1.URISchemeHTTP, corev1.URISchemeHTTPS)
 
 func validateHTTPGetAction(http *corev1.HTTPGetAction, fldPath *field.Path) field.ErrorList {
@@ -293,12 +324,18 @@ func ValidatePortNumOrName(port intstr.IntOrString, fldPath *field.Path) field.E
 	return allErrs
 }
 
-func validateProbeMarkerPolicy(policy *appsv1alpha1.ProbeMarkerPolicy, fldPath *field.Path) field.ErrorList {
+func validateProbeMarkerPolicy(policy *appsv1alpha1.ProbeMarkerPolicy, derivedStates sets.String, fldPath *field.Path) field.ErrorList {
 	allErrors := field.ErrorList{}
-	if policy.State != appsv1alpha1.ProbeSucceeded && policy.State != appsv1alpha1.ProbeFailed {
-		allErrors = append(allErrors, field.Required(fldPath.Child("state"), "marker policy state must be 'True' or 'False'"))
+	isDerived := derivedStates.Has(string(policy.State))
+	if policy.State != appsv1alpha1.ProbeSucceeded && policy.State != appsv1alpha1.ProbeFailed && !isDerived {
+		allErrors = append(allErrors, field.Required(fldPath.Child("state"), "marker policy state must be 'Succeeded', 'Failed' or a derived state"))
 		return allErrors
 	}
+	if policy.MinStableSeconds < 0 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("minStableSeconds"), policy.MinStableSeconds, "must be non-negative"))
+	} else if policy.MinStableSeconds > 0 && isDerived {
+		allErrors = append(allErrors, field.Forbidden(fldPath.Child("minStableSeconds"), "minStableSeconds is not supported for the derived state"))
+	}
 	if len(policy.Labels) == 0 && len(policy.Annotations) == 0 {
 		allErrors = append(allErrors, field.Required(fldPath, "marker policy annotations or labels can't be both empty"))
 		return allErrors
@@ -309,6 +346,41 @@ func validateProbeMarkerPolicy(policy *appsv1alpha1.ProbeMarkerPolicy, fldPath *
 	return allErrors
 }
 
+func validateProbeDerivedState(derived *appsv1alpha1.ProbeDerivedState, fldPath *field.Path) field.ErrorList {
+	allErrors := field.ErrorList{}
+	switch derived.Name {
+	case appsv1alpha1.ProbeSucceeded, appsv1alpha1.ProbeFailed, appsv1alpha1.ProbeUnknown:
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("name"), derived.Name, "derived state name can't be Succeeded, Failed or Unknown"))
+	case "":
+		allErrors = append(allErrors, field.Required(fldPath.Child("name"), ""))
+	}
+	if derived.FailureThreshold < 1 || derived.FailureThreshold > appsv1alpha1.MaxRecentProbeResults {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("failureThreshold"), derived.FailureThreshold,
+			fmt.Sprintf("must be between 1 and %d", appsv1alpha1.MaxRecentProbeResults)))
+	}
+	if derived.WindowSeconds < 1 {
+		allErrors = append(allErrors, field.Invalid(fldPath.Child("windowSeconds"), derived.WindowSeconds, "must be positive"))
+	}
+	return allErrors
+}
+
+func validateProbeScoreMarker(marker *appsv1alpha1.ProbeScoreMarker, fldPath *field.Path) field.ErrorList {
+	allErrors := field.ErrorList{}
+	if len(marker.Labels) == 0 && len(marker.Annotations) == 0 {
+		allErrors = append(allErrors, field.Required(fldPath, "score marker annotations or labels can't be both empty"))
+		return allErrors
+	}
+	for i, key := range marker.Labels {
+		allErrors = append(allErrors, metavalidation.ValidateLabelName(key, fldPath.Child("labels").Index(i))...)
+	}
+	for i, key := range marker.Annotations {
+		for _, msg := range validationutil.IsQualifiedName(strings.ToLower(key)) {
+			allErrors = append(allErrors, field.Invalid(fldPath.Child("annotations").Index(i), key, msg))
+		}
+	}
+	return allErrors
+}
+
 func validatePodProbeMarkerName(name string, prefix bool) (allErrs []string) {
 	if !validateNameRegex.MatchString(name) {
 		allErrs = append(allErrs, validationutil.RegexError(validateNameMsg, validNameFmt, "example-com"))
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
@@ -243,6 +244,95 @@ func TestValidatingPodProbeMarker(t *testing.T) {
 			},
 			expectErrList: 1,
 		},
+		{
+			name: "test12, invalid ppm",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].Probe.GRPCTLS = &appsv1alpha1.GRPCProbeTLS{ServerName: "app.example.com"}
+				return ppm
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "test13, valid ppm with grpc probe",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].Probe.ProbeHandler = corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: 9090}}
+				ppm.Spec.Probes[0].Probe.GRPCTLS = &appsv1alpha1.GRPCProbeTLS{ServerName: "app.example.com"}
+				return ppm
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "test14, valid ppm with derived state, hysteresis and score marker",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].DerivedStates = []appsv1alpha1.ProbeDerivedState{{Name: "Degraded", FailureThreshold: 3, WindowSeconds: 60}}
+				ppm.Spec.Probes[0].MarkerPolicy[0].MinStableSeconds = 30
+				ppm.Spec.Probes[0].MarkerPolicy = append(ppm.Spec.Probes[0].MarkerPolicy, appsv1alpha1.ProbeMarkerPolicy{
+					State:  "Degraded",
+					Labels: map[string]string{"server-healthy": "degraded"},
+				})
+				ppm.Spec.Probes[0].ScoreMarker = &appsv1alpha1.ProbeScoreMarker{Labels: []string{"weight"}}
+				return ppm
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "test15, invalid ppm with undefined derived state",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].MarkerPolicy[1].State = "Degraded"
+				return ppm
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "test16, invalid ppm with invalid derived states",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].DerivedStates = []appsv1alpha1.ProbeDerivedState{
+					{Name: appsv1alpha1.ProbeFailed, FailureThreshold: 3, WindowSeconds: 60},
+					{Name: "Degraded", FailureThreshold: appsv1alpha1.MaxRecentProbeResults + 1, WindowSeconds: 0},
+				}
+				return ppm
+			},
+			expectErrList: 3,
+		},
+		{
+			name: "test17, invalid ppm with minStableSeconds of derived state",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].DerivedStates = []appsv1alpha1.ProbeDerivedState{{Name: "Degraded", FailureThreshold: 3, WindowSeconds: 60}}
+				ppm.Spec.Probes[0].MarkerPolicy = append(ppm.Spec.Probes[0].MarkerPolicy, appsv1alpha1.ProbeMarkerPolicy{
+					State:            "Degraded",
+					Labels:           map[string]string{"server-healthy": "degraded"},
+					MinStableSeconds: 10,
+				})
+				return ppm
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "test18, valid ppm with only score marker",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].PodConditionType = ""
+				ppm.Spec.Probes[0].MarkerPolicy = nil
+				ppm.Spec.Probes[0].ScoreMarker = &appsv1alpha1.ProbeScoreMarker{Annotations: []string{"controller.kubernetes.io/pod-deletion-cost"}}
+				return ppm
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "test19, invalid ppm with empty score marker",
+			getPpm: func() *appsv1alpha1.PodProbeMarker {
+				ppm := ppmDemo.DeepCopy()
+				ppm.Spec.Probes[0].ScoreMarker = &appsv1alpha1.ProbeScoreMarker{}
+				return ppm
+			},
+			expectErrList: 1,
+		},
 	}
 
 	decoder := admission.NewDecoder(scheme)
@@ -268,6 +358,8 @@ func TestValidateHandler(t *testing.T) {
 		{TCPSocket: &corev1.TCPSocketAction{
 			Port: intstr.IntOrString{Type: intstr.String, StrVal: "container-port"},
 		}},
+		{GRPC: &corev1.GRPCAction{Port: 9090}},
+		{GRPC: &corev1.GRPCAction{Port: 9090, Service: ptr.To("health")}},
 	}
 	for _, h := range successCases {
 		if errs := validateHandler(&h, field.NewPath("field")); len(errs) != 0 {
@@ -287,6 +379,11 @@ func TestValidateHandler(t *testing.T) {
 		{HTTPGet: &corev1.HTTPGetAction{Path: "", Port: intstr.FromInt(0), Host: ""}},
 		{HTTPGet: &corev1.HTTPGetAction{Path: "/foo", Port: intstr.FromInt(65536), Host: "host"}},
 		{HTTPGet: &corev1.HTTPGetAction{Path: "", Port: intstr.FromString(""), Host: ""}},
+		{GRPC: &corev1.GRPCAction{Port: 0}},
+		{
+			Exec: &corev1.ExecAction{Command: []string{"echo"}},
+			GRPC: &corev1.GRPCAction{Port: 9090},
+		},
 		{
 			Exec: &corev1.ExecAction{Command: []string{}},
 			TCPSocket: &corev1.TCPSocketAction{
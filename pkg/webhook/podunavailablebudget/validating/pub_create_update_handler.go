@@ -22,11 +22,15 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	appsvalidation "k8s.io/kubernetes/pkg/apis/apps/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +38,7 @@ import (
 
 	policyv1alpha1 "github.com/openkruise/kruise/apis/policy/v1alpha1"
 	policyv1beta1 "github.com/openkruise/kruise/apis/policy/v1beta1"
+	"github.com/openkruise/kruise/pkg/control/pubcontrol"
 	"github.com/openkruise/kruise/pkg/features"
 	"github.com/openkruise/kruise/pkg/util"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
@@ -148,6 +153,9 @@ func validateUpdatePubConflictV1beta1(obj, old *policyv1beta1.PodUnavailableBudg
 	if !reflect.DeepEqual(obj.Spec.Selector, old.Spec.Selector) || !reflect.DeepEqual(obj.Spec.TargetReference, old.Spec.TargetReference) {
 		allErrs = append(allErrs, field.Required(fldPath.Child("selector, targetRef"), "selector and targetRef cannot be modified"))
 	}
+	if (len(obj.Spec.ChildBudgets) == 0) != (len(old.Spec.ChildBudgets) == 0) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("childBudgets"), "a PodUnavailableBudget cannot be changed between grouping and non-grouping"))
+	}
 	return allErrs
 }
 
@@ -155,7 +163,24 @@ func validatePodUnavailableBudgetSpecV1beta1(obj *policyv1beta1.PodUnavailableBu
 	spec := &obj.Spec
 	allErrs := field.ErrorList{}
 
-	if spec.Selector == nil && spec.TargetReference == nil {
+	if len(spec.ChildBudgets) > 0 {
+		if spec.Selector != nil || spec.TargetReference != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("childBudgets"), "childBudgets is mutually exclusive with selector and targetRef"))
+		}
+		names := sets.NewString()
+		for i, name := range spec.ChildBudgets {
+			if name == obj.Name {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("childBudgets").Index(i), name, "PodUnavailableBudget cannot be its own child budget"))
+			} else if names.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("childBudgets").Index(i), name))
+			} else {
+				for _, msg := range validation.IsDNS1123Subdomain(name) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("childBudgets").Index(i), name, msg))
+				}
+			}
+			names.Insert(name)
+		}
+	} else if spec.Selector == nil && spec.TargetReference == nil {
 		allErrs = append(allErrs, field.Required(fldPath.Child("selector, targetRef"), "no selector or targetRef defined in PodUnavailableBudget"))
 	} else if spec.Selector != nil && spec.TargetReference != nil {
 		allErrs = append(allErrs, field.Required(fldPath.Child("selector, targetRef"), "selector and targetRef are mutually exclusive"))
@@ -202,6 +227,23 @@ func validatePodUnavailableBudgetSpecV1beta1(obj *policyv1beta1.PodUnavailableBu
 		}
 	}
 
+	allErrs = append(allErrs, validatePubSchedulesV1beta1(spec.Schedules, fldPath.Child("schedules"))...)
+	if queue := spec.DisruptionQueue; queue != nil {
+		queuePath := fldPath.Child("disruptionQueue")
+		if queue.Policy != "" && queue.Policy != policyv1beta1.PubDisruptionQueueFIFO && queue.Policy != policyv1beta1.PubDisruptionQueuePriority {
+			allErrs = append(allErrs, field.NotSupported(queuePath.Child("policy"), queue.Policy, []string{
+				string(policyv1beta1.PubDisruptionQueueFIFO),
+				string(policyv1beta1.PubDisruptionQueuePriority),
+			}))
+		}
+		if queue.RequestTimeoutSeconds != nil && *queue.RequestTimeoutSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(queuePath.Child("requestTimeoutSeconds"), *queue.RequestTimeoutSeconds, "must be greater than 0"))
+		}
+		if queue.GrantTimeoutSeconds != nil && *queue.GrantTimeoutSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(queuePath.Child("grantTimeoutSeconds"), *queue.GrantTimeoutSeconds, "must be greater than 0"))
+		}
+	}
+
 	if spec.IgnoredPodSelector != nil {
 		allErrs = append(allErrs, metavalidation.ValidateLabelSelector(spec.IgnoredPodSelector, metavalidation.LabelSelectorValidationOptions{}, fldPath.Child("ignoredPodSelector"))...)
 		if len(spec.IgnoredPodSelector.MatchLabels)+len(spec.IgnoredPodSelector.MatchExpressions) == 0 {
@@ -211,6 +253,52 @@ func validatePodUnavailableBudgetSpecV1beta1(obj *policyv1beta1.PodUnavailableBu
 	return allErrs
 }
 
+func validatePubSchedulesV1beta1(schedules []policyv1beta1.PubScheduleWindow, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i := range schedules {
+		window := &schedules[i]
+		idxPath := fldPath.Index(i)
+		if window.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "name of schedule window is required"))
+		} else if names.Has(window.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), window.Name))
+		}
+		names.Insert(window.Name)
+
+		if window.TimeZone != nil {
+			if strings.Contains(window.Schedule, "TZ") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), window.Schedule, "cannot use both timeZone field and TZ or CRON_TZ in schedule"))
+			} else if _, err := time.LoadLocation(*window.TimeZone); err != nil || *window.TimeZone == "" || strings.EqualFold(*window.TimeZone, "Local") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("timeZone"), *window.TimeZone, "timeZone must be an explicit time zone as defined in https://www.iana.org/time-zones"))
+			}
+		}
+		if _, err := pubcontrol.ParseScheduleWindow(window); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), window.Schedule, err.Error()))
+		}
+		if window.DurationSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("durationSeconds"), window.DurationSeconds, "must be greater than 0"))
+		}
+
+		if window.Freeze {
+			if window.MaxUnavailable != nil || window.MinAvailable != nil {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("freeze"), "maxUnavailable and minAvailable are not allowed in freeze window"))
+			}
+		} else if window.MaxUnavailable == nil && window.MinAvailable == nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("maxUnavailable, minAvailable"), "no maxUnavailable, minAvailable or freeze defined in schedule window"))
+		} else if window.MaxUnavailable != nil && window.MinAvailable != nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("maxUnavailable, minAvailable"), "maxUnavailable and minAvailable are mutually exclusive"))
+		} else if window.MaxUnavailable != nil {
+			allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*window.MaxUnavailable, idxPath.Child("maxUnavailable"))...)
+			allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*window.MaxUnavailable, idxPath.Child("maxUnavailable"))...)
+		} else {
+			allErrs = append(allErrs, appsvalidation.ValidatePositiveIntOrPercent(*window.MinAvailable, idxPath.Child("minAvailable"))...)
+			allErrs = append(allErrs, appsvalidation.IsNotMoreThan100Percent(*window.MinAvailable, idxPath.Child("minAvailable"))...)
+		}
+	}
+	return allErrs
+}
+
 func validatePubConflictV1beta1(pub *policyv1beta1.PodUnavailableBudget, others []policyv1beta1.PodUnavailableBudget, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -218,6 +306,12 @@ func validatePubConflictV1beta1(pub *policyv1beta1.PodUnavailableBudget, others
 		if pub.Name == other.Name {
 			continue
 		}
+		if len(pub.Spec.ChildBudgets) > 0 || len(other.Spec.ChildBudgets) > 0 {
+			if errs := validateChildBudgetsConflictV1beta1(pub, &other, fldPath); len(errs) > 0 {
+				return errs
+			}
+			continue
+		}
 		if pub.Spec.TargetReference != nil && other.Spec.TargetReference != nil {
 			curRef := pub.Spec.TargetReference
 			otherRef := other.Spec.TargetReference
@@ -238,3 +332,27 @@ func validatePubConflictV1beta1(pub *policyv1beta1.PodUnavailableBudget, others
 	}
 	return allErrs
 }
+
+// validateChildBudgetsConflictV1beta1 makes sure that a child budget belongs to at most one grouping PUB,
+// and that grouping PUBs are not nested.
+func validateChildBudgetsConflictV1beta1(pub, other *policyv1beta1.PodUnavailableBudget, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	otherChildren := sets.NewString(other.Spec.ChildBudgets...)
+	if len(pub.Spec.ChildBudgets) > 0 && otherChildren.Has(pub.Name) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("childBudgets"), fmt.Sprintf(
+			"PodUnavailableBudget with childBudgets cannot be the child budget of other PodUnavailableBudget %s", other.Name)))
+		return allErrs
+	}
+	for i, name := range pub.Spec.ChildBudgets {
+		if otherChildren.Has(name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("childBudgets").Index(i), name, fmt.Sprintf(
+				"child budget is already grouped by other PodUnavailableBudget %s", other.Name)))
+			return allErrs
+		} else if name == other.Name && len(other.Spec.ChildBudgets) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("childBudgets").Index(i), name,
+				"child budget cannot have childBudgets itself"))
+			return allErrs
+		}
+	}
+	return allErrs
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -220,6 +221,96 @@ func TestValidatingPub(t *testing.T) {
 			},
 			expectErrList: 1,
 		},
+		{
+			name: "valid grouping pub",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.TargetReference = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.ChildBudgets = []string{"pub-a", "pub-b"}
+				return pub
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "invalid grouping pub, childBudgets and selector are mutually exclusive",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.TargetReference = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.ChildBudgets = []string{"pub-a"}
+				return pub
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "invalid grouping pub, duplicated and self child budgets",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.TargetReference = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.ChildBudgets = []string{"pub-a", "pub-a", pubDemo.Name}
+				return pub
+			},
+			expectErrList: 2,
+		},
+		{
+			name: "valid pub schedules",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.Schedules = []policyv1beta1.PubScheduleWindow{
+					{Name: "business-hours", Schedule: "0 9 * * 1-5", TimeZone: ptr.To("Asia/Shanghai"), DurationSeconds: 32400,
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "10%"}},
+					{Name: "release-freeze", Schedule: "0 0 24 12 *", DurationSeconds: 86400, Freeze: true},
+				}
+				return pub
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "invalid pub schedules",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.Schedules = []policyv1beta1.PubScheduleWindow{
+					// invalid schedule and no budget
+					{Name: "a", Schedule: "invalid", DurationSeconds: 60},
+					// duplicated name, invalid duration and budget in freeze window
+					{Name: "a", Schedule: "0 0 * * *", Freeze: true, MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}},
+					// invalid time zone
+					{Name: "b", Schedule: "0 0 * * *", TimeZone: ptr.To("Local"), DurationSeconds: 60, Freeze: true},
+				}
+				return pub
+			},
+			expectErrList: 6,
+		},
+		{
+			name: "valid pub disruptionQueue",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.DisruptionQueue = &policyv1beta1.PubDisruptionQueue{Policy: policyv1beta1.PubDisruptionQueuePriority, GrantTimeoutSeconds: ptr.To[int32](30)}
+				return pub
+			},
+			expectErrList: 0,
+		},
+		{
+			name: "invalid pub disruptionQueue",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.DisruptionQueue = &policyv1beta1.PubDisruptionQueue{Policy: "LIFO", RequestTimeoutSeconds: ptr.To[int32](0)}
+				return pub
+			},
+			expectErrList: 2,
+		},
 	}
 
 	decoder := admission.NewDecoder(scheme)
@@ -347,6 +438,66 @@ func TestPubConflictWithOthers(t *testing.T) {
 			},
 			expectErrList: 0,
 		},
+		{
+			name: "conflict with other grouping pub, child budget already grouped",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.TargetReference = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.ChildBudgets = []string{"pub1", "pub2"}
+				return pub
+			},
+			otherPubs: func() []*policyv1beta1.PodUnavailableBudget {
+				pub1 := pubDemo.DeepCopy()
+				pub1.Name = "group1"
+				pub1.Spec.Selector = nil
+				pub1.Spec.TargetReference = nil
+				pub1.Spec.ChildBudgets = []string{"pub2"}
+				return []*policyv1beta1.PodUnavailableBudget{pub1}
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "conflict with other grouping pub, nested grouping pub",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.TargetReference = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.ChildBudgets = []string{"pub1"}
+				return pub
+			},
+			otherPubs: func() []*policyv1beta1.PodUnavailableBudget {
+				pub1 := pubDemo.DeepCopy()
+				pub1.Name = "group1"
+				pub1.Spec.Selector = nil
+				pub1.Spec.TargetReference = nil
+				pub1.Spec.ChildBudgets = []string{pubDemo.Name}
+				return []*policyv1beta1.PodUnavailableBudget{pub1}
+			},
+			expectErrList: 1,
+		},
+		{
+			name: "no conflict with other grouping pub",
+			pub: func() *policyv1beta1.PodUnavailableBudget {
+				pub := pubDemo.DeepCopy()
+				pub.Spec.Selector = nil
+				pub.Spec.TargetReference = nil
+				pub.Spec.MinAvailable = nil
+				pub.Spec.ChildBudgets = []string{"pub1"}
+				return pub
+			},
+			otherPubs: func() []*policyv1beta1.PodUnavailableBudget {
+				pub1 := pubDemo.DeepCopy()
+				pub1.Name = "group1"
+				pub1.Spec.Selector = nil
+				pub1.Spec.TargetReference = nil
+				pub1.Spec.ChildBudgets = []string{"pub2"}
+				return []*policyv1beta1.PodUnavailableBudget{pub1}
+			},
+			expectErrList: 0,
+		},
 	}
 
 	for _, cs := range cases {
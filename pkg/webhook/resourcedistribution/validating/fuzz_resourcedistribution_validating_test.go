@@ -139,7 +139,7 @@ func FuzzValidateResourceDistributionResource(f *testing.F) {
 			}
 		}
 
-		_ = h.validateResourceDistributionSpecResource(newObj, oldObj, field.NewPath("resource"))
+		_ = h.validateResourceDistributionSpecResource(newObj, oldObj, nil, field.NewPath("resource"))
 	})
 }
 
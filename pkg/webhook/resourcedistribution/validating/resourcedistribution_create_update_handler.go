@@ -20,6 +20,7 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +34,7 @@ import (
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
 	"github.com/openkruise/kruise/pkg/features"
+	"github.com/openkruise/kruise/pkg/util/configuration"
 	utilfeature "github.com/openkruise/kruise/pkg/util/feature"
 	webhookutil "github.com/openkruise/kruise/pkg/webhook/util"
 )
@@ -137,23 +139,42 @@ func (h *ResourceDistributionCreateUpdateHandler) validateResourceDistributionSp
 		allErrs = append(allErrs, errs...)
 	}
 
-	allErrs = append(allErrs, h.validateResourceDistributionSpecResource(resource, oldResource, fldPath.Child("resource"))...)
+	allErrs = append(allErrs, h.validateResourceDistributionSpecResource(resource, oldResource, obj.Spec.Template, fldPath.Child("resource"))...)
 	allErrs = append(allErrs, validateResourceDistributionTargets(obj.Spec.Targets, fldPath.Child("targets"))...)
+	allErrs = append(allErrs, validateResourceDistributionTemplate(obj.Spec.Template, fldPath.Child("template"))...)
 	return allErrs
 }
 
-func (h *ResourceDistributionCreateUpdateHandler) validateResourceDistributionSpecResource(resource, oldResource runtime.Object, fldPath *field.Path) (allErrs field.ErrorList) {
-	if !isSupportedGK(resource) {
-		return append(allErrs, field.Invalid(fldPath, resource.GetObjectKind().GroupVersionKind().GroupKind(), fmt.Sprintf("unknown or unsupported resource GroupKind, only support %v", supportedGKList)))
+func (h *ResourceDistributionCreateUpdateHandler) validateResourceDistributionSpecResource(resource, oldResource runtime.Object, tmpl *appsv1beta1.ResourceDistributionTemplate, fldPath *field.Path) (allErrs field.ErrorList) {
+	whiteList, err := configuration.GetResourceDistributionWhiteList(h.Client)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("failed to get resource distribution white list: %v", err)))
+	}
+	gvk := resource.GetObjectKind().GroupVersionKind()
+	if !isSupportedGK(resource, whiteList) {
+		return append(allErrs, field.Invalid(fldPath, gvk.GroupKind(), fmt.Sprintf("unknown or unsupported resource GroupKind, only support %v and %s in %s",
+			supportedGKList, configuration.RDResourceWhiteList, configuration.KruiseConfigurationName)))
+	}
+	if mapping, err := h.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err == nil && mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return append(allErrs, field.Invalid(fldPath, gvk.GroupKind(), "only namespaced resource can be distributed"))
 	}
 	if oldResource != nil && !haveSameGVKAndName(resource, oldResource) {
 		return append(allErrs, field.Invalid(fldPath, nil, "resource apiVersion, kind, and name are immutable"))
 	}
 
+	// the templated resource differs from namespace to namespace, it is validated when distributed
+	// and the failures are reported in status.
+	if tmpl != nil {
+		if err := validateResourceTemplates(resource); err != nil {
+			return append(allErrs, field.Invalid(fldPath, nil, err.Error()))
+		}
+		return allErrs
+	}
+
 	mice := resource.DeepCopyObject().(client.Object)
 	// spec.resource.metadata.namespace is always overridden by the controller per target namespace.
 	ConvertToUnstructured(mice).SetNamespace(webhookutil.GetNamespace())
-	err := h.Client.Create(context.TODO(), mice, &client.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	err = h.Client.Create(context.TODO(), mice, &client.CreateOptions{DryRun: []string{metav1.DryRunAll}})
 	if err == nil || errors.IsAlreadyExists(err) {
 		return allErrs
 	}
@@ -166,6 +187,24 @@ func (h *ResourceDistributionCreateUpdateHandler) validateResourceDistributionSp
 	return append(allErrs, field.InternalError(fldPath, fmt.Errorf("failed to dry-run validate spec.resource: %v", err)))
 }
 
+func validateResourceDistributionTemplate(tmpl *appsv1beta1.ResourceDistributionTemplate, fldPath *field.Path) (allErrs field.ErrorList) {
+	if tmpl == nil {
+		return allErrs
+	}
+	names := sets.NewString()
+	for i, nsValues := range tmpl.NamespaceValues {
+		idxPath := fldPath.Child("namespaceValues").Index(i)
+		for _, msg := range coreval.ValidateNamespaceName(nsValues.Name, false) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), nsValues.Name, msg))
+		}
+		if names.Has(nsValues.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), nsValues.Name))
+		}
+		names.Insert(nsValues.Name)
+	}
+	return allErrs
+}
+
 func validateResourceDistributionTargets(targets appsv1beta1.ResourceDistributionTargets, fldPath *field.Path) (allErrs field.ErrorList) {
 	conflicted := make([]string, 0)
 	includedNS := sets.NewString()
@@ -18,6 +18,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -27,6 +30,8 @@ import (
 
 	appsv1alpha1 "github.com/openkruise/kruise/apis/apps/v1alpha1"
 	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+	"github.com/openkruise/kruise/pkg/util"
+	"github.com/openkruise/kruise/pkg/util/configuration"
 )
 
 var (
@@ -39,6 +44,8 @@ func init() {
 	utilruntime.Must(appsv1alpha1.AddToScheme(testScheme))
 	utilruntime.Must(appsv1beta1.AddToScheme(testScheme))
 	utilruntime.Must(corev1.AddToScheme(testScheme))
+	utilruntime.Must(networkingv1.AddToScheme(testScheme))
+	utilruntime.Must(rbacv1.AddToScheme(testScheme))
 }
 
 func TestResourceDistributionCreateValidation(t *testing.T) {
@@ -227,6 +234,48 @@ func TestValidateResourceDistributionSpecErrorCases(t *testing.T) {
 	}
 }
 
+func TestValidateResourceDistributionSupportedKinds(t *testing.T) {
+	whiteList := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: util.GetKruiseNamespace(), Name: configuration.KruiseConfigurationName},
+		Data: map[string]string{configuration.RDResourceWhiteList: `{"resources":[` +
+			`{"group":"rbac.authorization.k8s.io","version":"v1","kind":"Role"},` +
+			`{"group":"rbac.authorization.k8s.io","version":"v1","kind":"ClusterRole"}]}`},
+	}
+	networkPolicy := `{"apiVersion":"networking.k8s.io/v1","kind":"NetworkPolicy","metadata":{"name":"deny-all"},"spec":{"podSelector":{}}}`
+	role := `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"Role","metadata":{"name":"reader"},"rules":[]}`
+	clusterRole := `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"reader"},"rules":[]}`
+
+	cases := []struct {
+		name         string
+		resource     string
+		whiteList    bool
+		expectErrors int
+	}{
+		{name: "NetworkPolicy is supported by default", resource: networkPolicy},
+		{name: "Role is not supported by default", resource: role, expectErrors: 1},
+		{name: "Role is supported in white list", resource: role, whiteList: true},
+		{name: "cluster-scoped ClusterRole is not supported in white list", resource: clusterRole, whiteList: true, expectErrors: 1},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			if cs.whiteList {
+				makeEnvironment(whiteList.DeepCopy())
+			} else {
+				makeEnvironment()
+			}
+			mapper := meta.NewDefaultRESTMapper(testScheme.PreferredVersionAllGroups())
+			mapper.Add(rbacv1.SchemeGroupVersion.WithKind("Role"), meta.RESTScopeNamespace)
+			mapper.Add(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), meta.RESTScopeRoot)
+			mapper.Add(networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"), meta.RESTScopeNamespace)
+			handler.Client = &restMapperClient{Client: handler.Client, mapper: mapper}
+			errs := handler.validateResourceDistribution(buildResourceDistribution(cs.resource), nil)
+			if len(errs) != cs.expectErrors {
+				t.Fatalf("expect %d errors, but got %v", cs.expectErrors, errs)
+			}
+		})
+	}
+}
+
 // TestValidateResourceDistributionUpdateSuccessCases tests update-specific paths.
 func TestValidateResourceDistributionUpdateSuccessCases(t *testing.T) {
 	makeEnvironment()
@@ -310,6 +359,57 @@ func TestValidateResourceDistributionUpdateErrorCases(t *testing.T) {
 	}
 }
 
+func TestValidateResourceDistributionTemplate(t *testing.T) {
+	templated := `{"apiVersion":"networking.k8s.io/v1","kind":"NetworkPolicy","metadata":{"name":"allow-tenant"},` +
+		`"spec":{"podSelector":{},"ingress":[{"from":[{"ipBlock":{"cidr":"{{ .Values.cidr }}"}}]}]}}`
+	malformed := `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"},"data":{"owner":"{{ .Namespace.Name "}}`
+
+	cases := []struct {
+		name         string
+		resource     string
+		template     *appsv1beta1.ResourceDistributionTemplate
+		expectErrors int
+	}{
+		{
+			name:     "templated resource is not dry-run validated",
+			resource: templated,
+			template: &appsv1beta1.ResourceDistributionTemplate{
+				Values:          map[string]string{"cidr": "10.0.0.0/8"},
+				NamespaceValues: []appsv1beta1.ResourceDistributionNamespaceValues{{Name: "ns-1", Values: map[string]string{"cidr": "10.1.0.0/16"}}},
+			},
+		},
+		{
+			name:         "malformed template",
+			resource:     malformed,
+			template:     &appsv1beta1.ResourceDistributionTemplate{},
+			expectErrors: 1,
+		},
+		{
+			name:     "invalid and duplicated namespace values",
+			resource: templated,
+			template: &appsv1beta1.ResourceDistributionTemplate{
+				NamespaceValues: []appsv1beta1.ResourceDistributionNamespaceValues{{Name: "ns-1"}, {Name: "ns-1"}, {Name: "NS_2"}},
+			},
+			expectErrors: 2,
+		},
+	}
+	for _, cs := range cases {
+		t.Run(cs.name, func(t *testing.T) {
+			makeEnvironment()
+			mapper := meta.NewDefaultRESTMapper(testScheme.PreferredVersionAllGroups())
+			mapper.Add(networkingv1.SchemeGroupVersion.WithKind("NetworkPolicy"), meta.RESTScopeNamespace)
+			mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+			handler.Client = &restMapperClient{Client: handler.Client, mapper: mapper}
+			rd := buildResourceDistribution(cs.resource)
+			rd.Spec.Template = cs.template
+			errs := handler.validateResourceDistribution(rd, nil)
+			if len(errs) != cs.expectErrors {
+				t.Fatalf("expect %d errors, but got %v", cs.expectErrors, errs)
+			}
+		})
+	}
+}
+
 func TestValidateResourceDistributionTargets(t *testing.T) {
 	targets := appsv1beta1.ResourceDistributionTargets{
 		ExcludedNamespaces: appsv1beta1.ResourceDistributionTargetNamespaces{
@@ -436,3 +536,13 @@ func makeEnvironment(addition ...client.Object) {
 	env = append(env, addition...)
 	handler.Client = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(env...).Build()
 }
+
+// restMapperClient overrides the RESTMapper of fake client, which regards all kinds as namespaced.
+type restMapperClient struct {
+	client.Client
+	mapper meta.RESTMapper
+}
+
+func (c *restMapperClient) RESTMapper() meta.RESTMapper {
+	return c.mapper
+}
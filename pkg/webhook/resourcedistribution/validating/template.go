@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kruise Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+// TemplateNamespace is the namespace data rendered into resource
+type TemplateNamespace struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// TemplateData is the data rendered into resource
+type TemplateData struct {
+	Namespace TemplateNamespace
+	Values    map[string]string
+}
+
+// NewTemplateData returns the data rendered into resource for namespace,
+// the values of the namespace override the default values.
+func NewTemplateData(tmpl *appsv1beta1.ResourceDistributionTemplate, namespace *corev1.Namespace) *TemplateData {
+	data := &TemplateData{
+		Namespace: TemplateNamespace{
+			Name:        namespace.Name,
+			Labels:      namespace.Labels,
+			Annotations: namespace.Annotations,
+		},
+		Values: make(map[string]string, len(tmpl.Values)),
+	}
+	for k, v := range tmpl.Values {
+		data.Values[k] = v
+	}
+	for _, nsValues := range tmpl.NamespaceValues {
+		if nsValues.Name != namespace.Name {
+			continue
+		}
+		for k, v := range nsValues.Values {
+			data.Values[k] = v
+		}
+	}
+	return data
+}
+
+// RenderResource returns a copy of resource whose string fields are rendered with data,
+// metadata.name and metadata.namespace are never rendered.
+// reused by controller
+func RenderResource(resource runtime.Object, data *TemplateData) (runtime.Object, error) {
+	rendered := ConvertToUnstructured(resource.DeepCopyObject())
+	if rendered == nil {
+		return nil, fmt.Errorf("resource is not unstructured")
+	}
+	err := walkResourceStrings(rendered, func(path, value string) (string, error) {
+		t, err := parseTemplate(path, value)
+		if err != nil || t == nil {
+			return value, err
+		}
+		buf := &bytes.Buffer{}
+		if err := t.Execute(buf, data); err != nil {
+			return value, fmt.Errorf("failed to render %s: %v", path, err)
+		}
+		return buf.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+// validateResourceTemplates checks whether all templates in the string fields of resource can be parsed
+func validateResourceTemplates(resource runtime.Object) error {
+	return walkResourceStrings(ConvertToUnstructured(resource.DeepCopyObject()), func(path, value string) (string, error) {
+		_, err := parseTemplate(path, value)
+		return value, err
+	})
+}
+
+func parseTemplate(path, value string) (*template.Template, error) {
+	if !strings.Contains(value, "{{") {
+		return nil, nil
+	}
+	t, err := template.New(path).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template of %s: %v", path, err)
+	}
+	return t, nil
+}
+
+// walkResourceStrings replaces each string field of resource with the result of fn
+func walkResourceStrings(resource *unstructured.Unstructured, fn func(path, value string) (string, error)) error {
+	if resource == nil {
+		return nil
+	}
+	for key, value := range resource.Object {
+		if key == "apiVersion" || key == "kind" {
+			continue
+		}
+		newValue, err := walkValue(key, value, fn)
+		if err != nil {
+			return err
+		}
+		resource.Object[key] = newValue
+	}
+	return nil
+}
+
+func walkValue(path string, value interface{}, fn func(path, value string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return fn(path, v)
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := path + "." + key
+			if childPath == "metadata.name" || childPath == "metadata.namespace" {
+				continue
+			}
+			newChild, err := walkValue(childPath, child, fn)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = newChild
+		}
+		return v, nil
+	case []interface{}:
+		for i, child := range v {
+			newChild, err := walkValue(fmt.Sprintf("%s[%d]", path, i), child, fn)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = newChild
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kruise Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validating
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	appsv1beta1 "github.com/openkruise/kruise/apis/apps/v1beta1"
+)
+
+func TestRenderResource(t *testing.T) {
+	const resourceJSON = `{
+		"apiVersion": "v1",
+		"kind": "ConfigMap",
+		"metadata": {
+			"name": "{{ .Namespace.Name }}",
+			"labels": {"team": "{{ index .Namespace.Labels \"team\" }}"}
+		},
+		"data": {
+			"namespace": "{{ .Namespace.Name }}",
+			"endpoint": "https://{{ .Values.host }}:{{ .Values.port }}",
+			"owner": "{{ index .Namespace.Annotations \"owner\" }}",
+			"plain": "no template"
+		}
+	}`
+	tmpl := &appsv1beta1.ResourceDistributionTemplate{
+		Values: map[string]string{"host": "default.example.com", "port": "443"},
+		NamespaceValues: []appsv1beta1.ResourceDistributionNamespaceValues{
+			{Name: "ns-1", Values: map[string]string{"host": "ns-1.example.com"}},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "ns-1",
+		Labels:      map[string]string{"team": "a"},
+		Annotations: map[string]string{"owner": "alice"},
+	}}
+	resource, errs := DeserializeResource(&runtime.RawExtension{Raw: []byte(resourceJSON)}, field.NewPath("resource"))
+	if len(errs) != 0 {
+		t.Fatalf("failed to deserialize resource: %v", errs)
+	}
+
+	rendered, err := RenderResource(resource, NewTemplateData(tmpl, namespace))
+	if err != nil {
+		t.Fatalf("failed to render resource: %v", err)
+	}
+	obj := ConvertToUnstructured(rendered)
+	if obj.GetName() != "{{ .Namespace.Name }}" {
+		t.Fatalf("metadata.name should not be rendered, got %s", obj.GetName())
+	}
+	if obj.GetLabels()["team"] != "a" {
+		t.Fatalf("unexpected labels %v", obj.GetLabels())
+	}
+	data := obj.Object["data"]
+	expected := map[string]interface{}{
+		"namespace": "ns-1",
+		"endpoint":  "https://ns-1.example.com:443",
+		"owner":     "alice",
+		"plain":     "no template",
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Fatalf("expected data %v, got %v", expected, data)
+	}
+	if ConvertToUnstructured(resource).Object["data"].(map[string]interface{})["namespace"] != "{{ .Namespace.Name }}" {
+		t.Fatalf("the original resource should not be modified")
+	}
+
+	// missing values are not rendered as empty string
+	delete(tmpl.Values, "port")
+	if _, err := RenderResource(resource, NewTemplateData(tmpl, namespace)); err == nil {
+		t.Fatalf("expected error for missing value")
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openkruise/kruise/pkg/util/configuration"
 )
 
 const (
@@ -30,16 +32,21 @@ const (
 
 var (
 	// supportedGKList is a list that contains all supported resource group, and kind
-	// Support CustomResourceDefinition
+	// Other namespaced kinds, e.g. Role, RoleBinding and CustomResourceDefinition, can be supported by
+	// ResourceDistribution_Resource_WhiteList in kruise-configuration, and the RBAC of kruise-manager
+	// for them must be granted additionally.
 	/* ADD NEW RESOURCE TYPE HERE*/
 	supportedGKList = []schema.GroupKind{
 		{Group: "", Kind: "Secret"},
 		{Group: "", Kind: "ConfigMap"},
+		{Group: "", Kind: "LimitRange"},
+		{Group: "", Kind: "ResourceQuota"},
+		{Group: "networking.k8s.io", Kind: "NetworkPolicy"},
 	}
 )
 
 // isSupportedGVK check whether object is supported by ResourceDistribution
-func isSupportedGK(object runtime.Object) bool {
+func isSupportedGK(object runtime.Object, whiteList *configuration.ResourceDistributionWhiteList) bool {
 	if object == nil {
 		return false
 	}
@@ -49,7 +56,7 @@ func isSupportedGK(object runtime.Object) bool {
 			return true
 		}
 	}
-	return false
+	return whiteList != nil && whiteList.IsValid(objGK)
 }
 
 // haveSameGVKAndName return true if two resources have the same group, version, kind and name
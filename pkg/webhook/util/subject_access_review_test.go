@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kruise Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestCanGetPodLogs(t *testing.T) {
+	var reviewed *authorizationv1.SubjectAccessReview
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			sar := obj.(*authorizationv1.SubjectAccessReview)
+			reviewed = sar.DeepCopy()
+			sar.Status.Allowed = sar.Spec.User == "admin"
+			return nil
+		},
+	}).Build()
+
+	userInfo := authenticationv1.UserInfo{Username: "admin", Groups: []string{"system:authenticated"}, Extra: map[string]authenticationv1.ExtraValue{"scopes": {"logs"}}}
+	allowed, err := CanGetPodLogs(context.TODO(), c, userInfo, "default", "test-pod")
+	if err != nil || !allowed {
+		t.Fatalf("expected allowed, got %v, %v", allowed, err)
+	}
+	expectedAttributes := authorizationv1.ResourceAttributes{Namespace: "default", Verb: "get", Resource: "pods", Subresource: "log", Name: "test-pod"}
+	if !reflect.DeepEqual(*reviewed.Spec.ResourceAttributes, expectedAttributes) {
+		t.Fatalf("unexpected resource attributes: %+v", reviewed.Spec.ResourceAttributes)
+	}
+	if !reflect.DeepEqual(reviewed.Spec.Groups, userInfo.Groups) || !reflect.DeepEqual(reviewed.Spec.Extra["scopes"], authorizationv1.ExtraValue{"logs"}) {
+		t.Fatalf("unexpected user info in review: %+v", reviewed.Spec)
+	}
+
+	allowed, err = CanGetPodLogs(context.TODO(), c, authenticationv1.UserInfo{Username: "guest"}, "default", "test-pod")
+	if err != nil || allowed {
+		t.Fatalf("expected denied, got %v, %v", allowed, err)
+	}
+}
@@ -213,6 +213,18 @@ func validateWorkloadSpreadSpec(h *WorkloadSpreadCreateUpdateHandler, obj *appsv
 		}
 	}
 
+	// validate rebalanceStrategy
+	if spec.RebalanceStrategy != nil {
+		if spec.RebalanceStrategy.MaxEvictionsPerInterval != nil && *spec.RebalanceStrategy.MaxEvictionsPerInterval <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("rebalanceStrategy").Child("maxEvictionsPerInterval"),
+				*spec.RebalanceStrategy.MaxEvictionsPerInterval, "maxEvictionsPerInterval must be greater than 0"))
+		}
+		if spec.RebalanceStrategy.IntervalSeconds != nil && *spec.RebalanceStrategy.IntervalSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("rebalanceStrategy").Child("intervalSeconds"),
+				*spec.RebalanceStrategy.IntervalSeconds, "intervalSeconds must be greater than 0"))
+		}
+	}
+
 	// validate targetFilter
 	if spec.TargetFilter != nil {
 		if _, err := metav1.LabelSelectorAsSelector(spec.TargetFilter.Selector); err != nil {
@@ -807,6 +807,28 @@ func TestValidateWorkloadSpreadCreate(t *testing.T) {
 			},
 			errorSuffix: "spec.scheduleStrategy.adaptive",
 		},
+		{
+			name: "rebalanceStrategy's maxEvictionsPerInterval = 0",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				workloadSpread := workloadSpreadDemo.DeepCopy()
+				workloadSpread.Spec.RebalanceStrategy = &appsv1beta1.WorkloadSpreadRebalanceStrategy{
+					MaxEvictionsPerInterval: pointer.Int32Ptr(0),
+				}
+				return workloadSpread
+			},
+			errorSuffix: "spec.rebalanceStrategy.maxEvictionsPerInterval",
+		},
+		{
+			name: "rebalanceStrategy's intervalSeconds < 0",
+			getWorkloadSpread: func() *appsv1beta1.WorkloadSpread {
+				workloadSpread := workloadSpreadDemo.DeepCopy()
+				workloadSpread.Spec.RebalanceStrategy = &appsv1beta1.WorkloadSpreadRebalanceStrategy{
+					IntervalSeconds: pointer.Int32Ptr(-10),
+				}
+				return workloadSpread
+			},
+			errorSuffix: "spec.rebalanceStrategy.intervalSeconds",
+		},
 	}
 
 	for _, errorCase := range errorCases {